@@ -21,6 +21,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/api/option"
 )
 
 // Strings represents the value of a flag that accept multiple strings.
@@ -53,6 +55,35 @@ func (s *Strings) Set(value string) error {
 	return nil
 }
 
+// Paths represents the value of a flag that accepts multiple gs:// paths.
+type Paths struct {
+	vals []gcs.Path
+}
+
+// Paths returns the slice of paths set for this value instance.
+func (p *Paths) Paths() []gcs.Path {
+	return p.vals
+}
+
+// String returns a concatenated string of all the paths joined by commas.
+func (p *Paths) String() string {
+	strs := make([]string, 0, len(p.vals))
+	for _, v := range p.vals {
+		strs = append(strs, v.String())
+	}
+	return strings.Join(strs, ",")
+}
+
+// Set parses and records the path passed
+func (p *Paths) Set(value string) error {
+	var path gcs.Path
+	if err := path.Set(value); err != nil {
+		return err
+	}
+	p.vals = append(p.vals, path)
+	return nil
+}
+
 // options configures the updater
 type options struct {
 	config           gcs.Path // gs://path/to/config/proto
@@ -60,11 +91,30 @@ type options struct {
 	confirm          bool
 	groups           Strings
 	groupConcurrency int
+	groupRetries     int
 	buildConcurrency int
+	maxOpenReaders   int
 	wait             time.Duration
+	runTimeout       time.Duration
 	groupTimeout     time.Duration
 	buildTimeout     time.Duration
 	gridPrefix       string
+	replicas         Paths
+	configCache      string
+	configFile       string
+	configOverlays   Paths
+	verify           bool
+	healthPath       gcs.Path
+	writeStatus      bool
+	readinessAddr    string
+	uploadQPS        float64
+	uploadBurst      int
+	alertWebhook     string
+	webhookTemplate  string
+	publishTopic     string
+	recomputeAlerts  bool
+	afterBuildID     string
+	compressionLevel int
 
 	debug    bool
 	trace    bool
@@ -88,6 +138,15 @@ func (o *options) validate() error {
 			o.buildConcurrency = 4
 		}
 	}
+	if o.maxOpenReaders < 0 {
+		return errors.New("--max-open-readers must be non-negative")
+	}
+	if o.groupRetries < 0 {
+		return errors.New("--group-retries must be non-negative")
+	}
+	if err := gcs.ValidateCompressionLevel(o.compressionLevel); err != nil {
+		return fmt.Errorf("--compression-level: %w", err)
+	}
 
 	return nil
 }
@@ -100,11 +159,30 @@ func gatherFlagOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
 	fs.Var(&o.groups, "test-groups", "Only update named groups if set")
 	fs.IntVar(&o.groupConcurrency, "group-concurrency", 0, "Manually define the number of groups to concurrently update if non-zero")
+	fs.IntVar(&o.groupRetries, "group-retries", 0, "Retry groups which fail with a transient error, such as a storage server error, up to this many times")
 	fs.IntVar(&o.buildConcurrency, "build-concurrency", 0, "Manually define the number of builds to concurrently read if non-zero")
+	fs.IntVar(&o.maxOpenReaders, "max-open-readers", 0, "Keep at most this many results open at once while reading the builds of each group, such as to avoid exhausting file descriptors, if non-zero")
 	fs.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	fs.DurationVar(&o.runTimeout, "run-timeout", 0, "Stop starting new group updates after this much time, letting in-flight ones finish, if non-zero")
 	fs.DurationVar(&o.groupTimeout, "group-timeout", 10*time.Minute, "Maximum time to wait for each group to update")
 	fs.DurationVar(&o.buildTimeout, "build-timeout", 3*time.Minute, "Maximum time to wait to read each build")
 	fs.StringVar(&o.gridPrefix, "grid-prefix", "grid", "Join this with the grid name to create the GCS suffix")
+	fs.Var(&o.replicas, "replica", "Also copy each written grid to gs://bucket/prefix/<group> for each of these gs://bucket/prefix replicas")
+	fs.BoolVar(&o.verify, "verify", false, "Re-download and verify each grid after uploading it if set")
+	fs.Float64Var(&o.uploadQPS, "upload-qps", 0, "Limit grid uploads to this many per second across all groups if non-zero")
+	fs.IntVar(&o.uploadBurst, "upload-burst", 1, "Allow this many grid uploads at once when --upload-qps is set")
+	fs.StringVar(&o.alertWebhook, "alert-webhook", "", "POST a JSON payload to this URL whenever an alert opens or resolves if set")
+	fs.StringVar(&o.webhookTemplate, "alert-webhook-template", "", "Render each --alert-webhook payload with this Go template instead of the default")
+	fs.StringVar(&o.publishTopic, "publish-topic", "", "Publish a JSON event to this projects/PROJECT/topics/TOPIC Pub/Sub topic after writing each grid if set")
+	fs.StringVar(&o.afterBuildID, "after-build-id", "", "Only read builds at or after this build id, such as the one triggering an update, merging them into the existing grids if set")
+	fs.IntVar(&o.compressionLevel, "compression-level", gcs.DefaultCompression, "Compress grids at this level, from -2 (huffman only) or 1 (best speed) through 9 (best compression), or -1 for the default")
+	fs.BoolVar(&o.recomputeAlerts, "recompute-alerts", false, "Only recompute the alerts of existing grids with the current config, without reading builds, if set")
+	fs.Var(&o.healthPath, "health-path", "Write a grid summarizing the health of every group to gs://path/to/health if set")
+	fs.BoolVar(&o.writeStatus, "write-status", false, "Write a JSON status of each group update to <grid>.status.json if set")
+	fs.StringVar(&o.readinessAddr, "readiness-addr", "", "Serve liveness at /healthz and JSON readiness at /readyz on this address, such as :8080, if set")
+	fs.StringVar(&o.configCache, "config-cache", "", "Cache the last-known-good config to this local /path/to/config.pb and use it when --config is unreadable if set")
+	fs.StringVar(&o.configFile, "config-file", "", "Read the config from this local /path/to/config.pb instead of --config if set, which still determines where grids are written")
+	fs.Var(&o.configOverlays, "config-overlay", "Merge each of these gs://path/to/overlay.pb configs in order over the config, replacing test groups and dashboards with the same name")
 
 	fs.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
 	fs.BoolVar(&o.trace, "trace", false, "Log trace and debug lines if set")
@@ -155,15 +233,82 @@ func main() {
 		"build": opt.buildConcurrency,
 	}).Info("Configured concurrency")
 
-	groupUpdater := updater.GCS(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, opt.confirm, updater.SortStarted)
+	var limiter *updater.UploadLimiter
+	if opt.uploadQPS > 0 {
+		limiter = updater.NewUploadLimiter(opt.uploadQPS, opt.uploadBurst)
+	}
+
+	var notifier updater.AlertNotifier
+	if opt.alertWebhook != "" {
+		webhook, err := updater.NewWebhook(opt.alertWebhook, opt.webhookTemplate, 30*time.Second, 3)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to configure --alert-webhook")
+		}
+		notifier = webhook
+	}
+
+	var publisher updater.Publisher
+	if opt.publishTopic != "" {
+		var pubOpts []option.ClientOption
+		if opt.creds != "" {
+			pubOpts = append(pubOpts, option.WithCredentialsFile(opt.creds))
+		}
+		pub, err := updater.NewPubSubPublisher(ctx, opt.publishTopic, pubOpts...)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to configure --publish-topic")
+		}
+		defer pub.Close()
+		publisher = pub
+	}
+
+	groupUpdater := updater.GCS(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, opt.maxOpenReaders, opt.confirm, updater.SortStarted, publisher, opt.verify, nil, limiter, notifier, opt.afterBuildID, opt.compressionLevel, nil)
+	if opt.recomputeAlerts {
+		groupUpdater = updater.RecomputeGroupAlerts(opt.groupTimeout, opt.confirm, nil, limiter)
+	}
 
 	mets := setupMetrics(ctx)
 
-	if err := updater.Update(ctx, client, mets, opt.config, opt.gridPrefix, opt.groupConcurrency, opt.groups.Strings(), groupUpdater, opt.confirm, opt.wait); err != nil {
+	var healthPath *gcs.Path
+	if opt.healthPath.String() != "" {
+		healthPath = &opt.healthPath
+	}
+
+	var ready *updater.Readiness
+	if opt.readinessAddr != "" {
+		ready = updater.NewReadiness()
+		go serveReadiness(opt.readinessAddr, ready)
+	}
+
+	source := updater.GCSConfig(opt.config, opt.configCache)
+	if opt.configFile != "" {
+		source = updater.FileConfig(opt.configFile)
+	}
+	if overlays := opt.configOverlays.Paths(); len(overlays) > 0 {
+		sources := []updater.ConfigSource{source}
+		for _, p := range overlays {
+			sources = append(sources, updater.GCSConfig(p, ""))
+		}
+		source = updater.OverlayConfig(sources...)
+	}
+
+	if err := updater.Update(ctx, client, mets, opt.config, source, opt.gridPrefix, opt.replicas.Paths(), opt.groupConcurrency, opt.groupRetries, opt.groups.Strings(), groupUpdater, opt.confirm, opt.wait, opt.runTimeout, healthPath, opt.writeStatus, ready); err != nil {
 		logrus.WithError(err).Error("Could not update")
 	}
 }
 
+// serveReadiness serves liveness and readiness checks of the updater on addr.
+func serveReadiness(addr string, ready *updater.Readiness) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.Handle("/readyz", ready)
+	logrus.WithField("addr", addr).Info("Serving readiness")
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logrus.WithError(err).Fatal("Failed to serve readiness")
+	}
+}
+
 func setupMetrics(ctx context.Context) *updater.Metrics {
 	var reporter metrics.Reporter
 	log := logrus.New()
@@ -53,6 +53,162 @@ func TestGatherFlagOptions(t *testing.T) {
 				o.config = *newPathOrDie("gs://bucket/whatever")
 			},
 		},
+		{
+			name: "config cache works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--config-cache=/tmp/config.pb",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.configCache = "/tmp/config.pb"
+			},
+		},
+		{
+			name: "config file works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--config-file=/tmp/config.pb",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.configFile = "/tmp/config.pb"
+			},
+		},
+		{
+			name: "config overlays work",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--config-overlay=gs://bucket/overlay",
+				"--config-overlay=gs://other-bucket/overlay",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.configOverlays = Paths{
+					vals: []gcs.Path{
+						*newPathOrDie("gs://bucket/overlay"),
+						*newPathOrDie("gs://other-bucket/overlay"),
+					},
+				}
+			},
+		},
+		{
+			name: "health path works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--health-path=gs://bucket/health",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.healthPath = *newPathOrDie("gs://bucket/health")
+			},
+		},
+		{
+			name: "upload rate limit works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--upload-qps=0.5",
+				"--upload-burst=3",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.uploadQPS = 0.5
+				o.uploadBurst = 3
+			},
+		},
+		{
+			name: "run timeout works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--run-timeout=50m",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.runTimeout = 50 * time.Minute
+			},
+		},
+		{
+			name: "replicas work",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--replica=gs://other-bucket/grid",
+				"--replica=gs://another-bucket/replica",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.replicas = Paths{
+					vals: []gcs.Path{
+						*newPathOrDie("gs://other-bucket/grid"),
+						*newPathOrDie("gs://another-bucket/replica"),
+					},
+				}
+			},
+		},
+		{
+			name: "after build id works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--after-build-id=1234",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.afterBuildID = "1234"
+			},
+		},
+		{
+			name: "compression level works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--compression-level=9",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.compressionLevel = 9
+			},
+		},
+		{
+			name: "reject invalid compression level",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--compression-level=10",
+			},
+			err: true,
+		},
+		{
+			name: "recompute alerts works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--recompute-alerts",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.recomputeAlerts = true
+			},
+		},
+		{
+			name: "alert webhook works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--alert-webhook=https://example.com/hook",
+				"--alert-webhook-template={{.Row}}",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.alertWebhook = "https://example.com/hook"
+				o.webhookTemplate = "{{.Row}}"
+			},
+		},
+		{
+			name: "publish topic works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--publish-topic=projects/my-project/topics/grid-events",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.publishTopic = "projects/my-project/topics/grid-events"
+			},
+		},
 		{
 			name: "allow --config=gs://k8s-testgrid/config with default grid prefix",
 			args: []string{
@@ -88,6 +244,47 @@ func TestGatherFlagOptions(t *testing.T) {
 				o.confirm = true
 			},
 		},
+		{
+			name: "group retries work",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--group-retries=3",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.groupRetries = 3
+			},
+		},
+		{
+			name: "max open readers work",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--max-open-readers=50",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.maxOpenReaders = 50
+			},
+		},
+		{
+			name: "readiness address works",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--readiness-addr=:8080",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.readinessAddr = ":8080"
+			},
+		},
+		{
+			name: "reject negative group retries",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--group-retries=-1",
+			},
+			err: true,
+		},
 		{
 			name: "reject --config=gs://k8s-testgrid/config --grid-prefix=",
 			args: []string{
@@ -124,6 +321,8 @@ func TestGatherFlagOptions(t *testing.T) {
 				groupConcurrency: runtime.NumCPU(),
 				groupTimeout:     10 * time.Minute,
 				gridPrefix:       "grid",
+				uploadBurst:      1,
+				compressionLevel: gcs.DefaultCompression,
 			}
 			if tc.expected != nil {
 				tc.expected(&expected)
@@ -137,7 +336,7 @@ func TestGatherFlagOptions(t *testing.T) {
 			case tc.err:
 				t.Error("validate() failed to return an error")
 			default:
-				if diff := cmp.Diff(expected, actual, cmp.AllowUnexported(options{}, gcs.Path{}), cmp.AllowUnexported(options{}, Strings{})); diff != "" {
+				if diff := cmp.Diff(expected, actual, cmp.AllowUnexported(options{}, gcs.Path{}), cmp.AllowUnexported(options{}, Strings{}, Paths{})); diff != "" {
 					t.Fatalf("gatherFlagOptions() got unexpected diff (-want +got):\n%s", diff)
 				}
 
@@ -25,6 +25,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
@@ -258,8 +259,8 @@ func validateTestGroup(tg *configpb.TestGroup) error {
 		return multierror.Append(mErr, errors.New("got an empty TestGroup"))
 	}
 	// Check that required fields are a non-zero-value.
-	if tg.GetGcsPrefix() == "" {
-		mErr = multierror.Append(mErr, errors.New("gcs_prefix can't be empty"))
+	if tg.GetGcsPrefix() == "" && tg.GetAliasOf() == "" {
+		mErr = multierror.Append(mErr, errors.New("gcs_prefix can't be empty unless alias_of is set"))
 	}
 	if tg.GetDaysOfResults() <= 0 {
 		mErr = multierror.Append(mErr, errors.New("days_of_results should be positive"))
@@ -272,6 +273,109 @@ func validateTestGroup(tg *configpb.TestGroup) error {
 	if _, err := regexp.Compile(tg.GetTestMethodMatchRegex()); err != nil {
 		mErr = multierror.Append(mErr, fmt.Errorf("test_method_match_regex doesn't compile: %v", err))
 	}
+	for _, rule := range tg.GetRowRenameRules() {
+		if _, err := regexp.Compile(rule.GetPattern()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("row_rename_rules pattern doesn't compile: %v", err))
+		}
+	}
+
+	for _, rule := range tg.GetMessageNormalizationRules() {
+		if _, err := regexp.Compile(rule.GetPattern()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("message_normalization_rules pattern doesn't compile: %v", err))
+		}
+	}
+
+	for _, rule := range tg.GetMetricRegressionRules() {
+		if rule.GetMetric() == "" {
+			mErr = multierror.Append(mErr, errors.New("metric_regression_rules metric is required"))
+		}
+		if rule.GetBaselineColumns() < 0 {
+			mErr = multierror.Append(mErr, errors.New("metric_regression_rules baseline_columns can't be negative"))
+		}
+		if rule.GetThresholdPercent() < 0 {
+			mErr = multierror.Append(mErr, errors.New("metric_regression_rules threshold_percent can't be negative"))
+		}
+	}
+
+	for _, alias := range tg.GetMetricAliases() {
+		if alias.GetName() == "" || alias.GetCanonical() == "" {
+			mErr = multierror.Append(mErr, errors.New("metric_aliases require a name and canonical name"))
+		}
+	}
+
+	if w := tg.GetWeightedAlert(); w != nil {
+		if w.GetDecay() < 0 || w.GetDecay() > 1 {
+			mErr = multierror.Append(mErr, errors.New("weighted_alert decay must be between 0 and 1"))
+		}
+		if w.GetThreshold() <= 0 || w.GetThreshold() > 1 {
+			mErr = multierror.Append(mErr, errors.New("weighted_alert threshold must be greater than 0 and at most 1"))
+		}
+	}
+
+	if tg.GetReadConcurrency() < 0 {
+		mErr = multierror.Append(mErr, errors.New("read_concurrency can't be negative"))
+	}
+
+	if h := tg.GetColumnHealth(); h != nil {
+		if h.GetBrokenPercent() < 0 || h.GetBrokenPercent() > 100 {
+			mErr = multierror.Append(mErr, errors.New("column_health broken_percent must be between 0 and 100"))
+		}
+		if h.GetFlakyPercent() < 0 || h.GetFlakyPercent() > 100 {
+			mErr = multierror.Append(mErr, errors.New("column_health flaky_percent must be between 0 and 100"))
+		}
+	}
+
+	if tg.GetColumnGroupingPrefixLength() < 0 {
+		mErr = multierror.Append(mErr, errors.New("column_grouping_prefix_length can't be negative"))
+	}
+
+	if tg.GetAlertCooldownMinutes() < 0 {
+		mErr = multierror.Append(mErr, errors.New("alert_cooldown_minutes can't be negative"))
+	}
+
+	if tg.GetLivenessMinutes() < 0 {
+		mErr = multierror.Append(mErr, errors.New("liveness_minutes can't be negative"))
+	}
+
+	if d := tg.GetMetricDownsample(); d.GetRecentColumns() < 0 || d.GetEveryNth() < 0 || d.GetMaxPoints() < 0 {
+		mErr = multierror.Append(mErr, errors.New("metric_downsample fields can't be negative"))
+	}
+
+	if tg.GetMinBuilds() < 0 {
+		mErr = multierror.Append(mErr, errors.New("min_builds can't be negative"))
+	}
+
+	if tg.GetAlertGroupMinRows() < 0 {
+		mErr = multierror.Append(mErr, errors.New("alert_group_min_rows can't be negative"))
+	}
+
+	if f := tg.GetOldestColumnMinFill(); f < 0 || f > 1 {
+		mErr = multierror.Append(mErr, errors.New("oldest_column_min_fill must be between 0 and 1"))
+	}
+
+	// Alert message templates should be valid.
+	if _, err := template.New("alert").Parse(tg.GetAlertMessageTemplate()); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("alert_message_template doesn't parse: %v", err))
+	}
+	for _, o := range tg.GetRowAlertThresholds() {
+		if _, err := template.New("alert").Parse(o.GetAlertMessageTemplate()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("row_alert_thresholds alert_message_template doesn't parse: %v", err))
+		}
+	}
+
+	for _, md := range tg.GetTargetMetadata() {
+		if md.GetTargetRegex() == "" {
+			mErr = multierror.Append(mErr, errors.New("target_metadata must specify a target_regex"))
+		} else if _, err := regexp.Compile(md.GetTargetRegex()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("target_metadata target_regex doesn't compile: %v", err))
+		}
+	}
+
+	for _, f := range tg.GetBuildMetadataFilters() {
+		if f.GetKey() == "" {
+			mErr = multierror.Append(mErr, errors.New("build_metadata_filters must specify a key"))
+		}
+	}
 
 	// Email address for alerts should be valid.
 	if tg.GetAlertMailToAddresses() != "" {
@@ -431,6 +535,12 @@ func validateEntityConfigs(c *configpb.Configuration) error {
 		}
 	}
 
+	for _, s := range c.GetAlertSilences() {
+		if _, err := regexp.Compile(s.GetGroupPattern()); err != nil {
+			mErr = multierror.Append(mErr, &ValidationError{s.GetGroupPattern(), "AlertSilence", fmt.Sprintf("group pattern must be a valid regex: %v", err)})
+		}
+	}
+
 	return mErr
 }
 
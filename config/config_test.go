@@ -483,6 +483,16 @@ func TestValidateTestGroup(t *testing.T) {
 				NumColumnsRecent: 1,
 			},
 		},
+		{
+			name: "alias_of replaces gcs_prefix",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				AliasOf:          "other_group",
+				NumColumnsRecent: 1,
+			},
+		},
 		{
 			name: "Must have num_columns_recent",
 			testGroup: &configpb.TestGroup{
@@ -510,6 +520,236 @@ func TestValidateTestGroup(t *testing.T) {
 				TestMethodMatchRegex: "[.*",
 			},
 		},
+		{
+			name: "row_rename_rules pattern must compile",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				RowRenameRules: []*configpb.TestGroup_RowRenameRule{
+					{Pattern: "[.*"},
+				},
+			},
+		},
+		{
+			name: "message_normalization_rules pattern must compile",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MessageNormalizationRules: []*configpb.TestGroup_MessageNormalizationRule{
+					{Pattern: "[.*"},
+				},
+			},
+		},
+		{
+			name: "metric_regression_rules must name a metric",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MetricRegressionRules: []*configpb.TestGroup_MetricRegressionRule{
+					{ThresholdPercent: 10},
+				},
+			},
+		},
+		{
+			name: "metric_regression_rules threshold_percent can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MetricRegressionRules: []*configpb.TestGroup_MetricRegressionRule{
+					{Metric: "duration", ThresholdPercent: -10},
+				},
+			},
+		},
+		{
+			name: "metric_aliases require a canonical name",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MetricAliases: []*configpb.TestGroup_MetricAlias{
+					{Name: "p50"},
+				},
+			},
+		},
+		{
+			name: "weighted_alert requires a threshold",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				WeightedAlert:    &configpb.TestGroup_WeightedAlert{Decay: 0.5},
+			},
+		},
+		{
+			name: "weighted_alert decay can't exceed 1",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				WeightedAlert:    &configpb.TestGroup_WeightedAlert{Decay: 2, Threshold: 0.5},
+			},
+		},
+		{
+			name: "read_concurrency can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ReadConcurrency:  -1,
+			},
+		},
+		{
+			name: "column_health percentages can't exceed 100",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnHealth:     &configpb.TestGroup_ColumnHealth{BrokenPercent: 150},
+			},
+		},
+		{
+			name: "column_grouping_prefix_length can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:                       "test_group",
+				DaysOfResults:              1,
+				GcsPrefix:                  "fake path",
+				NumColumnsRecent:           1,
+				ColumnGroupingPrefixLength: -1,
+			},
+		},
+		{
+			name: "alert_cooldown_minutes can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:                 "test_group",
+				DaysOfResults:        1,
+				GcsPrefix:            "fake path",
+				NumColumnsRecent:     1,
+				AlertCooldownMinutes: -1,
+			},
+		},
+		{
+			name: "target_metadata must have a target_regex",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				TargetMetadata: []*configpb.TestGroup_TargetMetadata{
+					{Owner: "someone"},
+				},
+			},
+		},
+		{
+			name: "target_metadata target_regex must compile",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				TargetMetadata: []*configpb.TestGroup_TargetMetadata{
+					{TargetRegex: "[", Owner: "someone"},
+				},
+			},
+		},
+		{
+			name: "build_metadata_filters must specify a key",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				BuildMetadataFilters: []*configpb.TestGroup_BuildMetadataFilter{
+					{Value: "gce"},
+				},
+			},
+		},
+		{
+			name: "liveness_minutes can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				LivenessMinutes:  -1,
+			},
+		},
+		{
+			name: "metric_downsample fields can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MetricDownsample: &configpb.TestGroup_MetricDownsample{
+					EveryNth: -1,
+				},
+			},
+		},
+		{
+			name: "min_builds can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MinBuilds:        -1,
+			},
+		},
+		{
+			name: "oldest_column_min_fill must be a fraction",
+			testGroup: &configpb.TestGroup{
+				Name:                "test_group",
+				DaysOfResults:       1,
+				GcsPrefix:           "fake path",
+				NumColumnsRecent:    1,
+				OldestColumnMinFill: 1.5,
+			},
+		},
+		{
+			name: "alert_group_min_rows can't be negative",
+			testGroup: &configpb.TestGroup{
+				Name:              "test_group",
+				DaysOfResults:     1,
+				GcsPrefix:         "fake path",
+				NumColumnsRecent:  1,
+				AlertGroupMinRows: -1,
+			},
+		},
+		{
+			name: "alert_message_template must parse",
+			testGroup: &configpb.TestGroup{
+				Name:                 "test_group",
+				DaysOfResults:        1,
+				GcsPrefix:            "fake path",
+				NumColumnsRecent:     1,
+				AlertMessageTemplate: "{{.Row",
+			},
+		},
+		{
+			name: "row alert_message_template must parse",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				RowAlertThresholds: []*configpb.TestGroup_RowAlertThreshold{
+					{RowName: "hello", AlertMessageTemplate: "{{end}}"},
+				},
+			},
+		},
 		{
 			name: "Notifications must have a summary",
 			testGroup: &configpb.TestGroup{
@@ -1127,6 +1367,37 @@ func TestUpdate_Validate(t *testing.T) {
 				ValidationError{"dash_1", "Dashboard", "A Dashboard cannot be in more than 1 Dashboard Group."},
 			},
 		},
+		{
+			name: "Alert silences must have a valid group pattern",
+			input: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash_1",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "tab_1",
+								TestGroupName: "test_group_1",
+							},
+						},
+					},
+				},
+				TestGroups: []*configpb.TestGroup{
+					{
+						Name:             "test_group_1",
+						GcsPrefix:        "fake GcsPrefix",
+						DaysOfResults:    1,
+						NumColumnsRecent: 1,
+					},
+				},
+				AlertSilences: []*configpb.AlertSilence{
+					{GroupPattern: "^test_", Until: 1},
+					{GroupPattern: "[", Until: 1},
+				},
+			},
+			expectedErrs: []error{
+				&ValidationError{"[", "AlertSilence", "group pattern must be a valid regex: error parsing regexp: missing closing ]: `[`"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -96,6 +96,52 @@ func Converge(shards map[string]*configpb.Configuration) (*configpb.Configuratio
 	return &result, nil
 }
 
+// Overlay merges together the Configurations in order, where later ones override earlier ones.
+//
+// A TestGroup, Dashboard or DashboardGroup replaces the earlier one with the same name,
+// keeping its position, while new ones are appended. AlertSilences accumulate.
+// The inputs are not modified.
+func Overlay(cfgs ...*configpb.Configuration) *configpb.Configuration {
+	var result configpb.Configuration
+	testGroups := map[string]int{}
+	dashboards := map[string]int{}
+	dashboardGroups := map[string]int{}
+
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			continue
+		}
+		cfg = proto.Clone(cfg).(*configpb.Configuration)
+		for _, tg := range cfg.TestGroups {
+			if i, ok := testGroups[tg.Name]; ok {
+				result.TestGroups[i] = tg
+				continue
+			}
+			testGroups[tg.Name] = len(result.TestGroups)
+			result.TestGroups = append(result.TestGroups, tg)
+		}
+		for _, dash := range cfg.Dashboards {
+			if i, ok := dashboards[dash.Name]; ok {
+				result.Dashboards[i] = dash
+				continue
+			}
+			dashboards[dash.Name] = len(result.Dashboards)
+			result.Dashboards = append(result.Dashboards, dash)
+		}
+		for _, group := range cfg.DashboardGroups {
+			if i, ok := dashboardGroups[group.Name]; ok {
+				result.DashboardGroups[i] = group
+				continue
+			}
+			dashboardGroups[group.Name] = len(result.DashboardGroups)
+			result.DashboardGroups = append(result.DashboardGroups, group)
+		}
+		result.AlertSilences = append(result.AlertSilences, cfg.AlertSilences...)
+	}
+
+	return &result
+}
+
 // Given two sets of strings, returns a "conversions" for each duplicate.
 // If there are no duplicates, returns a zero-length map.
 // If there are duplicates, returns a map of the string in "new" -> the string it should become.
@@ -296,6 +296,121 @@ func TestConverge(t *testing.T) {
 	}
 }
 
+func TestOverlay(t *testing.T) {
+	base := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "hello", GcsPrefix: "base/hello", DaysOfResults: 1},
+			{Name: "world", GcsPrefix: "base/world"},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{Name: "dash", DashboardTab: []*configpb.DashboardTab{{Name: "hello", TestGroupName: "hello"}}},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "all", DashboardNames: []string{"dash"}},
+		},
+		AlertSilences: []*configpb.AlertSilence{{GroupPattern: "hello"}},
+	}
+	overlay := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "world", GcsPrefix: "prod/world", NumFailuresToAlert: 3},
+			{Name: "new", GcsPrefix: "prod/new"},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{Name: "dash", DashboardTab: []*configpb.DashboardTab{{Name: "world", TestGroupName: "world"}}},
+			{Name: "prod", DashboardTab: []*configpb.DashboardTab{{Name: "new", TestGroupName: "new"}}},
+		},
+		DashboardGroups: []*configpb.DashboardGroup{
+			{Name: "all", DashboardNames: []string{"dash", "prod"}},
+		},
+		AlertSilences: []*configpb.AlertSilence{{GroupPattern: "world"}},
+	}
+
+	cases := []struct {
+		name     string
+		inputs   []*configpb.Configuration
+		expected *configpb.Configuration
+	}{
+		{
+			name:     "basically works",
+			expected: &configpb.Configuration{},
+		},
+		{
+			name:     "single config is unchanged",
+			inputs:   []*configpb.Configuration{base},
+			expected: base,
+		},
+		{
+			name:   "later configs override earlier ones by name",
+			inputs: []*configpb.Configuration{base, nil, overlay},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "hello", GcsPrefix: "base/hello", DaysOfResults: 1},
+					{Name: "world", GcsPrefix: "prod/world", NumFailuresToAlert: 3},
+					{Name: "new", GcsPrefix: "prod/new"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{Name: "dash", DashboardTab: []*configpb.DashboardTab{{Name: "world", TestGroupName: "world"}}},
+					{Name: "prod", DashboardTab: []*configpb.DashboardTab{{Name: "new", TestGroupName: "new"}}},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{Name: "all", DashboardNames: []string{"dash", "prod"}},
+				},
+				AlertSilences: []*configpb.AlertSilence{
+					{GroupPattern: "hello"},
+					{GroupPattern: "world"},
+				},
+			},
+		},
+		{
+			name:   "order matters",
+			inputs: []*configpb.Configuration{overlay, base},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "world", GcsPrefix: "base/world"},
+					{Name: "new", GcsPrefix: "prod/new"},
+					{Name: "hello", GcsPrefix: "base/hello", DaysOfResults: 1},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{Name: "dash", DashboardTab: []*configpb.DashboardTab{{Name: "hello", TestGroupName: "hello"}}},
+					{Name: "prod", DashboardTab: []*configpb.DashboardTab{{Name: "new", TestGroupName: "new"}}},
+				},
+				DashboardGroups: []*configpb.DashboardGroup{
+					{Name: "all", DashboardNames: []string{"dash"}},
+				},
+				AlertSilences: []*configpb.AlertSilence{
+					{GroupPattern: "world"},
+					{GroupPattern: "hello"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var originals []*configpb.Configuration
+			for _, in := range tc.inputs {
+				if in != nil {
+					originals = append(originals, proto.Clone(in).(*configpb.Configuration))
+				}
+			}
+			result := Overlay(tc.inputs...)
+			if !proto.Equal(tc.expected, result) {
+				t.Errorf("Overlay() got %v, want %v", result, tc.expected)
+			}
+			var i int
+			for _, in := range tc.inputs {
+				if in == nil {
+					continue
+				}
+				if !proto.Equal(originals[i], in) {
+					t.Errorf("Overlay() modified input %d: got %v, want %v", i, in, originals[i])
+				}
+				i++
+			}
+		})
+	}
+}
+
 func TestRenameTestGroup(t *testing.T) {
 	cases := []struct {
 		name     string
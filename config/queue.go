@@ -192,6 +192,7 @@ func (q *TestGroupQueue) Send(ctx context.Context, receivers chan<- *configpb.Te
 		q.lock.Lock()
 		select {
 		case <-ctx.Done():
+			q.lock.Unlock()
 			return ctx.Err()
 		default:
 		}
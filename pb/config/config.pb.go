@@ -132,6 +132,259 @@ func (TestGroup_PrimaryGrouping) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 2}
 }
 
+type TestGroup_RowSort int32
+
+const (
+	// Natural sort by row name.
+	TestGroup_ROW_SORT_NAME TestGroup_RowSort = 0
+	// Highest ratio of failures to results first.
+	TestGroup_ROW_SORT_FAILURE_RATE TestGroup_RowSort = 1
+	// Rows failing in the most recent column first.
+	TestGroup_ROW_SORT_LAST_FAILURE TestGroup_RowSort = 2
+)
+
+var TestGroup_RowSort_name = map[int32]string{
+	0: "ROW_SORT_NAME",
+	1: "ROW_SORT_FAILURE_RATE",
+	2: "ROW_SORT_LAST_FAILURE",
+}
+
+var TestGroup_RowSort_value = map[string]int32{
+	"ROW_SORT_NAME":         0,
+	"ROW_SORT_FAILURE_RATE": 1,
+	"ROW_SORT_LAST_FAILURE": 2,
+}
+
+func (x TestGroup_RowSort) String() string {
+	return proto.EnumName(TestGroup_RowSort_name, int32(x))
+}
+
+func (TestGroup_RowSort) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 3}
+}
+
+type TestGroup_GridCompression int32
+
+const (
+	TestGroup_GRID_COMPRESSION_ZLIB TestGroup_GridCompression = 0
+	TestGroup_GRID_COMPRESSION_GZIP TestGroup_GridCompression = 1
+	TestGroup_GRID_COMPRESSION_NONE TestGroup_GridCompression = 2
+)
+
+var TestGroup_GridCompression_name = map[int32]string{
+	0: "GRID_COMPRESSION_ZLIB",
+	1: "GRID_COMPRESSION_GZIP",
+	2: "GRID_COMPRESSION_NONE",
+}
+
+var TestGroup_GridCompression_value = map[string]int32{
+	"GRID_COMPRESSION_ZLIB": 0,
+	"GRID_COMPRESSION_GZIP": 1,
+	"GRID_COMPRESSION_NONE": 2,
+}
+
+func (x TestGroup_GridCompression) String() string {
+	return proto.EnumName(TestGroup_GridCompression_name, int32(x))
+}
+
+func (TestGroup_GridCompression) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4}
+}
+
+type TestGroup_RetryPolicy int32
+
+const (
+	// Mark rows with both passing and failing runs as flaky.
+	TestGroup_RETRY_POLICY_FLAKY_IF_MIXED TestGroup_RetryPolicy = 0
+	// Pass when any run passes.
+	TestGroup_RETRY_POLICY_ANY_PASS TestGroup_RetryPolicy = 1
+	// Use the result of the last run.
+	TestGroup_RETRY_POLICY_LAST_RESULT TestGroup_RetryPolicy = 2
+	// Use the most severe result, such as failing when any run fails.
+	TestGroup_RETRY_POLICY_WORST_RESULT TestGroup_RetryPolicy = 3
+)
+
+var TestGroup_RetryPolicy_name = map[int32]string{
+	0: "RETRY_POLICY_FLAKY_IF_MIXED",
+	1: "RETRY_POLICY_ANY_PASS",
+	2: "RETRY_POLICY_LAST_RESULT",
+	3: "RETRY_POLICY_WORST_RESULT",
+}
+
+var TestGroup_RetryPolicy_value = map[string]int32{
+	"RETRY_POLICY_FLAKY_IF_MIXED": 0,
+	"RETRY_POLICY_ANY_PASS":       1,
+	"RETRY_POLICY_LAST_RESULT":    2,
+	"RETRY_POLICY_WORST_RESULT":   3,
+}
+
+func (x TestGroup_RetryPolicy) String() string {
+	return proto.EnumName(TestGroup_RetryPolicy_name, int32(x))
+}
+
+func (TestGroup_RetryPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 5}
+}
+
+type TestGroup_SkippedResult int32
+
+const (
+	// Show skipped tests with a message as PASS_WITH_SKIPS and drop the others.
+	//
+	// This is the default so existing groups keep rendering skipped tests as
+	// they did before skipped_result was added.
+	TestGroup_SKIPPED_RESULT_PASS_WITH_SKIPS TestGroup_SkippedResult = 0
+	// Show every skipped test as NO_RESULT, which neither opens nor closes alerts.
+	TestGroup_SKIPPED_RESULT_NO_RESULT TestGroup_SkippedResult = 1
+	// Drop every skipped test, so it adds no cell to the column.
+	TestGroup_SKIPPED_RESULT_DROP TestGroup_SkippedResult = 2
+)
+
+var TestGroup_SkippedResult_name = map[int32]string{
+	0: "SKIPPED_RESULT_PASS_WITH_SKIPS",
+	1: "SKIPPED_RESULT_NO_RESULT",
+	2: "SKIPPED_RESULT_DROP",
+}
+
+var TestGroup_SkippedResult_value = map[string]int32{
+	"SKIPPED_RESULT_PASS_WITH_SKIPS": 0,
+	"SKIPPED_RESULT_NO_RESULT":       1,
+	"SKIPPED_RESULT_DROP":            2,
+}
+
+func (x TestGroup_SkippedResult) String() string {
+	return proto.EnumName(TestGroup_SkippedResult_name, int32(x))
+}
+
+func (TestGroup_SkippedResult) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 6}
+}
+
+type TestGroup_MissingStarted int32
+
+const (
+	// Drop builds without a started time.
+	TestGroup_MISSING_STARTED_DROP TestGroup_MissingStarted = 0
+	// Derive the started time from the build id, such as 1600000000 (seconds),
+	// 1600000000000 (milliseconds) or 20200913-122640 (UTC). Drops builds
+	// whose id is not a time.
+	TestGroup_MISSING_STARTED_BUILD_ID TestGroup_MissingStarted = 1
+)
+
+var TestGroup_MissingStarted_name = map[int32]string{
+	0: "MISSING_STARTED_DROP",
+	1: "MISSING_STARTED_BUILD_ID",
+}
+
+var TestGroup_MissingStarted_value = map[string]int32{
+	"MISSING_STARTED_DROP":     0,
+	"MISSING_STARTED_BUILD_ID": 1,
+}
+
+func (x TestGroup_MissingStarted) String() string {
+	return proto.EnumName(TestGroup_MissingStarted_name, int32(x))
+}
+
+func (TestGroup_MissingStarted) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 7}
+}
+
+type TestGroup_ColumnGrouping int32
+
+const (
+	// Do not group columns.
+	TestGroup_COLUMN_GROUPING_NONE TestGroup_ColumnGrouping = 0
+	// Group columns by the UTC day they started, such as 2020-09-13.
+	TestGroup_COLUMN_GROUPING_DAY TestGroup_ColumnGrouping = 1
+	// Group columns by the ISO week they started, such as 2020-W37.
+	TestGroup_COLUMN_GROUPING_WEEK TestGroup_ColumnGrouping = 2
+	// Group columns by the first column_grouping_prefix_length characters of
+	// their Commit column header.
+	TestGroup_COLUMN_GROUPING_COMMIT_PREFIX TestGroup_ColumnGrouping = 3
+)
+
+var TestGroup_ColumnGrouping_name = map[int32]string{
+	0: "COLUMN_GROUPING_NONE",
+	1: "COLUMN_GROUPING_DAY",
+	2: "COLUMN_GROUPING_WEEK",
+	3: "COLUMN_GROUPING_COMMIT_PREFIX",
+}
+
+var TestGroup_ColumnGrouping_value = map[string]int32{
+	"COLUMN_GROUPING_NONE":          0,
+	"COLUMN_GROUPING_DAY":           1,
+	"COLUMN_GROUPING_WEEK":          2,
+	"COLUMN_GROUPING_COMMIT_PREFIX": 3,
+}
+
+func (x TestGroup_ColumnGrouping) String() string {
+	return proto.EnumName(TestGroup_ColumnGrouping_name, int32(x))
+}
+
+func (TestGroup_ColumnGrouping) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 8}
+}
+
+type TestGroup_ColumnLimit int32
+
+const (
+	// Read the oldest builds beyond the last update, so later updates read
+	// the rest.
+	TestGroup_COLUMN_LIMIT_OLDEST TestGroup_ColumnLimit = 0
+	// Read the newest builds, skipping older ones beyond the limit.
+	TestGroup_COLUMN_LIMIT_NEWEST TestGroup_ColumnLimit = 1
+)
+
+var TestGroup_ColumnLimit_name = map[int32]string{
+	0: "COLUMN_LIMIT_OLDEST",
+	1: "COLUMN_LIMIT_NEWEST",
+}
+
+var TestGroup_ColumnLimit_value = map[string]int32{
+	"COLUMN_LIMIT_OLDEST": 0,
+	"COLUMN_LIMIT_NEWEST": 1,
+}
+
+func (x TestGroup_ColumnLimit) String() string {
+	return proto.EnumName(TestGroup_ColumnLimit_name, int32(x))
+}
+
+func (TestGroup_ColumnLimit) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 9}
+}
+
+type TestGroup_FlakyAlert int32
+
+const (
+	// A flaky result neither passes nor fails, so it resets the failures
+	// needed to open an alert, and never closes an open one.
+	TestGroup_FLAKY_ALERT_RESET TestGroup_FlakyAlert = 0
+	// Count flaky results as failures.
+	TestGroup_FLAKY_ALERT_FAIL TestGroup_FlakyAlert = 1
+	// Skip flaky results, like empty cells.
+	TestGroup_FLAKY_ALERT_IGNORE TestGroup_FlakyAlert = 2
+)
+
+var TestGroup_FlakyAlert_name = map[int32]string{
+	0: "FLAKY_ALERT_RESET",
+	1: "FLAKY_ALERT_FAIL",
+	2: "FLAKY_ALERT_IGNORE",
+}
+
+var TestGroup_FlakyAlert_value = map[string]int32{
+	"FLAKY_ALERT_RESET":  0,
+	"FLAKY_ALERT_FAIL":   1,
+	"FLAKY_ALERT_IGNORE": 2,
+}
+
+func (x TestGroup_FlakyAlert) String() string {
+	return proto.EnumName(TestGroup_FlakyAlert_name, int32(x))
+}
+
+func (TestGroup_FlakyAlert) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 10}
+}
+
 // Scale of issue priority, used to indicate importance of issue.
 type AutoBugOptions_Priority int32
 
@@ -514,10 +767,219 @@ type TestGroup struct {
 	BuildOverrideStrftime string `protobuf:"bytes,55,opt,name=build_override_strftime,json=buildOverrideStrftime,proto3" json:"build_override_strftime,omitempty"`
 	// Specify a property that will be read into state in the user_property field.
 	// These can be substituted into LinkTemplates.
-	UserProperty         string   `protobuf:"bytes,56,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UserProperty string `protobuf:"bytes,56,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// Reattach annotations from the previous grid to rebuilt columns with a
+	// matching build, so a full rebuild does not wipe user-set annotations.
+	PreserveColumnAnnotations bool `protobuf:"varint,63,opt,name=preserve_column_annotations,json=preserveColumnAnnotations,proto3" json:"preserve_column_annotations,omitempty"`
+	// Replace RUNNING results in columns that started more than this many
+	// minutes ago, since the build is unlikely to ever finish.
+	// Disabled when zero.
+	RunningTimeoutMinutes int32 `protobuf:"varint,64,opt,name=running_timeout_minutes,json=runningTimeoutMinutes,proto3" json:"running_timeout_minutes,omitempty"`
+	// Replace timed out RUNNING results with a failure rather than NO_RESULT.
+	FailRunningTimeout bool `protobuf:"varint,65,opt,name=fail_running_timeout,json=failRunningTimeout,proto3" json:"fail_running_timeout,omitempty"`
+	// Compute the flakiness of each row in the grid.
+	// A result is flaky when it is FLAKY, or when it failed and a more recent
+	// column with the same Commit column header passed.
+	ComputeRowFlakiness bool `protobuf:"varint,66,opt,name=compute_row_flakiness,json=computeRowFlakiness,proto3" json:"compute_row_flakiness,omitempty"`
+	// Rules to link alerts to issues, where the first matching rule wins.
+	IssueLinkRules []*TestGroup_IssueLinkRule `protobuf:"bytes,67,rep,name=issue_link_rules,json=issueLinkRules,proto3" json:"issue_link_rules,omitempty"`
+	// Only open alerts on rows with at least this many results, which avoids
+	// noisy alerts on newly added tests.
+	MinHistoryColumns int32 `protobuf:"varint,68,opt,name=min_history_columns,json=minHistoryColumns,proto3" json:"min_history_columns,omitempty"`
+	// Per-row alert thresholds, for rows noisier (or quieter) than the rest of
+	// the group.
+	RowAlertThresholds []*TestGroup_RowAlertThreshold `protobuf:"bytes,69,rep,name=row_alert_thresholds,json=rowAlertThresholds,proto3" json:"row_alert_thresholds,omitempty"`
+	// Drop rows with a name matching any of these regexes from the grid, such as
+	// setup/teardown pseudo-tests.
+	ExcludeRowRegexes []string `protobuf:"bytes,70,rep,name=exclude_row_regexes,json=excludeRowRegexes,proto3" json:"exclude_row_regexes,omitempty"`
+	// Rules applied in order to each test row name, after test_name_config.
+	// Results of tests renamed to the same row are merged together (or split
+	// when disable_merged_status is set).
+	RowRenameRules []*TestGroup_RowRenameRule `protobuf:"bytes,71,rep,name=row_rename_rules,json=rowRenameRules,proto3" json:"row_rename_rules,omitempty"`
+	// How to sort rows in the grid, where ties are sorted by name.
+	RowSort TestGroup_RowSort `protobuf:"varint,72,opt,name=row_sort,json=rowSort,proto3,enum=TestGroup_RowSort" json:"row_sort,omitempty"`
+	// How to compress the grid state written for this group.
+	// Readers detect which compression was used.
+	GridCompression TestGroup_GridCompression `protobuf:"varint,73,opt,name=grid_compression,json=gridCompression,proto3,enum=TestGroup_GridCompression" json:"grid_compression,omitempty"`
+	// Stream the compressed grid to storage rather than buffering it in memory first.
+	StreamUpload bool `protobuf:"varint,74,opt,name=stream_upload,json=streamUpload,proto3" json:"stream_upload,omitempty"`
+	// Only rows with a name matching one of these regexes may alert.
+	// All rows may alert when empty.
+	AlertRowRegexes []string `protobuf:"bytes,75,rep,name=alert_row_regexes,json=alertRowRegexes,proto3" json:"alert_row_regexes,omitempty"`
+	// Drop columns where every cell is empty (NO_RESULT).
+	PruneEmptyColumns bool `protobuf:"varint,76,opt,name=prune_empty_columns,json=pruneEmptyColumns,proto3" json:"prune_empty_columns,omitempty"`
+	// Count the pass, fail, flaky and empty results of each column.
+	ComputeColumnStats bool `protobuf:"varint,77,opt,name=compute_column_stats,json=computeColumnStats,proto3" json:"compute_column_stats,omitempty"`
+	// Name of an archive under each build holding its results, such as results.tar.gz.
+	// Results are read from inside this archive rather than from loose objects.
+	// Supports .tar, .tar.gz, .tgz and .zip archives.
+	ResultArchive string `protobuf:"bytes,78,opt,name=result_archive,json=resultArchive,proto3" json:"result_archive,omitempty"`
+	// Truncate cell messages longer than this many characters, ending them with an ellipsis.
+	// Messages are unlimited when unset.
+	MaxMessageLength int32 `protobuf:"varint,79,opt,name=max_message_length,json=maxMessageLength,proto3" json:"max_message_length,omitempty"`
+	// Clear cell icons not in this list. All icons are allowed when empty.
+	AllowedIcons []string `protobuf:"bytes,80,rep,name=allowed_icons,json=allowedIcons,proto3" json:"allowed_icons,omitempty"`
+	// Store each unique message once in a table on the grid, with rows
+	// referencing messages by index.
+	InternMessages bool `protobuf:"varint,81,opt,name=intern_messages,json=internMessages,proto3" json:"intern_messages,omitempty"`
+	// Suppress alerts until this time, in seconds since epoch.
+	// Usually set by a matching silence, see Configuration.alert_silences.
+	SilenceAlertsUntil int64 `protobuf:"varint,82,opt,name=silence_alerts_until,json=silenceAlertsUntil,proto3" json:"silence_alerts_until,omitempty"`
+	// How to combine multiple results for the same row in one column, such as
+	// retries or parameterized tests reporting the same name. Ignored when
+	// disable_merged_status is set, which splits them into foo, foo [1], etc.
+	// See flaky_alert for how alerts treat flaky results.
+	RetryPolicy TestGroup_RetryPolicy `protobuf:"varint,83,opt,name=retry_policy,json=retryPolicy,proto3,enum=TestGroup_RetryPolicy" json:"retry_policy,omitempty"`
+	// Keep at most this many of the newest results in each row, dropping older columns.
+	// History is unlimited when unset.
+	MaxRowHistory int32 `protobuf:"varint,84,opt,name=max_row_history,json=maxRowHistory,proto3" json:"max_row_history,omitempty"`
+	// Open a disappeared alert for rows which previously reported results but
+	// have no result in this many of the newest columns. Disabled when unset.
+	DisappearedAfter int32 `protobuf:"varint,85,opt,name=disappeared_after,json=disappearedAfter,proto3" json:"disappeared_after,omitempty"`
+	// Alerts report the first column header value as the build id by default,
+	// or else the build. Selected columns without a value fall back to the build.
+	BuildIdSelector *TestGroup_BuildIdSelector `protobuf:"bytes,86,opt,name=build_id_selector,json=buildIdSelector,proto3" json:"build_id_selector,omitempty"`
+	// Parse junit artifacts with the named result parser, which must be
+	// registered with the updater. Empty uses the standard junit parser, while
+	// "ndjson" reads newline-delimited JSON with one result object per line.
+	ResultParser string `protobuf:"bytes,87,opt,name=result_parser,json=resultParser,proto3" json:"result_parser,omitempty"`
+	// Link each cell with a result to its build artifacts, such as logs or
+	// screenshots. Expands <job>, <build> and <test-name>, as well as
+	// <KEY> for the value of KEY in finished.json metadata, for example:
+	// https://example.com/<job>/<build>/artifacts/<test-name>
+	ArtifactUrlTemplate string `protobuf:"bytes,88,opt,name=artifact_url_template,json=artifactUrlTemplate,proto3" json:"artifact_url_template,omitempty"`
+	// Pad grids with fewer columns than this with empty placeholder columns,
+	// which start before the oldest column.
+	MinColumns int32 `protobuf:"varint,89,opt,name=min_columns,json=minColumns,proto3" json:"min_columns,omitempty"`
+	// Re-sort columns by start time when clock skew makes builds start out of
+	// order, rather than only logging a warning.
+	SortSkewedColumns bool `protobuf:"varint,90,opt,name=sort_skewed_columns,json=sortSkewedColumns,proto3" json:"sort_skewed_columns,omitempty"`
+	// Compute how many days passed since each row last passed, see
+	// Row.days_since_green.
+	ComputeDaysSinceGreen bool `protobuf:"varint,91,opt,name=compute_days_since_green,json=computeDaysSinceGreen,proto3" json:"compute_days_since_green,omitempty"`
+	// Open an alert when every result of a row failed, even when the row has
+	// fewer than num_failures_to_alert results.
+	AlertOnAllFailing bool `protobuf:"varint,92,opt,name=alert_on_all_failing,json=alertOnAllFailing,proto3" json:"alert_on_all_failing,omitempty"`
+	// Ignore this many of the most recent columns when opening or closing
+	// alerts, which often contain partial results.
+	IgnoreLatestColumns int32 `protobuf:"varint,93,opt,name=ignore_latest_columns,json=ignoreLatestColumns,proto3" json:"ignore_latest_columns,omitempty"`
+	// Log updates to this group at this logrus level, such as debug, rather
+	// than the level of other groups.
+	LogLevel string `protobuf:"bytes,94,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	// Read the builds of this group, which has an empty gcs_prefix, from the
+	// gcs_prefix of the named group. Otherwise applies this group's config.
+	AliasOf string `protobuf:"bytes,95,opt,name=alias_of,json=aliasOf,proto3" json:"alias_of,omitempty"`
+	// Rules applied in order to the message of each cell before it is stored,
+	// and therefore before alerts compare messages.
+	MessageNormalizationRules []*TestGroup_MessageNormalizationRule `protobuf:"bytes,96,rep,name=message_normalization_rules,json=messageNormalizationRules,proto3" json:"message_normalization_rules,omitempty"`
+	// Flag metric values which regressed, see Row.metric_regressions.
+	MetricRegressionRules []*TestGroup_MetricRegressionRule `protobuf:"bytes,97,rep,name=metric_regression_rules,json=metricRegressionRules,proto3" json:"metric_regression_rules,omitempty"`
+	// Also open a weighted alert on rows without a consecutive failure alert
+	// when set.
+	WeightedAlert *TestGroup_WeightedAlert `protobuf:"bytes,98,opt,name=weighted_alert,json=weightedAlert,proto3" json:"weighted_alert,omitempty"`
+	// Read every build within days_of_results in an update, rather than at
+	// most 50 builds, up to a ceiling of 1000 builds which prevents runaway
+	// grids. Updates still read fewer builds when the rows of the grid exceed
+	// the update area, in which case later updates read the remaining builds.
+	CapColumnsByTime bool `protobuf:"varint,99,opt,name=cap_columns_by_time,json=capColumnsByTime,proto3" json:"cap_columns_by_time,omitempty"`
+	// Treat the junit files of each build as shards of a single run, such as
+	// junit_01.xml and junit_02.xml. Row names ignore the shard (Thread) of the
+	// file, and a test reported by several shards becomes one cell combined
+	// per retry_policy, even when disable_merged_status is set.
+	MergeShards bool `protobuf:"varint,100,opt,name=merge_shards,json=mergeShards,proto3" json:"merge_shards,omitempty"`
+	// How to show the results of skipped tests.
+	//
+	// Defaults to SKIPPED_RESULT_PASS_WITH_SKIPS for compatibility; set
+	// SKIPPED_RESULT_NO_RESULT to show every skipped test as NO_RESULT.
+	SkippedResult TestGroup_SkippedResult `protobuf:"varint,101,opt,name=skipped_result,json=skippedResult,proto3,enum=TestGroup_SkippedResult" json:"skipped_result,omitempty"`
+	// Read this many builds of the group in parallel, overriding the updater's
+	// --build-concurrency flag. Uses the flag when unset.
+	ReadConcurrency int32 `protobuf:"varint,102,opt,name=read_concurrency,json=readConcurrency,proto3" json:"read_concurrency,omitempty"`
+	// Summarize the health of each column when set, see Column.health.
+	ColumnHealth *TestGroup_ColumnHealth `protobuf:"bytes,103,opt,name=column_health,json=columnHealth,proto3" json:"column_health,omitempty"`
+	// Only keep rows with an open alert, such as for a grid of active incidents.
+	// Columns remain unchanged.
+	AlertsOnly bool `protobuf:"varint,104,opt,name=alerts_only,json=alertsOnly,proto3" json:"alerts_only,omitempty"`
+	// Store metrics under lowercase names, so names which only differ in case,
+	// such as Latency and latency, become one metric.
+	LowercaseMetricNames bool `protobuf:"varint,105,opt,name=lowercase_metric_names,json=lowercaseMetricNames,proto3" json:"lowercase_metric_names,omitempty"`
+	// Store metrics under the canonical name of their alias, before any
+	// lowercasing. Values with the same name in one cell are averaged.
+	MetricAliases []*TestGroup_MetricAlias `protobuf:"bytes,106,rep,name=metric_aliases,json=metricAliases,proto3" json:"metric_aliases,omitempty"`
+	// Also write a GridDelta beside each grid, which patches the previous grid
+	// into the new one so clients need not download the full grid again.
+	WriteGridDelta bool `protobuf:"varint,107,opt,name=write_grid_delta,json=writeGridDelta,proto3" json:"write_grid_delta,omitempty"`
+	// How to handle builds whose started.json is missing or lacks a timestamp.
+	MissingStarted TestGroup_MissingStarted `protobuf:"varint,108,opt,name=missing_started,json=missingStarted,proto3,enum=TestGroup_MissingStarted" json:"missing_started,omitempty"`
+	// Store a key on each column so the UI can group them under headers.
+	ColumnGrouping TestGroup_ColumnGrouping `protobuf:"varint,109,opt,name=column_grouping,json=columnGrouping,proto3,enum=TestGroup_ColumnGrouping" json:"column_grouping,omitempty"`
+	// Characters of the commit which group columns, see
+	// COLUMN_GROUPING_COMMIT_PREFIX. Defaults to 7.
+	ColumnGroupingPrefixLength int32 `protobuf:"varint,110,opt,name=column_grouping_prefix_length,json=columnGroupingPrefixLength,proto3" json:"column_grouping_prefix_length,omitempty"`
+	// Once the alert of a row closes, do not reopen it until columns start this
+	// many minutes later, which prevents flapping alerts from notifying
+	// repeatedly. Reopens alerts at once when unset.
+	AlertCooldownMinutes int32 `protobuf:"varint,111,opt,name=alert_cooldown_minutes,json=alertCooldownMinutes,proto3" json:"alert_cooldown_minutes,omitempty"`
+	// Rules to attach an owner and component to each row, where the first
+	// matching rule wins. Rows which match no rule have neither.
+	TargetMetadata []*TestGroup_TargetMetadata `protobuf:"bytes,112,rep,name=target_metadata,json=targetMetadata,proto3" json:"target_metadata,omitempty"`
+	// Set Grid.last_time_updated to when the updater wrote the grid, so clients
+	// can tell from the grid alone when it was generated.
+	RecordUpdateTime bool `protobuf:"varint,113,opt,name=record_update_time,json=recordUpdateTime,proto3" json:"record_update_time,omitempty"`
+	// Order the metrics of each row with these names first, in this order, such
+	// as latency before throughput. Other metrics follow in natural order.
+	MetricOrder []string `protobuf:"bytes,114,rep,name=metric_order,json=metricOrder,proto3" json:"metric_order,omitempty"`
+	// Skip computing alerts, so no row alerts, such as for groups which only
+	// track metric trends. Saves time on large grids.
+	DisableAlerts bool `protobuf:"varint,115,opt,name=disable_alerts,json=disableAlerts,proto3" json:"disable_alerts,omitempty"`
+	// Upload the grid and its delta as publicly readable, rather than with the
+	// default ACL of the bucket. Leave unset for groups with sensitive results.
+	WorldReadable bool `protobuf:"varint,116,opt,name=world_readable,json=worldReadable,proto3" json:"world_readable,omitempty"`
+	// Only read builds whose metadata matches every filter, such as only
+	// release-blocking runs. Other builds are dropped before becoming columns.
+	BuildMetadataFilters []*TestGroup_BuildMetadataFilter `protobuf:"bytes,117,rep,name=build_metadata_filters,json=buildMetadataFilters,proto3" json:"build_metadata_filters,omitempty"`
+	// Store the seconds between the started and finished time of each build on
+	// its column, so clients can chart build time trends.
+	RecordColumnDuration bool `protobuf:"varint,118,opt,name=record_column_duration,json=recordColumnDuration,proto3" json:"record_column_duration,omitempty"`
+	// Which builds to read when an update lists more than the column limit.
+	ColumnLimit TestGroup_ColumnLimit `protobuf:"varint,119,opt,name=column_limit,json=columnLimit,proto3,enum=TestGroup_ColumnLimit" json:"column_limit,omitempty"`
+	// When positive, start the grid with a placeholder column at the update
+	// time, showing at a glance whether the job still runs. The column is empty
+	// while the newest build started within this many minutes, and otherwise
+	// marks the overall row stale.
+	LivenessMinutes int32 `protobuf:"varint,120,opt,name=liveness_minutes,json=livenessMinutes,proto3" json:"liveness_minutes,omitempty"`
+	// Downsample the metrics of each row, when set.
+	MetricDownsample *TestGroup_MetricDownsample `protobuf:"bytes,121,opt,name=metric_downsample,json=metricDownsample,proto3" json:"metric_downsample,omitempty"`
+	// Replace the failure message of each alert with this Go text/template,
+	// such as "{{.Group}}/{{.Row}} failed {{.FailCount}} times since {{.FailBuild}}".
+	//
+	// Templates may reference .Group, .Row, .FailBuild, .FailCount and the
+	// original .Message. Issue link rules still match the original message.
+	AlertMessageTemplate string `protobuf:"bytes,122,opt,name=alert_message_template,json=alertMessageTemplate,proto3" json:"alert_message_template,omitempty"`
+	// Only write the grid when an update reads at least this many builds,
+	// preserving the existing grid otherwise, such as when listing builds
+	// transiently fails. Columns kept from the existing grid do not count.
+	MinBuilds int32 `protobuf:"varint,123,opt,name=min_builds,json=minBuilds,proto3" json:"min_builds,omitempty"`
+	// Rebuild the grid from scratch, rather than appending to it, when the
+	// existing grid was written under a different version of this config.
+	RebuildOnConfigChange bool `protobuf:"varint,124,opt,name=rebuild_on_config_change,json=rebuildOnConfigChange,proto3" json:"rebuild_on_config_change,omitempty"`
+	// Drop the oldest column when fewer than this fraction of rows, such as 0.5,
+	// have a result in it. The oldest column may be missing results from builds
+	// before the window, for example when several builds share a column.
+	OldestColumnMinFill float32 `protobuf:"fixed32,125,opt,name=oldest_column_min_fill,json=oldestColumnMinFill,proto3" json:"oldest_column_min_fill,omitempty"`
+	// Group the open alerts of at least this many rows which share a failure
+	// message into a summary alert of the grid, alongside each row's alert.
+	//
+	// Messages match after applying message_normalization_rules and collapsing
+	// whitespace. The summary describes the row which failed first, counting
+	// the failures of every row.
+	AlertGroupMinRows int32 `protobuf:"varint,126,opt,name=alert_group_min_rows,json=alertGroupMinRows,proto3" json:"alert_group_min_rows,omitempty"`
+	// How alerts treat flaky results, such as cells whose runs both passed and
+	// failed under RETRY_POLICY_FLAKY_IF_MIXED.
+	FlakyAlert           TestGroup_FlakyAlert `protobuf:"varint,127,opt,name=flaky_alert,json=flakyAlert,proto3,enum=TestGroup_FlakyAlert" json:"flaky_alert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *TestGroup) Reset()         { *m = TestGroup{} }
@@ -860,305 +1322,1431 @@ func (m *TestGroup) GetUseFullMethodNames() bool {
 	return false
 }
 
-func (m *TestGroup) GetFallbackGroupingConfigurationValue() string {
+func (m *TestGroup) GetFallbackGroupingConfigurationValue() string {
+	if m != nil {
+		return m.FallbackGroupingConfigurationValue
+	}
+	return ""
+}
+
+func (m *TestGroup) GetResultSource() *TestGroup_ResultSource {
+	if m != nil {
+		return m.ResultSource
+	}
+	return nil
+}
+
+func (m *TestGroup) GetCustomEvaluatorRuleSet() *custom_evaluator.RuleSet {
+	if m != nil {
+		return m.CustomEvaluatorRuleSet
+	}
+	return nil
+}
+
+func (m *TestGroup) GetReadStateFromStorage() bool {
+	if m != nil {
+		return m.ReadStateFromStorage
+	}
+	return false
+}
+
+func (m *TestGroup) GetIgnoreOldResults() bool {
+	if m != nil {
+		return m.IgnoreOldResults
+	}
+	return false
+}
+
+func (m *TestGroup) GetIgnoreSkip() bool {
+	if m != nil {
+		return m.IgnoreSkip
+	}
+	return false
+}
+
+func (m *TestGroup) GetBuildOverrideStrftime() string {
+	if m != nil {
+		return m.BuildOverrideStrftime
+	}
+	return ""
+}
+
+func (m *TestGroup) GetUserProperty() string {
+	if m != nil {
+		return m.UserProperty
+	}
+	return ""
+}
+
+func (m *TestGroup) GetPreserveColumnAnnotations() bool {
+	if m != nil {
+		return m.PreserveColumnAnnotations
+	}
+	return false
+}
+
+func (m *TestGroup) GetRunningTimeoutMinutes() int32 {
+	if m != nil {
+		return m.RunningTimeoutMinutes
+	}
+	return 0
+}
+
+func (m *TestGroup) GetFailRunningTimeout() bool {
+	if m != nil {
+		return m.FailRunningTimeout
+	}
+	return false
+}
+
+func (m *TestGroup) GetComputeRowFlakiness() bool {
+	if m != nil {
+		return m.ComputeRowFlakiness
+	}
+	return false
+}
+
+func (m *TestGroup) GetIssueLinkRules() []*TestGroup_IssueLinkRule {
+	if m != nil {
+		return m.IssueLinkRules
+	}
+	return nil
+}
+
+func (m *TestGroup) GetMinHistoryColumns() int32 {
+	if m != nil {
+		return m.MinHistoryColumns
+	}
+	return 0
+}
+
+func (m *TestGroup) GetRowAlertThresholds() []*TestGroup_RowAlertThreshold {
+	if m != nil {
+		return m.RowAlertThresholds
+	}
+	return nil
+}
+
+func (m *TestGroup) GetExcludeRowRegexes() []string {
+	if m != nil {
+		return m.ExcludeRowRegexes
+	}
+	return nil
+}
+
+func (m *TestGroup) GetRowRenameRules() []*TestGroup_RowRenameRule {
+	if m != nil {
+		return m.RowRenameRules
+	}
+	return nil
+}
+
+func (m *TestGroup) GetRowSort() TestGroup_RowSort {
+	if m != nil {
+		return m.RowSort
+	}
+	return TestGroup_ROW_SORT_NAME
+}
+
+func (m *TestGroup) GetGridCompression() TestGroup_GridCompression {
+	if m != nil {
+		return m.GridCompression
+	}
+	return TestGroup_GRID_COMPRESSION_ZLIB
+}
+
+func (m *TestGroup) GetStreamUpload() bool {
+	if m != nil {
+		return m.StreamUpload
+	}
+	return false
+}
+
+func (m *TestGroup) GetAlertRowRegexes() []string {
+	if m != nil {
+		return m.AlertRowRegexes
+	}
+	return nil
+}
+
+func (m *TestGroup) GetPruneEmptyColumns() bool {
+	if m != nil {
+		return m.PruneEmptyColumns
+	}
+	return false
+}
+
+func (m *TestGroup) GetComputeColumnStats() bool {
+	if m != nil {
+		return m.ComputeColumnStats
+	}
+	return false
+}
+
+func (m *TestGroup) GetResultArchive() string {
+	if m != nil {
+		return m.ResultArchive
+	}
+	return ""
+}
+
+func (m *TestGroup) GetMaxMessageLength() int32 {
+	if m != nil {
+		return m.MaxMessageLength
+	}
+	return 0
+}
+
+func (m *TestGroup) GetAllowedIcons() []string {
+	if m != nil {
+		return m.AllowedIcons
+	}
+	return nil
+}
+
+func (m *TestGroup) GetInternMessages() bool {
+	if m != nil {
+		return m.InternMessages
+	}
+	return false
+}
+
+func (m *TestGroup) GetSilenceAlertsUntil() int64 {
+	if m != nil {
+		return m.SilenceAlertsUntil
+	}
+	return 0
+}
+
+func (m *TestGroup) GetRetryPolicy() TestGroup_RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return TestGroup_RETRY_POLICY_FLAKY_IF_MIXED
+}
+
+func (m *TestGroup) GetMaxRowHistory() int32 {
+	if m != nil {
+		return m.MaxRowHistory
+	}
+	return 0
+}
+
+func (m *TestGroup) GetDisappearedAfter() int32 {
+	if m != nil {
+		return m.DisappearedAfter
+	}
+	return 0
+}
+
+func (m *TestGroup) GetBuildIdSelector() *TestGroup_BuildIdSelector {
+	if m != nil {
+		return m.BuildIdSelector
+	}
+	return nil
+}
+
+func (m *TestGroup) GetResultParser() string {
+	if m != nil {
+		return m.ResultParser
+	}
+	return ""
+}
+
+func (m *TestGroup) GetArtifactUrlTemplate() string {
+	if m != nil {
+		return m.ArtifactUrlTemplate
+	}
+	return ""
+}
+
+func (m *TestGroup) GetMinColumns() int32 {
+	if m != nil {
+		return m.MinColumns
+	}
+	return 0
+}
+
+func (m *TestGroup) GetSortSkewedColumns() bool {
+	if m != nil {
+		return m.SortSkewedColumns
+	}
+	return false
+}
+
+func (m *TestGroup) GetComputeDaysSinceGreen() bool {
+	if m != nil {
+		return m.ComputeDaysSinceGreen
+	}
+	return false
+}
+
+func (m *TestGroup) GetAlertOnAllFailing() bool {
+	if m != nil {
+		return m.AlertOnAllFailing
+	}
+	return false
+}
+
+func (m *TestGroup) GetIgnoreLatestColumns() int32 {
+	if m != nil {
+		return m.IgnoreLatestColumns
+	}
+	return 0
+}
+
+func (m *TestGroup) GetLogLevel() string {
+	if m != nil {
+		return m.LogLevel
+	}
+	return ""
+}
+
+func (m *TestGroup) GetAliasOf() string {
+	if m != nil {
+		return m.AliasOf
+	}
+	return ""
+}
+
+func (m *TestGroup) GetMessageNormalizationRules() []*TestGroup_MessageNormalizationRule {
+	if m != nil {
+		return m.MessageNormalizationRules
+	}
+	return nil
+}
+
+func (m *TestGroup) GetMetricRegressionRules() []*TestGroup_MetricRegressionRule {
+	if m != nil {
+		return m.MetricRegressionRules
+	}
+	return nil
+}
+
+func (m *TestGroup) GetWeightedAlert() *TestGroup_WeightedAlert {
+	if m != nil {
+		return m.WeightedAlert
+	}
+	return nil
+}
+
+func (m *TestGroup) GetCapColumnsByTime() bool {
+	if m != nil {
+		return m.CapColumnsByTime
+	}
+	return false
+}
+
+func (m *TestGroup) GetMergeShards() bool {
+	if m != nil {
+		return m.MergeShards
+	}
+	return false
+}
+
+func (m *TestGroup) GetSkippedResult() TestGroup_SkippedResult {
+	if m != nil {
+		return m.SkippedResult
+	}
+	return TestGroup_SKIPPED_RESULT_PASS_WITH_SKIPS
+}
+
+func (m *TestGroup) GetReadConcurrency() int32 {
+	if m != nil {
+		return m.ReadConcurrency
+	}
+	return 0
+}
+
+func (m *TestGroup) GetColumnHealth() *TestGroup_ColumnHealth {
+	if m != nil {
+		return m.ColumnHealth
+	}
+	return nil
+}
+
+func (m *TestGroup) GetAlertsOnly() bool {
+	if m != nil {
+		return m.AlertsOnly
+	}
+	return false
+}
+
+func (m *TestGroup) GetLowercaseMetricNames() bool {
+	if m != nil {
+		return m.LowercaseMetricNames
+	}
+	return false
+}
+
+func (m *TestGroup) GetMetricAliases() []*TestGroup_MetricAlias {
+	if m != nil {
+		return m.MetricAliases
+	}
+	return nil
+}
+
+func (m *TestGroup) GetWriteGridDelta() bool {
+	if m != nil {
+		return m.WriteGridDelta
+	}
+	return false
+}
+
+func (m *TestGroup) GetMissingStarted() TestGroup_MissingStarted {
+	if m != nil {
+		return m.MissingStarted
+	}
+	return TestGroup_MISSING_STARTED_DROP
+}
+
+func (m *TestGroup) GetColumnGrouping() TestGroup_ColumnGrouping {
+	if m != nil {
+		return m.ColumnGrouping
+	}
+	return TestGroup_COLUMN_GROUPING_NONE
+}
+
+func (m *TestGroup) GetColumnGroupingPrefixLength() int32 {
+	if m != nil {
+		return m.ColumnGroupingPrefixLength
+	}
+	return 0
+}
+
+func (m *TestGroup) GetAlertCooldownMinutes() int32 {
+	if m != nil {
+		return m.AlertCooldownMinutes
+	}
+	return 0
+}
+
+func (m *TestGroup) GetTargetMetadata() []*TestGroup_TargetMetadata {
+	if m != nil {
+		return m.TargetMetadata
+	}
+	return nil
+}
+
+func (m *TestGroup) GetRecordUpdateTime() bool {
+	if m != nil {
+		return m.RecordUpdateTime
+	}
+	return false
+}
+
+func (m *TestGroup) GetMetricOrder() []string {
+	if m != nil {
+		return m.MetricOrder
+	}
+	return nil
+}
+
+func (m *TestGroup) GetDisableAlerts() bool {
+	if m != nil {
+		return m.DisableAlerts
+	}
+	return false
+}
+
+func (m *TestGroup) GetWorldReadable() bool {
+	if m != nil {
+		return m.WorldReadable
+	}
+	return false
+}
+
+func (m *TestGroup) GetBuildMetadataFilters() []*TestGroup_BuildMetadataFilter {
+	if m != nil {
+		return m.BuildMetadataFilters
+	}
+	return nil
+}
+
+func (m *TestGroup) GetRecordColumnDuration() bool {
+	if m != nil {
+		return m.RecordColumnDuration
+	}
+	return false
+}
+
+func (m *TestGroup) GetColumnLimit() TestGroup_ColumnLimit {
+	if m != nil {
+		return m.ColumnLimit
+	}
+	return TestGroup_COLUMN_LIMIT_OLDEST
+}
+
+func (m *TestGroup) GetLivenessMinutes() int32 {
+	if m != nil {
+		return m.LivenessMinutes
+	}
+	return 0
+}
+
+func (m *TestGroup) GetMetricDownsample() *TestGroup_MetricDownsample {
+	if m != nil {
+		return m.MetricDownsample
+	}
+	return nil
+}
+
+func (m *TestGroup) GetAlertMessageTemplate() string {
+	if m != nil {
+		return m.AlertMessageTemplate
+	}
+	return ""
+}
+
+func (m *TestGroup) GetMinBuilds() int32 {
+	if m != nil {
+		return m.MinBuilds
+	}
+	return 0
+}
+
+func (m *TestGroup) GetRebuildOnConfigChange() bool {
+	if m != nil {
+		return m.RebuildOnConfigChange
+	}
+	return false
+}
+
+func (m *TestGroup) GetOldestColumnMinFill() float32 {
+	if m != nil {
+		return m.OldestColumnMinFill
+	}
+	return 0
+}
+
+func (m *TestGroup) GetAlertGroupMinRows() int32 {
+	if m != nil {
+		return m.AlertGroupMinRows
+	}
+	return 0
+}
+
+func (m *TestGroup) GetFlakyAlert() TestGroup_FlakyAlert {
+	if m != nil {
+		return m.FlakyAlert
+	}
+	return TestGroup_FLAKY_ALERT_RESET
+}
+
+// Custom column headers for defining extra column-heading rows from values in
+// the test result.
+type TestGroup_ColumnHeader struct {
+	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Property             string   `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
+	ConfigurationValue   string   `protobuf:"bytes,3,opt,name=configuration_value,json=configurationValue,proto3" json:"configuration_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_ColumnHeader) Reset()         { *m = TestGroup_ColumnHeader{} }
+func (m *TestGroup_ColumnHeader) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ColumnHeader) ProtoMessage()    {}
+func (*TestGroup_ColumnHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 0}
+}
+
+func (m *TestGroup_ColumnHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ColumnHeader.Unmarshal(m, b)
+}
+func (m *TestGroup_ColumnHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ColumnHeader.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ColumnHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ColumnHeader.Merge(m, src)
+}
+func (m *TestGroup_ColumnHeader) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ColumnHeader.Size(m)
+}
+func (m *TestGroup_ColumnHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ColumnHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ColumnHeader proto.InternalMessageInfo
+
+func (m *TestGroup_ColumnHeader) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader) GetProperty() string {
+	if m != nil {
+		return m.Property
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader) GetConfigurationValue() string {
+	if m != nil {
+		return m.ConfigurationValue
+	}
+	return ""
+}
+
+// Associates the presence of a named test property with a custom short text
+// displayed over the results. Short text must be <=5 characters long.
+type TestGroup_TestAnnotation struct {
+	ShortText string `protobuf:"bytes,1,opt,name=short_text,json=shortText,proto3" json:"short_text,omitempty"`
+	// Types that are valid to be assigned to ShortTextMessageSource:
+	//	*TestGroup_TestAnnotation_PropertyName
+	ShortTextMessageSource isTestGroup_TestAnnotation_ShortTextMessageSource `protobuf_oneof:"short_text_message_source"`
+	XXX_NoUnkeyedLiteral   struct{}                                          `json:"-"`
+	XXX_unrecognized       []byte                                            `json:"-"`
+	XXX_sizecache          int32                                             `json:"-"`
+}
+
+func (m *TestGroup_TestAnnotation) Reset()         { *m = TestGroup_TestAnnotation{} }
+func (m *TestGroup_TestAnnotation) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_TestAnnotation) ProtoMessage()    {}
+func (*TestGroup_TestAnnotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 1}
+}
+
+func (m *TestGroup_TestAnnotation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_TestAnnotation.Unmarshal(m, b)
+}
+func (m *TestGroup_TestAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_TestAnnotation.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_TestAnnotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_TestAnnotation.Merge(m, src)
+}
+func (m *TestGroup_TestAnnotation) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_TestAnnotation.Size(m)
+}
+func (m *TestGroup_TestAnnotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_TestAnnotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_TestAnnotation proto.InternalMessageInfo
+
+func (m *TestGroup_TestAnnotation) GetShortText() string {
+	if m != nil {
+		return m.ShortText
+	}
+	return ""
+}
+
+type isTestGroup_TestAnnotation_ShortTextMessageSource interface {
+	isTestGroup_TestAnnotation_ShortTextMessageSource()
+}
+
+type TestGroup_TestAnnotation_PropertyName struct {
+	PropertyName string `protobuf:"bytes,2,opt,name=property_name,json=propertyName,proto3,oneof"`
+}
+
+func (*TestGroup_TestAnnotation_PropertyName) isTestGroup_TestAnnotation_ShortTextMessageSource() {}
+
+func (m *TestGroup_TestAnnotation) GetShortTextMessageSource() isTestGroup_TestAnnotation_ShortTextMessageSource {
+	if m != nil {
+		return m.ShortTextMessageSource
+	}
+	return nil
+}
+
+func (m *TestGroup_TestAnnotation) GetPropertyName() string {
+	if x, ok := m.GetShortTextMessageSource().(*TestGroup_TestAnnotation_PropertyName); ok {
+		return x.PropertyName
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestGroup_TestAnnotation) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestGroup_TestAnnotation_PropertyName)(nil),
+	}
+}
+
+// A string key value pair message
+type TestGroup_KeyValue struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_KeyValue) Reset()         { *m = TestGroup_KeyValue{} }
+func (m *TestGroup_KeyValue) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_KeyValue) ProtoMessage()    {}
+func (*TestGroup_KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 2}
+}
+
+func (m *TestGroup_KeyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_KeyValue.Unmarshal(m, b)
+}
+func (m *TestGroup_KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_KeyValue.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_KeyValue.Merge(m, src)
+}
+func (m *TestGroup_KeyValue) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_KeyValue.Size(m)
+}
+func (m *TestGroup_KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_KeyValue proto.InternalMessageInfo
+
+func (m *TestGroup_KeyValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *TestGroup_KeyValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type TestGroup_ResultSource struct {
+	// Types that are valid to be assigned to ResultSourceConfig:
+	//	*TestGroup_ResultSource_JunitConfig
+	ResultSourceConfig   isTestGroup_ResultSource_ResultSourceConfig `protobuf_oneof:"result_source_config"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *TestGroup_ResultSource) Reset()         { *m = TestGroup_ResultSource{} }
+func (m *TestGroup_ResultSource) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ResultSource) ProtoMessage()    {}
+func (*TestGroup_ResultSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 3}
+}
+
+func (m *TestGroup_ResultSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ResultSource.Unmarshal(m, b)
+}
+func (m *TestGroup_ResultSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ResultSource.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ResultSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ResultSource.Merge(m, src)
+}
+func (m *TestGroup_ResultSource) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ResultSource.Size(m)
+}
+func (m *TestGroup_ResultSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ResultSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ResultSource proto.InternalMessageInfo
+
+type isTestGroup_ResultSource_ResultSourceConfig interface {
+	isTestGroup_ResultSource_ResultSourceConfig()
+}
+
+type TestGroup_ResultSource_JunitConfig struct {
+	JunitConfig *JUnitConfig `protobuf:"bytes,2,opt,name=junit_config,json=junitConfig,proto3,oneof"`
+}
+
+func (*TestGroup_ResultSource_JunitConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (m *TestGroup_ResultSource) GetResultSourceConfig() isTestGroup_ResultSource_ResultSourceConfig {
+	if m != nil {
+		return m.ResultSourceConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetJunitConfig() *JUnitConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_JunitConfig); ok {
+		return x.JunitConfig
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestGroup_ResultSource) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestGroup_ResultSource_JunitConfig)(nil),
+	}
+}
+
+// Links an alert to an issue when its failure message matches a regex.
+type TestGroup_IssueLinkRule struct {
+	// Regex to match against the alert's failure message.
+	MessageRegex string `protobuf:"bytes,1,opt,name=message_regex,json=messageRegex,proto3" json:"message_regex,omitempty"`
+	// URL of the issue, which may reference submatches of message_regex such
+	// as $1 or ${name}.
+	IssueUrlTemplate     string   `protobuf:"bytes,2,opt,name=issue_url_template,json=issueUrlTemplate,proto3" json:"issue_url_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_IssueLinkRule) Reset()         { *m = TestGroup_IssueLinkRule{} }
+func (m *TestGroup_IssueLinkRule) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_IssueLinkRule) ProtoMessage()    {}
+func (*TestGroup_IssueLinkRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4}
+}
+
+func (m *TestGroup_IssueLinkRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_IssueLinkRule.Unmarshal(m, b)
+}
+func (m *TestGroup_IssueLinkRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_IssueLinkRule.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_IssueLinkRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_IssueLinkRule.Merge(m, src)
+}
+func (m *TestGroup_IssueLinkRule) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_IssueLinkRule.Size(m)
+}
+func (m *TestGroup_IssueLinkRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_IssueLinkRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_IssueLinkRule proto.InternalMessageInfo
+
+func (m *TestGroup_IssueLinkRule) GetMessageRegex() string {
+	if m != nil {
+		return m.MessageRegex
+	}
+	return ""
+}
+
+func (m *TestGroup_IssueLinkRule) GetIssueUrlTemplate() string {
+	if m != nil {
+		return m.IssueUrlTemplate
+	}
+	return ""
+}
+
+// Overrides the alert thresholds of a specific row.
+type TestGroup_RowAlertThreshold struct {
+	// Exact name of the row.
+	RowName string `protobuf:"bytes,1,opt,name=row_name,json=rowName,proto3" json:"row_name,omitempty"`
+	// Overrides num_failures_to_alert for this row when set.
+	NumFailuresToAlert int32 `protobuf:"varint,2,opt,name=num_failures_to_alert,json=numFailuresToAlert,proto3" json:"num_failures_to_alert,omitempty"`
+	// Overrides num_passes_to_disable_alert for this row when set.
+	NumPassesToDisableAlert int32 `protobuf:"varint,3,opt,name=num_passes_to_disable_alert,json=numPassesToDisableAlert,proto3" json:"num_passes_to_disable_alert,omitempty"`
+	// Overrides alert_message_template for this row when set.
+	AlertMessageTemplate string   `protobuf:"bytes,4,opt,name=alert_message_template,json=alertMessageTemplate,proto3" json:"alert_message_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_RowAlertThreshold) Reset()         { *m = TestGroup_RowAlertThreshold{} }
+func (m *TestGroup_RowAlertThreshold) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_RowAlertThreshold) ProtoMessage()    {}
+func (*TestGroup_RowAlertThreshold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 5}
+}
+
+func (m *TestGroup_RowAlertThreshold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_RowAlertThreshold.Unmarshal(m, b)
+}
+func (m *TestGroup_RowAlertThreshold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_RowAlertThreshold.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_RowAlertThreshold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_RowAlertThreshold.Merge(m, src)
+}
+func (m *TestGroup_RowAlertThreshold) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_RowAlertThreshold.Size(m)
+}
+func (m *TestGroup_RowAlertThreshold) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_RowAlertThreshold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_RowAlertThreshold proto.InternalMessageInfo
+
+func (m *TestGroup_RowAlertThreshold) GetRowName() string {
+	if m != nil {
+		return m.RowName
+	}
+	return ""
+}
+
+func (m *TestGroup_RowAlertThreshold) GetNumFailuresToAlert() int32 {
+	if m != nil {
+		return m.NumFailuresToAlert
+	}
+	return 0
+}
+
+func (m *TestGroup_RowAlertThreshold) GetNumPassesToDisableAlert() int32 {
+	if m != nil {
+		return m.NumPassesToDisableAlert
+	}
+	return 0
+}
+
+func (m *TestGroup_RowAlertThreshold) GetAlertMessageTemplate() string {
+	if m != nil {
+		return m.AlertMessageTemplate
+	}
+	return ""
+}
+
+// Rewrites row names, such as to strip a churning UUID from the name.
+type TestGroup_RowRenameRule struct {
+	// Regex to find in the row name.
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Replacement for each match, which may reference submatches of pattern
+	// such as $1 or ${name}.
+	Replacement          string   `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_RowRenameRule) Reset()         { *m = TestGroup_RowRenameRule{} }
+func (m *TestGroup_RowRenameRule) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_RowRenameRule) ProtoMessage()    {}
+func (*TestGroup_RowRenameRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 6}
+}
+
+func (m *TestGroup_RowRenameRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_RowRenameRule.Unmarshal(m, b)
+}
+func (m *TestGroup_RowRenameRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_RowRenameRule.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_RowRenameRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_RowRenameRule.Merge(m, src)
+}
+func (m *TestGroup_RowRenameRule) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_RowRenameRule.Size(m)
+}
+func (m *TestGroup_RowRenameRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_RowRenameRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_RowRenameRule proto.InternalMessageInfo
+
+func (m *TestGroup_RowRenameRule) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *TestGroup_RowRenameRule) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+// Selects the build id of each column reported in alerts.
+type TestGroup_BuildIdSelector struct {
+	// Use the column header value at this index, see column_header.
+	HeaderIndex int32 `protobuf:"varint,1,opt,name=header_index,json=headerIndex,proto3" json:"header_index,omitempty"`
+	// Use the value of the column header with this configuration_value,
+	// such as a metadata key. Takes precedence over header_index.
+	ConfigurationValue   string   `protobuf:"bytes,2,opt,name=configuration_value,json=configurationValue,proto3" json:"configuration_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_BuildIdSelector) Reset()         { *m = TestGroup_BuildIdSelector{} }
+func (m *TestGroup_BuildIdSelector) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_BuildIdSelector) ProtoMessage()    {}
+func (*TestGroup_BuildIdSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 7}
+}
+
+func (m *TestGroup_BuildIdSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_BuildIdSelector.Unmarshal(m, b)
+}
+func (m *TestGroup_BuildIdSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_BuildIdSelector.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_BuildIdSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_BuildIdSelector.Merge(m, src)
+}
+func (m *TestGroup_BuildIdSelector) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_BuildIdSelector.Size(m)
+}
+func (m *TestGroup_BuildIdSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_BuildIdSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_BuildIdSelector proto.InternalMessageInfo
+
+func (m *TestGroup_BuildIdSelector) GetHeaderIndex() int32 {
+	if m != nil {
+		return m.HeaderIndex
+	}
+	return 0
+}
+
+func (m *TestGroup_BuildIdSelector) GetConfigurationValue() string {
+	if m != nil {
+		return m.ConfigurationValue
+	}
+	return ""
+}
+
+// Rewrites cell messages, such as to replace volatile timestamps or
+// pointers, so the same failure always has the same message.
+type TestGroup_MessageNormalizationRule struct {
+	// Regex to find in the message.
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Replacement for each match, which may reference submatches of pattern
+	// such as $1 or ${name}.
+	Replacement          string   `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_MessageNormalizationRule) Reset()         { *m = TestGroup_MessageNormalizationRule{} }
+func (m *TestGroup_MessageNormalizationRule) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_MessageNormalizationRule) ProtoMessage()    {}
+func (*TestGroup_MessageNormalizationRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 8}
+}
+
+func (m *TestGroup_MessageNormalizationRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_MessageNormalizationRule.Unmarshal(m, b)
+}
+func (m *TestGroup_MessageNormalizationRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_MessageNormalizationRule.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_MessageNormalizationRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_MessageNormalizationRule.Merge(m, src)
+}
+func (m *TestGroup_MessageNormalizationRule) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_MessageNormalizationRule.Size(m)
+}
+func (m *TestGroup_MessageNormalizationRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_MessageNormalizationRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_MessageNormalizationRule proto.InternalMessageInfo
+
+func (m *TestGroup_MessageNormalizationRule) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *TestGroup_MessageNormalizationRule) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+// Detects regressions of a metric, such as duration, against the median of
+// its values in older columns.
+type TestGroup_MetricRegressionRule struct {
+	// Name of the metric, such as duration.
+	Metric string `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	// Compare each value against the median of this many older values of the
+	// metric, skipping columns without one. Defaults to 5.
+	BaselineColumns int32 `protobuf:"varint,2,opt,name=baseline_columns,json=baselineColumns,proto3" json:"baseline_columns,omitempty"`
+	// Flag values worse than the baseline by more than this percentage.
+	ThresholdPercent float64 `protobuf:"fixed64,3,opt,name=threshold_percent,json=thresholdPercent,proto3" json:"threshold_percent,omitempty"`
+	// Lower values are worse, such as for throughput. Otherwise higher values
+	// are worse.
+	LowerIsWorse         bool     `protobuf:"varint,4,opt,name=lower_is_worse,json=lowerIsWorse,proto3" json:"lower_is_worse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_MetricRegressionRule) Reset()         { *m = TestGroup_MetricRegressionRule{} }
+func (m *TestGroup_MetricRegressionRule) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_MetricRegressionRule) ProtoMessage()    {}
+func (*TestGroup_MetricRegressionRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 9}
+}
+
+func (m *TestGroup_MetricRegressionRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_MetricRegressionRule.Unmarshal(m, b)
+}
+func (m *TestGroup_MetricRegressionRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_MetricRegressionRule.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_MetricRegressionRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_MetricRegressionRule.Merge(m, src)
+}
+func (m *TestGroup_MetricRegressionRule) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_MetricRegressionRule.Size(m)
+}
+func (m *TestGroup_MetricRegressionRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_MetricRegressionRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_MetricRegressionRule proto.InternalMessageInfo
+
+func (m *TestGroup_MetricRegressionRule) GetMetric() string {
 	if m != nil {
-		return m.FallbackGroupingConfigurationValue
+		return m.Metric
 	}
 	return ""
 }
 
-func (m *TestGroup) GetResultSource() *TestGroup_ResultSource {
+func (m *TestGroup_MetricRegressionRule) GetBaselineColumns() int32 {
 	if m != nil {
-		return m.ResultSource
+		return m.BaselineColumns
 	}
-	return nil
+	return 0
 }
 
-func (m *TestGroup) GetCustomEvaluatorRuleSet() *custom_evaluator.RuleSet {
+func (m *TestGroup_MetricRegressionRule) GetThresholdPercent() float64 {
 	if m != nil {
-		return m.CustomEvaluatorRuleSet
+		return m.ThresholdPercent
 	}
-	return nil
+	return 0
 }
 
-func (m *TestGroup) GetReadStateFromStorage() bool {
+func (m *TestGroup_MetricRegressionRule) GetLowerIsWorse() bool {
 	if m != nil {
-		return m.ReadStateFromStorage
+		return m.LowerIsWorse
 	}
 	return false
 }
 
-func (m *TestGroup) GetIgnoreOldResults() bool {
-	if m != nil {
-		return m.IgnoreOldResults
-	}
-	return false
+// Opens an alert when the recent results of a row are mostly failing, even
+// with occasional passes. Each result weighs decay times the result after
+// it, so recent results count the most.
+type TestGroup_WeightedAlert struct {
+	// Weight of each result relative to the next newer one, between 0 and 1.
+	// Defaults to 0.8.
+	Decay float64 `protobuf:"fixed64,1,opt,name=decay,proto3" json:"decay,omitempty"`
+	// Alert when the weighted fraction of failing results exceeds this value,
+	// between 0 and 1.
+	Threshold            float64  `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestGroup) GetIgnoreSkip() bool {
-	if m != nil {
-		return m.IgnoreSkip
-	}
-	return false
+func (m *TestGroup_WeightedAlert) Reset()         { *m = TestGroup_WeightedAlert{} }
+func (m *TestGroup_WeightedAlert) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_WeightedAlert) ProtoMessage()    {}
+func (*TestGroup_WeightedAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 10}
 }
 
-func (m *TestGroup) GetBuildOverrideStrftime() string {
+func (m *TestGroup_WeightedAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_WeightedAlert.Unmarshal(m, b)
+}
+func (m *TestGroup_WeightedAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_WeightedAlert.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_WeightedAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_WeightedAlert.Merge(m, src)
+}
+func (m *TestGroup_WeightedAlert) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_WeightedAlert.Size(m)
+}
+func (m *TestGroup_WeightedAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_WeightedAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_WeightedAlert proto.InternalMessageInfo
+
+func (m *TestGroup_WeightedAlert) GetDecay() float64 {
 	if m != nil {
-		return m.BuildOverrideStrftime
+		return m.Decay
 	}
-	return ""
+	return 0
 }
 
-func (m *TestGroup) GetUserProperty() string {
+func (m *TestGroup_WeightedAlert) GetThreshold() float64 {
 	if m != nil {
-		return m.UserProperty
+		return m.Threshold
 	}
-	return ""
+	return 0
 }
 
-// Custom column headers for defining extra column-heading rows from values in
-// the test result.
-type TestGroup_ColumnHeader struct {
-	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
-	Property             string   `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
-	ConfigurationValue   string   `protobuf:"bytes,3,opt,name=configuration_value,json=configurationValue,proto3" json:"configuration_value,omitempty"`
+// Percentages of the passing, failing and flaky results of a column which
+// determine its overall health.
+type TestGroup_ColumnHealth struct {
+	// A column is broken when at least this percentage of results fail.
+	// Defaults to 50.
+	BrokenPercent float64 `protobuf:"fixed64,1,opt,name=broken_percent,json=brokenPercent,proto3" json:"broken_percent,omitempty"`
+	// A column is flaky when at least this percentage of results are flaky.
+	// Defaults to 10.
+	FlakyPercent         float64  `protobuf:"fixed64,2,opt,name=flaky_percent,json=flakyPercent,proto3" json:"flaky_percent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestGroup_ColumnHeader) Reset()         { *m = TestGroup_ColumnHeader{} }
-func (m *TestGroup_ColumnHeader) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_ColumnHeader) ProtoMessage()    {}
-func (*TestGroup_ColumnHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 0}
+func (m *TestGroup_ColumnHealth) Reset()         { *m = TestGroup_ColumnHealth{} }
+func (m *TestGroup_ColumnHealth) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ColumnHealth) ProtoMessage()    {}
+func (*TestGroup_ColumnHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 11}
 }
 
-func (m *TestGroup_ColumnHeader) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_ColumnHeader.Unmarshal(m, b)
+func (m *TestGroup_ColumnHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ColumnHealth.Unmarshal(m, b)
 }
-func (m *TestGroup_ColumnHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_ColumnHeader.Marshal(b, m, deterministic)
+func (m *TestGroup_ColumnHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ColumnHealth.Marshal(b, m, deterministic)
 }
-func (m *TestGroup_ColumnHeader) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_ColumnHeader.Merge(m, src)
+func (m *TestGroup_ColumnHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ColumnHealth.Merge(m, src)
 }
-func (m *TestGroup_ColumnHeader) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_ColumnHeader.Size(m)
+func (m *TestGroup_ColumnHealth) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ColumnHealth.Size(m)
 }
-func (m *TestGroup_ColumnHeader) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_ColumnHeader.DiscardUnknown(m)
+func (m *TestGroup_ColumnHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ColumnHealth.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TestGroup_ColumnHeader proto.InternalMessageInfo
-
-func (m *TestGroup_ColumnHeader) GetLabel() string {
-	if m != nil {
-		return m.Label
-	}
-	return ""
-}
+var xxx_messageInfo_TestGroup_ColumnHealth proto.InternalMessageInfo
 
-func (m *TestGroup_ColumnHeader) GetProperty() string {
+func (m *TestGroup_ColumnHealth) GetBrokenPercent() float64 {
 	if m != nil {
-		return m.Property
+		return m.BrokenPercent
 	}
-	return ""
+	return 0
 }
 
-func (m *TestGroup_ColumnHeader) GetConfigurationValue() string {
+func (m *TestGroup_ColumnHealth) GetFlakyPercent() float64 {
 	if m != nil {
-		return m.ConfigurationValue
+		return m.FlakyPercent
 	}
-	return ""
+	return 0
 }
 
-// Associates the presence of a named test property with a custom short text
-// displayed over the results. Short text must be <=5 characters long.
-type TestGroup_TestAnnotation struct {
-	ShortText string `protobuf:"bytes,1,opt,name=short_text,json=shortText,proto3" json:"short_text,omitempty"`
-	// Types that are valid to be assigned to ShortTextMessageSource:
-	//	*TestGroup_TestAnnotation_PropertyName
-	ShortTextMessageSource isTestGroup_TestAnnotation_ShortTextMessageSource `protobuf_oneof:"short_text_message_source"`
-	XXX_NoUnkeyedLiteral   struct{}                                          `json:"-"`
-	XXX_unrecognized       []byte                                            `json:"-"`
-	XXX_sizecache          int32                                             `json:"-"`
+type TestGroup_MetricAlias struct {
+	// Name of the metric as reported by the results.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Store the metric under this name instead.
+	Canonical            string   `protobuf:"bytes,2,opt,name=canonical,proto3" json:"canonical,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestGroup_TestAnnotation) Reset()         { *m = TestGroup_TestAnnotation{} }
-func (m *TestGroup_TestAnnotation) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_TestAnnotation) ProtoMessage()    {}
-func (*TestGroup_TestAnnotation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 1}
+func (m *TestGroup_MetricAlias) Reset()         { *m = TestGroup_MetricAlias{} }
+func (m *TestGroup_MetricAlias) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_MetricAlias) ProtoMessage()    {}
+func (*TestGroup_MetricAlias) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 12}
 }
 
-func (m *TestGroup_TestAnnotation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_TestAnnotation.Unmarshal(m, b)
+func (m *TestGroup_MetricAlias) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_MetricAlias.Unmarshal(m, b)
 }
-func (m *TestGroup_TestAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_TestAnnotation.Marshal(b, m, deterministic)
+func (m *TestGroup_MetricAlias) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_MetricAlias.Marshal(b, m, deterministic)
 }
-func (m *TestGroup_TestAnnotation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_TestAnnotation.Merge(m, src)
+func (m *TestGroup_MetricAlias) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_MetricAlias.Merge(m, src)
 }
-func (m *TestGroup_TestAnnotation) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_TestAnnotation.Size(m)
+func (m *TestGroup_MetricAlias) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_MetricAlias.Size(m)
 }
-func (m *TestGroup_TestAnnotation) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_TestAnnotation.DiscardUnknown(m)
+func (m *TestGroup_MetricAlias) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_MetricAlias.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TestGroup_TestAnnotation proto.InternalMessageInfo
+var xxx_messageInfo_TestGroup_MetricAlias proto.InternalMessageInfo
 
-func (m *TestGroup_TestAnnotation) GetShortText() string {
+func (m *TestGroup_MetricAlias) GetName() string {
 	if m != nil {
-		return m.ShortText
+		return m.Name
 	}
 	return ""
 }
 
-type isTestGroup_TestAnnotation_ShortTextMessageSource interface {
-	isTestGroup_TestAnnotation_ShortTextMessageSource()
+func (m *TestGroup_MetricAlias) GetCanonical() string {
+	if m != nil {
+		return m.Canonical
+	}
+	return ""
 }
 
-type TestGroup_TestAnnotation_PropertyName struct {
-	PropertyName string `protobuf:"bytes,2,opt,name=property_name,json=propertyName,proto3,oneof"`
+// Attaches triage metadata to rows whose target matches a regex.
+type TestGroup_TargetMetadata struct {
+	// Regex to match against the row's target, such as //pkg/foo:go_test.
+	TargetRegex string `protobuf:"bytes,1,opt,name=target_regex,json=targetRegex,proto3" json:"target_regex,omitempty"`
+	// Owner of the matching rows, such as a team or an email address.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Component of the matching rows.
+	Component            string   `protobuf:"bytes,3,opt,name=component,proto3" json:"component,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (*TestGroup_TestAnnotation_PropertyName) isTestGroup_TestAnnotation_ShortTextMessageSource() {}
+func (m *TestGroup_TargetMetadata) Reset()         { *m = TestGroup_TargetMetadata{} }
+func (m *TestGroup_TargetMetadata) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_TargetMetadata) ProtoMessage()    {}
+func (*TestGroup_TargetMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 13}
+}
 
-func (m *TestGroup_TestAnnotation) GetShortTextMessageSource() isTestGroup_TestAnnotation_ShortTextMessageSource {
+func (m *TestGroup_TargetMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_TargetMetadata.Unmarshal(m, b)
+}
+func (m *TestGroup_TargetMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_TargetMetadata.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_TargetMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_TargetMetadata.Merge(m, src)
+}
+func (m *TestGroup_TargetMetadata) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_TargetMetadata.Size(m)
+}
+func (m *TestGroup_TargetMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_TargetMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_TargetMetadata proto.InternalMessageInfo
+
+func (m *TestGroup_TargetMetadata) GetTargetRegex() string {
 	if m != nil {
-		return m.ShortTextMessageSource
+		return m.TargetRegex
 	}
-	return nil
+	return ""
 }
 
-func (m *TestGroup_TestAnnotation) GetPropertyName() string {
-	if x, ok := m.GetShortTextMessageSource().(*TestGroup_TestAnnotation_PropertyName); ok {
-		return x.PropertyName
+func (m *TestGroup_TargetMetadata) GetOwner() string {
+	if m != nil {
+		return m.Owner
 	}
 	return ""
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*TestGroup_TestAnnotation) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*TestGroup_TestAnnotation_PropertyName)(nil),
+func (m *TestGroup_TargetMetadata) GetComponent() string {
+	if m != nil {
+		return m.Component
 	}
+	return ""
 }
 
-// A string key value pair message
-type TestGroup_KeyValue struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+// Matches the metadata in finished.json of a build.
+type TestGroup_BuildMetadataFilter struct {
+	// Metadata key to check, such as cloud-provider.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Value the key must hold. Empty only requires the key to be present.
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestGroup_KeyValue) Reset()         { *m = TestGroup_KeyValue{} }
-func (m *TestGroup_KeyValue) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_KeyValue) ProtoMessage()    {}
-func (*TestGroup_KeyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 2}
+func (m *TestGroup_BuildMetadataFilter) Reset()         { *m = TestGroup_BuildMetadataFilter{} }
+func (m *TestGroup_BuildMetadataFilter) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_BuildMetadataFilter) ProtoMessage()    {}
+func (*TestGroup_BuildMetadataFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 14}
 }
 
-func (m *TestGroup_KeyValue) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_KeyValue.Unmarshal(m, b)
+func (m *TestGroup_BuildMetadataFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_BuildMetadataFilter.Unmarshal(m, b)
 }
-func (m *TestGroup_KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_KeyValue.Marshal(b, m, deterministic)
+func (m *TestGroup_BuildMetadataFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_BuildMetadataFilter.Marshal(b, m, deterministic)
 }
-func (m *TestGroup_KeyValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_KeyValue.Merge(m, src)
+func (m *TestGroup_BuildMetadataFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_BuildMetadataFilter.Merge(m, src)
 }
-func (m *TestGroup_KeyValue) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_KeyValue.Size(m)
+func (m *TestGroup_BuildMetadataFilter) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_BuildMetadataFilter.Size(m)
 }
-func (m *TestGroup_KeyValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_KeyValue.DiscardUnknown(m)
+func (m *TestGroup_BuildMetadataFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_BuildMetadataFilter.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TestGroup_KeyValue proto.InternalMessageInfo
+var xxx_messageInfo_TestGroup_BuildMetadataFilter proto.InternalMessageInfo
 
-func (m *TestGroup_KeyValue) GetKey() string {
+func (m *TestGroup_BuildMetadataFilter) GetKey() string {
 	if m != nil {
 		return m.Key
 	}
 	return ""
 }
 
-func (m *TestGroup_KeyValue) GetValue() string {
+func (m *TestGroup_BuildMetadataFilter) GetValue() string {
 	if m != nil {
 		return m.Value
 	}
 	return ""
 }
 
-type TestGroup_ResultSource struct {
-	// Types that are valid to be assigned to ResultSourceConfig:
-	//	*TestGroup_ResultSource_JunitConfig
-	ResultSourceConfig   isTestGroup_ResultSource_ResultSourceConfig `protobuf_oneof:"result_source_config"`
-	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
-	XXX_unrecognized     []byte                                      `json:"-"`
-	XXX_sizecache        int32                                       `json:"-"`
+// Thins older metric values to bound the size of rows reporting a metric in
+// every column. Values are kept at least a number of columns apart, starting
+// from the oldest, so thinning an already thinned row changes nothing.
+type TestGroup_MetricDownsample struct {
+	// Keep every value in this many of the newest columns.
+	RecentColumns int32 `protobuf:"varint,1,opt,name=recent_columns,json=recentColumns,proto3" json:"recent_columns,omitempty"`
+	// Keep at most one older value in this many adjacent columns, such as
+	// every 5th value of a metric reported in every column.
+	EveryNth int32 `protobuf:"varint,2,opt,name=every_nth,json=everyNth,proto3" json:"every_nth,omitempty"`
+	// Space older values further apart to keep about this many per metric.
+	MaxPoints            int32    `protobuf:"varint,3,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestGroup_ResultSource) Reset()         { *m = TestGroup_ResultSource{} }
-func (m *TestGroup_ResultSource) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_ResultSource) ProtoMessage()    {}
-func (*TestGroup_ResultSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 3}
+func (m *TestGroup_MetricDownsample) Reset()         { *m = TestGroup_MetricDownsample{} }
+func (m *TestGroup_MetricDownsample) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_MetricDownsample) ProtoMessage()    {}
+func (*TestGroup_MetricDownsample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 15}
 }
 
-func (m *TestGroup_ResultSource) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_ResultSource.Unmarshal(m, b)
-}
-func (m *TestGroup_ResultSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_ResultSource.Marshal(b, m, deterministic)
-}
-func (m *TestGroup_ResultSource) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_ResultSource.Merge(m, src)
+func (m *TestGroup_MetricDownsample) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_MetricDownsample.Unmarshal(m, b)
 }
-func (m *TestGroup_ResultSource) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_ResultSource.Size(m)
+func (m *TestGroup_MetricDownsample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_MetricDownsample.Marshal(b, m, deterministic)
 }
-func (m *TestGroup_ResultSource) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_ResultSource.DiscardUnknown(m)
+func (m *TestGroup_MetricDownsample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_MetricDownsample.Merge(m, src)
 }
-
-var xxx_messageInfo_TestGroup_ResultSource proto.InternalMessageInfo
-
-type isTestGroup_ResultSource_ResultSourceConfig interface {
-	isTestGroup_ResultSource_ResultSourceConfig()
+func (m *TestGroup_MetricDownsample) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_MetricDownsample.Size(m)
 }
-
-type TestGroup_ResultSource_JunitConfig struct {
-	JunitConfig *JUnitConfig `protobuf:"bytes,2,opt,name=junit_config,json=junitConfig,proto3,oneof"`
+func (m *TestGroup_MetricDownsample) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_MetricDownsample.DiscardUnknown(m)
 }
 
-func (*TestGroup_ResultSource_JunitConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+var xxx_messageInfo_TestGroup_MetricDownsample proto.InternalMessageInfo
 
-func (m *TestGroup_ResultSource) GetResultSourceConfig() isTestGroup_ResultSource_ResultSourceConfig {
+func (m *TestGroup_MetricDownsample) GetRecentColumns() int32 {
 	if m != nil {
-		return m.ResultSourceConfig
+		return m.RecentColumns
 	}
-	return nil
+	return 0
 }
 
-func (m *TestGroup_ResultSource) GetJunitConfig() *JUnitConfig {
-	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_JunitConfig); ok {
-		return x.JunitConfig
+func (m *TestGroup_MetricDownsample) GetEveryNth() int32 {
+	if m != nil {
+		return m.EveryNth
 	}
-	return nil
+	return 0
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*TestGroup_ResultSource) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*TestGroup_ResultSource_JunitConfig)(nil),
+func (m *TestGroup_MetricDownsample) GetMaxPoints() int32 {
+	if m != nil {
+		return m.MaxPoints
 	}
+	return 0
 }
 
 type JUnitConfig struct {
@@ -2292,10 +3880,12 @@ type Configuration struct {
 	// A list of all of the dashboards for a server.
 	Dashboards []*Dashboard `protobuf:"bytes,2,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
 	// A list of all the dashboard groups for a server.
-	DashboardGroups      []*DashboardGroup `protobuf:"bytes,3,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DashboardGroups []*DashboardGroup `protobuf:"bytes,3,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
+	// Silences suppressing alerts, such as during planned maintenance.
+	AlertSilences        []*AlertSilence `protobuf:"bytes,4,rep,name=alert_silences,json=alertSilences,proto3" json:"alert_silences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *Configuration) Reset()         { *m = Configuration{} }
@@ -2344,6 +3934,72 @@ func (m *Configuration) GetDashboardGroups() []*DashboardGroup {
 	return nil
 }
 
+func (m *Configuration) GetAlertSilences() []*AlertSilence {
+	if m != nil {
+		return m.AlertSilences
+	}
+	return nil
+}
+
+// Suppresses the alerts of matching test groups until the silence expires.
+type AlertSilence struct {
+	// Regex matching the names of silenced test groups.
+	GroupPattern string `protobuf:"bytes,1,opt,name=group_pattern,json=groupPattern,proto3" json:"group_pattern,omitempty"`
+	// When the silence expires, in seconds since epoch.
+	Until int64 `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	// Why alerts are silenced, such as a link to a maintenance announcement.
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertSilence) Reset()         { *m = AlertSilence{} }
+func (m *AlertSilence) String() string { return proto.CompactTextString(m) }
+func (*AlertSilence) ProtoMessage()    {}
+func (*AlertSilence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{15}
+}
+
+func (m *AlertSilence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertSilence.Unmarshal(m, b)
+}
+func (m *AlertSilence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertSilence.Marshal(b, m, deterministic)
+}
+func (m *AlertSilence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertSilence.Merge(m, src)
+}
+func (m *AlertSilence) XXX_Size() int {
+	return xxx_messageInfo_AlertSilence.Size(m)
+}
+func (m *AlertSilence) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertSilence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertSilence proto.InternalMessageInfo
+
+func (m *AlertSilence) GetGroupPattern() string {
+	if m != nil {
+		return m.GroupPattern
+	}
+	return ""
+}
+
+func (m *AlertSilence) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *AlertSilence) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 // A grouping of configuration options for the flakiness analysis tool.
 // Later configuration options could include the ability to choose different kinds of
 // flakiness and choosing if and who to email a copy of the flakiness report.
@@ -2376,7 +4032,7 @@ func (m *HealthAnalysisOptions) Reset()         { *m = HealthAnalysisOptions{} }
 func (m *HealthAnalysisOptions) String() string { return proto.CompactTextString(m) }
 func (*HealthAnalysisOptions) ProtoMessage()    {}
 func (*HealthAnalysisOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{15}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{16}
 }
 
 func (m *HealthAnalysisOptions) XXX_Unmarshal(b []byte) error {
@@ -2448,7 +4104,7 @@ func (m *DefaultConfiguration) Reset()         { *m = DefaultConfiguration{} }
 func (m *DefaultConfiguration) String() string { return proto.CompactTextString(m) }
 func (*DefaultConfiguration) ProtoMessage()    {}
 func (*DefaultConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{16}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{17}
 }
 
 func (m *DefaultConfiguration) XXX_Unmarshal(b []byte) error {
@@ -2489,6 +4145,14 @@ func init() {
 	proto.RegisterEnum("TestGroup_TestsName", TestGroup_TestsName_name, TestGroup_TestsName_value)
 	proto.RegisterEnum("TestGroup_FallbackGrouping", TestGroup_FallbackGrouping_name, TestGroup_FallbackGrouping_value)
 	proto.RegisterEnum("TestGroup_PrimaryGrouping", TestGroup_PrimaryGrouping_name, TestGroup_PrimaryGrouping_value)
+	proto.RegisterEnum("TestGroup_RowSort", TestGroup_RowSort_name, TestGroup_RowSort_value)
+	proto.RegisterEnum("TestGroup_GridCompression", TestGroup_GridCompression_name, TestGroup_GridCompression_value)
+	proto.RegisterEnum("TestGroup_RetryPolicy", TestGroup_RetryPolicy_name, TestGroup_RetryPolicy_value)
+	proto.RegisterEnum("TestGroup_SkippedResult", TestGroup_SkippedResult_name, TestGroup_SkippedResult_value)
+	proto.RegisterEnum("TestGroup_MissingStarted", TestGroup_MissingStarted_name, TestGroup_MissingStarted_value)
+	proto.RegisterEnum("TestGroup_ColumnGrouping", TestGroup_ColumnGrouping_name, TestGroup_ColumnGrouping_value)
+	proto.RegisterEnum("TestGroup_ColumnLimit", TestGroup_ColumnLimit_name, TestGroup_ColumnLimit_value)
+	proto.RegisterEnum("TestGroup_FlakyAlert", TestGroup_FlakyAlert_name, TestGroup_FlakyAlert_value)
 	proto.RegisterEnum("AutoBugOptions_Priority", AutoBugOptions_Priority_name, AutoBugOptions_Priority_value)
 	proto.RegisterType((*TestNameConfig)(nil), "TestNameConfig")
 	proto.RegisterType((*TestNameConfig_NameElement)(nil), "TestNameConfig.NameElement")
@@ -2498,6 +4162,18 @@ func init() {
 	proto.RegisterType((*TestGroup_TestAnnotation)(nil), "TestGroup.TestAnnotation")
 	proto.RegisterType((*TestGroup_KeyValue)(nil), "TestGroup.KeyValue")
 	proto.RegisterType((*TestGroup_ResultSource)(nil), "TestGroup.ResultSource")
+	proto.RegisterType((*TestGroup_IssueLinkRule)(nil), "TestGroup.IssueLinkRule")
+	proto.RegisterType((*TestGroup_RowAlertThreshold)(nil), "TestGroup.RowAlertThreshold")
+	proto.RegisterType((*TestGroup_RowRenameRule)(nil), "TestGroup.RowRenameRule")
+	proto.RegisterType((*TestGroup_BuildIdSelector)(nil), "TestGroup.BuildIdSelector")
+	proto.RegisterType((*TestGroup_MessageNormalizationRule)(nil), "TestGroup.MessageNormalizationRule")
+	proto.RegisterType((*TestGroup_MetricRegressionRule)(nil), "TestGroup.MetricRegressionRule")
+	proto.RegisterType((*TestGroup_WeightedAlert)(nil), "TestGroup.WeightedAlert")
+	proto.RegisterType((*TestGroup_ColumnHealth)(nil), "TestGroup.ColumnHealth")
+	proto.RegisterType((*TestGroup_MetricAlias)(nil), "TestGroup.MetricAlias")
+	proto.RegisterType((*TestGroup_TargetMetadata)(nil), "TestGroup.TargetMetadata")
+	proto.RegisterType((*TestGroup_BuildMetadataFilter)(nil), "TestGroup.BuildMetadataFilter")
+	proto.RegisterType((*TestGroup_MetricDownsample)(nil), "TestGroup.MetricDownsample")
 	proto.RegisterType((*JUnitConfig)(nil), "JUnitConfig")
 	proto.RegisterType((*TestMetadataOptions)(nil), "TestMetadataOptions")
 	proto.RegisterType((*AutoBugOptions)(nil), "AutoBugOptions")
@@ -2511,6 +4187,7 @@ func init() {
 	proto.RegisterType((*DashboardTabFlakinessAlertOptions)(nil), "DashboardTabFlakinessAlertOptions")
 	proto.RegisterType((*DashboardGroup)(nil), "DashboardGroup")
 	proto.RegisterType((*Configuration)(nil), "Configuration")
+	proto.RegisterType((*AlertSilence)(nil), "AlertSilence")
 	proto.RegisterType((*HealthAnalysisOptions)(nil), "HealthAnalysisOptions")
 	proto.RegisterType((*DefaultConfiguration)(nil), "DefaultConfiguration")
 }
@@ -2518,217 +4195,356 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptor_3eaf2c85e69e9ea4) }
 
 var fileDescriptor_3eaf2c85e69e9ea4 = []byte{
-	// 3386 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3a, 0x5b, 0x7b, 0xdb, 0x46,
-	0x76, 0xe6, 0x45, 0x36, 0x75, 0x44, 0x4a, 0xd0, 0x50, 0xa2, 0x20, 0x29, 0x6e, 0x64, 0x66, 0xbd,
-	0x51, 0x92, 0x5d, 0x25, 0x96, 0x93, 0x6d, 0xbc, 0xb1, 0x77, 0x97, 0x92, 0x28, 0x4b, 0xb2, 0x2e,
-	0x2c, 0x44, 0x6d, 0xbf, 0xdd, 0x17, 0x74, 0x08, 0x0c, 0x49, 0x44, 0xb8, 0xb0, 0x98, 0x41, 0x6c,
-	0xbd, 0xf5, 0x7f, 0x74, 0x1f, 0xfb, 0xf5, 0x2d, 0x7f, 0xa3, 0x0f, 0x7d, 0xec, 0xd7, 0xfe, 0x9f,
-	0x7e, 0x73, 0x66, 0x00, 0x02, 0x22, 0xed, 0xb8, 0x5f, 0x9f, 0xc4, 0x39, 0xb7, 0x99, 0x39, 0xb7,
-	0x39, 0xe7, 0x40, 0x50, 0x77, 0xa2, 0x70, 0xe8, 0x8d, 0xf6, 0x26, 0x71, 0x24, 0xa2, 0xad, 0x2f,
-	0x27, 0x83, 0xaf, 0x9d, 0x84, 0x8b, 0x28, 0xb0, 0xd9, 0x4f, 0xd4, 0x4f, 0xa8, 0x88, 0xe2, 0x19,
-	0x80, 0xa2, 0x6d, 0xff, 0xad, 0x0c, 0xcb, 0x7d, 0xc6, 0xc5, 0x25, 0x0d, 0xd8, 0x21, 0x0a, 0x21,
-	0x7f, 0x82, 0x46, 0x48, 0x03, 0x66, 0x33, 0x9f, 0x05, 0x2c, 0x14, 0xdc, 0x2c, 0xed, 0x54, 0x76,
-	0x97, 0xf6, 0xb7, 0xf7, 0x8a, 0x74, 0x7b, 0xf2, 0x67, 0x57, 0xd1, 0x58, 0xf5, 0x70, 0xba, 0xe0,
-	0xe4, 0x53, 0x58, 0x42, 0x09, 0xc3, 0x28, 0x0e, 0xa8, 0x30, 0xcb, 0x3b, 0xa5, 0xdd, 0x45, 0x0b,
-	0x24, 0xe8, 0x18, 0x21, 0x5b, 0xff, 0x5e, 0x82, 0xa5, 0x1c, 0x3b, 0x69, 0xc1, 0x43, 0x9f, 0x0e,
-	0x98, 0x2f, 0xf7, 0x92, 0xb4, 0x7a, 0x45, 0x3e, 0x83, 0x86, 0xa0, 0xf1, 0x88, 0x09, 0x5b, 0x5d,
-	0x50, 0x8b, 0xaa, 0x2b, 0xa0, 0x3e, 0xef, 0x13, 0xa8, 0x0f, 0x12, 0xcf, 0x77, 0x6d, 0x05, 0x35,
-	0x2b, 0x3b, 0xa5, 0xdd, 0x9a, 0xb5, 0x84, 0xb0, 0x3e, 0x82, 0x08, 0x81, 0xaa, 0xa0, 0x23, 0x6e,
-	0x56, 0x91, 0x1d, 0x7f, 0xa3, 0x6c, 0xc6, 0x85, 0x3d, 0x89, 0xa3, 0x09, 0x8b, 0xc5, 0x9d, 0xb9,
-	0xa0, 0x65, 0x33, 0x2e, 0x7a, 0x1a, 0xd6, 0x7e, 0x03, 0xf5, 0xcb, 0x48, 0x78, 0x43, 0xcf, 0xa1,
-	0xc2, 0x8b, 0x42, 0x62, 0xc2, 0x23, 0x9e, 0x04, 0x01, 0x8d, 0xef, 0xf4, 0x49, 0xd3, 0xa5, 0x3c,
-	0x85, 0x13, 0x85, 0x82, 0xbd, 0x13, 0xb6, 0xef, 0x85, 0xb7, 0xfa, 0xa4, 0x4b, 0x1a, 0x76, 0xee,
-	0x85, 0xb7, 0xed, 0xbf, 0x7d, 0x02, 0x8b, 0x52, 0x87, 0xaf, 0xe3, 0x28, 0x99, 0xc8, 0x33, 0x49,
-	0x8d, 0x68, 0x39, 0xf8, 0x9b, 0x3c, 0x06, 0x18, 0x39, 0xdc, 0x9e, 0xc4, 0x6c, 0xe8, 0xbd, 0xd3,
-	0x22, 0x16, 0x47, 0x0e, 0xef, 0x21, 0x80, 0xfc, 0x1a, 0x56, 0x5c, 0x7a, 0xc7, 0xed, 0x68, 0x68,
-	0xc7, 0x8c, 0x27, 0xbe, 0xe0, 0x78, 0xd9, 0x05, 0xab, 0x21, 0xc1, 0x57, 0x43, 0x4b, 0x01, 0xc9,
-	0x53, 0x58, 0xf6, 0x46, 0x61, 0x14, 0x33, 0x7b, 0xc2, 0x42, 0xd7, 0x0b, 0x47, 0x78, 0xf1, 0x9a,
-	0xd5, 0x50, 0xd0, 0x9e, 0x02, 0xca, 0x23, 0x6b, 0x32, 0xa9, 0x2b, 0x81, 0x0a, 0xa8, 0x59, 0x4b,
-	0x0a, 0x76, 0x20, 0x41, 0xe4, 0x4f, 0xb0, 0x2a, 0xf5, 0xc1, 0x6d, 0xb4, 0xe7, 0x24, 0xf2, 0x3d,
-	0xe7, 0xce, 0x7c, 0xb8, 0x53, 0xda, 0x5d, 0xde, 0x5f, 0xdb, 0xcb, 0xee, 0x82, 0xbf, 0xb8, 0x34,
-	0xa8, 0xb5, 0x22, 0xd2, 0x9f, 0x3d, 0x24, 0x26, 0xfb, 0xb0, 0xae, 0x37, 0x41, 0x6d, 0xf3, 0x64,
-	0xc0, 0x45, 0x2c, 0x8f, 0x54, 0xdb, 0xa9, 0xec, 0x2e, 0x5a, 0x4d, 0x85, 0x94, 0x02, 0xae, 0x53,
-	0x14, 0x79, 0x09, 0x0d, 0x27, 0xf2, 0x93, 0x20, 0xb4, 0xc7, 0x8c, 0xba, 0x2c, 0x36, 0x17, 0xd1,
-	0x03, 0x37, 0x72, 0x3b, 0x1e, 0x22, 0xfe, 0x04, 0xd1, 0x56, 0xdd, 0xc9, 0xad, 0xc8, 0x09, 0xac,
-	0x0e, 0xa9, 0xef, 0x0f, 0xa8, 0x73, 0x6b, 0x8f, 0x24, 0xb1, 0xdc, 0x0d, 0xf0, 0xcc, 0xdb, 0x39,
-	0x09, 0xc7, 0x9a, 0xe6, 0xb5, 0x26, 0xb1, 0x8c, 0xe1, 0x3d, 0x08, 0x79, 0x05, 0x9b, 0xd4, 0x67,
-	0xb1, 0xb0, 0xb9, 0xa0, 0x3e, 0x4b, 0x75, 0x6e, 0x8f, 0xa3, 0x24, 0xe6, 0xe6, 0x92, 0xd4, 0xfc,
-	0x41, 0xd9, 0x2c, 0x59, 0x2d, 0x24, 0xba, 0x96, 0x34, 0xda, 0x02, 0x27, 0x92, 0x82, 0x7c, 0x07,
-	0xeb, 0x61, 0x12, 0xd8, 0x43, 0xea, 0xf9, 0x49, 0xcc, 0xb8, 0x2d, 0x22, 0x1b, 0x29, 0xcd, 0x7a,
-	0xc6, 0x4a, 0xc2, 0x24, 0x38, 0xd6, 0xf8, 0x7e, 0xd4, 0x91, 0x58, 0xe9, 0x98, 0x83, 0x64, 0x64,
-	0x3b, 0x51, 0x30, 0x89, 0x42, 0x16, 0x0a, 0xb3, 0x81, 0x36, 0xae, 0x0f, 0x92, 0xd1, 0x61, 0x0a,
-	0x23, 0xbb, 0x60, 0x38, 0x91, 0xcb, 0x6c, 0xce, 0x68, 0xec, 0x8c, 0xed, 0x09, 0x15, 0x63, 0x73,
-	0x19, 0xfd, 0x65, 0x59, 0xc2, 0xaf, 0x11, 0xdc, 0xa3, 0x62, 0x4c, 0x7e, 0x03, 0x72, 0x13, 0x5b,
-	0xa9, 0x88, 0xdb, 0x31, 0x73, 0xa4, 0xcc, 0x15, 0x94, 0x69, 0x84, 0x49, 0xa0, 0x34, 0xc9, 0x2d,
-	0x84, 0x93, 0x2f, 0x61, 0x35, 0xe1, 0xda, 0x56, 0x01, 0x13, 0xd4, 0xa5, 0x82, 0x9a, 0x06, 0x3a,
-	0xc6, 0x4a, 0xc2, 0xd1, 0x4e, 0x17, 0x1a, 0x4c, 0x5e, 0xc0, 0x86, 0x52, 0x4f, 0x40, 0x3d, 0x1f,
-	0x6f, 0xe7, 0xba, 0x31, 0xe3, 0x9c, 0x71, 0x73, 0x55, 0x1e, 0x05, 0x6f, 0xb8, 0x86, 0x24, 0x17,
-	0xd4, 0xf3, 0xfb, 0x51, 0x27, 0xc5, 0x93, 0x6f, 0x80, 0xe4, 0x58, 0x79, 0x32, 0xf8, 0x91, 0x39,
-	0xc2, 0x24, 0x19, 0x97, 0x91, 0x71, 0x5d, 0x2b, 0x1c, 0xf9, 0x23, 0x6c, 0xe5, 0x38, 0xb4, 0x4e,
-	0xed, 0x80, 0x71, 0x4e, 0x47, 0xcc, 0x6c, 0x66, 0x9c, 0x1b, 0x19, 0xa7, 0xd6, 0xeb, 0x85, 0x22,
-	0x21, 0xcf, 0x61, 0x2d, 0x27, 0xc0, 0x65, 0x52, 0xc7, 0x49, 0xec, 0x9b, 0x6b, 0x19, 0xeb, 0x6a,
-	0xc6, 0x7a, 0x24, 0xb1, 0x37, 0xb1, 0x4f, 0xce, 0xe1, 0x49, 0xe0, 0x85, 0x36, 0xf3, 0xe9, 0x84,
-	0x33, 0xd7, 0x0e, 0xbc, 0x30, 0x11, 0x8c, 0xdb, 0x03, 0x26, 0xde, 0x32, 0x16, 0xa2, 0x28, 0x6e,
-	0xae, 0x67, 0xe6, 0x7c, 0x1c, 0x78, 0x61, 0x57, 0xd1, 0x5e, 0x28, 0xd2, 0x03, 0x45, 0x29, 0x85,
-	0x72, 0xb2, 0x07, 0x4d, 0x16, 0xd2, 0x81, 0xcf, 0xec, 0xa1, 0x4f, 0x6f, 0xef, 0xa4, 0x5b, 0x89,
-	0x84, 0x9b, 0x1b, 0xa8, 0xde, 0x55, 0x85, 0x3a, 0x96, 0x98, 0x6b, 0x44, 0xc8, 0xd8, 0x71, 0x3d,
-	0x8e, 0x0c, 0x01, 0x8b, 0x47, 0xcc, 0x4d, 0x39, 0x5e, 0x22, 0x47, 0x53, 0x23, 0x2f, 0x10, 0x37,
-	0xe5, 0x91, 0x06, 0xbc, 0x4d, 0x06, 0x2c, 0x0e, 0x99, 0x3c, 0xac, 0xe3, 0x7b, 0xd2, 0xe2, 0xa6,
-	0xe2, 0x49, 0x38, 0x7b, 0x93, 0xe1, 0x0e, 0x11, 0x45, 0xbe, 0x07, 0x33, 0xdd, 0x67, 0x12, 0x47,
-	0x6f, 0x7f, 0x8c, 0x06, 0x36, 0x0d, 0xa9, 0x7f, 0xc7, 0x3d, 0x6e, 0xfe, 0x01, 0xd9, 0x5a, 0x1a,
-	0xdf, 0x53, 0xe8, 0x8e, 0xc6, 0xca, 0x4c, 0xef, 0x71, 0x9b, 0xbd, 0x13, 0x2c, 0x0e, 0xa9, 0x6f,
-	0x6e, 0x22, 0x31, 0x78, 0xbc, 0xab, 0x21, 0xe4, 0x05, 0x18, 0xe8, 0x4b, 0x98, 0x3f, 0x74, 0x12,
-	0xdf, 0xda, 0x29, 0xed, 0x2e, 0xed, 0xaf, 0xdc, 0x7b, 0x4f, 0xac, 0x65, 0x51, 0x7c, 0x87, 0x9e,
-	0x43, 0x23, 0xcc, 0xe5, 0x5e, 0x6e, 0x6e, 0x63, 0x16, 0x68, 0xec, 0xe5, 0x33, 0xb2, 0x55, 0xa4,
-	0x21, 0x5d, 0x30, 0x26, 0xb1, 0x27, 0x33, 0xf2, 0x34, 0xf6, 0x1f, 0x63, 0xec, 0x6f, 0xe5, 0x62,
-	0xbf, 0xa7, 0x48, 0xb2, 0xd0, 0x5f, 0x99, 0x14, 0x01, 0x39, 0x4b, 0xa5, 0x91, 0x30, 0x8e, 0x5c,
-	0x6e, 0xfe, 0x5d, 0xde, 0x52, 0x3a, 0x16, 0x24, 0x82, 0x1c, 0xe9, 0x6b, 0xd2, 0x30, 0x8c, 0x84,
-	0x3e, 0xee, 0xa7, 0x78, 0xdc, 0xcd, 0x7b, 0x69, 0xb2, 0x93, 0x51, 0xa8, 0x5c, 0x39, 0x5d, 0x73,
-	0xf2, 0x3d, 0x6c, 0x06, 0xf4, 0x5d, 0x61, 0x4b, 0x7b, 0xc2, 0x62, 0x04, 0x98, 0x3b, 0x18, 0xb1,
-	0xeb, 0x01, 0x7d, 0x97, 0xdb, 0xb8, 0xc7, 0x62, 0xb9, 0x22, 0x27, 0xb0, 0x5e, 0x08, 0x59, 0x3b,
-	0x9a, 0xa8, 0x43, 0xb4, 0xf1, 0x10, 0x2a, 0x57, 0xa7, 0x81, 0x7b, 0xa5, 0x70, 0x56, 0x53, 0xcc,
-	0x02, 0x65, 0x62, 0x41, 0x49, 0x82, 0x8e, 0x64, 0x56, 0x91, 0x66, 0x34, 0x3f, 0x53, 0x89, 0x45,
-	0xc2, 0xfb, 0x74, 0xd4, 0x53, 0x50, 0x69, 0x5a, 0x9a, 0x88, 0xc8, 0x96, 0x81, 0x94, 0x6e, 0xf7,
-	0x2b, 0x6d, 0xda, 0x4e, 0x22, 0xa2, 0x83, 0x64, 0x94, 0xee, 0xb4, 0x4c, 0x0b, 0x6b, 0xf2, 0x1c,
-	0x5a, 0xd9, 0x45, 0xe3, 0x24, 0x14, 0x5e, 0xc0, 0x74, 0x56, 0x7d, 0x8a, 0xb7, 0x6c, 0xea, 0x5b,
-	0x5a, 0x0a, 0xa7, 0xd2, 0xe9, 0x4b, 0xd8, 0x96, 0x89, 0x6c, 0x42, 0x65, 0x06, 0x91, 0xe9, 0x26,
-	0xf5, 0x59, 0x95, 0x54, 0x7f, 0x8d, 0x9c, 0x1b, 0x61, 0x12, 0xf4, 0x90, 0xa2, 0x1f, 0x1d, 0x29,
-	0xbc, 0xca, 0xaa, 0x5f, 0x01, 0x91, 0xef, 0xb2, 0x3c, 0x2d, 0xb7, 0x07, 0xda, 0x3b, 0xcc, 0xcf,
-	0x55, 0x66, 0x93, 0x98, 0x83, 0x64, 0xc4, 0x0f, 0x94, 0x07, 0x90, 0x53, 0x68, 0xe5, 0x8c, 0x90,
-	0x96, 0x08, 0x1e, 0xe3, 0xe6, 0x17, 0xa8, 0xcf, 0x66, 0xce, 0xa8, 0x6f, 0xd8, 0xdd, 0x9f, 0xa9,
-	0x9f, 0x30, 0x6b, 0x4d, 0x64, 0x76, 0xe9, 0x65, 0x0c, 0x32, 0x42, 0x46, 0x54, 0x8c, 0x59, 0x8c,
-	0x3b, 0x9b, 0x5f, 0xaa, 0x08, 0x51, 0x20, 0xb9, 0xa5, 0xcc, 0xb8, 0x7c, 0x1c, 0xc5, 0xc2, 0xc6,
-	0xda, 0x21, 0x60, 0x22, 0xf6, 0x1c, 0xf3, 0x2b, 0xd4, 0xf8, 0x0a, 0x22, 0xfa, 0xec, 0x9d, 0x14,
-	0x1b, 0x7b, 0x8e, 0x74, 0x90, 0xc2, 0x25, 0x0a, 0xce, 0xf9, 0x5b, 0x14, 0xbd, 0x3e, 0xbd, 0x4b,
-	0xde, 0x41, 0xbf, 0x83, 0x8d, 0xfc, 0x8d, 0x02, 0x2a, 0x9c, 0xb1, 0x1d, 0xb3, 0x11, 0x7b, 0x67,
-	0xee, 0xe1, 0x5e, 0xb9, 0xd3, 0x5f, 0x48, 0xa4, 0x25, 0x71, 0xe4, 0x05, 0x6c, 0xe6, 0xd9, 0x92,
-	0x30, 0xcf, 0xf8, 0x0a, 0x19, 0x5b, 0x53, 0xc6, 0x1b, 0x85, 0x56, 0xac, 0xcf, 0x54, 0x22, 0x1a,
-	0x26, 0xbe, 0x9f, 0xb2, 0xcb, 0x24, 0xc0, 0xcd, 0xaf, 0xf1, 0x9c, 0x24, 0xe1, 0xec, 0x38, 0xf1,
-	0x7d, 0xc5, 0x29, 0xc3, 0x9e, 0x93, 0x7f, 0x80, 0xa7, 0x33, 0x2f, 0xb7, 0x4e, 0x1a, 0x49, 0x8c,
-	0x31, 0x62, 0xcb, 0xf2, 0x95, 0x99, 0xcf, 0x70, 0xe7, 0xf6, 0xfd, 0x07, 0xfb, 0x30, 0x4f, 0x8a,
-	0x46, 0x91, 0xa5, 0x84, 0x7a, 0xb6, 0x6d, 0x1e, 0x25, 0xb1, 0xc3, 0xcc, 0x7d, 0xf4, 0xd0, 0x7c,
-	0x29, 0xa1, 0xde, 0xec, 0x6b, 0x44, 0x5b, 0xf5, 0x38, 0xb7, 0x22, 0x87, 0xb0, 0x79, 0xbf, 0x6e,
-	0xb6, 0xe3, 0xc4, 0x97, 0xcf, 0xae, 0x30, 0x9f, 0xa3, 0xa4, 0xda, 0x9e, 0x95, 0xf8, 0xec, 0x9a,
-	0x09, 0xab, 0xa5, 0x48, 0xbb, 0x29, 0xa5, 0x86, 0x4b, 0xd5, 0xc7, 0x8c, 0xaa, 0xdc, 0xcd, 0xec,
-	0x61, 0x1c, 0x05, 0x36, 0x17, 0x51, 0x2c, 0x9f, 0xad, 0x6f, 0x51, 0x15, 0x6b, 0x12, 0x2d, 0xd3,
-	0x37, 0x3b, 0x8e, 0xa3, 0xe0, 0x5a, 0xe1, 0xe4, 0xbb, 0xad, 0x0b, 0xa7, 0xc8, 0x77, 0xb3, 0x7a,
-	0xef, 0x3b, 0xe4, 0x30, 0x14, 0xe6, 0xca, 0x77, 0xd3, 0x92, 0x4f, 0x26, 0x62, 0x45, 0xcd, 0x6f,
-	0xbd, 0x89, 0xf9, 0x3b, 0x9d, 0x88, 0x11, 0x74, 0x7d, 0xeb, 0x4d, 0xc8, 0xef, 0x60, 0x43, 0x55,
-	0xc9, 0xd1, 0x4f, 0x2c, 0x8e, 0x3d, 0x59, 0x3a, 0x88, 0x78, 0x28, 0xa3, 0xcb, 0xfc, 0x7b, 0xd4,
-	0xe6, 0x3a, 0xa2, 0xaf, 0x34, 0xf6, 0x5a, 0x23, 0x65, 0x35, 0x92, 0x70, 0x16, 0x4f, 0xcb, 0xe4,
-	0xef, 0x55, 0x99, 0x2c, 0x81, 0x69, 0x99, 0xbc, 0xf5, 0xcf, 0x50, 0xcf, 0x17, 0x64, 0x64, 0x0d,
-	0x16, 0xb0, 0x82, 0xd7, 0xc5, 0xad, 0x5a, 0x90, 0x2d, 0xa8, 0x65, 0x52, 0x54, 0x6d, 0x9b, 0xad,
-	0xc9, 0xd7, 0xd0, 0x9c, 0x67, 0xe8, 0x0a, 0x92, 0x11, 0x67, 0xc6, 0xb0, 0x5b, 0x5c, 0xf5, 0x2d,
-	0xd3, 0xf4, 0x29, 0x8b, 0xe7, 0x69, 0x20, 0xe9, 0x9d, 0x17, 0xb3, 0x08, 0x22, 0x4f, 0xa1, 0x91,
-	0xee, 0x86, 0x8e, 0xa8, 0x8e, 0x70, 0xf2, 0xc0, 0xaa, 0xa7, 0x60, 0xe9, 0x84, 0x07, 0xdb, 0xb0,
-	0x59, 0x08, 0x47, 0x2c, 0x1e, 0xb4, 0xf3, 0x6c, 0xed, 0x43, 0x2d, 0x0d, 0x77, 0x62, 0x40, 0xe5,
-	0x96, 0xa5, 0x6d, 0x80, 0xfc, 0x29, 0x6f, 0xad, 0x4e, 0xad, 0x2e, 0xa7, 0x16, 0x5b, 0xb7, 0x50,
-	0xcf, 0x7b, 0x18, 0x79, 0x06, 0xf5, 0x1f, 0x93, 0xd0, 0x2b, 0xb4, 0x34, 0x4b, 0xfb, 0xf5, 0xbd,
-	0xb3, 0x9b, 0xd0, 0xd3, 0x2d, 0xcd, 0xc9, 0x03, 0x6b, 0x09, 0x69, 0xd4, 0xf2, 0xa0, 0x05, 0x6b,
-	0x05, 0x27, 0xd6, 0xac, 0x67, 0xd5, 0x5a, 0xc9, 0x28, 0x9f, 0x55, 0x6b, 0x15, 0xa3, 0x7a, 0x56,
-	0xad, 0x55, 0x8d, 0x85, 0x76, 0xa0, 0x3a, 0x0c, 0x2c, 0xc0, 0xc9, 0x16, 0xb4, 0xfa, 0xdd, 0xeb,
-	0xfe, 0xb5, 0x7d, 0xd9, 0xb9, 0xe8, 0xda, 0x37, 0x97, 0xd7, 0xbd, 0xee, 0xe1, 0xe9, 0xf1, 0x69,
-	0xf7, 0xc8, 0x78, 0x40, 0xd6, 0x61, 0x35, 0x87, 0x3b, 0x7d, 0x7d, 0x79, 0x65, 0x75, 0x8d, 0x12,
-	0x69, 0x01, 0xc9, 0x81, 0xad, 0x6e, 0xef, 0xbc, 0x73, 0xd8, 0x35, 0xca, 0xf7, 0xc8, 0x3b, 0xbd,
-	0x5e, 0xf7, 0xf2, 0xc8, 0xa8, 0xb4, 0xff, 0xb3, 0x04, 0xc6, 0xfd, 0x3a, 0x5a, 0x6e, 0x7b, 0xdc,
-	0x39, 0x3f, 0x3f, 0xe8, 0x1c, 0xbe, 0xb1, 0x5f, 0x5b, 0x57, 0x37, 0xbd, 0xd3, 0xcb, 0xd7, 0xf6,
-	0xe5, 0xd5, 0x65, 0xd7, 0x78, 0x30, 0x1f, 0x77, 0xd4, 0xe9, 0xcb, 0xbd, 0x3f, 0x01, 0x73, 0x16,
-	0x77, 0xde, 0x39, 0xe8, 0x9e, 0x5f, 0x1b, 0x65, 0x62, 0xc2, 0xda, 0x2c, 0xf6, 0xf4, 0xc8, 0xa8,
-	0x90, 0x6d, 0xd8, 0x98, 0xc5, 0x1c, 0xdc, 0x9c, 0x9e, 0x1f, 0x19, 0x55, 0xf2, 0x05, 0x3c, 0x9d,
-	0x45, 0x1e, 0x5e, 0x5d, 0x1e, 0x9f, 0xbe, 0xbe, 0xb1, 0x3a, 0xfd, 0xd3, 0xab, 0x4b, 0xfb, 0xcf,
-	0x9d, 0xf3, 0x9b, 0xae, 0xb1, 0xd0, 0x3e, 0x81, 0x95, 0x7b, 0x75, 0x01, 0xd9, 0x84, 0xf5, 0x9e,
-	0x75, 0x7a, 0xd1, 0xb1, 0xfe, 0x32, 0xef, 0x26, 0x33, 0x28, 0xb5, 0x69, 0xe9, 0xac, 0x5a, 0x7b,
-	0x64, 0xd4, 0xce, 0xaa, 0xb5, 0x96, 0xb1, 0x71, 0x56, 0xad, 0x7d, 0x62, 0x3c, 0x3e, 0xab, 0xd6,
-	0x9e, 0x18, 0xed, 0xb3, 0x6a, 0x6d, 0xd7, 0xf8, 0xe2, 0xac, 0x5a, 0xfb, 0x8d, 0xf1, 0xdb, 0xb3,
-	0x6a, 0xed, 0x1b, 0xe3, 0xd9, 0x59, 0xb5, 0xf6, 0x7b, 0xe3, 0x87, 0xb3, 0x6a, 0xed, 0x07, 0xe3,
-	0x65, 0xbb, 0x01, 0x4b, 0x39, 0x1f, 0x68, 0xff, 0x5c, 0x82, 0xe6, 0x9c, 0x57, 0x5b, 0x36, 0x81,
-	0xd3, 0x8a, 0x4a, 0x25, 0x62, 0xe5, 0x83, 0x8d, 0xb4, 0x7e, 0x52, 0xf9, 0x77, 0xa6, 0x8d, 0x28,
-	0xcf, 0x69, 0x23, 0xd6, 0x60, 0x21, 0x7a, 0x1b, 0xb2, 0x58, 0x07, 0x9a, 0x5a, 0x90, 0x65, 0x28,
-	0x3b, 0x8e, 0x59, 0xc5, 0x06, 0xad, 0xec, 0x38, 0x52, 0x54, 0x1a, 0x08, 0x6a, 0x43, 0xdd, 0x2a,
-	0x6b, 0x20, 0xee, 0xd7, 0xfe, 0x97, 0x87, 0xb0, 0x5c, 0x7c, 0xf6, 0xc9, 0xb7, 0xd0, 0x1a, 0x30,
-	0x41, 0x6d, 0xf9, 0xfa, 0x17, 0xcf, 0x02, 0x78, 0x96, 0x35, 0x89, 0xed, 0x28, 0xe4, 0xf4, 0x4c,
-	0x8f, 0x01, 0xb0, 0xae, 0x70, 0xfc, 0x88, 0xab, 0xf6, 0xb8, 0x66, 0x2d, 0x4a, 0xc8, 0xa1, 0x04,
-	0xc8, 0x4c, 0x37, 0x8e, 0x84, 0xef, 0x71, 0x61, 0x7b, 0x2e, 0x37, 0xcb, 0x3b, 0x95, 0xdd, 0x8a,
-	0x05, 0x1a, 0x74, 0xea, 0xca, 0x5d, 0x6b, 0x93, 0xd8, 0x8b, 0x62, 0x4f, 0xdc, 0xe1, 0xb5, 0x96,
-	0xf7, 0xcd, 0x7b, 0xf5, 0x88, 0xac, 0xff, 0x10, 0x6f, 0x65, 0x94, 0xe4, 0x0d, 0x6c, 0xe4, 0xc4,
-	0xea, 0x34, 0xad, 0x9e, 0x8c, 0xaa, 0xae, 0xa1, 0x4e, 0xd2, 0x3d, 0x30, 0x4d, 0xab, 0xf7, 0x62,
-	0x6d, 0xba, 0xf1, 0x14, 0x4a, 0x3e, 0x87, 0x95, 0xa1, 0xe7, 0x33, 0xdb, 0x0b, 0x5d, 0xef, 0x27,
-	0xcf, 0x4d, 0xa8, 0xaf, 0x9b, 0xeb, 0x65, 0x09, 0x3e, 0xcd, 0xa0, 0xe4, 0x2b, 0x58, 0xe5, 0x5e,
-	0x38, 0xf2, 0x99, 0x88, 0xc2, 0x54, 0x4d, 0xd8, 0x5f, 0xd7, 0x2c, 0x23, 0x43, 0x68, 0x0d, 0x91,
-	0x57, 0xb0, 0x2d, 0xab, 0x26, 0xea, 0xfb, 0xd1, 0x5b, 0xe6, 0xe6, 0x84, 0xab, 0xd2, 0xe2, 0x11,
-	0xea, 0xd4, 0x0c, 0xe8, 0xbb, 0x8e, 0xa2, 0x98, 0xee, 0x83, 0x85, 0xc6, 0x13, 0xa8, 0xe3, 0xa1,
-	0xe4, 0x03, 0x40, 0x7d, 0xdf, 0xac, 0xa9, 0x76, 0x5f, 0xc2, 0xae, 0x14, 0x88, 0xfc, 0x23, 0xac,
-	0xbb, 0x6c, 0x48, 0x65, 0xa6, 0x29, 0x76, 0x80, 0x8b, 0x98, 0xa4, 0x3e, 0xbb, 0xaf, 0xc7, 0x23,
-	0x45, 0x9c, 0x77, 0x53, 0xab, 0xe9, 0xce, 0x02, 0xa5, 0x27, 0x50, 0xf7, 0x27, 0x1a, 0x3a, 0xcc,
-	0xbd, 0x27, 0x79, 0x49, 0x3d, 0x81, 0x29, 0x36, 0xcf, 0xb5, 0xf5, 0x4f, 0xd0, 0x9c, 0xb3, 0xc3,
-	0xac, 0x67, 0x97, 0x3e, 0xe4, 0xd9, 0xe5, 0x59, 0xcf, 0x56, 0xce, 0x5e, 0x76, 0x9c, 0xf6, 0x39,
-	0xd4, 0x52, 0x5f, 0x90, 0x19, 0xa6, 0x67, 0x9d, 0x5e, 0x59, 0xa7, 0xfd, 0xbf, 0xdc, 0x4b, 0x96,
-	0x0f, 0xa1, 0xdc, 0xfb, 0xc6, 0x28, 0xe1, 0xdf, 0x67, 0x46, 0x19, 0xff, 0xee, 0x1b, 0x15, 0xfc,
-	0xfb, 0xdc, 0xa8, 0xe2, 0xdf, 0x6f, 0x8d, 0x85, 0xf6, 0x5f, 0xa1, 0x39, 0xc7, 0x47, 0x48, 0x2b,
-	0x7d, 0x17, 0xe4, 0x39, 0x2b, 0x27, 0x0f, 0xf4, 0xcb, 0x20, 0xe1, 0xea, 0x95, 0x4c, 0x5f, 0x22,
-	0xb5, 0x3c, 0x68, 0xc2, 0xea, 0xd4, 0x15, 0xb5, 0x13, 0xb6, 0xff, 0xa3, 0x0c, 0x8b, 0x47, 0x94,
-	0x8f, 0x07, 0x11, 0x8d, 0x5d, 0xb2, 0x0f, 0x0d, 0x37, 0x5d, 0xd8, 0x82, 0x0e, 0xf4, 0x8c, 0xae,
-	0xb1, 0x97, 0x91, 0xf4, 0xe9, 0xc0, 0xaa, 0xbb, 0xb9, 0x55, 0x36, 0x70, 0x2a, 0xe7, 0x06, 0x4e,
-	0x33, 0x3d, 0x56, 0xe5, 0x23, 0x7a, 0xac, 0x4f, 0x61, 0x29, 0xf3, 0x12, 0x3a, 0xd0, 0xc9, 0x00,
-	0x52, 0xb3, 0xd3, 0x01, 0xf6, 0xad, 0xd1, 0xdb, 0x70, 0xe2, 0xd3, 0x3b, 0xec, 0xd4, 0x65, 0x19,
-	0x27, 0xe8, 0x80, 0x6b, 0x97, 0x6b, 0xa6, 0xc8, 0x63, 0x85, 0xeb, 0xd3, 0x81, 0xec, 0x7d, 0x5a,
-	0x63, 0x6f, 0x34, 0xf6, 0xbd, 0xd1, 0x58, 0x14, 0x99, 0x30, 0x1c, 0xd4, 0x2c, 0x21, 0xa3, 0xc8,
-	0x73, 0x7e, 0x0e, 0x2b, 0x53, 0x4e, 0x11, 0xb9, 0xf4, 0x0e, 0x43, 0xa1, 0x66, 0x2d, 0x67, 0xe0,
-	0xbe, 0x84, 0xea, 0x27, 0xd2, 0x85, 0xfa, 0xb9, 0x17, 0xde, 0xf6, 0x59, 0x30, 0xf1, 0xa9, 0xc0,
-	0x77, 0x3c, 0x89, 0xd3, 0x4a, 0x45, 0xfe, 0x24, 0x7b, 0xf0, 0x28, 0xed, 0x67, 0xca, 0x3a, 0xf4,
-	0x25, 0x87, 0x76, 0xfa, 0x94, 0xd1, 0x4a, 0x89, 0x32, 0xc5, 0x56, 0xa6, 0x8a, 0x6d, 0xbf, 0x82,
-	0xe6, 0x1c, 0x9e, 0x8f, 0x2d, 0x1a, 0xda, 0xff, 0x0d, 0x50, 0x3f, 0x9a, 0x67, 0xbc, 0xfc, 0xb4,
-	0x30, 0x7d, 0x09, 0xb0, 0x54, 0xce, 0xd5, 0x34, 0xea, 0x25, 0xc0, 0x47, 0x0c, 0xeb, 0x80, 0x99,
-	0x78, 0xa9, 0x7c, 0xe4, 0x40, 0xa9, 0xfa, 0x7f, 0x18, 0x28, 0x2d, 0xbc, 0x67, 0xa0, 0xf4, 0x04,
-	0xea, 0x03, 0xca, 0x59, 0xd6, 0x21, 0x3e, 0x54, 0x73, 0x51, 0x09, 0x4b, 0x9f, 0x89, 0x1f, 0x80,
-	0x44, 0x13, 0x16, 0xaa, 0xc4, 0x20, 0xb4, 0xaa, 0xd0, 0x86, 0xd2, 0x13, 0xf3, 0xc6, 0xb2, 0x0c,
-	0x49, 0x28, 0x93, 0x41, 0xa6, 0xd1, 0x17, 0xb0, 0x8a, 0x59, 0x4d, 0xde, 0x30, 0xe3, 0xad, 0xcd,
-	0xe3, 0xc5, 0x94, 0x7c, 0x90, 0x8c, 0x32, 0xd6, 0x57, 0xd0, 0xa4, 0x42, 0x50, 0x67, 0x5c, 0x64,
-	0x5e, 0x9c, 0xc7, 0xbc, 0xaa, 0x28, 0xf3, 0xec, 0x4f, 0xa0, 0x9e, 0x4e, 0x04, 0xb1, 0xe2, 0x04,
-	0x75, 0x33, 0x0d, 0xc3, 0x9a, 0xf3, 0x8f, 0x69, 0xe1, 0xc6, 0xed, 0x24, 0xf6, 0xa7, 0x5b, 0x2c,
-	0xcd, 0xdb, 0x82, 0x68, 0xd2, 0x9b, 0xd8, 0xcf, 0xf6, 0x38, 0x06, 0x33, 0x6f, 0x95, 0x82, 0x90,
-	0xfa, 0x3c, 0x21, 0xeb, 0x53, 0x63, 0xe5, 0xe5, 0xec, 0xc8, 0x90, 0xe5, 0x4e, 0xec, 0xa1, 0xca,
-	0x71, 0xa2, 0xb8, 0x68, 0xe5, 0x41, 0x64, 0x0f, 0x9a, 0x82, 0x0e, 0x12, 0x9f, 0xc6, 0xaa, 0x4d,
-	0xd3, 0x2f, 0xbd, 0x9a, 0x29, 0xae, 0x6a, 0x14, 0xb6, 0x69, 0xaa, 0xbc, 0xf8, 0x03, 0x34, 0xd4,
-	0x38, 0x2d, 0x35, 0xec, 0x0a, 0x1e, 0x67, 0xb3, 0x90, 0x81, 0xb0, 0xf5, 0x4e, 0x87, 0x00, 0x75,
-	0x9a, 0x5b, 0x91, 0xbf, 0xc2, 0xc6, 0xd0, 0xa7, 0xb7, 0x5e, 0xc8, 0x38, 0xb7, 0x8b, 0x92, 0x4c,
-	0x94, 0xd4, 0x2e, 0x48, 0x3a, 0x4e, 0x69, 0x0b, 0x22, 0xd7, 0x87, 0xf3, 0xc0, 0xf2, 0x2e, 0x74,
-	0x10, 0x25, 0xc2, 0x9e, 0xe6, 0x48, 0x19, 0xe2, 0x86, 0xba, 0x0b, 0xa2, 0x32, 0xd9, 0x37, 0xb1,
-	0x2f, 0x7d, 0x08, 0x1d, 0xb0, 0xe0, 0x06, 0xab, 0x73, 0x7d, 0x48, 0xd2, 0xe5, 0x9d, 0xe0, 0x57,
-	0x80, 0xb3, 0x0d, 0x3b, 0xf5, 0x41, 0x8e, 0x43, 0xcc, 0x9a, 0x55, 0x97, 0xd0, 0x63, 0xe5, 0x70,
-	0x5c, 0x86, 0x8c, 0xeb, 0x71, 0xcc, 0x87, 0x7e, 0xe4, 0x50, 0xdf, 0xc6, 0xbe, 0xab, 0xa9, 0xde,
-	0x79, 0x8d, 0x39, 0x97, 0x88, 0xbe, 0x6c, 0xb9, 0x3a, 0xb0, 0x9e, 0x7e, 0x4a, 0x08, 0x58, 0x98,
-	0x4c, 0x8f, 0xb4, 0x36, 0xef, 0x48, 0x4d, 0x4d, 0x7b, 0xc1, 0xc2, 0x24, 0x3b, 0x96, 0xec, 0xf6,
-	0xe2, 0xe8, 0x96, 0x85, 0x3a, 0x4c, 0x6d, 0x31, 0x8e, 0x19, 0x1f, 0x47, 0xbe, 0x8b, 0xd3, 0xca,
-	0xb2, 0xb5, 0xae, 0xd0, 0x2a, 0x56, 0xfb, 0x29, 0x92, 0x74, 0x60, 0xad, 0x50, 0xb1, 0xa5, 0x26,
-	0x69, 0xcd, 0x9f, 0xeb, 0x90, 0x5c, 0x01, 0x97, 0x2a, 0xff, 0x12, 0x36, 0xc6, 0x8c, 0xfa, 0x62,
-	0x9c, 0xcd, 0x10, 0x33, 0x29, 0x1b, 0x28, 0xa5, 0xb5, 0x77, 0x82, 0xf8, 0x74, 0x88, 0x98, 0x19,
-	0x73, 0x3c, 0x0f, 0x4c, 0xce, 0x60, 0x4b, 0xdf, 0xc1, 0xf5, 0x86, 0x43, 0xfc, 0xb8, 0x92, 0x69,
-	0x84, 0x9b, 0x9b, 0xfa, 0xbd, 0x2a, 0xa8, 0x64, 0x43, 0x31, 0x1c, 0x79, 0xc3, 0x61, 0x1e, 0xce,
-	0xdb, 0xff, 0x53, 0x01, 0xf3, 0x7d, 0xfe, 0x49, 0x5e, 0x7c, 0x68, 0xda, 0xaf, 0x4a, 0x8c, 0xf7,
-	0x4d, 0xfa, 0x9f, 0xbd, 0x6f, 0xd2, 0xaf, 0x6a, 0xee, 0x79, 0x53, 0xfe, 0xef, 0xde, 0x3f, 0x3c,
-	0x57, 0xef, 0xc8, 0xfc, 0xc1, 0xf9, 0x2f, 0x0c, 0xc1, 0xaa, 0x1f, 0x1e, 0x82, 0xe1, 0xe7, 0x2b,
-	0x35, 0x6b, 0x5f, 0x48, 0x3f, 0x5f, 0xa9, 0xf1, 0xfa, 0x36, 0x2c, 0x4e, 0x47, 0xe2, 0x2a, 0x47,
-	0xd7, 0xdc, 0x74, 0x0a, 0xfe, 0x19, 0x34, 0x14, 0x32, 0x1d, 0xb7, 0x3f, 0x52, 0xf5, 0x3f, 0x02,
-	0xd3, 0xf9, 0xfa, 0x2b, 0xd8, 0x7e, 0x4b, 0x3d, 0x31, 0x33, 0x23, 0x67, 0x6a, 0x48, 0x5e, 0x53,
-	0xd5, 0xa9, 0x24, 0x29, 0x8e, 0xc6, 0xbb, 0x88, 0x27, 0x3f, 0x7c, 0x70, 0xbe, 0xbf, 0x88, 0x1b,
-	0xbe, 0x6f, 0xb6, 0xdf, 0xfe, 0xb9, 0x0c, 0x4f, 0x7e, 0x31, 0x5b, 0xc8, 0x2d, 0x02, 0x2f, 0xf4,
-	0x02, 0x69, 0xa9, 0x2c, 0xf5, 0x64, 0xa6, 0x2a, 0x61, 0x5c, 0x6c, 0x68, 0x8a, 0x4c, 0xc2, 0x47,
-	0xd8, 0xab, 0xfc, 0x01, 0x7b, 0xe5, 0x34, 0x5e, 0x29, 0x6a, 0xfc, 0x17, 0xf4, 0x55, 0xfd, 0x7f,
-	0xe9, 0x6b, 0xe1, 0xc3, 0xfa, 0xba, 0x80, 0xe5, 0x4c, 0x5d, 0xef, 0xff, 0x1a, 0xf9, 0x39, 0xac,
-	0x4c, 0x13, 0xa8, 0x9a, 0xdd, 0x95, 0xb1, 0x27, 0x5c, 0xce, 0xc0, 0xf8, 0x20, 0xb4, 0xff, 0xad,
-	0x04, 0x8d, 0xc2, 0xec, 0x8d, 0x7c, 0x05, 0x4b, 0xd3, 0xd2, 0x24, 0xfd, 0x82, 0x0c, 0xd3, 0xa1,
-	0x9b, 0x05, 0x59, 0x89, 0xc2, 0xc9, 0x97, 0x00, 0x99, 0xc0, 0xb4, 0xe4, 0x82, 0x69, 0xf6, 0xb7,
-	0x72, 0x58, 0xf2, 0x7b, 0x30, 0xa6, 0x67, 0xd2, 0xd2, 0x55, 0xcd, 0xba, 0xb2, 0x57, 0xbc, 0x92,
-	0x35, 0x3d, 0xbc, 0xda, 0xa7, 0xfd, 0x5f, 0x25, 0x58, 0x9f, 0x9b, 0x7a, 0x48, 0x0b, 0x1e, 0xaa,
-	0x99, 0xbe, 0x6e, 0x37, 0xf5, 0x4a, 0x16, 0x45, 0xe9, 0x07, 0xd7, 0xec, 0x83, 0x88, 0x0a, 0xe9,
-	0x65, 0xf5, 0xc5, 0x35, 0xfb, 0x10, 0xf2, 0x14, 0x96, 0x99, 0xfa, 0x96, 0xe5, 0x8c, 0x99, 0x9b,
-	0xf8, 0x69, 0x35, 0xd8, 0x40, 0xe8, 0xb5, 0x06, 0x92, 0x2f, 0xc0, 0x50, 0x64, 0x31, 0x73, 0xbc,
-	0x89, 0x87, 0x9f, 0xd7, 0x55, 0x95, 0xb5, 0x82, 0x70, 0x2b, 0x03, 0x4b, 0x89, 0xd9, 0x0c, 0x34,
-	0xdf, 0x75, 0x37, 0x52, 0xa8, 0x6a, 0xbb, 0xff, 0xb5, 0x04, 0x6b, 0xba, 0x49, 0x2a, 0x9a, 0xe0,
-	0x25, 0x90, 0x42, 0x2f, 0xa7, 0x06, 0xde, 0x25, 0x4c, 0xc1, 0x39, 0x4b, 0xa8, 0xcf, 0x6d, 0xb9,
-	0x9e, 0x4d, 0xf9, 0x43, 0x77, 0xda, 0x09, 0x16, 0x1b, 0x8d, 0xb2, 0x7e, 0x83, 0xf2, 0xe1, 0x86,
-	0x32, 0xd2, 0xbe, 0x2f, 0x8f, 0x18, 0x3c, 0xc4, 0xff, 0x32, 0x78, 0xfe, 0xbf, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x3d, 0x2e, 0xb8, 0xf1, 0xa1, 0x20, 0x00, 0x00,
+	// 5606 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x7b, 0x4d, 0x7b, 0xe3, 0xc6,
+	0x91, 0xf0, 0x90, 0xd4, 0x78, 0xa8, 0x16, 0x49, 0x41, 0xad, 0x2f, 0x48, 0xe3, 0x89, 0x35, 0x74,
+	0x1c, 0x8f, 0xe3, 0x58, 0xb6, 0xc7, 0x76, 0x12, 0xc7, 0x9e, 0x38, 0x94, 0x44, 0x8d, 0xa8, 0xa1,
+	0x44, 0x06, 0xa4, 0x3c, 0x19, 0xbf, 0x1f, 0x08, 0x08, 0x34, 0x49, 0x78, 0x40, 0x80, 0x41, 0x03,
+	0x23, 0x29, 0xef, 0xbb, 0xbb, 0x39, 0xed, 0x3f, 0xd8, 0xd3, 0xee, 0x79, 0x4f, 0x9b, 0xbf, 0xb1,
+	0x87, 0x3d, 0xee, 0xb3, 0xb9, 0xec, 0xaf, 0xd9, 0xa7, 0xaa, 0x1a, 0x20, 0x40, 0x72, 0x6c, 0xef,
+	0xe6, 0x44, 0x76, 0x55, 0xf5, 0x57, 0x55, 0x75, 0x7d, 0x75, 0x83, 0x55, 0xec, 0xc0, 0x1f, 0xba,
+	0xa3, 0xc3, 0x69, 0x18, 0x44, 0xc1, 0xfe, 0x4f, 0xa7, 0x83, 0x0f, 0xed, 0x58, 0x46, 0xc1, 0xc4,
+	0x14, 0xaf, 0x2c, 0x2f, 0xb6, 0xa2, 0x20, 0x5c, 0x00, 0x10, 0x6d, 0xfd, 0x9f, 0x8a, 0xac, 0xd6,
+	0x17, 0x32, 0xba, 0xb4, 0x26, 0xe2, 0x18, 0x07, 0xe1, 0xbf, 0x61, 0x55, 0xdf, 0x9a, 0x08, 0x53,
+	0x78, 0x62, 0x22, 0xfc, 0x48, 0xea, 0x85, 0x83, 0xd2, 0xa3, 0xb5, 0xc7, 0xf7, 0x0f, 0xf3, 0x74,
+	0x87, 0xf0, 0xb7, 0x49, 0x34, 0x46, 0xc5, 0x9f, 0x35, 0x24, 0x7f, 0x8b, 0xad, 0xe1, 0x08, 0xc3,
+	0x20, 0x9c, 0x58, 0x91, 0x5e, 0x3c, 0x28, 0x3c, 0x5a, 0x35, 0x18, 0x80, 0x4e, 0x11, 0xb2, 0xff,
+	0xcf, 0x05, 0xb6, 0x96, 0xe9, 0xce, 0x77, 0xd8, 0x1b, 0x9e, 0x35, 0x10, 0x1e, 0xcc, 0x05, 0xb4,
+	0xaa, 0xc5, 0xdf, 0x66, 0xd5, 0xc8, 0x0a, 0x47, 0x22, 0x32, 0x69, 0x83, 0x6a, 0xa8, 0x0a, 0x01,
+	0xd5, 0x7a, 0x1f, 0xb2, 0xca, 0x20, 0x76, 0x3d, 0xc7, 0x24, 0xa8, 0x5e, 0x3a, 0x28, 0x3c, 0x2a,
+	0x1b, 0x6b, 0x08, 0xeb, 0x23, 0x88, 0x73, 0xb6, 0x12, 0x59, 0x23, 0xa9, 0xaf, 0x60, 0x77, 0xfc,
+	0x8f, 0x63, 0x0b, 0x19, 0x99, 0xd3, 0x30, 0x98, 0x8a, 0x30, 0xba, 0xd5, 0xef, 0xaa, 0xb1, 0x85,
+	0x8c, 0xba, 0x0a, 0x56, 0x7f, 0xc6, 0x2a, 0x97, 0x41, 0xe4, 0x0e, 0x5d, 0xdb, 0x8a, 0xdc, 0xc0,
+	0xe7, 0x3a, 0xbb, 0x27, 0xe3, 0xc9, 0xc4, 0x0a, 0x6f, 0xd5, 0x4a, 0x93, 0x26, 0xac, 0xc2, 0x0e,
+	0xfc, 0x48, 0xdc, 0x44, 0xa6, 0xe7, 0xfa, 0x2f, 0xd5, 0x4a, 0xd7, 0x14, 0xac, 0xed, 0xfa, 0x2f,
+	0xeb, 0xff, 0xf0, 0x94, 0xad, 0x02, 0x0f, 0x9f, 0x86, 0x41, 0x3c, 0x85, 0x35, 0x01, 0x47, 0xd4,
+	0x38, 0xf8, 0x9f, 0x3f, 0x60, 0x6c, 0x64, 0x4b, 0x73, 0x1a, 0x8a, 0xa1, 0x7b, 0xa3, 0x86, 0x58,
+	0x1d, 0xd9, 0xb2, 0x8b, 0x00, 0xfe, 0x13, 0xb6, 0xee, 0x58, 0xb7, 0xd2, 0x0c, 0x86, 0x66, 0x28,
+	0x64, 0xec, 0x45, 0x12, 0x37, 0x7b, 0xd7, 0xa8, 0x02, 0xb8, 0x33, 0x34, 0x08, 0xc8, 0xdf, 0x61,
+	0x35, 0x77, 0xe4, 0x07, 0xa1, 0x30, 0xa7, 0xc2, 0x77, 0x5c, 0x7f, 0x84, 0x1b, 0x2f, 0x1b, 0x55,
+	0x82, 0x76, 0x09, 0x08, 0x4b, 0x56, 0x64, 0xc0, 0xab, 0x08, 0x19, 0x50, 0x36, 0xd6, 0x08, 0x76,
+	0x04, 0x20, 0xfe, 0x1b, 0xb6, 0x01, 0xfc, 0x90, 0x26, 0xca, 0x73, 0x1a, 0x78, 0xae, 0x7d, 0xab,
+	0xbf, 0x71, 0x50, 0x78, 0x54, 0x7b, 0xbc, 0x75, 0x98, 0xee, 0x05, 0xff, 0x49, 0x10, 0xa8, 0xb1,
+	0x1e, 0x25, 0x7f, 0xbb, 0x48, 0xcc, 0x1f, 0xb3, 0x6d, 0x35, 0x09, 0x72, 0x5b, 0xc6, 0x03, 0x19,
+	0x85, 0xb0, 0xa4, 0xf2, 0x41, 0xe9, 0xd1, 0xaa, 0xb1, 0x49, 0x48, 0x18, 0xa0, 0x97, 0xa0, 0xf8,
+	0x97, 0xac, 0x6a, 0x07, 0x5e, 0x3c, 0xf1, 0xcd, 0xb1, 0xb0, 0x1c, 0x11, 0xea, 0xab, 0xa8, 0x81,
+	0xbb, 0x99, 0x19, 0x8f, 0x11, 0x7f, 0x86, 0x68, 0xa3, 0x62, 0x67, 0x5a, 0xfc, 0x8c, 0x6d, 0x0c,
+	0x2d, 0xcf, 0x1b, 0x58, 0xf6, 0x4b, 0x73, 0x04, 0xc4, 0x30, 0x1b, 0xc3, 0x35, 0xdf, 0xcf, 0x8c,
+	0x70, 0xaa, 0x68, 0x9e, 0x2a, 0x12, 0x43, 0x1b, 0xce, 0x41, 0xf8, 0x13, 0xb6, 0x67, 0x79, 0x22,
+	0x8c, 0x4c, 0x19, 0x59, 0x9e, 0x48, 0x78, 0x6e, 0x8e, 0x83, 0x38, 0x94, 0xfa, 0x1a, 0x70, 0xfe,
+	0xa8, 0xa8, 0x17, 0x8c, 0x1d, 0x24, 0xea, 0x01, 0x8d, 0x92, 0xc0, 0x19, 0x50, 0xf0, 0xcf, 0xd8,
+	0xb6, 0x1f, 0x4f, 0xcc, 0xa1, 0xe5, 0x7a, 0x71, 0x28, 0xa4, 0x19, 0x05, 0x26, 0x52, 0xea, 0x95,
+	0xb4, 0x2b, 0xf7, 0xe3, 0xc9, 0xa9, 0xc2, 0xf7, 0x83, 0x06, 0x60, 0x41, 0x31, 0x07, 0xf1, 0xc8,
+	0xb4, 0x83, 0xc9, 0x34, 0xf0, 0x85, 0x1f, 0xe9, 0x55, 0x94, 0x71, 0x65, 0x10, 0x8f, 0x8e, 0x13,
+	0x18, 0x7f, 0xc4, 0x34, 0x3b, 0x70, 0x84, 0x29, 0x85, 0x15, 0xda, 0x63, 0x73, 0x6a, 0x45, 0x63,
+	0xbd, 0x86, 0xfa, 0x52, 0x03, 0x78, 0x0f, 0xc1, 0x5d, 0x2b, 0x1a, 0xf3, 0x9f, 0x31, 0x98, 0xc4,
+	0x24, 0x16, 0x49, 0x33, 0x14, 0x36, 0x8c, 0xb9, 0x8e, 0x63, 0x6a, 0x7e, 0x3c, 0x21, 0x4e, 0x4a,
+	0x03, 0xe1, 0xfc, 0xa7, 0x6c, 0x23, 0x96, 0x4a, 0x56, 0x13, 0x11, 0x59, 0x8e, 0x15, 0x59, 0xba,
+	0x86, 0x8a, 0xb1, 0x1e, 0x4b, 0x94, 0xd3, 0x85, 0x02, 0xf3, 0xcf, 0xd9, 0x2e, 0xb1, 0x67, 0x62,
+	0xb9, 0x1e, 0xee, 0xce, 0x71, 0x42, 0x21, 0xa5, 0x90, 0xfa, 0x06, 0x2c, 0x05, 0x77, 0xb8, 0x85,
+	0x24, 0x17, 0x96, 0xeb, 0xf5, 0x83, 0x46, 0x82, 0xe7, 0x1f, 0x31, 0x9e, 0xe9, 0x2a, 0xe3, 0xc1,
+	0xb7, 0xc2, 0x8e, 0x74, 0x9e, 0xf6, 0xd2, 0xd2, 0x5e, 0x3d, 0xc2, 0xf1, 0xaf, 0xd8, 0x7e, 0xa6,
+	0x87, 0xe2, 0xa9, 0x39, 0x11, 0x52, 0x5a, 0x23, 0xa1, 0x6f, 0xa6, 0x3d, 0x77, 0xd3, 0x9e, 0x8a,
+	0xaf, 0x17, 0x44, 0xc2, 0x3f, 0x61, 0x5b, 0x99, 0x01, 0x1c, 0x01, 0x3c, 0x8e, 0x43, 0x4f, 0xdf,
+	0x4a, 0xbb, 0x6e, 0xa4, 0x5d, 0x4f, 0x00, 0x7b, 0x15, 0x7a, 0xbc, 0xcd, 0x1e, 0x4e, 0x5c, 0xdf,
+	0x14, 0x9e, 0x35, 0x95, 0xc2, 0x31, 0x27, 0xae, 0x1f, 0x47, 0x42, 0x9a, 0x03, 0x11, 0x5d, 0x0b,
+	0xe1, 0xe3, 0x50, 0x52, 0xdf, 0x4e, 0xc5, 0xf9, 0x60, 0xe2, 0xfa, 0x4d, 0xa2, 0xbd, 0x20, 0xd2,
+	0x23, 0xa2, 0x84, 0x41, 0x25, 0x3f, 0x64, 0x9b, 0xc2, 0xb7, 0x06, 0x9e, 0x30, 0x87, 0x9e, 0xf5,
+	0xf2, 0x16, 0xd4, 0x2a, 0x8a, 0xa5, 0xbe, 0x8b, 0xec, 0xdd, 0x20, 0xd4, 0x29, 0x60, 0x7a, 0x88,
+	0x80, 0xb3, 0xe3, 0xb8, 0x12, 0x3b, 0x4c, 0x44, 0x38, 0x12, 0x4e, 0xd2, 0xe3, 0x4b, 0xec, 0xb1,
+	0xa9, 0x90, 0x17, 0x88, 0x9b, 0xf5, 0x01, 0x01, 0xbe, 0x8c, 0x07, 0x22, 0xf4, 0x05, 0x2c, 0xd6,
+	0xf6, 0x5c, 0x90, 0xb8, 0x4e, 0x7d, 0x62, 0x29, 0x9e, 0xa5, 0xb8, 0x63, 0x44, 0xf1, 0x5f, 0x32,
+	0x3d, 0x99, 0x67, 0x1a, 0x06, 0xd7, 0xdf, 0x06, 0x03, 0xd3, 0xf2, 0x2d, 0xef, 0x56, 0xba, 0x52,
+	0xff, 0x35, 0x76, 0xdb, 0x51, 0xf8, 0x2e, 0xa1, 0x1b, 0x0a, 0x0b, 0x96, 0xde, 0x95, 0xa6, 0xb8,
+	0x89, 0x44, 0xe8, 0x5b, 0x9e, 0xbe, 0x87, 0xc4, 0xcc, 0x95, 0x4d, 0x05, 0xe1, 0x9f, 0x33, 0x0d,
+	0x75, 0x09, 0xed, 0x87, 0x32, 0xe2, 0xfb, 0x07, 0x85, 0x47, 0x6b, 0x8f, 0xd7, 0xe7, 0xfc, 0x89,
+	0x51, 0x8b, 0x72, 0x6d, 0xfe, 0x09, 0xab, 0xfa, 0x19, 0xdb, 0x2b, 0xf5, 0xfb, 0x68, 0x05, 0xaa,
+	0x87, 0x59, 0x8b, 0x6c, 0xe4, 0x69, 0x78, 0x93, 0x69, 0xd3, 0xd0, 0x05, 0x8b, 0x3c, 0x3b, 0xfb,
+	0x0f, 0xf0, 0xec, 0xef, 0x67, 0xce, 0x7e, 0x97, 0x48, 0xd2, 0xa3, 0xbf, 0x3e, 0xcd, 0x03, 0x32,
+	0x92, 0x4a, 0x4e, 0xc2, 0x38, 0x70, 0xa4, 0xfe, 0xa3, 0xac, 0xa4, 0xd4, 0x59, 0x00, 0x04, 0x3f,
+	0x51, 0xdb, 0xb4, 0x7c, 0x3f, 0x88, 0xd4, 0x72, 0xdf, 0xc2, 0xe5, 0xee, 0xcd, 0x99, 0xc9, 0x46,
+	0x4a, 0x41, 0xb6, 0x72, 0xd6, 0x96, 0xfc, 0x97, 0x6c, 0x6f, 0x62, 0xdd, 0xe4, 0xa6, 0x34, 0xa7,
+	0x22, 0x44, 0x80, 0x7e, 0x80, 0x27, 0x76, 0x7b, 0x62, 0xdd, 0x64, 0x26, 0xee, 0x8a, 0x10, 0x5a,
+	0xfc, 0x8c, 0x6d, 0xe7, 0x8e, 0xac, 0x19, 0x4c, 0x69, 0x11, 0x75, 0x5c, 0xc4, 0xd6, 0x61, 0xf6,
+	0xe0, 0x76, 0x08, 0x67, 0x6c, 0x46, 0x8b, 0x40, 0x30, 0x2c, 0x38, 0x52, 0x64, 0x8d, 0xc0, 0xaa,
+	0x80, 0x18, 0xf5, 0xb7, 0xc9, 0xb0, 0x00, 0xbc, 0x6f, 0x8d, 0xba, 0x04, 0x05, 0xd1, 0x5a, 0x71,
+	0x14, 0x98, 0x70, 0x90, 0x92, 0xe9, 0x7e, 0xac, 0x44, 0xdb, 0x88, 0xa3, 0xe0, 0x28, 0x1e, 0x25,
+	0x33, 0xd5, 0xac, 0x5c, 0x9b, 0x7f, 0xc2, 0x76, 0xd2, 0x8d, 0x86, 0xb1, 0x1f, 0xb9, 0x13, 0xa1,
+	0xac, 0xea, 0x3b, 0xb8, 0xcb, 0x4d, 0xb5, 0x4b, 0x83, 0x70, 0x64, 0x4e, 0xbf, 0x64, 0xf7, 0xc1,
+	0x90, 0x4d, 0x2d, 0x29, 0xc9, 0x98, 0x26, 0x3a, 0x4b, 0x46, 0xf5, 0x27, 0xd8, 0x73, 0xd7, 0x8f,
+	0x27, 0x5d, 0xa4, 0xe8, 0x07, 0x27, 0x84, 0x27, 0xab, 0xfa, 0x3e, 0xe3, 0xe0, 0x97, 0x61, 0xb5,
+	0xd2, 0x1c, 0x28, 0xed, 0xd0, 0xdf, 0x25, 0xcb, 0x06, 0x98, 0xa3, 0x78, 0x24, 0x8f, 0x48, 0x03,
+	0x78, 0x8b, 0xed, 0x64, 0x84, 0x90, 0x84, 0x08, 0xae, 0x90, 0xfa, 0x7b, 0xc8, 0xcf, 0xcd, 0x8c,
+	0x50, 0x9f, 0x89, 0xdb, 0xaf, 0x2d, 0x2f, 0x16, 0xc6, 0x56, 0x94, 0xca, 0xa5, 0x9b, 0x76, 0x80,
+	0x13, 0x32, 0xb2, 0xa2, 0xb1, 0x08, 0x71, 0x66, 0xfd, 0xa7, 0x74, 0x42, 0x08, 0x04, 0x53, 0x82,
+	0xc5, 0x95, 0xe3, 0x20, 0x8c, 0x4c, 0x8c, 0x1d, 0x26, 0x22, 0x0a, 0x5d, 0x5b, 0x7f, 0x1f, 0x39,
+	0xbe, 0x8e, 0x88, 0xbe, 0xb8, 0x81, 0x61, 0x43, 0xd7, 0x06, 0x05, 0xc9, 0x6d, 0x22, 0xa7, 0x9c,
+	0x1f, 0xe0, 0xd0, 0xdb, 0xb3, 0xbd, 0x64, 0x15, 0xf4, 0x33, 0xb6, 0x9b, 0xdd, 0xd1, 0xc4, 0x8a,
+	0xec, 0xb1, 0x19, 0x8a, 0x91, 0xb8, 0xd1, 0x0f, 0x71, 0xae, 0xcc, 0xea, 0x2f, 0x00, 0x69, 0x00,
+	0x8e, 0x7f, 0xce, 0xf6, 0xb2, 0xdd, 0x62, 0x3f, 0xdb, 0xf1, 0x09, 0x76, 0xdc, 0x99, 0x75, 0xbc,
+	0xf2, 0x27, 0xb3, 0xae, 0x1f, 0x93, 0x21, 0x1a, 0xc6, 0x9e, 0x97, 0x74, 0x07, 0x23, 0x20, 0xf5,
+	0x0f, 0x71, 0x9d, 0x3c, 0x96, 0xe2, 0x34, 0xf6, 0x3c, 0xea, 0x09, 0xc7, 0x5e, 0xf2, 0xdf, 0xb2,
+	0x77, 0x16, 0x3c, 0xb7, 0x32, 0x1a, 0x71, 0x88, 0x67, 0xc4, 0x84, 0xf0, 0x55, 0xe8, 0x1f, 0xe3,
+	0xcc, 0xf5, 0x79, 0x87, 0x7d, 0x9c, 0x25, 0x45, 0xa1, 0x40, 0x28, 0x41, 0x6e, 0xdb, 0x94, 0x41,
+	0x1c, 0xda, 0x42, 0x7f, 0x7c, 0x50, 0x98, 0x0b, 0x25, 0xc8, 0x67, 0xf7, 0x10, 0x6d, 0x54, 0xc2,
+	0x4c, 0x8b, 0x1f, 0xb3, 0xbd, 0xf9, 0xb8, 0xd9, 0x0c, 0x63, 0x0f, 0xdc, 0x6e, 0xa4, 0x7f, 0x82,
+	0x23, 0x95, 0x0f, 0x8d, 0xd8, 0x13, 0x3d, 0x11, 0x19, 0x3b, 0x44, 0xda, 0x4c, 0x28, 0x15, 0x1c,
+	0x58, 0x1f, 0x0a, 0x8b, 0x6c, 0xb7, 0x30, 0x87, 0x61, 0x30, 0x31, 0x65, 0x14, 0x84, 0xe0, 0xb6,
+	0x3e, 0x45, 0x56, 0x6c, 0x01, 0x1a, 0xcc, 0xb7, 0x38, 0x0d, 0x83, 0x49, 0x8f, 0x70, 0xe0, 0xb7,
+	0x55, 0xe0, 0x14, 0x78, 0x4e, 0x1a, 0xef, 0x7d, 0x86, 0x3d, 0x34, 0xc2, 0x74, 0x3c, 0x27, 0x09,
+	0xf9, 0xc0, 0x10, 0x13, 0xb5, 0x7c, 0xe9, 0x4e, 0xf5, 0x9f, 0x2b, 0x43, 0x8c, 0xa0, 0xde, 0x4b,
+	0x77, 0xca, 0x7f, 0xce, 0x76, 0x29, 0x4a, 0x0e, 0x5e, 0x89, 0x30, 0x74, 0x21, 0x74, 0x88, 0xc2,
+	0x21, 0x9c, 0x2e, 0xfd, 0x17, 0xc8, 0xcd, 0x6d, 0x44, 0x77, 0x14, 0xb6, 0xa7, 0x90, 0x10, 0x8d,
+	0xc4, 0x52, 0x84, 0xb3, 0x30, 0xf9, 0x97, 0x14, 0x26, 0x03, 0x30, 0x09, 0x93, 0xf9, 0xaf, 0xd9,
+	0xfd, 0x69, 0x28, 0xa4, 0x08, 0x5f, 0x09, 0x15, 0x68, 0xe4, 0x2c, 0xe1, 0x57, 0xb8, 0x9a, 0xbd,
+	0x84, 0x84, 0x22, 0x8e, 0xac, 0xe1, 0xfb, 0x39, 0xdb, 0x0d, 0x63, 0xdf, 0x07, 0x71, 0xc3, 0xa4,
+	0x41, 0x1c, 0x25, 0xae, 0x56, 0xff, 0x0d, 0x99, 0x3d, 0x85, 0xee, 0x13, 0x56, 0x39, 0x57, 0xfe,
+	0x11, 0xdb, 0x82, 0x48, 0xc0, 0x9c, 0xeb, 0xac, 0x37, 0x48, 0xc5, 0x00, 0x67, 0xe4, 0x3a, 0x82,
+	0x7b, 0x84, 0xc0, 0x2a, 0x8e, 0x84, 0x19, 0x06, 0xd7, 0xe8, 0x87, 0x5d, 0x5f, 0x48, 0xa9, 0x1f,
+	0x91, 0x7b, 0x54, 0x48, 0x23, 0xb8, 0x3e, 0x4d, 0x50, 0xfc, 0x88, 0x69, 0xae, 0x94, 0xb1, 0xc0,
+	0xc0, 0x1e, 0xe5, 0x2f, 0xf5, 0x63, 0xb4, 0x03, 0x7a, 0x46, 0x8d, 0x5a, 0x40, 0x02, 0x71, 0x3e,
+	0xc8, 0xdd, 0xa8, 0xb9, 0xd9, 0x26, 0xba, 0x7e, 0x08, 0x24, 0xc6, 0x2e, 0x88, 0xfe, 0x36, 0x89,
+	0xc6, 0xf4, 0x13, 0xdc, 0xdd, 0xc6, 0xc4, 0xf5, 0xcf, 0x08, 0xa3, 0xa2, 0x31, 0x7e, 0xc9, 0xb6,
+	0x60, 0x7d, 0x14, 0xb1, 0x44, 0xe3, 0x50, 0xc8, 0x71, 0xe0, 0x39, 0x52, 0x6f, 0xe2, 0xbc, 0x6f,
+	0x66, 0xd5, 0x37, 0xb8, 0x46, 0x0b, 0xd7, 0x4f, 0x88, 0x0c, 0x1e, 0xce, 0x83, 0x70, 0x7e, 0x71,
+	0x63, 0x7b, 0xb1, 0x43, 0xfb, 0xc6, 0x03, 0x2c, 0xa4, 0x7e, 0x8a, 0x41, 0xf8, 0x86, 0x42, 0x19,
+	0xc1, 0xb5, 0x41, 0x08, 0xd8, 0x33, 0xd1, 0xa1, 0xe3, 0xa6, 0x3d, 0x3f, 0x5d, 0xd8, 0x33, 0x76,
+	0x00, 0x0a, 0xda, 0x73, 0x98, 0x6d, 0x4a, 0xfe, 0x01, 0x2b, 0xc3, 0x18, 0x32, 0x08, 0x23, 0xfd,
+	0x0c, 0x7d, 0x30, 0xcf, 0xf7, 0xed, 0x05, 0x61, 0x64, 0xdc, 0x0b, 0xe9, 0x0f, 0xb8, 0xee, 0x51,
+	0xe8, 0x3a, 0x18, 0xf8, 0x86, 0x42, 0x4a, 0x37, 0xf0, 0xf5, 0xd6, 0x82, 0xeb, 0x7e, 0x1a, 0xba,
+	0xce, 0xf1, 0x8c, 0xc2, 0x58, 0x1f, 0xe5, 0x01, 0xa0, 0xb0, 0x32, 0x0a, 0x85, 0x35, 0x31, 0xe3,
+	0xa9, 0x17, 0x58, 0x8e, 0x7e, 0x8e, 0x92, 0xad, 0x10, 0xf0, 0x0a, 0x61, 0x60, 0x74, 0x89, 0xb5,
+	0x59, 0x66, 0x3c, 0x43, 0x66, 0xac, 0x23, 0x22, 0xc3, 0x8a, 0x43, 0xb6, 0x39, 0x0d, 0x63, 0x5f,
+	0x98, 0x62, 0x32, 0x8d, 0x66, 0xa2, 0x6b, 0x53, 0x2c, 0x80, 0xa8, 0x26, 0x60, 0x12, 0xd1, 0x7d,
+	0xc4, 0xb6, 0x12, 0x15, 0x53, 0x67, 0x01, 0x4e, 0xbe, 0xd4, 0x2f, 0x48, 0x29, 0x15, 0x8e, 0xa8,
+	0xe1, 0xd4, 0x63, 0xbe, 0xa6, 0x8c, 0x14, 0x44, 0xed, 0xee, 0x2b, 0xa1, 0x5f, 0xe2, 0x21, 0x53,
+	0xa6, 0xab, 0x41, 0x40, 0xb0, 0x08, 0xe0, 0x35, 0x55, 0xcc, 0x6b, 0x7a, 0xc2, 0x1f, 0x45, 0x63,
+	0xbd, 0x43, 0x91, 0xfc, 0xc4, 0xba, 0x51, 0x91, 0x6e, 0x1b, 0xe1, 0xc0, 0x07, 0xcb, 0xf3, 0x82,
+	0x6b, 0xe1, 0x98, 0xae, 0x0d, 0xa7, 0xb0, 0x8b, 0xdb, 0xab, 0x28, 0x60, 0x0b, 0x60, 0xfc, 0x5d,
+	0xb6, 0xee, 0xfa, 0xe0, 0xcd, 0x93, 0x51, 0xa5, 0xfe, 0x5b, 0x5c, 0x66, 0x8d, 0xc0, 0x6a, 0x48,
+	0xdc, 0x94, 0x74, 0x3d, 0xe1, 0xdb, 0xca, 0xdd, 0x4a, 0x13, 0x5c, 0xb3, 0xa7, 0x1b, 0x07, 0x85,
+	0x47, 0x25, 0x83, 0x2b, 0x1c, 0x6a, 0x9d, 0xbc, 0x02, 0x0c, 0xff, 0x9c, 0x55, 0x42, 0x11, 0x85,
+	0xb7, 0x49, 0xd6, 0xd8, 0x43, 0x51, 0xee, 0xe4, 0x0c, 0x6f, 0x14, 0xde, 0x52, 0x9a, 0x68, 0xac,
+	0x85, 0xb3, 0x06, 0xe4, 0xb9, 0xb0, 0x51, 0x90, 0x8d, 0x3a, 0x30, 0x7a, 0x9f, 0xf2, 0xdc, 0x89,
+	0x75, 0x63, 0x04, 0xd7, 0xea, 0xac, 0xf0, 0xf7, 0xd9, 0x06, 0xc4, 0x00, 0xd3, 0xa9, 0xb0, 0x42,
+	0xe1, 0x98, 0xd6, 0x30, 0x12, 0xa1, 0x7e, 0x45, 0xfc, 0xc8, 0x20, 0x1a, 0x00, 0xe7, 0xa7, 0x6c,
+	0x83, 0x0c, 0xa0, 0xeb, 0x98, 0x52, 0x78, 0xc2, 0x8e, 0x82, 0x50, 0xff, 0x1a, 0x6d, 0x78, 0x56,
+	0xbf, 0x20, 0xef, 0x75, 0x5a, 0x4e, 0x4f, 0x51, 0x18, 0xeb, 0x83, 0x3c, 0x00, 0xf8, 0xaa, 0x84,
+	0x35, 0xb5, 0x42, 0x29, 0x42, 0xfd, 0x39, 0x19, 0x44, 0x02, 0x76, 0x11, 0x06, 0x66, 0xc6, 0x0a,
+	0x23, 0x77, 0x68, 0xd9, 0x11, 0x24, 0x19, 0x66, 0x24, 0x26, 0x53, 0xcf, 0x8a, 0x84, 0xfe, 0x3b,
+	0x24, 0xde, 0x4c, 0x90, 0x57, 0xa1, 0xd7, 0x57, 0x28, 0x30, 0xe1, 0x60, 0x22, 0x12, 0xfd, 0x7a,
+	0x81, 0xfb, 0x60, 0x13, 0xd7, 0x4f, 0x14, 0xeb, 0x90, 0x6d, 0xc2, 0x59, 0x32, 0xe5, 0x4b, 0x01,
+	0x52, 0x4d, 0x08, 0xbf, 0x21, 0x45, 0x04, 0x54, 0x0f, 0x31, 0x09, 0xfd, 0x2f, 0x98, 0x9e, 0x28,
+	0x22, 0x96, 0x0d, 0xa4, 0x0b, 0xe2, 0x1b, 0x85, 0x42, 0xf8, 0xfa, 0xff, 0xa2, 0x60, 0x41, 0xe1,
+	0x4f, 0xac, 0x5b, 0xd9, 0x03, 0xec, 0x53, 0x40, 0xf2, 0x0f, 0x93, 0x54, 0x29, 0xf0, 0x4d, 0xcb,
+	0xa3, 0x6c, 0x0b, 0x02, 0xe9, 0xff, 0x4d, 0x33, 0x21, 0xae, 0xe3, 0x37, 0x3c, 0x4c, 0xb1, 0x20,
+	0x5c, 0x9e, 0x25, 0xf9, 0xb0, 0x13, 0x19, 0xa5, 0x6b, 0xfb, 0x3f, 0x14, 0xce, 0x11, 0xb2, 0x8d,
+	0xb8, 0x64, 0x75, 0xf7, 0xd9, 0xaa, 0x17, 0x8c, 0x4c, 0x4f, 0xbc, 0x12, 0x9e, 0xfe, 0x7f, 0x91,
+	0x2d, 0x65, 0x2f, 0x18, 0xb5, 0xa1, 0xcd, 0xf7, 0x58, 0xd9, 0xf2, 0x5c, 0x0b, 0x4a, 0x1d, 0xba,
+	0x49, 0x85, 0x16, 0x6c, 0x77, 0x86, 0xdc, 0x66, 0xf7, 0x93, 0x13, 0xe0, 0x43, 0x35, 0xc9, 0x73,
+	0xff, 0x48, 0xa1, 0x01, 0x19, 0xa9, 0xdf, 0xa3, 0x91, 0x7a, 0x3b, 0x23, 0x51, 0xa5, 0xc3, 0x97,
+	0x59, 0x62, 0xb4, 0x57, 0x7b, 0x93, 0xd7, 0x60, 0x24, 0x7f, 0xce, 0x76, 0x29, 0x12, 0x03, 0xe3,
+	0xa0, 0x2c, 0x8b, 0x9a, 0xc0, 0xc2, 0x09, 0xde, 0xca, 0x4d, 0x00, 0x94, 0x46, 0x4a, 0x88, 0x83,
+	0x6f, 0x4f, 0x96, 0x40, 0x25, 0xff, 0x8a, 0xd5, 0xae, 0x85, 0x3b, 0x1a, 0x47, 0xa0, 0xaf, 0x18,
+	0xb7, 0x0e, 0x0e, 0x0a, 0x73, 0x56, 0xf5, 0xb9, 0x22, 0xc0, 0xd3, 0x64, 0x54, 0xaf, 0xb3, 0x4d,
+	0xfe, 0x01, 0xdb, 0xb4, 0xad, 0x69, 0x9a, 0xce, 0x43, 0x10, 0x08, 0x3e, 0xdc, 0xa6, 0xb8, 0xc0,
+	0xb6, 0xa6, 0x8a, 0xbf, 0x47, 0xb7, 0xe0, 0xf2, 0xa0, 0xc6, 0x83, 0xa9, 0xa3, 0x29, 0xc7, 0x56,
+	0xe8, 0x48, 0xdd, 0x41, 0xba, 0x35, 0x84, 0xf5, 0x10, 0x04, 0x4b, 0x82, 0x98, 0x61, 0x2a, 0x92,
+	0x28, 0x43, 0x17, 0x78, 0x54, 0xb3, 0x4b, 0xea, 0x11, 0x01, 0x45, 0x1b, 0x46, 0x55, 0x66, 0x9b,
+	0xfc, 0x3d, 0xa6, 0x61, 0x80, 0x63, 0x07, 0xbe, 0x1d, 0x87, 0xa1, 0xf0, 0xed, 0x5b, 0x7d, 0x88,
+	0x82, 0x5f, 0x07, 0xf8, 0xf1, 0x0c, 0x9c, 0xaf, 0xec, 0x78, 0xd1, 0x58, 0x1f, 0x2d, 0x84, 0x63,
+	0x69, 0x65, 0xc7, 0x8b, 0xc6, 0x99, 0xca, 0x8e, 0x17, 0x8d, 0xe1, 0x84, 0x28, 0xe3, 0x13, 0xf8,
+	0xde, 0xad, 0x3e, 0xa6, 0x20, 0x87, 0x40, 0x1d, 0xdf, 0xbb, 0xe5, 0x9f, 0xb2, 0x1d, 0x30, 0x6e,
+	0xa1, 0x6d, 0x49, 0xa1, 0x42, 0x69, 0x15, 0x74, 0xba, 0x14, 0x69, 0xa5, 0x58, 0x92, 0x19, 0x85,
+	0x9d, 0x4f, 0x58, 0x4d, 0xd1, 0xa2, 0x8e, 0x09, 0xa9, 0x7f, 0x8b, 0x32, 0xde, 0x59, 0x90, 0x71,
+	0x03, 0xf0, 0x46, 0x75, 0x32, 0x6b, 0x08, 0xcc, 0x98, 0xae, 0x43, 0x37, 0x82, 0x93, 0xe5, 0x3a,
+	0xa6, 0x23, 0xbc, 0xc8, 0xd2, 0x5f, 0x92, 0x11, 0x45, 0x38, 0x78, 0xac, 0x13, 0x80, 0xf2, 0x23,
+	0xb6, 0x3e, 0x71, 0xa5, 0x84, 0x48, 0x45, 0x46, 0x56, 0x18, 0x09, 0x47, 0xf7, 0x90, 0xd5, 0xd9,
+	0x24, 0xf1, 0x82, 0x28, 0x7a, 0x44, 0x60, 0xd4, 0x26, 0xb9, 0x36, 0x8c, 0xa1, 0x38, 0x98, 0xe6,
+	0xb7, 0x93, 0x85, 0x31, 0x88, 0x87, 0x69, 0x7a, 0x5b, 0xb3, 0x73, 0x6d, 0xde, 0x60, 0x0f, 0xe6,
+	0xc6, 0x50, 0x25, 0xc7, 0xc4, 0xa7, 0xf8, 0x28, 0xbd, 0xfd, 0x7c, 0x37, 0x2a, 0x42, 0x2a, 0xef,
+	0xf2, 0x29, 0xa3, 0xaa, 0x97, 0x69, 0x07, 0x81, 0xe7, 0x04, 0xd7, 0x7e, 0x1a, 0xb0, 0x05, 0xd8,
+	0x97, 0x0c, 0xc8, 0xb1, 0x42, 0x26, 0xf1, 0xda, 0x11, 0x5b, 0x57, 0xf5, 0xdc, 0xb4, 0xb6, 0x34,
+	0x5d, 0xcc, 0x92, 0x91, 0x22, 0xc9, 0x4b, 0x8d, 0x5a, 0x94, 0x6b, 0x83, 0x17, 0x0c, 0x85, 0x1d,
+	0x84, 0x8e, 0x19, 0x4f, 0x1d, 0x2b, 0x12, 0xa4, 0xff, 0x7f, 0x20, 0xfd, 0x27, 0xcc, 0x15, 0x22,
+	0x66, 0xfa, 0x8f, 0xb2, 0x0d, 0x42, 0xa8, 0x24, 0x86, 0xe8, 0x04, 0xd7, 0x08, 0xd6, 0x01, 0x10,
+	0x78, 0xdf, 0x5c, 0x26, 0x29, 0x75, 0x49, 0xd5, 0x52, 0x27, 0x93, 0x3f, 0xa2, 0x93, 0xbe, 0x0e,
+	0x42, 0x0c, 0xc5, 0x2d, 0x07, 0xe0, 0x7a, 0x44, 0x64, 0x08, 0x35, 0x14, 0x90, 0xf7, 0xd9, 0x0e,
+	0xb9, 0x99, 0x34, 0x15, 0x1f, 0xba, 0x5e, 0x24, 0x42, 0xa9, 0xc7, 0xb8, 0xd3, 0x1f, 0xcd, 0xfb,
+	0x9a, 0x64, 0x63, 0xa7, 0x48, 0x66, 0x6c, 0x0d, 0x16, 0x81, 0x12, 0xd8, 0xad, 0x36, 0xad, 0x04,
+	0xe7, 0xa8, 0x2c, 0x47, 0x7f, 0x95, 0xa4, 0x10, 0x80, 0x25, 0xb9, 0x9f, 0x28, 0x1c, 0xb8, 0x60,
+	0x45, 0xee, 0xb9, 0x13, 0x37, 0xd2, 0xaf, 0x17, 0x5c, 0x30, 0x75, 0x68, 0x03, 0x16, 0x6a, 0xd5,
+	0x69, 0x03, 0xce, 0xb4, 0xe7, 0xbe, 0x12, 0xbe, 0x90, 0x32, 0x95, 0xec, 0x0d, 0x9d, 0xe9, 0x04,
+	0x9e, 0x08, 0xf5, 0x8c, 0x6d, 0x28, 0x16, 0x83, 0xa8, 0xa5, 0x35, 0x99, 0x7a, 0x42, 0xbf, 0xc5,
+	0x73, 0x7d, 0x7f, 0xe1, 0x04, 0x9d, 0xa4, 0x24, 0x86, 0x36, 0x99, 0x83, 0xcc, 0x94, 0x2a, 0x31,
+	0xf0, 0xa9, 0xdb, 0xfc, 0x23, 0xe5, 0xa8, 0x88, 0x55, 0xf6, 0x3c, 0xf5, 0x9b, 0x0f, 0x18, 0x38,
+	0x49, 0xac, 0x61, 0x3b, 0x52, 0xff, 0x7f, 0xb8, 0xc8, 0xd5, 0x89, 0xeb, 0x23, 0x77, 0xd1, 0x0b,
+	0x86, 0x42, 0xa5, 0x3e, 0xbe, 0xca, 0x26, 0x4d, 0x7b, 0x6c, 0xf9, 0x23, 0xa1, 0xff, 0x7f, 0xf2,
+	0x82, 0x0a, 0xdf, 0xf1, 0x29, 0x81, 0x3c, 0x46, 0x24, 0x14, 0x29, 0x02, 0xcf, 0x99, 0x79, 0x33,
+	0xe0, 0x03, 0x08, 0xd3, 0xd3, 0xff, 0xe6, 0xa0, 0xf0, 0xa8, 0x68, 0x6c, 0x12, 0x96, 0x58, 0x78,
+	0xe1, 0xfa, 0xa7, 0xae, 0xe7, 0xcd, 0x5c, 0x27, 0x9e, 0x2c, 0xec, 0x12, 0x06, 0xd7, 0x52, 0xff,
+	0x5b, 0x0a, 0xf4, 0x11, 0x87, 0xfc, 0xb8, 0x70, 0x7d, 0x23, 0xb8, 0x86, 0xd4, 0x67, 0x8d, 0x8a,
+	0x81, 0x88, 0xd2, 0xff, 0x0e, 0x45, 0xb4, 0x9d, 0xad, 0x53, 0x03, 0x96, 0x5c, 0x01, 0x1b, 0xa6,
+	0xff, 0xf7, 0xff, 0xc0, 0x2a, 0xd9, 0x1a, 0x38, 0xdf, 0x62, 0x77, 0xf1, 0xd2, 0x44, 0xdd, 0x27,
+	0x50, 0x83, 0xef, 0xb3, 0x72, 0x9a, 0xb8, 0xd1, 0x75, 0x42, 0xda, 0xe6, 0x1f, 0xb2, 0xcd, 0x65,
+	0xb9, 0x75, 0x09, 0xc9, 0xb8, 0xbd, 0x90, 0x4b, 0xef, 0x4b, 0xba, 0x2a, 0x9a, 0x25, 0x6e, 0xc0,
+	0xfa, 0x59, 0xed, 0x42, 0xcd, 0xbc, 0x9a, 0x16, 0x2d, 0xf8, 0x3b, 0xac, 0x9a, 0xcc, 0x86, 0x66,
+	0x98, 0x96, 0x70, 0x76, 0xc7, 0xa8, 0x24, 0x60, 0x30, 0xc0, 0x47, 0xf7, 0xd9, 0x5e, 0xae, 0x02,
+	0x42, 0xb2, 0xa7, 0x7c, 0x7d, 0xff, 0x31, 0x2b, 0x27, 0x15, 0x16, 0xae, 0xb1, 0xd2, 0x4b, 0x91,
+	0xdc, 0xbc, 0xc0, 0x5f, 0xd8, 0x35, 0xad, 0x9a, 0x36, 0x47, 0x8d, 0xfd, 0x97, 0xac, 0x92, 0x4d,
+	0xea, 0xf9, 0xc7, 0xac, 0xf2, 0x6d, 0xec, 0xbb, 0xb9, 0x5b, 0xa4, 0xb5, 0xc7, 0x95, 0xc3, 0xf3,
+	0x2b, 0xdf, 0x55, 0xb7, 0x48, 0x67, 0x77, 0x8c, 0xb5, 0x6f, 0xe3, 0xb4, 0x79, 0xb4, 0xc3, 0xb6,
+	0x72, 0x75, 0x03, 0xd5, 0xf5, 0x7c, 0xa5, 0x5c, 0xd0, 0x8a, 0xe7, 0x2b, 0xe5, 0x92, 0xb6, 0x72,
+	0xbe, 0x52, 0x5e, 0xd1, 0xee, 0xee, 0x0f, 0x58, 0x35, 0x97, 0xfa, 0x41, 0x80, 0x98, 0xec, 0x81,
+	0xea, 0x24, 0xb4, 0xde, 0x8a, 0x02, 0x52, 0x75, 0x04, 0xb2, 0x7b, 0xe8, 0x95, 0x8f, 0x0e, 0x69,
+	0x17, 0x94, 0x6d, 0x66, 0x42, 0xc3, 0xfd, 0xbf, 0x14, 0xd8, 0xc6, 0x42, 0x9e, 0x07, 0x41, 0x12,
+	0x84, 0xc8, 0x99, 0x5b, 0x24, 0xc8, 0xa5, 0x80, 0xa5, 0x50, 0x7c, 0x59, 0x7e, 0xf5, 0x50, 0x44,
+	0x3d, 0x5c, 0x76, 0xed, 0xf0, 0x3d, 0xe5, 0xb5, 0xd2, 0x77, 0x97, 0xd7, 0x5e, 0x7f, 0x74, 0x57,
+	0x5e, 0x7f, 0x74, 0xf7, 0x9f, 0xb1, 0x6a, 0x2e, 0x85, 0x84, 0xfb, 0xb5, 0xa4, 0xe8, 0xa8, 0x76,
+	0xa4, 0x9a, 0xfc, 0x80, 0xad, 0x85, 0x62, 0xea, 0x59, 0x36, 0xde, 0x18, 0x26, 0xd7, 0x6b, 0x19,
+	0xd0, 0xbe, 0x60, 0xeb, 0x73, 0xc1, 0x3b, 0x58, 0x7f, 0xba, 0x41, 0x32, 0x5d, 0xdf, 0x51, 0x92,
+	0xb8, 0x6b, 0xac, 0x11, 0xac, 0x05, 0xa0, 0xd7, 0x9d, 0x82, 0xe2, 0x6b, 0x4f, 0xc1, 0xd7, 0x4c,
+	0x7f, 0x5d, 0x44, 0xf9, 0x57, 0x2d, 0xff, 0x5f, 0x0a, 0x6c, 0x6b, 0x59, 0x24, 0x09, 0x97, 0xa3,
+	0xaa, 0x2a, 0xa8, 0x2e, 0x47, 0xa9, 0x05, 0x26, 0x7a, 0x60, 0x49, 0xe1, 0xb9, 0xbe, 0x48, 0xe3,
+	0x6d, 0x12, 0xef, 0x7a, 0x02, 0x4f, 0x62, 0xed, 0xf7, 0xd9, 0x46, 0x5a, 0x43, 0x80, 0x8a, 0x32,
+	0x5e, 0x01, 0x81, 0x44, 0x0b, 0x86, 0x96, 0x22, 0xba, 0x04, 0xe7, 0x3f, 0x66, 0x35, 0x0c, 0x93,
+	0x4c, 0x57, 0x9a, 0xd7, 0x41, 0x28, 0x85, 0xba, 0x3d, 0xac, 0x20, 0xb4, 0x25, 0x9f, 0x03, 0x6c,
+	0xff, 0x98, 0x55, 0x73, 0x71, 0x2a, 0x1c, 0x45, 0x47, 0xd8, 0x16, 0x1d, 0xcf, 0x82, 0x41, 0x0d,
+	0xfe, 0x26, 0x5b, 0x4d, 0x27, 0xc0, 0xd5, 0x15, 0x8c, 0x19, 0x60, 0xff, 0x9b, 0x8c, 0x11, 0x83,
+	0x00, 0xef, 0x1d, 0x56, 0x1b, 0x84, 0xc1, 0x4b, 0xe1, 0xa7, 0x8b, 0xa4, 0xc1, 0xaa, 0x04, 0x4d,
+	0x56, 0xf8, 0x36, 0xab, 0x92, 0xcd, 0x4c, 0xa8, 0x68, 0xe0, 0x0a, 0x02, 0x15, 0xd1, 0xfe, 0x57,
+	0x6c, 0x2d, 0x13, 0xb4, 0x2d, 0xbd, 0x6e, 0x7d, 0x93, 0xad, 0xda, 0x96, 0x1f, 0xf8, 0xae, 0x6d,
+	0x79, 0xc9, 0x6d, 0x6b, 0x0a, 0xd8, 0x1f, 0xb1, 0x5a, 0x3e, 0x14, 0x01, 0x75, 0x52, 0xe1, 0x4b,
+	0xf6, 0x60, 0xaf, 0x11, 0x8c, 0xce, 0xf5, 0x16, 0xbb, 0x1b, 0x5c, 0xfb, 0x22, 0x4c, 0x0c, 0x12,
+	0x36, 0x70, 0xa2, 0xf4, 0x3a, 0xaf, 0xa4, 0x26, 0x4a, 0x00, 0xfb, 0x4f, 0xd8, 0xe6, 0x92, 0x48,
+	0xe0, 0x07, 0x5b, 0xbb, 0x98, 0x69, 0xf3, 0xbe, 0x95, 0x2a, 0x0a, 0xc0, 0x86, 0x54, 0x33, 0x48,
+	0xf5, 0xab, 0x04, 0xcd, 0xe4, 0x60, 0xe2, 0x95, 0x08, 0x6f, 0x4d, 0x3f, 0x1a, 0x2b, 0xdd, 0x29,
+	0x23, 0xe0, 0x32, 0x1a, 0xa3, 0x5f, 0xb5, 0x6e, 0xcc, 0x69, 0xe0, 0xfa, 0xe9, 0x45, 0xf3, 0xea,
+	0xc4, 0xba, 0xe9, 0x22, 0xa0, 0x3e, 0xa1, 0xcb, 0x6c, 0xbc, 0xeb, 0xe5, 0xfb, 0x6c, 0xa7, 0xdf,
+	0xec, 0xf5, 0x7b, 0xe6, 0x65, 0xe3, 0xa2, 0x69, 0x5e, 0x5d, 0xf6, 0xba, 0xcd, 0xe3, 0xd6, 0x69,
+	0xab, 0x79, 0xa2, 0xdd, 0xe1, 0xdb, 0x6c, 0x23, 0x83, 0x6b, 0x3d, 0xbd, 0xec, 0x18, 0x4d, 0xad,
+	0xc0, 0x77, 0x18, 0xcf, 0x80, 0x8d, 0x66, 0xb7, 0xdd, 0x38, 0x6e, 0x6a, 0xc5, 0x39, 0xf2, 0x46,
+	0xb7, 0xdb, 0xbc, 0x3c, 0xd1, 0x4a, 0xf5, 0x7f, 0x2b, 0x30, 0x6d, 0xfe, 0xca, 0x16, 0xa6, 0x3d,
+	0x6d, 0xb4, 0xdb, 0x47, 0x8d, 0xe3, 0x67, 0xe6, 0x53, 0xa3, 0x73, 0xd5, 0x6d, 0x5d, 0x3e, 0x35,
+	0x2f, 0x3b, 0x97, 0x4d, 0xed, 0xce, 0x72, 0xdc, 0x49, 0xa3, 0x0f, 0x73, 0xbf, 0xc9, 0xf4, 0x45,
+	0x5c, 0xbb, 0x71, 0xd4, 0x6c, 0xf7, 0xb4, 0x22, 0xd7, 0xd9, 0xd6, 0x22, 0xb6, 0x75, 0xa2, 0x95,
+	0xf8, 0x7d, 0xb6, 0xbb, 0x88, 0x39, 0xba, 0x6a, 0xb5, 0x4f, 0xb4, 0x15, 0xfe, 0x1e, 0x7b, 0x67,
+	0x11, 0x79, 0xdc, 0xb9, 0x3c, 0x6d, 0x3d, 0xbd, 0x32, 0x1a, 0xfd, 0x56, 0xe7, 0xd2, 0xfc, 0xba,
+	0xd1, 0xbe, 0x6a, 0x6a, 0x77, 0xeb, 0x67, 0x6c, 0x7d, 0xee, 0x0a, 0x8a, 0xef, 0xb1, 0xed, 0xae,
+	0xd1, 0xba, 0x68, 0x18, 0x2f, 0x96, 0xed, 0x64, 0x01, 0x45, 0x93, 0x16, 0xea, 0x06, 0xbb, 0xa7,
+	0x0a, 0x69, 0x7c, 0x83, 0x55, 0x8d, 0xce, 0x73, 0xb3, 0xd7, 0x31, 0xfa, 0xc8, 0x3b, 0xed, 0x0e,
+	0x0c, 0x9a, 0x82, 0x4e, 0x1b, 0xad, 0xf6, 0x95, 0xd1, 0x34, 0x0d, 0x62, 0x41, 0x16, 0xd5, 0x6e,
+	0xf4, 0x52, 0xbc, 0x56, 0xac, 0x0f, 0xd8, 0xfa, 0x5c, 0x95, 0x0d, 0xa8, 0x9f, 0x1a, 0xad, 0x13,
+	0xf3, 0xb8, 0x73, 0xd1, 0x35, 0x9a, 0xbd, 0x1e, 0x6c, 0xe6, 0x9b, 0x76, 0xeb, 0x48, 0xbb, 0xb3,
+	0x14, 0xf5, 0xf4, 0x9b, 0x56, 0x57, 0x2b, 0x2c, 0x45, 0xe1, 0x9e, 0x8a, 0xf5, 0xbf, 0x2f, 0xb0,
+	0xb5, 0x4c, 0xfd, 0x87, 0xbf, 0xc5, 0xee, 0x1b, 0xcd, 0xbe, 0xf1, 0xc2, 0xec, 0x76, 0xda, 0xad,
+	0xe3, 0x17, 0xe6, 0x69, 0xbb, 0xf1, 0xec, 0x85, 0xd9, 0x3a, 0x35, 0x2f, 0x5a, 0xbf, 0x43, 0x2d,
+	0x82, 0xf5, 0x66, 0x09, 0x1a, 0x97, 0x2f, 0xcc, 0x6e, 0xa3, 0xd7, 0x23, 0x69, 0xe6, 0x50, 0xb8,
+	0x1d, 0xa3, 0xd9, 0xbb, 0x6a, 0xf7, 0xb5, 0x22, 0x7f, 0xc0, 0xf6, 0x72, 0xd8, 0xe7, 0x1d, 0x63,
+	0x86, 0x2e, 0xd5, 0xbf, 0x65, 0xd5, 0x5c, 0x72, 0xcb, 0xeb, 0xec, 0x47, 0xbd, 0x67, 0xad, 0x6e,
+	0xb7, 0x79, 0xa2, 0x88, 0x70, 0x1a, 0xf3, 0x79, 0xab, 0x7f, 0x66, 0x02, 0xa2, 0xa7, 0xdd, 0x81,
+	0x19, 0xe7, 0x68, 0x2e, 0x3b, 0xc9, 0x90, 0x05, 0xbe, 0xcb, 0x36, 0xe7, 0xb0, 0x27, 0x46, 0xa7,
+	0xab, 0x15, 0xeb, 0x67, 0xac, 0x96, 0xcf, 0xee, 0x40, 0xd5, 0x2e, 0x5a, 0xbd, 0x1e, 0x48, 0xb4,
+	0xd7, 0x6f, 0x18, 0xfd, 0xe6, 0x09, 0xd1, 0xe2, 0x14, 0xf3, 0x18, 0x94, 0x39, 0x28, 0x62, 0xa1,
+	0xfe, 0xa7, 0x02, 0xab, 0xe5, 0x93, 0x3c, 0x18, 0xea, 0xb8, 0xd3, 0xbe, 0xba, 0xb8, 0x5c, 0xd0,
+	0x9f, 0x5d, 0xb6, 0x39, 0x8f, 0x39, 0x69, 0xbc, 0xd0, 0x0a, 0xcb, 0xba, 0x3c, 0x6f, 0x36, 0x9f,
+	0x69, 0x45, 0xfe, 0x90, 0x3d, 0x98, 0xc7, 0x1c, 0x77, 0x2e, 0x2e, 0x5a, 0x7d, 0xb3, 0x6b, 0x34,
+	0x4f, 0x5b, 0xbf, 0xd3, 0x4a, 0xf5, 0xaf, 0xd8, 0x5a, 0x26, 0x7b, 0xc8, 0x4c, 0xd2, 0x6e, 0x01,
+	0x5d, 0xa7, 0x7d, 0xd2, 0xec, 0xf5, 0xb5, 0x3b, 0x0b, 0x88, 0xcb, 0xe6, 0x73, 0x40, 0x14, 0xea,
+	0xbf, 0x65, 0x6c, 0x16, 0xdb, 0xc2, 0xb1, 0x27, 0x99, 0x37, 0xda, 0x4d, 0x03, 0xe5, 0xd3, 0x84,
+	0xde, 0x5b, 0x4c, 0xcb, 0x82, 0x41, 0x49, 0xc9, 0x76, 0x64, 0xa1, 0xca, 0xa6, 0x40, 0x5c, 0x76,
+	0x4f, 0x2b, 0x9f, 0xaf, 0x94, 0x77, 0xb4, 0xdd, 0xf3, 0x95, 0xf2, 0x9b, 0xda, 0x83, 0xf3, 0x95,
+	0xf2, 0x43, 0xad, 0x7e, 0xbe, 0x52, 0x7e, 0xa4, 0xbd, 0x77, 0xbe, 0x52, 0xfe, 0x99, 0xf6, 0xc1,
+	0xf9, 0x4a, 0xf9, 0x23, 0xed, 0xe3, 0xf3, 0x95, 0xf2, 0xaf, 0xb4, 0x2f, 0xce, 0x57, 0xca, 0x5f,
+	0x68, 0x5f, 0xd6, 0xab, 0x6c, 0x2d, 0x13, 0x09, 0xd6, 0xff, 0x5c, 0x60, 0x9b, 0x4b, 0xae, 0x4b,
+	0xa1, 0x2a, 0x39, 0xbb, 0xca, 0xce, 0x3a, 0x80, 0x6a, 0x72, 0x71, 0x4d, 0x2e, 0x60, 0xe1, 0xfd,
+	0x46, 0x71, 0xc9, 0xfb, 0x8d, 0xd4, 0x4f, 0x94, 0xb2, 0x7e, 0xa2, 0xc6, 0x8a, 0xb6, 0xad, 0xaf,
+	0x60, 0x8e, 0x5a, 0xb4, 0xed, 0xc5, 0x50, 0xf2, 0xee, 0x62, 0x28, 0x59, 0xff, 0xd3, 0x1b, 0xac,
+	0x96, 0xbf, 0x6f, 0x85, 0x68, 0x6c, 0x20, 0x22, 0xcb, 0xb4, 0xe2, 0x28, 0xc8, 0xaf, 0x85, 0x51,
+	0x76, 0x0e, 0xd8, 0x06, 0x21, 0x67, 0x6b, 0x7a, 0xc0, 0x18, 0x74, 0x30, 0x6d, 0x2f, 0x90, 0xe4,
+	0x28, 0xcb, 0xc6, 0x2a, 0x40, 0x8e, 0x01, 0x00, 0xd5, 0x97, 0x71, 0x10, 0x79, 0xae, 0x8c, 0x4c,
+	0xd7, 0x81, 0x50, 0xa3, 0xf4, 0xa8, 0x64, 0x30, 0x05, 0x6a, 0x39, 0x30, 0x6b, 0x79, 0x1a, 0xba,
+	0x41, 0xe8, 0x46, 0xb7, 0x7a, 0x49, 0x95, 0x90, 0xf2, 0x0b, 0x3b, 0xec, 0x2a, 0xbc, 0x91, 0x52,
+	0xf2, 0x67, 0x6c, 0x37, 0x33, 0xac, 0xba, 0x1f, 0xa3, 0xbb, 0xba, 0x15, 0x75, 0x79, 0x7d, 0x96,
+	0xcc, 0x81, 0xf7, 0x63, 0x88, 0x33, 0xb6, 0x66, 0x13, 0xcf, 0xa0, 0x50, 0xcf, 0x1e, 0xba, 0x9e,
+	0x80, 0x70, 0xcf, 0x7d, 0xe5, 0x3a, 0xb1, 0xe5, 0xa9, 0x57, 0x4d, 0x35, 0x00, 0xb7, 0x52, 0x28,
+	0x44, 0x44, 0x70, 0x0e, 0x3d, 0x11, 0x41, 0x8d, 0x93, 0x38, 0x81, 0x0f, 0x9b, 0xca, 0x86, 0x96,
+	0x22, 0x14, 0x87, 0xf8, 0x13, 0x76, 0x1f, 0x3c, 0x61, 0x5a, 0x4e, 0x4f, 0x87, 0xa1, 0x3b, 0xdd,
+	0x7b, 0xc8, 0x53, 0x7d, 0x62, 0xdd, 0x34, 0x88, 0x62, 0x36, 0x0f, 0xde, 0xf0, 0x3e, 0x64, 0x15,
+	0x5c, 0x14, 0xdc, 0xbc, 0x59, 0x9e, 0xa7, 0x97, 0xa9, 0x06, 0x07, 0xb0, 0x0e, 0x81, 0xf8, 0x73,
+	0xb6, 0xed, 0x88, 0xa1, 0x05, 0xf9, 0x46, 0xfe, 0xe9, 0xcd, 0x2a, 0xa6, 0x2a, 0x6f, 0xcf, 0xf3,
+	0xf1, 0x84, 0x88, 0xb3, 0x6a, 0x6a, 0x6c, 0x3a, 0x8b, 0x40, 0x8c, 0xcb, 0x9d, 0x57, 0x96, 0x6f,
+	0x0b, 0x67, 0x6e, 0xe4, 0x35, 0x2a, 0x1c, 0x24, 0xd8, 0x6c, 0xaf, 0xfd, 0xdf, 0xb3, 0xcd, 0x25,
+	0x33, 0x2c, 0x6a, 0x76, 0xe1, 0xbb, 0x34, 0xbb, 0xb8, 0xa8, 0xd9, 0xa4, 0xec, 0x45, 0xdb, 0xae,
+	0xb7, 0x59, 0x39, 0xd1, 0x05, 0x30, 0x43, 0x5d, 0xa3, 0xd5, 0x31, 0x5a, 0xfd, 0x17, 0x73, 0xa1,
+	0xc3, 0x1b, 0xac, 0xd8, 0xfd, 0x48, 0x2b, 0xe0, 0xef, 0xc7, 0x5a, 0x11, 0x7f, 0x1f, 0x6b, 0x25,
+	0xfc, 0xfd, 0x44, 0x5b, 0xc1, 0xdf, 0x4f, 0xb5, 0xbb, 0xf5, 0x6f, 0xd8, 0xe6, 0x12, 0x1d, 0xe1,
+	0x3b, 0x49, 0xbc, 0x04, 0xeb, 0x2c, 0x9d, 0xdd, 0x51, 0x11, 0x13, 0xc0, 0x29, 0x57, 0x4e, 0xf2,
+	0x51, 0x6a, 0x1e, 0x6d, 0xb2, 0x8d, 0x99, 0x2a, 0x2a, 0x25, 0xac, 0xff, 0x6b, 0x91, 0xad, 0x9e,
+	0x58, 0x72, 0x3c, 0x08, 0xac, 0xd0, 0xe1, 0x8f, 0x59, 0xd5, 0x49, 0x1a, 0x66, 0x64, 0x0d, 0xd4,
+	0xe3, 0xc8, 0xea, 0x61, 0x4a, 0xd2, 0xb7, 0x06, 0x46, 0xc5, 0xc9, 0xb4, 0xd2, 0xd0, 0xb3, 0x98,
+	0x09, 0x3d, 0x17, 0x1e, 0xb7, 0x94, 0x7e, 0xc0, 0xe3, 0x96, 0xb7, 0xd8, 0x5a, 0xaa, 0x25, 0xd6,
+	0x40, 0x19, 0x03, 0x96, 0x88, 0xdd, 0x1a, 0xe0, 0x83, 0xa1, 0xe0, 0xda, 0x9f, 0x7a, 0xd6, 0x6d,
+	0x52, 0xb4, 0x07, 0x4a, 0xa9, 0x54, 0x6e, 0x33, 0x41, 0xaa, 0xba, 0x7d, 0xdf, 0x1a, 0xc0, 0xa3,
+	0x93, 0x9d, 0xb1, 0x3b, 0x1a, 0x7b, 0x10, 0xcb, 0xe7, 0x3b, 0xe1, 0x71, 0xa0, 0x47, 0x5c, 0x29,
+	0x45, 0xb6, 0xe7, 0xbb, 0x6c, 0x7d, 0xd6, 0x33, 0x0a, 0x1c, 0xeb, 0x16, 0x8f, 0x42, 0xd9, 0xa8,
+	0xa5, 0xe0, 0x3e, 0x40, 0x29, 0x51, 0xae, 0x3b, 0xac, 0x02, 0x39, 0x72, 0x5a, 0xb7, 0xd1, 0x58,
+	0x09, 0xde, 0x5f, 0xa9, 0xf8, 0x36, 0x0e, 0x3d, 0x7e, 0xc8, 0xee, 0x25, 0x0f, 0x49, 0x8a, 0xea,
+	0xe8, 0x43, 0x0f, 0xa5, 0xf4, 0x49, 0x47, 0x23, 0x21, 0x4a, 0x19, 0x5b, 0x9a, 0x31, 0xb6, 0xfe,
+	0x84, 0x6d, 0x2e, 0xe9, 0xf3, 0x43, 0x83, 0xe9, 0xfa, 0x7f, 0x30, 0x56, 0x39, 0x59, 0x26, 0xbc,
+	0x6c, 0xde, 0x90, 0x78, 0x02, 0xaa, 0xf1, 0x64, 0x64, 0x8b, 0x9e, 0x00, 0x3d, 0x32, 0x46, 0xc5,
+	0x0b, 0xe7, 0xa5, 0xf4, 0x03, 0x5f, 0xf2, 0xad, 0xfc, 0x37, 0x5e, 0xf2, 0xdd, 0x7d, 0xcd, 0x4b,
+	0x3e, 0x78, 0x16, 0x6b, 0x49, 0x91, 0x3e, 0xcd, 0x79, 0x83, 0x92, 0x15, 0x80, 0x25, 0x6e, 0xe2,
+	0x0b, 0xc6, 0x83, 0xa9, 0xf0, 0xc9, 0x30, 0xa4, 0x09, 0xfb, 0x3d, 0x34, 0x39, 0xd5, 0xc3, 0xac,
+	0xb0, 0x0c, 0x0d, 0x08, 0xc1, 0x18, 0xa4, 0x1c, 0xfd, 0x9c, 0x6d, 0xa0, 0x55, 0x83, 0x1d, 0xa6,
+	0x7d, 0xcb, 0xcb, 0xfa, 0xa2, 0x49, 0x3e, 0x8a, 0x47, 0x69, 0xd7, 0x27, 0x6c, 0xd3, 0x8a, 0x22,
+	0xcb, 0x1e, 0xe7, 0x3b, 0xaf, 0x2e, 0xeb, 0xbc, 0x41, 0x94, 0xd9, 0xee, 0x0f, 0x59, 0x25, 0x79,
+	0x8a, 0x89, 0x75, 0x27, 0x96, 0x24, 0xd3, 0x08, 0xc3, 0xca, 0xd3, 0x57, 0x49, 0xf9, 0x46, 0xe6,
+	0x0b, 0x2c, 0x6b, 0xcb, 0xa6, 0xe0, 0x8a, 0x34, 0x7b, 0x19, 0x77, 0xca, 0xf4, 0xac, 0x54, 0x72,
+	0x83, 0x54, 0x96, 0x0d, 0xb2, 0x3d, 0x13, 0x56, 0x76, 0x9c, 0x03, 0x38, 0xb2, 0xd2, 0x0e, 0x5d,
+	0x64, 0x39, 0x3e, 0xe5, 0x5c, 0x35, 0xb2, 0x20, 0xb8, 0xd5, 0x8b, 0xac, 0x41, 0xec, 0x59, 0x21,
+	0x5d, 0x55, 0x28, 0x4f, 0x4f, 0x8f, 0x39, 0x37, 0x14, 0x0a, 0x2f, 0x2a, 0x28, 0xbc, 0xf8, 0x35,
+	0xab, 0x52, 0xa5, 0x25, 0x11, 0xec, 0x3a, 0x2e, 0x67, 0x2f, 0x67, 0x81, 0x30, 0xa6, 0x52, 0x62,
+	0x86, 0x2b, 0xdf, 0x59, 0x8b, 0x7f, 0xc3, 0x76, 0xd3, 0x57, 0x0f, 0x66, 0x7e, 0x24, 0x1d, 0x47,
+	0xaa, 0xe7, 0x46, 0x4a, 0x9f, 0x41, 0xe4, 0x86, 0xdc, 0x1e, 0x2e, 0x03, 0xc3, 0x5e, 0xac, 0x41,
+	0x10, 0x47, 0xe6, 0xcc, 0x46, 0xc2, 0x11, 0xd7, 0x68, 0x2f, 0x88, 0x4a, 0xc7, 0x86, 0xe7, 0x95,
+	0x9f, 0xb3, 0x0d, 0x54, 0xc0, 0x9c, 0x1a, 0x6c, 0x2c, 0xd5, 0x21, 0xa0, 0xcb, 0x2a, 0xc1, 0x8f,
+	0x19, 0x3e, 0x2a, 0x33, 0x13, 0x1d, 0x94, 0xf8, 0x7a, 0xb4, 0x6c, 0x54, 0x00, 0x7a, 0x4a, 0x0a,
+	0x27, 0xe1, 0xc8, 0x38, 0xae, 0x44, 0x7b, 0xe8, 0x05, 0xb6, 0xe5, 0xd1, 0x65, 0xc1, 0x26, 0xf9,
+	0x79, 0x85, 0x69, 0x03, 0x02, 0x2f, 0x0b, 0x1a, 0x6c, 0x5b, 0xbd, 0xd7, 0x36, 0x27, 0xc2, 0x8f,
+	0x67, 0x4b, 0xda, 0x5a, 0xb6, 0xa4, 0x4d, 0x45, 0x7b, 0x21, 0xfc, 0x38, 0x5d, 0x16, 0x3c, 0xb3,
+	0xa1, 0x0a, 0x86, 0x2a, 0x1a, 0xcf, 0xaa, 0x1f, 0xdb, 0x58, 0x35, 0xde, 0x26, 0x34, 0x9d, 0xd5,
+	0x59, 0x2d, 0xaf, 0xc1, 0xb6, 0x72, 0x11, 0x5b, 0x22, 0x92, 0x9d, 0xe5, 0x0f, 0xea, 0x78, 0x26,
+	0x80, 0x4b, 0x98, 0x7f, 0xc9, 0x76, 0xe9, 0x52, 0x2d, 0x7d, 0xbc, 0x99, 0x8e, 0xb2, 0x8b, 0xa3,
+	0xec, 0x1c, 0x52, 0x99, 0x25, 0x79, 0xbd, 0x99, 0x0a, 0x73, 0xbc, 0x0c, 0xcc, 0xcf, 0xd9, 0x7e,
+	0x72, 0xd9, 0xe0, 0x0e, 0x87, 0xf4, 0xf8, 0x25, 0xe1, 0x88, 0xd4, 0xf7, 0x0e, 0x4a, 0x8b, 0x2c,
+	0xd9, 0xa5, 0x0e, 0x27, 0xee, 0x70, 0x98, 0x85, 0xcb, 0xfa, 0x5f, 0x4a, 0x4c, 0x7f, 0x9d, 0x7e,
+	0xc2, 0x23, 0xb3, 0xd7, 0x3f, 0xb3, 0xa6, 0x10, 0xe3, 0x75, 0x4f, 0xac, 0xff, 0x07, 0x75, 0xce,
+	0xcf, 0x5e, 0xff, 0x6a, 0xb9, 0x94, 0x2d, 0x55, 0xce, 0xbd, 0x58, 0xfe, 0x9e, 0xf2, 0xe8, 0xca,
+	0x77, 0x97, 0x47, 0xf1, 0xbb, 0x01, 0x7a, 0xe4, 0x7c, 0x37, 0xf9, 0x6e, 0x00, 0x9b, 0x50, 0x82,
+	0x99, 0xbd, 0x45, 0x26, 0x1b, 0x5d, 0x76, 0x92, 0xe7, 0xc7, 0x6f, 0xb3, 0x2a, 0x21, 0x93, 0x77,
+	0xce, 0xf7, 0x28, 0xfe, 0x47, 0x60, 0xf2, 0xb0, 0xf9, 0x09, 0xbb, 0x7f, 0x6d, 0xb9, 0xd1, 0xc2,
+	0xe3, 0x64, 0x41, 0xaf, 0x93, 0xcb, 0x14, 0x9d, 0x02, 0x49, 0xfe, 0x4d, 0x72, 0x13, 0xf1, 0xfc,
+	0x8b, 0xef, 0x7c, 0x58, 0xbd, 0x8a, 0x13, 0xbe, 0xee, 0x51, 0x75, 0xfd, 0xcf, 0x45, 0xf6, 0xf0,
+	0x7b, 0xad, 0x05, 0x4c, 0x31, 0x71, 0x7d, 0x77, 0x02, 0x92, 0x4a, 0x08, 0x66, 0xa2, 0x2a, 0xe0,
+	0xb9, 0xd8, 0x55, 0x14, 0xe9, 0x08, 0x3f, 0x40, 0x5e, 0xc5, 0xef, 0x90, 0x57, 0x86, 0xe3, 0xa5,
+	0x3c, 0xc7, 0xbf, 0x87, 0x5f, 0x2b, 0x7f, 0x15, 0xbf, 0xee, 0x7e, 0x37, 0xbf, 0x2e, 0x58, 0x2d,
+	0x65, 0xd7, 0xeb, 0x3f, 0x03, 0x79, 0x17, 0xbe, 0xf3, 0x50, 0x54, 0xea, 0xfe, 0xba, 0x88, 0x39,
+	0x61, 0x2d, 0x05, 0xa3, 0x43, 0xa8, 0xff, 0x67, 0x81, 0x55, 0x73, 0x8f, 0x1e, 0xf9, 0xfb, 0x6c,
+	0x6d, 0x16, 0x9a, 0x24, 0x9f, 0xee, 0xb0, 0xd9, 0x75, 0x92, 0xc1, 0xd2, 0x10, 0x05, 0x9e, 0x9e,
+	0xb2, 0x74, 0xc0, 0x24, 0xe4, 0x62, 0x33, 0xeb, 0x6f, 0x64, 0xb0, 0xfc, 0x57, 0x4c, 0x9b, 0xad,
+	0x49, 0x8d, 0x4e, 0x31, 0xeb, 0xfa, 0x61, 0x7e, 0x4b, 0xc6, 0xba, 0x93, 0x6b, 0x43, 0x62, 0x58,
+	0x53, 0x07, 0x9c, 0x9e, 0x09, 0x49, 0x95, 0xd9, 0x55, 0x0f, 0x51, 0xc4, 0x3d, 0x82, 0x1a, 0x55,
+	0x2b, 0xd3, 0x92, 0x75, 0x8b, 0x55, 0xb2, 0x68, 0x38, 0x0c, 0x38, 0xaf, 0x99, 0x2f, 0xb1, 0x57,
+	0x10, 0x98, 0x3c, 0x4a, 0xde, 0x62, 0x77, 0xe9, 0x61, 0x52, 0x11, 0x1f, 0x26, 0x51, 0x03, 0x4a,
+	0xe8, 0xa1, 0xb0, 0x64, 0xe0, 0x2b, 0x5d, 0x50, 0xad, 0xfa, 0xbf, 0x17, 0xd8, 0xf6, 0x52, 0x9b,
+	0x08, 0x3d, 0xe8, 0x95, 0xb7, 0xca, 0x83, 0x55, 0x0b, 0xa2, 0xb5, 0xe4, 0x13, 0x9c, 0xf4, 0x89,
+	0x3c, 0xd9, 0x9a, 0x1a, 0x7d, 0x83, 0x93, 0x0c, 0x04, 0x25, 0x58, 0xd4, 0x28, 0x53, 0xda, 0x63,
+	0xe1, 0xc4, 0x5e, 0x12, 0xa6, 0x56, 0x11, 0xda, 0x53, 0x40, 0xa8, 0xe2, 0x13, 0x59, 0x28, 0x6c,
+	0x77, 0xea, 0xe2, 0x07, 0x57, 0x14, 0xfe, 0xad, 0x23, 0xdc, 0x48, 0xc1, 0x30, 0x62, 0x7a, 0x5f,
+	0x9f, 0x2d, 0x07, 0x54, 0x13, 0x28, 0xd5, 0x03, 0xfe, 0xb1, 0xc0, 0xb6, 0x54, 0xf6, 0x96, 0xd7,
+	0x8d, 0x2f, 0x19, 0xcf, 0x25, 0x99, 0xd8, 0x0d, 0xf7, 0x97, 0x53, 0x11, 0xfa, 0x00, 0x23, 0x93,
+	0x4c, 0x22, 0x94, 0x37, 0x67, 0x29, 0x6a, 0x3e, 0x03, 0x2a, 0x2a, 0xe7, 0x98, 0xb5, 0x03, 0x38,
+	0x46, 0x92, 0x90, 0x66, 0x11, 0x83, 0x37, 0xf0, 0xbb, 0xb3, 0x4f, 0xfe, 0x6b, 0x00, 0x8d, 0xba,
+	0xf3, 0x9c, 0xb3, 0x36, 0x00, 0x00,
 }
@@ -38,6 +38,78 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type AlertInfo_AlertType int32
+
+const (
+	// The row is failing.
+	AlertInfo_ALERT_TYPE_FAILING AlertInfo_AlertType = 0
+	// The row reported results but has none in the newest columns.
+	AlertInfo_ALERT_TYPE_DISAPPEARED AlertInfo_AlertType = 1
+	// The weighted recent results of the row are mostly failing, see
+	// TestGroup.weighted_alert.
+	AlertInfo_ALERT_TYPE_WEIGHTED AlertInfo_AlertType = 2
+)
+
+var AlertInfo_AlertType_name = map[int32]string{
+	0: "ALERT_TYPE_FAILING",
+	1: "ALERT_TYPE_DISAPPEARED",
+	2: "ALERT_TYPE_WEIGHTED",
+}
+
+var AlertInfo_AlertType_value = map[string]int32{
+	"ALERT_TYPE_FAILING":     0,
+	"ALERT_TYPE_DISAPPEARED": 1,
+	"ALERT_TYPE_WEIGHTED":    2,
+}
+
+func (x AlertInfo_AlertType) String() string {
+	return proto.EnumName(AlertInfo_AlertType_name, int32(x))
+}
+
+func (AlertInfo_AlertType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{3, 0}
+}
+
+// Overall health of the results in the column.
+type Column_Health int32
+
+const (
+	// No passing, failing or flaky results.
+	Column_HEALTH_UNKNOWN Column_Health = 0
+	// Every result passed.
+	Column_HEALTH_PASSING Column_Health = 1
+	// Some results failed or flaked, below the thresholds of the group.
+	Column_HEALTH_MOSTLY_PASSING Column_Health = 2
+	// At least the flaky_percent of the group flaked.
+	Column_HEALTH_FLAKY Column_Health = 3
+	// At least the broken_percent of the group failed.
+	Column_HEALTH_BROKEN Column_Health = 4
+)
+
+var Column_Health_name = map[int32]string{
+	0: "HEALTH_UNKNOWN",
+	1: "HEALTH_PASSING",
+	2: "HEALTH_MOSTLY_PASSING",
+	3: "HEALTH_FLAKY",
+	4: "HEALTH_BROKEN",
+}
+
+var Column_Health_value = map[string]int32{
+	"HEALTH_UNKNOWN":        0,
+	"HEALTH_PASSING":        1,
+	"HEALTH_MOSTLY_PASSING": 2,
+	"HEALTH_FLAKY":          3,
+	"HEALTH_BROKEN":         4,
+}
+
+func (x Column_Health) String() string {
+	return proto.EnumName(Column_Health_name, int32(x))
+}
+
+func (Column_Health) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{5, 0}
+}
+
 // A metric and its values for each test cycle.
 type Metric struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -222,7 +294,17 @@ type AlertInfo struct {
 	// A list of IDs for issue hotlists related to this failure.
 	HotlistIds []string `protobuf:"bytes,13,rep,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
 	// Dynamic email list, route email alerts to these instead of the configured defaults.
-	EmailAddresses       []string `protobuf:"bytes,15,rep,name=email_addresses,json=emailAddresses,proto3" json:"email_addresses,omitempty"`
+	EmailAddresses []string `protobuf:"bytes,15,rep,name=email_addresses,json=emailAddresses,proto3" json:"email_addresses,omitempty"`
+	// Link to an issue tracking this failure.
+	IssueLink string `protobuf:"bytes,16,opt,name=issue_link,json=issueLink,proto3" json:"issue_link,omitempty"`
+	// Number of open bugs associated with this failing test.
+	OpenBugs int32 `protobuf:"varint,17,opt,name=open_bugs,json=openBugs,proto3" json:"open_bugs,omitempty"`
+	// Why the alert opened.
+	AlertType AlertInfo_AlertType `protobuf:"varint,18,opt,name=alert_type,json=alertType,proto3,enum=AlertInfo_AlertType" json:"alert_type,omitempty"`
+	// Build IDs which may have introduced the failure, from fail_build_id back
+	// to (but excluding) pass_build_id, in column order. Empty when the row
+	// has not passed since the failures began.
+	SuspectBuildIds      []string `protobuf:"bytes,19,rep,name=suspect_build_ids,json=suspectBuildIds,proto3" json:"suspect_build_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -358,6 +440,34 @@ func (m *AlertInfo) GetEmailAddresses() []string {
 	return nil
 }
 
+func (m *AlertInfo) GetIssueLink() string {
+	if m != nil {
+		return m.IssueLink
+	}
+	return ""
+}
+
+func (m *AlertInfo) GetOpenBugs() int32 {
+	if m != nil {
+		return m.OpenBugs
+	}
+	return 0
+}
+
+func (m *AlertInfo) GetAlertType() AlertInfo_AlertType {
+	if m != nil {
+		return m.AlertType
+	}
+	return AlertInfo_ALERT_TYPE_FAILING
+}
+
+func (m *AlertInfo) GetSuspectBuildIds() []string {
+	if m != nil {
+		return m.SuspectBuildIds
+	}
+	return nil
+}
+
 // Info on default test metadata for a dashboard tab.
 type TestMetadata struct {
 	// Name of the test with associated test metadata.
@@ -453,7 +563,22 @@ type Column struct {
 	// An optional hint for the updater.
 	Hint string `protobuf:"bytes,6,opt,name=hint,proto3" json:"hint,omitempty"`
 	// Dynamic email list, route email alerts to these instead of the configured defaults.
-	EmailAddresses       []string `protobuf:"bytes,7,rep,name=email_addresses,json=emailAddresses,proto3" json:"email_addresses,omitempty"`
+	EmailAddresses []string `protobuf:"bytes,7,rep,name=email_addresses,json=emailAddresses,proto3" json:"email_addresses,omitempty"`
+	// User-set annotations, such as marking a column as a known-bad release.
+	Annotations map[string]string `protobuf:"bytes,8,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Aggregate results, when the group computes them.
+	Stats *Column_Stats `protobuf:"bytes,9,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Set on empty columns which pad the grid to TestGroup.min_columns.
+	// Placeholders are dropped when the grid is next updated.
+	Placeholder bool `protobuf:"varint,10,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	// Summary of the results, when the group configures column_health.
+	Health Column_Health `protobuf:"varint,11,opt,name=health,proto3,enum=Column_Health" json:"health,omitempty"`
+	// Key shared by adjacent columns the UI groups under one header, such as
+	// 2020-09-13, when the group configures column_grouping.
+	GroupKey string `protobuf:"bytes,12,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	// Seconds between the started and finished time of the build, when the
+	// group configures record_column_duration. Zero until the build finishes.
+	DurationSeconds      float64  `protobuf:"fixed64,13,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -533,6 +658,111 @@ func (m *Column) GetEmailAddresses() []string {
 	return nil
 }
 
+func (m *Column) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *Column) GetStats() *Column_Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *Column) GetPlaceholder() bool {
+	if m != nil {
+		return m.Placeholder
+	}
+	return false
+}
+
+func (m *Column) GetHealth() Column_Health {
+	if m != nil {
+		return m.Health
+	}
+	return Column_HEALTH_UNKNOWN
+}
+
+func (m *Column) GetGroupKey() string {
+	if m != nil {
+		return m.GroupKey
+	}
+	return ""
+}
+
+func (m *Column) GetDurationSeconds() float64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+type Column_Stats struct {
+	PassCount            int32    `protobuf:"varint,1,opt,name=pass_count,json=passCount,proto3" json:"pass_count,omitempty"`
+	FailCount            int32    `protobuf:"varint,2,opt,name=fail_count,json=failCount,proto3" json:"fail_count,omitempty"`
+	FlakyCount           int32    `protobuf:"varint,3,opt,name=flaky_count,json=flakyCount,proto3" json:"flaky_count,omitempty"`
+	NoResultCount        int32    `protobuf:"varint,4,opt,name=no_result_count,json=noResultCount,proto3" json:"no_result_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Column_Stats) Reset()         { *m = Column_Stats{} }
+func (m *Column_Stats) String() string { return proto.CompactTextString(m) }
+func (*Column_Stats) ProtoMessage()    {}
+func (*Column_Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{5, 1}
+}
+
+func (m *Column_Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Column_Stats.Unmarshal(m, b)
+}
+func (m *Column_Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Column_Stats.Marshal(b, m, deterministic)
+}
+func (m *Column_Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Column_Stats.Merge(m, src)
+}
+func (m *Column_Stats) XXX_Size() int {
+	return xxx_messageInfo_Column_Stats.Size(m)
+}
+func (m *Column_Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Column_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Column_Stats proto.InternalMessageInfo
+
+func (m *Column_Stats) GetPassCount() int32 {
+	if m != nil {
+		return m.PassCount
+	}
+	return 0
+}
+
+func (m *Column_Stats) GetFailCount() int32 {
+	if m != nil {
+		return m.FailCount
+	}
+	return 0
+}
+
+func (m *Column_Stats) GetFlakyCount() int32 {
+	if m != nil {
+		return m.FlakyCount
+	}
+	return 0
+}
+
+func (m *Column_Stats) GetNoResultCount() int32 {
+	if m != nil {
+		return m.NoResultCount
+	}
+	return 0
+}
+
 // TestGrid rows (also known as TestRow)
 type Row struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -562,7 +792,28 @@ type Row struct {
 	// An alert for the failure if there's a recent failure for this row.
 	AlertInfo *AlertInfo `protobuf:"bytes,11,opt,name=alert_info,json=alertInfo,proto3" json:"alert_info,omitempty"`
 	// Values of a user-defined property found in cells for this row.
-	UserProperty         []string `protobuf:"bytes,12,rep,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	UserProperty []string `protobuf:"bytes,12,rep,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// The flakiness of the row over the grid's columns, measured out of 100.
+	Flakiness float32 `protobuf:"fixed32,13,opt,name=flakiness,proto3" json:"flakiness,omitempty"`
+	// Index into the grid's message_table for each message, replacing messages
+	// when the grid interns its messages.
+	MessageIndices []int32 `protobuf:"varint,14,rep,packed,name=message_indices,json=messageIndices,proto3" json:"message_indices,omitempty"`
+	// Link to the artifacts of each cell with a result, see
+	// TestGroup.artifact_url_template. Empty without a template.
+	ArtifactUrls []string `protobuf:"bytes,15,rep,name=artifact_urls,json=artifactUrls,proto3" json:"artifact_urls,omitempty"`
+	// Days since the most recent passing cell of this row when the grid was
+	// built, see TestGroup.compute_days_since_green.
+	// Zero when the most recent result passed, and -1 when no cell passed.
+	DaysSinceGreen float64 `protobuf:"fixed64,16,opt,name=days_since_green,json=daysSinceGreen,proto3" json:"days_since_green,omitempty"`
+	// Metric values which regressed from their baseline, see
+	// TestGroup.metric_regression_rules.
+	MetricRegressions []*MetricRegression `protobuf:"bytes,17,rep,name=metric_regressions,json=metricRegressions,proto3" json:"metric_regressions,omitempty"`
+	// Start of the newest column, in milliseconds since epoch, when the most
+	// recent alert of this row closed. See TestGroup.alert_cooldown_minutes.
+	LastAlertClosed float64 `protobuf:"fixed64,18,opt,name=last_alert_closed,json=lastAlertClosed,proto3" json:"last_alert_closed,omitempty"`
+	// Owner and component of the row's target, see TestGroup.target_metadata.
+	Owner                string   `protobuf:"bytes,19,opt,name=owner,proto3" json:"owner,omitempty"`
+	Component            string   `protobuf:"bytes,20,opt,name=component,proto3" json:"component,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -670,6 +921,139 @@ func (m *Row) GetUserProperty() []string {
 	return nil
 }
 
+func (m *Row) GetFlakiness() float32 {
+	if m != nil {
+		return m.Flakiness
+	}
+	return 0
+}
+
+func (m *Row) GetMessageIndices() []int32 {
+	if m != nil {
+		return m.MessageIndices
+	}
+	return nil
+}
+
+func (m *Row) GetArtifactUrls() []string {
+	if m != nil {
+		return m.ArtifactUrls
+	}
+	return nil
+}
+
+func (m *Row) GetDaysSinceGreen() float64 {
+	if m != nil {
+		return m.DaysSinceGreen
+	}
+	return 0
+}
+
+func (m *Row) GetMetricRegressions() []*MetricRegression {
+	if m != nil {
+		return m.MetricRegressions
+	}
+	return nil
+}
+
+func (m *Row) GetLastAlertClosed() float64 {
+	if m != nil {
+		return m.LastAlertClosed
+	}
+	return 0
+}
+
+func (m *Row) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Row) GetComponent() string {
+	if m != nil {
+		return m.Component
+	}
+	return ""
+}
+
+// A metric value worse than the median of older values.
+type MetricRegression struct {
+	// Name of the metric, such as duration.
+	Metric string `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	// Build of the column with the regressed value.
+	Build string `protobuf:"bytes,2,opt,name=build,proto3" json:"build,omitempty"`
+	// The regressed value.
+	Value float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Median of the older values compared against.
+	Baseline float64 `protobuf:"fixed64,4,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	// Describes the regression, such as "duration regressed 50% from 10 to 15".
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetricRegression) Reset()         { *m = MetricRegression{} }
+func (m *MetricRegression) String() string { return proto.CompactTextString(m) }
+func (*MetricRegression) ProtoMessage()    {}
+func (*MetricRegression) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{7}
+}
+
+func (m *MetricRegression) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricRegression.Unmarshal(m, b)
+}
+func (m *MetricRegression) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricRegression.Marshal(b, m, deterministic)
+}
+func (m *MetricRegression) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricRegression.Merge(m, src)
+}
+func (m *MetricRegression) XXX_Size() int {
+	return xxx_messageInfo_MetricRegression.Size(m)
+}
+func (m *MetricRegression) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricRegression.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricRegression proto.InternalMessageInfo
+
+func (m *MetricRegression) GetMetric() string {
+	if m != nil {
+		return m.Metric
+	}
+	return ""
+}
+
+func (m *MetricRegression) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *MetricRegression) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *MetricRegression) GetBaseline() float64 {
+	if m != nil {
+		return m.Baseline
+	}
+	return 0
+}
+
+func (m *MetricRegression) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 // A single table of test results backing a dashboard tab.
 type Grid struct {
 	// A cycle of test results, not including the results. In the TestGrid client,
@@ -689,17 +1073,23 @@ type Grid struct {
 	// Clusters of failures for a TestResultTable instance.
 	Cluster []*Cluster `protobuf:"bytes,10,rep,name=cluster,proto3" json:"cluster,omitempty"`
 	// Most recent timestamp that clusters have processed.
-	MostRecentClusterTimestamp float64  `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	MostRecentClusterTimestamp float64 `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
+	// Unique messages referenced by the message_indices of each row.
+	// Rows store messages rather than indices when empty.
+	MessageTable []string `protobuf:"bytes,12,rep,name=message_table,json=messageTable,proto3" json:"message_table,omitempty"`
+	// Open alerts of several rows sharing a failure message, see
+	// TestGroup.alert_group_min_rows.
+	AlertGroups          []*AlertGroup `protobuf:"bytes,13,rep,name=alert_groups,json=alertGroups,proto3" json:"alert_groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *Grid) Reset()         { *m = Grid{} }
 func (m *Grid) String() string { return proto.CompactTextString(m) }
 func (*Grid) ProtoMessage()    {}
 func (*Grid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{7}
+	return fileDescriptor_a888679467bb7853, []int{8}
 }
 
 func (m *Grid) XXX_Unmarshal(b []byte) error {
@@ -776,6 +1166,304 @@ func (m *Grid) GetMostRecentClusterTimestamp() float64 {
 	return 0
 }
 
+func (m *Grid) GetMessageTable() []string {
+	if m != nil {
+		return m.MessageTable
+	}
+	return nil
+}
+
+func (m *Grid) GetAlertGroups() []*AlertGroup {
+	if m != nil {
+		return m.AlertGroups
+	}
+	return nil
+}
+
+// The open alerts of several rows which fail with the same message, such as
+// during an infrastructure outage.
+type AlertGroup struct {
+	// Summarizes the alerts of every row, see TestGroup.alert_group_min_rows.
+	Alert *AlertInfo `protobuf:"bytes,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	// Names of the alerting rows, sorted.
+	Rows                 []string `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertGroup) Reset()         { *m = AlertGroup{} }
+func (m *AlertGroup) String() string { return proto.CompactTextString(m) }
+func (*AlertGroup) ProtoMessage()    {}
+func (*AlertGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{9}
+}
+
+func (m *AlertGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertGroup.Unmarshal(m, b)
+}
+func (m *AlertGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertGroup.Marshal(b, m, deterministic)
+}
+func (m *AlertGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertGroup.Merge(m, src)
+}
+func (m *AlertGroup) XXX_Size() int {
+	return xxx_messageInfo_AlertGroup.Size(m)
+}
+func (m *AlertGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertGroup proto.InternalMessageInfo
+
+func (m *AlertGroup) GetAlert() *AlertInfo {
+	if m != nil {
+		return m.Alert
+	}
+	return nil
+}
+
+func (m *AlertGroup) GetRows() []string {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+// Changes which patch an old grid into a new one, see
+// TestGroup.write_grid_delta.
+//
+// Each column and row of the new grid either references an identical one in
+// the old grid by index, or holds the new one in full.
+type GridDelta struct {
+	// The hex-encoded SHA-256 of the old grid this delta patches, after
+	// expanding any interned messages.
+	BaseSha256 string `protobuf:"bytes,1,opt,name=base_sha256,json=baseSha256,proto3" json:"base_sha256,omitempty"`
+	// Each column of the new grid, in order.
+	Columns []*GridDelta_ColumnPatch `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	// Each row of the new grid, in order.
+	Rows []*GridDelta_RowPatch `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Every other field of the new grid, without its columns or rows.
+	Grid                 *Grid    `protobuf:"bytes,4,opt,name=grid,proto3" json:"grid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GridDelta) Reset()         { *m = GridDelta{} }
+func (m *GridDelta) String() string { return proto.CompactTextString(m) }
+func (*GridDelta) ProtoMessage()    {}
+func (*GridDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{10}
+}
+
+func (m *GridDelta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GridDelta.Unmarshal(m, b)
+}
+func (m *GridDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GridDelta.Marshal(b, m, deterministic)
+}
+func (m *GridDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GridDelta.Merge(m, src)
+}
+func (m *GridDelta) XXX_Size() int {
+	return xxx_messageInfo_GridDelta.Size(m)
+}
+func (m *GridDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_GridDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GridDelta proto.InternalMessageInfo
+
+func (m *GridDelta) GetBaseSha256() string {
+	if m != nil {
+		return m.BaseSha256
+	}
+	return ""
+}
+
+func (m *GridDelta) GetColumns() []*GridDelta_ColumnPatch {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *GridDelta) GetRows() []*GridDelta_RowPatch {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+func (m *GridDelta) GetGrid() *Grid {
+	if m != nil {
+		return m.Grid
+	}
+	return nil
+}
+
+type GridDelta_ColumnPatch struct {
+	// Types that are valid to be assigned to Patch:
+	//	*GridDelta_ColumnPatch_Old
+	//	*GridDelta_ColumnPatch_Column
+	Patch                isGridDelta_ColumnPatch_Patch `protobuf_oneof:"patch"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GridDelta_ColumnPatch) Reset()         { *m = GridDelta_ColumnPatch{} }
+func (m *GridDelta_ColumnPatch) String() string { return proto.CompactTextString(m) }
+func (*GridDelta_ColumnPatch) ProtoMessage()    {}
+func (*GridDelta_ColumnPatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{10, 0}
+}
+
+func (m *GridDelta_ColumnPatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GridDelta_ColumnPatch.Unmarshal(m, b)
+}
+func (m *GridDelta_ColumnPatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GridDelta_ColumnPatch.Marshal(b, m, deterministic)
+}
+func (m *GridDelta_ColumnPatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GridDelta_ColumnPatch.Merge(m, src)
+}
+func (m *GridDelta_ColumnPatch) XXX_Size() int {
+	return xxx_messageInfo_GridDelta_ColumnPatch.Size(m)
+}
+func (m *GridDelta_ColumnPatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_GridDelta_ColumnPatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GridDelta_ColumnPatch proto.InternalMessageInfo
+
+type isGridDelta_ColumnPatch_Patch interface {
+	isGridDelta_ColumnPatch_Patch()
+}
+
+type GridDelta_ColumnPatch_Old struct {
+	Old int32 `protobuf:"varint,1,opt,name=old,proto3,oneof"`
+}
+
+type GridDelta_ColumnPatch_Column struct {
+	Column *Column `protobuf:"bytes,2,opt,name=column,proto3,oneof"`
+}
+
+func (*GridDelta_ColumnPatch_Old) isGridDelta_ColumnPatch_Patch() {}
+
+func (*GridDelta_ColumnPatch_Column) isGridDelta_ColumnPatch_Patch() {}
+
+func (m *GridDelta_ColumnPatch) GetPatch() isGridDelta_ColumnPatch_Patch {
+	if m != nil {
+		return m.Patch
+	}
+	return nil
+}
+
+func (m *GridDelta_ColumnPatch) GetOld() int32 {
+	if x, ok := m.GetPatch().(*GridDelta_ColumnPatch_Old); ok {
+		return x.Old
+	}
+	return 0
+}
+
+func (m *GridDelta_ColumnPatch) GetColumn() *Column {
+	if x, ok := m.GetPatch().(*GridDelta_ColumnPatch_Column); ok {
+		return x.Column
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GridDelta_ColumnPatch) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GridDelta_ColumnPatch_Old)(nil),
+		(*GridDelta_ColumnPatch_Column)(nil),
+	}
+}
+
+type GridDelta_RowPatch struct {
+	// Types that are valid to be assigned to Patch:
+	//	*GridDelta_RowPatch_Old
+	//	*GridDelta_RowPatch_Row
+	Patch                isGridDelta_RowPatch_Patch `protobuf_oneof:"patch"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GridDelta_RowPatch) Reset()         { *m = GridDelta_RowPatch{} }
+func (m *GridDelta_RowPatch) String() string { return proto.CompactTextString(m) }
+func (*GridDelta_RowPatch) ProtoMessage()    {}
+func (*GridDelta_RowPatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{10, 1}
+}
+
+func (m *GridDelta_RowPatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GridDelta_RowPatch.Unmarshal(m, b)
+}
+func (m *GridDelta_RowPatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GridDelta_RowPatch.Marshal(b, m, deterministic)
+}
+func (m *GridDelta_RowPatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GridDelta_RowPatch.Merge(m, src)
+}
+func (m *GridDelta_RowPatch) XXX_Size() int {
+	return xxx_messageInfo_GridDelta_RowPatch.Size(m)
+}
+func (m *GridDelta_RowPatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_GridDelta_RowPatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GridDelta_RowPatch proto.InternalMessageInfo
+
+type isGridDelta_RowPatch_Patch interface {
+	isGridDelta_RowPatch_Patch()
+}
+
+type GridDelta_RowPatch_Old struct {
+	Old int32 `protobuf:"varint,1,opt,name=old,proto3,oneof"`
+}
+
+type GridDelta_RowPatch_Row struct {
+	Row *Row `protobuf:"bytes,2,opt,name=row,proto3,oneof"`
+}
+
+func (*GridDelta_RowPatch_Old) isGridDelta_RowPatch_Patch() {}
+
+func (*GridDelta_RowPatch_Row) isGridDelta_RowPatch_Patch() {}
+
+func (m *GridDelta_RowPatch) GetPatch() isGridDelta_RowPatch_Patch {
+	if m != nil {
+		return m.Patch
+	}
+	return nil
+}
+
+func (m *GridDelta_RowPatch) GetOld() int32 {
+	if x, ok := m.GetPatch().(*GridDelta_RowPatch_Old); ok {
+		return x.Old
+	}
+	return 0
+}
+
+func (m *GridDelta_RowPatch) GetRow() *Row {
+	if x, ok := m.GetPatch().(*GridDelta_RowPatch_Row); ok {
+		return x.Row
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GridDelta_RowPatch) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GridDelta_RowPatch_Old)(nil),
+		(*GridDelta_RowPatch_Row)(nil),
+	}
+}
+
 // A cluster of failures grouped by test status and message for a test results
 // table.
 type Cluster struct {
@@ -794,7 +1482,7 @@ func (m *Cluster) Reset()         { *m = Cluster{} }
 func (m *Cluster) String() string { return proto.CompactTextString(m) }
 func (*Cluster) ProtoMessage()    {}
 func (*Cluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{8}
+	return fileDescriptor_a888679467bb7853, []int{11}
 }
 
 func (m *Cluster) XXX_Unmarshal(b []byte) error {
@@ -851,7 +1539,7 @@ func (m *ClusterRow) Reset()         { *m = ClusterRow{} }
 func (m *ClusterRow) String() string { return proto.CompactTextString(m) }
 func (*ClusterRow) ProtoMessage()    {}
 func (*ClusterRow) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{9}
+	return fileDescriptor_a888679467bb7853, []int{12}
 }
 
 func (m *ClusterRow) XXX_Unmarshal(b []byte) error {
@@ -887,6 +1575,8 @@ func (m *ClusterRow) GetIndex() []int32 {
 }
 
 func init() {
+	proto.RegisterEnum("AlertInfo_AlertType", AlertInfo_AlertType_name, AlertInfo_AlertType_value)
+	proto.RegisterEnum("Column_Health", Column_Health_name, Column_Health_value)
 	proto.RegisterType((*Metric)(nil), "Metric")
 	proto.RegisterType((*UpdatePhaseData)(nil), "UpdatePhaseData")
 	proto.RegisterType((*UpdateInfo)(nil), "UpdateInfo")
@@ -894,8 +1584,15 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "AlertInfo.PropertiesEntry")
 	proto.RegisterType((*TestMetadata)(nil), "TestMetadata")
 	proto.RegisterType((*Column)(nil), "Column")
+	proto.RegisterMapType((map[string]string)(nil), "Column.AnnotationsEntry")
+	proto.RegisterType((*Column_Stats)(nil), "Column.Stats")
 	proto.RegisterType((*Row)(nil), "Row")
+	proto.RegisterType((*MetricRegression)(nil), "MetricRegression")
 	proto.RegisterType((*Grid)(nil), "Grid")
+	proto.RegisterType((*AlertGroup)(nil), "AlertGroup")
+	proto.RegisterType((*GridDelta)(nil), "GridDelta")
+	proto.RegisterType((*GridDelta_ColumnPatch)(nil), "GridDelta.ColumnPatch")
+	proto.RegisterType((*GridDelta_RowPatch)(nil), "GridDelta.RowPatch")
 	proto.RegisterType((*Cluster)(nil), "Cluster")
 	proto.RegisterType((*ClusterRow)(nil), "ClusterRow")
 }
@@ -903,74 +1600,120 @@ func init() {
 func init() { proto.RegisterFile("state.proto", fileDescriptor_a888679467bb7853) }
 
 var fileDescriptor_a888679467bb7853 = []byte{
-	// 1100 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xcf, 0x8e, 0xdb, 0x36,
-	0x13, 0x87, 0x6c, 0xd9, 0x96, 0x46, 0xf6, 0xda, 0xe1, 0x17, 0x04, 0xfa, 0xb6, 0x08, 0xe2, 0x28,
-	0x45, 0xbb, 0x2d, 0x5a, 0x2d, 0xe0, 0x1e, 0x5a, 0x04, 0xed, 0x21, 0xdd, 0xa6, 0xc1, 0x2e, 0x9a,
-	0x20, 0x60, 0x36, 0x67, 0x41, 0x2b, 0x71, 0x1d, 0x21, 0xb2, 0x28, 0x90, 0x54, 0xbd, 0x7e, 0x90,
-	0x3e, 0x4c, 0x6f, 0xed, 0x0b, 0xf5, 0x19, 0x8a, 0x19, 0x52, 0xb6, 0x13, 0x04, 0xe8, 0xc9, 0xfc,
-	0xfd, 0x66, 0x34, 0x33, 0x9c, 0x7f, 0x34, 0x44, 0xda, 0xe4, 0x46, 0xa4, 0xad, 0x92, 0x46, 0x9e,
-	0x3e, 0x5a, 0x4b, 0xb9, 0xae, 0xc5, 0x39, 0xa1, 0x9b, 0xee, 0xf6, 0xdc, 0x54, 0x1b, 0xa1, 0x4d,
-	0xbe, 0x69, 0x9d, 0xc2, 0x83, 0xf6, 0xe6, 0xbc, 0x90, 0xcd, 0x6d, 0xb5, 0x76, 0x3f, 0x96, 0x4f,
-	0x5e, 0xc1, 0xf8, 0xa5, 0x30, 0xaa, 0x2a, 0x18, 0x03, 0xbf, 0xc9, 0x37, 0x22, 0xf6, 0x96, 0xde,
-	0x59, 0xc8, 0xe9, 0xcc, 0x62, 0x98, 0x54, 0x4d, 0x59, 0x15, 0x42, 0xc7, 0x83, 0xe5, 0xf0, 0x6c,
-	0xc4, 0x7b, 0xc8, 0x1e, 0xc0, 0xf8, 0xf7, 0xbc, 0xee, 0x84, 0x8e, 0x87, 0xcb, 0xe1, 0x99, 0xc7,
-	0x1d, 0x4a, 0xde, 0xc2, 0xfc, 0x6d, 0x5b, 0xe6, 0x46, 0xbc, 0x7e, 0x97, 0x6b, 0xf1, 0x4b, 0x6e,
-	0x72, 0xf6, 0x10, 0xa0, 0x45, 0x90, 0x1d, 0x99, 0x0f, 0x89, 0x79, 0x85, 0x3e, 0x9e, 0xc0, 0xcc,
-	0x8a, 0xb5, 0x28, 0x64, 0x53, 0xa2, 0x27, 0xef, 0xcc, 0xe3, 0x53, 0x22, 0xdf, 0x58, 0x2e, 0xb9,
-	0x02, 0xb0, 0x66, 0x2f, 0x9b, 0x5b, 0xc9, 0x7e, 0x84, 0x7b, 0x1d, 0xa1, 0xcc, 0x7e, 0x59, 0xe6,
-	0x26, 0x8f, 0xbd, 0xe5, 0xf0, 0x2c, 0x5a, 0x2d, 0xd2, 0x8f, 0xdc, 0xf3, 0x79, 0xf7, 0x21, 0x91,
-	0xfc, 0x3d, 0x82, 0xf0, 0x59, 0x2d, 0x94, 0x21, 0x5b, 0x0f, 0x01, 0x6e, 0xf3, 0xaa, 0xce, 0x0a,
-	0xd9, 0x35, 0x86, 0xa2, 0x1b, 0xf1, 0x10, 0x99, 0x0b, 0x24, 0x58, 0x02, 0x33, 0x12, 0xdf, 0x74,
-	0x55, 0x5d, 0x66, 0x55, 0x49, 0xd1, 0x85, 0x3c, 0x42, 0xf2, 0x67, 0xe4, 0x2e, 0x4b, 0xf6, 0x3d,
-	0xd0, 0x07, 0x19, 0xe6, 0x3c, 0x1e, 0x2e, 0xbd, 0xb3, 0x68, 0x75, 0x9a, 0xda, 0x82, 0xa4, 0x7d,
-	0x41, 0xd2, 0xeb, 0xbe, 0x20, 0x3c, 0x40, 0x65, 0x84, 0x6c, 0x09, 0x53, 0xfb, 0xa1, 0xd0, 0x06,
-	0x6d, 0xfb, 0x64, 0x9b, 0xe2, 0xb9, 0x16, 0xda, 0x5c, 0x96, 0xe8, 0xbe, 0xcd, 0xb5, 0x3e, 0xb8,
-	0x1f, 0x59, 0xf7, 0x48, 0x1e, 0xb9, 0x27, 0x1d, 0x72, 0x3f, 0xfe, 0x6f, 0xf7, 0xa8, 0x4c, 0xee,
-	0xbf, 0x84, 0x39, 0xba, 0xea, 0x94, 0xc8, 0x36, 0x42, 0xeb, 0x7c, 0x2d, 0xe2, 0x09, 0x99, 0x3f,
-	0x71, 0xf4, 0x4b, 0xcb, 0x62, 0x8e, 0x6c, 0x00, 0x75, 0xd5, 0xbc, 0x8f, 0x03, 0x5b, 0x41, 0x62,
-	0x7e, 0xab, 0x9a, 0xf7, 0xec, 0x0b, 0x98, 0x1f, 0xc4, 0x99, 0x11, 0x77, 0x26, 0x0e, 0x49, 0x67,
-	0xb6, 0xd7, 0xb9, 0x16, 0x77, 0x86, 0x7d, 0x0e, 0x27, 0x56, 0xaf, 0x53, 0xb5, 0x55, 0x03, 0x52,
-	0x9b, 0x12, 0xfb, 0x56, 0xd5, 0xa4, 0x75, 0x0e, 0xf7, 0xeb, 0x9c, 0x32, 0xf2, 0x61, 0xe2, 0x23,
-	0xd2, 0xbd, 0x67, 0x65, 0xbf, 0x1e, 0xa5, 0xff, 0x5b, 0xf8, 0xdf, 0xf1, 0x07, 0x7d, 0x32, 0x4f,
-	0x48, 0x7f, 0x71, 0xd0, 0x77, 0x29, 0x7d, 0x0a, 0xd0, 0x2a, 0xd9, 0x0a, 0x65, 0x2a, 0xa1, 0xe3,
-	0x29, 0x75, 0xcd, 0x69, 0xba, 0x6f, 0x88, 0xf4, 0xf5, 0x5e, 0xf8, 0xbc, 0x31, 0x6a, 0xc7, 0x8f,
-	0xb4, 0xd9, 0x23, 0x88, 0xde, 0x49, 0x53, 0x57, 0xe4, 0x41, 0xc7, 0xb3, 0xe5, 0x10, 0xeb, 0xe5,
-	0xa8, 0xcb, 0x52, 0x63, 0x4a, 0xc5, 0x06, 0xa3, 0xc8, 0xcb, 0x52, 0x09, 0xad, 0x85, 0x8e, 0xe7,
-	0xa4, 0x74, 0x42, 0xf4, 0xb3, 0x9e, 0x3d, 0xfd, 0x09, 0xe6, 0x1f, 0x39, 0x62, 0x0b, 0x18, 0xbe,
-	0x17, 0x3b, 0x37, 0x20, 0x78, 0x64, 0xf7, 0x61, 0x44, 0x63, 0xe5, 0x9a, 0xce, 0x82, 0xa7, 0x83,
-	0x1f, 0xbc, 0xe4, 0x0f, 0x0f, 0xa6, 0x78, 0x9f, 0x97, 0xc2, 0xe4, 0xd8, 0xfd, 0xec, 0x33, 0x08,
-	0xe9, 0xe2, 0x47, 0x33, 0x16, 0x20, 0xd1, 0x8f, 0xd8, 0x4d, 0xb7, 0xce, 0x0a, 0xb9, 0x69, 0x65,
-	0x23, 0x1a, 0x43, 0xf6, 0x46, 0x98, 0xf7, 0xf5, 0x45, 0xcf, 0xa1, 0x33, 0xb9, 0x6d, 0x84, 0xa2,
-	0x0e, 0x0e, 0xb9, 0x05, 0xec, 0x04, 0x06, 0x45, 0x11, 0xfb, 0x74, 0x87, 0x41, 0x51, 0x60, 0x2b,
-	0x08, 0xa5, 0xa4, 0xca, 0xcc, 0xae, 0x15, 0xae, 0x1b, 0x43, 0x62, 0xae, 0x77, 0xad, 0x48, 0xfe,
-	0xf2, 0x60, 0x7c, 0x21, 0xeb, 0x6e, 0xd3, 0xa0, 0x3d, 0xaa, 0x9d, 0x8b, 0xc6, 0x82, 0xfd, 0x96,
-	0x19, 0x7c, 0xb8, 0x65, 0xb4, 0xc9, 0x95, 0x11, 0x25, 0xf9, 0xf6, 0x78, 0x0f, 0xd1, 0x86, 0xb8,
-	0x33, 0x2a, 0x77, 0x01, 0x58, 0xf0, 0x71, 0x15, 0x6c, 0x10, 0xc7, 0x55, 0x60, 0xe0, 0xbf, 0xab,
-	0x1a, 0x43, 0xc3, 0x10, 0x72, 0x3a, 0x7f, 0xaa, 0x32, 0x93, 0x4f, 0x55, 0x26, 0xf9, 0x73, 0x00,
-	0x43, 0x2e, 0xb7, 0x9f, 0xdc, 0x87, 0x27, 0x30, 0xd8, 0xaf, 0x80, 0x41, 0x55, 0x62, 0xe4, 0x4a,
-	0xe8, 0xae, 0x36, 0x76, 0x0d, 0x8e, 0x78, 0x0f, 0xd9, 0xff, 0x21, 0x28, 0x44, 0x5d, 0x53, 0x80,
-	0x36, 0xf8, 0x09, 0x62, 0x8c, 0xee, 0x14, 0x02, 0x37, 0x6e, 0x18, 0x3b, 0x8a, 0xf6, 0x18, 0xd7,
-	0xea, 0x86, 0xd6, 0xb1, 0x0b, 0xce, 0x21, 0xf6, 0x18, 0x26, 0xf6, 0xa4, 0xe3, 0x80, 0x3a, 0x76,
-	0x92, 0xda, 0xb5, 0xcd, 0x7b, 0x1e, 0x73, 0x55, 0x15, 0xb2, 0xd1, 0x71, 0x68, 0x73, 0x45, 0x00,
-	0x0d, 0x56, 0x5a, 0xe3, 0x9e, 0x06, 0x6b, 0xd0, 0x22, 0xf6, 0x15, 0x40, 0x8e, 0x2d, 0x9f, 0x55,
-	0xcd, 0xad, 0xa4, 0xd9, 0x8a, 0x56, 0x70, 0x98, 0x02, 0x1e, 0xe6, 0xfb, 0x0d, 0xf9, 0x04, 0x66,
-	0x9d, 0x16, 0x2a, 0x73, 0x73, 0xb0, 0xa3, 0x99, 0x09, 0xf9, 0x14, 0x49, 0xd7, 0xc3, 0xbb, 0x2b,
-	0x3f, 0x18, 0x2f, 0x26, 0xc9, 0x3f, 0x03, 0xf0, 0x5f, 0xa8, 0xaa, 0xc4, 0x78, 0x0b, 0x6a, 0x03,
-	0xed, 0xf6, 0xf2, 0x24, 0xb5, 0x6d, 0xc1, 0x7b, 0x9e, 0xc5, 0xe0, 0x2b, 0xb9, 0xb5, 0x0f, 0x4b,
-	0xb4, 0xf2, 0x53, 0x2e, 0xb7, 0x9c, 0x18, 0x96, 0xc0, 0xd8, 0xbe, 0x51, 0xb4, 0x10, 0x31, 0x2e,
-	0x6c, 0xf5, 0x17, 0x4a, 0x76, 0x2d, 0x77, 0x12, 0xf6, 0x35, 0xdc, 0xab, 0x73, 0x6d, 0x68, 0xe9,
-	0x65, 0x76, 0xc3, 0x97, 0x54, 0x6f, 0x8f, 0xcf, 0x51, 0x80, 0x0b, 0xce, 0xbe, 0x04, 0x25, 0xfb,
-	0x06, 0x22, 0xf7, 0x5c, 0xd0, 0x65, 0x6d, 0x02, 0xa3, 0xf4, 0xf0, 0xa0, 0x70, 0xe8, 0x0e, 0x8f,
-	0xcb, 0x0a, 0x66, 0x34, 0x49, 0x1b, 0x37, 0x5a, 0x94, 0xcf, 0x68, 0x35, 0x4b, 0x8f, 0xe7, 0x8d,
-	0x4f, 0xcd, 0xf1, 0xf4, 0x25, 0x30, 0x29, 0xea, 0x4e, 0x1b, 0xa1, 0x28, 0xcd, 0xd1, 0x2a, 0x48,
-	0x2f, 0x2c, 0xe6, 0xbd, 0x80, 0x3d, 0x83, 0x87, 0x1b, 0xa9, 0x4d, 0xa6, 0x44, 0x21, 0x1a, 0x93,
-	0x39, 0x3a, 0xdb, 0x3f, 0xd4, 0x54, 0x04, 0x8f, 0x9f, 0xa2, 0x12, 0x27, 0x1d, 0x67, 0x62, 0xbf,
-	0xba, 0xaf, 0xfc, 0x60, 0xb8, 0xf0, 0xaf, 0xfc, 0x60, 0xb4, 0x18, 0x5f, 0xf9, 0xc1, 0x64, 0x11,
-	0x24, 0x0a, 0x26, 0x4e, 0x0b, 0xa7, 0x82, 0xe2, 0xc6, 0xbf, 0x05, 0x9d, 0x76, 0x2f, 0x19, 0x20,
-	0xf5, 0x86, 0x18, 0x6c, 0xd6, 0x7e, 0xcd, 0xdb, 0x0e, 0xee, 0x21, 0x26, 0xa8, 0x0f, 0x47, 0xc9,
-	0x2d, 0xb5, 0x32, 0x26, 0xa8, 0xbf, 0x82, 0xdc, 0x72, 0x28, 0xf6, 0xe7, 0xe4, 0x39, 0xc0, 0x41,
-	0xc2, 0x1e, 0xc3, 0xb4, 0xac, 0x74, 0x5b, 0xe7, 0xbb, 0xe3, 0xdd, 0x13, 0x39, 0x8e, 0xd6, 0x0f,
-	0x76, 0x66, 0x53, 0x8a, 0x3b, 0xf7, 0x1f, 0xc2, 0x82, 0x9b, 0x31, 0xbd, 0x4d, 0xdf, 0xfd, 0x1b,
-	0x00, 0x00, 0xff, 0xff, 0x23, 0xe8, 0x42, 0x8f, 0xc8, 0x08, 0x00, 0x00,
+	// 1840 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x5b, 0x6f, 0xdb, 0xca,
+	0xf1, 0x0f, 0x75, 0xd7, 0x48, 0x96, 0xe8, 0x75, 0xfe, 0xfe, 0x33, 0xea, 0x09, 0xa2, 0xe8, 0x14,
+	0x39, 0x3e, 0x45, 0xab, 0x14, 0x3a, 0xe8, 0x05, 0x41, 0x5b, 0x54, 0xb6, 0x15, 0x5f, 0x62, 0x3b,
+	0xc6, 0x5a, 0xc6, 0x69, 0x9e, 0x08, 0x8a, 0x5c, 0xcb, 0x44, 0x28, 0x52, 0xe0, 0x2e, 0xeb, 0xe8,
+	0x3b, 0x14, 0x7d, 0xeb, 0x37, 0xe9, 0x43, 0xdf, 0xfb, 0x89, 0xfa, 0xd2, 0xe7, 0x62, 0x66, 0x97,
+	0x14, 0x6d, 0x04, 0x28, 0xfa, 0xa4, 0x9d, 0xdf, 0x0c, 0x77, 0x76, 0x67, 0x7f, 0x73, 0x11, 0x74,
+	0xa4, 0xf2, 0x94, 0x18, 0xaf, 0xd3, 0x44, 0x25, 0x83, 0x57, 0xcb, 0x24, 0x59, 0x46, 0xe2, 0x2d,
+	0x49, 0x8b, 0xec, 0xee, 0xad, 0x0a, 0x57, 0x42, 0x2a, 0x6f, 0xb5, 0x36, 0x06, 0xfb, 0xeb, 0xc5,
+	0x5b, 0x3f, 0x89, 0xef, 0xc2, 0xa5, 0xf9, 0xd1, 0xf8, 0xe8, 0x0a, 0x1a, 0x97, 0x42, 0xa5, 0xa1,
+	0xcf, 0x18, 0xd4, 0x62, 0x6f, 0x25, 0x1c, 0x6b, 0x68, 0x1d, 0xb4, 0x39, 0xad, 0x99, 0x03, 0xcd,
+	0x30, 0x0e, 0x42, 0x5f, 0x48, 0xa7, 0x32, 0xac, 0x1e, 0xd4, 0x79, 0x2e, 0xb2, 0x7d, 0x68, 0xfc,
+	0xd9, 0x8b, 0x32, 0x21, 0x9d, 0xea, 0xb0, 0x7a, 0x60, 0x71, 0x23, 0x8d, 0x6e, 0xa1, 0x7f, 0xbb,
+	0x0e, 0x3c, 0x25, 0xae, 0xef, 0x3d, 0x29, 0x8e, 0x3d, 0xe5, 0xb1, 0x97, 0x00, 0x6b, 0x14, 0xdc,
+	0xd2, 0xf6, 0x6d, 0x42, 0xae, 0xd0, 0xc7, 0xb7, 0xb0, 0xa3, 0xd5, 0x52, 0xf8, 0x49, 0x1c, 0xa0,
+	0x27, 0xeb, 0xc0, 0xe2, 0x5d, 0x02, 0x6f, 0x34, 0x36, 0x3a, 0x07, 0xd0, 0xdb, 0x9e, 0xc5, 0x77,
+	0x09, 0xfb, 0x1d, 0xec, 0x66, 0x24, 0xb9, 0xfa, 0xcb, 0xc0, 0x53, 0x9e, 0x63, 0x0d, 0xab, 0x07,
+	0x9d, 0x89, 0x3d, 0x7e, 0xe2, 0x9e, 0xf7, 0xb3, 0xc7, 0xc0, 0xe8, 0x1f, 0x4d, 0x68, 0x4f, 0x23,
+	0x91, 0x2a, 0xda, 0xeb, 0x25, 0xc0, 0x9d, 0x17, 0x46, 0xae, 0x9f, 0x64, 0xb1, 0xa2, 0xd3, 0xd5,
+	0x79, 0x1b, 0x91, 0x23, 0x04, 0xd8, 0x08, 0x76, 0x48, 0xbd, 0xc8, 0xc2, 0x28, 0x70, 0xc3, 0x80,
+	0x4e, 0xd7, 0xe6, 0x1d, 0x04, 0x0f, 0x11, 0x3b, 0x0b, 0xd8, 0x6f, 0x80, 0x3e, 0x70, 0x31, 0xe6,
+	0x4e, 0x75, 0x68, 0x1d, 0x74, 0x26, 0x83, 0xb1, 0x7e, 0x90, 0x71, 0xfe, 0x20, 0xe3, 0x79, 0xfe,
+	0x20, 0xbc, 0x85, 0xc6, 0x28, 0xb2, 0x21, 0x74, 0xf5, 0x87, 0x42, 0x2a, 0xdc, 0xbb, 0x46, 0x7b,
+	0xd3, 0x79, 0xe6, 0x42, 0xaa, 0xb3, 0x00, 0xdd, 0xaf, 0x3d, 0x29, 0xb7, 0xee, 0xeb, 0xda, 0x3d,
+	0x82, 0x25, 0xf7, 0x64, 0x43, 0xee, 0x1b, 0xff, 0xdd, 0x3d, 0x1a, 0x93, 0xfb, 0xef, 0xa0, 0x8f,
+	0xae, 0xb2, 0x54, 0xb8, 0x2b, 0x21, 0xa5, 0xb7, 0x14, 0x4e, 0x93, 0xb6, 0xef, 0x19, 0xf8, 0x52,
+	0xa3, 0x18, 0x23, 0x7d, 0x80, 0x28, 0x8c, 0x3f, 0x3b, 0x2d, 0xfd, 0x82, 0x84, 0x5c, 0x84, 0xf1,
+	0x67, 0xf6, 0x06, 0xfa, 0x5b, 0xb5, 0xab, 0xc4, 0x17, 0xe5, 0xb4, 0xc9, 0x66, 0xa7, 0xb0, 0x99,
+	0x8b, 0x2f, 0x8a, 0xfd, 0x14, 0x7a, 0xda, 0x2e, 0x4b, 0x23, 0x6d, 0x06, 0x64, 0xd6, 0x25, 0xf4,
+	0x36, 0x8d, 0xc8, 0xea, 0x2d, 0x3c, 0x8f, 0x3c, 0x8a, 0xc8, 0xe3, 0xc0, 0x77, 0xc8, 0x76, 0x57,
+	0xeb, 0xde, 0x97, 0xc2, 0xff, 0x0b, 0xd8, 0x2b, 0x7f, 0x90, 0x07, 0xb3, 0x47, 0xf6, 0xf6, 0xd6,
+	0xde, 0x84, 0xf4, 0x1d, 0xc0, 0x3a, 0x4d, 0xd6, 0x22, 0x55, 0xa1, 0x90, 0x4e, 0x97, 0x58, 0x33,
+	0x18, 0x17, 0x84, 0x18, 0x5f, 0x17, 0xca, 0x59, 0xac, 0xd2, 0x0d, 0x2f, 0x59, 0xb3, 0x57, 0xd0,
+	0xb9, 0x4f, 0x54, 0x14, 0x92, 0x07, 0xe9, 0xec, 0x0c, 0xab, 0xf8, 0x5e, 0x06, 0x3a, 0x0b, 0x24,
+	0x86, 0x54, 0xac, 0xf0, 0x14, 0x5e, 0x10, 0xa4, 0x42, 0x4a, 0x21, 0x9d, 0x3e, 0x19, 0xf5, 0x08,
+	0x9e, 0xe6, 0x28, 0x86, 0x34, 0x94, 0x32, 0x13, 0x3a, 0xa4, 0xb6, 0x0e, 0x29, 0x21, 0x14, 0xd2,
+	0x9f, 0x40, 0x3b, 0x59, 0x8b, 0xd8, 0x5d, 0x64, 0x4b, 0xe9, 0xec, 0x12, 0x29, 0x5b, 0x08, 0x1c,
+	0x66, 0x4b, 0xc9, 0x7e, 0x00, 0xf0, 0xf0, 0xb8, 0xae, 0xda, 0xac, 0x85, 0xc3, 0x86, 0xd6, 0x41,
+	0x6f, 0xf2, 0xbc, 0x74, 0x03, 0x5a, 0xcd, 0x37, 0x6b, 0xc1, 0xdb, 0x5e, 0xbe, 0x64, 0x3f, 0x83,
+	0x5d, 0x99, 0xc9, 0xb5, 0xf0, 0x55, 0x11, 0x52, 0xe9, 0xec, 0xd1, 0xd9, 0xfa, 0x46, 0x61, 0x02,
+	0x2a, 0x07, 0xbf, 0x87, 0xfe, 0x93, 0x28, 0x30, 0x1b, 0xaa, 0x9f, 0xc5, 0xc6, 0x64, 0x2f, 0x2e,
+	0xd9, 0x73, 0xa8, 0x53, 0xce, 0x9b, 0x8c, 0xd0, 0xc2, 0xbb, 0xca, 0x6f, 0xad, 0xd1, 0x9f, 0x4c,
+	0x7e, 0x91, 0xdf, 0x7d, 0x60, 0xd3, 0x8b, 0x19, 0x9f, 0xbb, 0xf3, 0x4f, 0xd7, 0x33, 0xf7, 0xfd,
+	0xf4, 0xec, 0xe2, 0xec, 0xea, 0xc4, 0x7e, 0xc6, 0x06, 0xb0, 0x5f, 0xc2, 0x8f, 0xcf, 0x6e, 0xa6,
+	0xd7, 0xd7, 0xb3, 0x29, 0x9f, 0x1d, 0xdb, 0x16, 0xfb, 0x7f, 0xd8, 0x2b, 0xe9, 0x7e, 0x9c, 0x9d,
+	0x9d, 0x9c, 0xce, 0x67, 0xc7, 0x76, 0x65, 0xf4, 0x37, 0x0b, 0xba, 0xf8, 0x8c, 0x97, 0x42, 0x79,
+	0x98, 0xf4, 0x18, 0x27, 0x7a, 0xef, 0x52, 0x69, 0x69, 0x21, 0x90, 0x57, 0x96, 0x45, 0xb6, 0x74,
+	0xfd, 0x64, 0xb5, 0x4e, 0x62, 0x11, 0x2b, 0x3a, 0x69, 0x1d, 0xe9, 0xb6, 0x3c, 0xca, 0x31, 0xbc,
+	0x46, 0xf2, 0x10, 0x8b, 0x94, 0x12, 0xb7, 0xcd, 0xb5, 0xc0, 0x7a, 0x50, 0xf1, 0x7d, 0xa7, 0x46,
+	0xe1, 0xa9, 0xf8, 0x3e, 0x3e, 0x97, 0x48, 0xd3, 0x24, 0xd5, 0x21, 0xd7, 0x49, 0xd8, 0x26, 0x04,
+	0x2f, 0x39, 0xfa, 0x77, 0x1d, 0x1a, 0x47, 0x49, 0x94, 0xad, 0x62, 0xdc, 0x8f, 0xe2, 0x6b, 0x4e,
+	0xa3, 0x85, 0xa2, 0xb8, 0x56, 0x1e, 0x17, 0x57, 0xa9, 0xbc, 0x54, 0x89, 0x80, 0x7c, 0x5b, 0x3c,
+	0x17, 0x71, 0x0f, 0xf1, 0x45, 0xa5, 0x9e, 0x39, 0x80, 0x16, 0x9e, 0x92, 0x4f, 0x1f, 0xa2, 0x4c,
+	0x3e, 0x06, 0xb5, 0xfb, 0x30, 0x56, 0x54, 0x03, 0xda, 0x9c, 0xd6, 0x5f, 0x23, 0x64, 0xf3, 0xab,
+	0x84, 0x7c, 0x07, 0x1d, 0x2f, 0x8e, 0x13, 0xe5, 0xa9, 0x30, 0x89, 0xa5, 0xd3, 0xa2, 0xbc, 0x70,
+	0xc6, 0xfa, 0x56, 0xe3, 0xe9, 0x56, 0xa5, 0xb3, 0xa2, 0x6c, 0xcc, 0xbe, 0x85, 0x3a, 0x36, 0x23,
+	0x49, 0x69, 0xdf, 0x99, 0xec, 0xe4, 0x5f, 0xdd, 0x20, 0xc8, 0xb5, 0x8e, 0x0d, 0xa1, 0xb3, 0x8e,
+	0x3c, 0x5f, 0xdc, 0x27, 0x51, 0x20, 0x52, 0x4a, 0xfd, 0x16, 0x2f, 0x43, 0xec, 0x0d, 0x34, 0xee,
+	0x85, 0x17, 0xa9, 0x7b, 0xca, 0xf5, 0xde, 0xa4, 0x97, 0xef, 0x73, 0x4a, 0x28, 0x37, 0x5a, 0x7c,
+	0xf4, 0x65, 0x9a, 0x64, 0x6b, 0x17, 0x19, 0xd9, 0xd5, 0x8f, 0x4e, 0xc0, 0x07, 0xb1, 0x61, 0xdf,
+	0x83, 0x1d, 0x64, 0x29, 0x1d, 0xac, 0xe8, 0x28, 0x3b, 0x14, 0xde, 0x7e, 0x8e, 0x9b, 0xa6, 0x32,
+	0xf8, 0x03, 0xd8, 0x4f, 0xef, 0xf5, 0xbf, 0xf0, 0x7c, 0xf0, 0x57, 0x0b, 0xea, 0x74, 0x45, 0x6a,
+	0x71, 0x58, 0x82, 0x1f, 0x35, 0x11, 0x44, 0x74, 0x13, 0x79, 0xdc, 0x63, 0x2a, 0x4f, 0x7b, 0xcc,
+	0x2b, 0xe8, 0xdc, 0x45, 0xde, 0xe7, 0x8d, 0xd1, 0x57, 0x49, 0x0f, 0x04, 0x69, 0x83, 0x37, 0xd0,
+	0x8f, 0x13, 0x37, 0x15, 0x32, 0x8b, 0x94, 0x31, 0xaa, 0x91, 0xd1, 0x4e, 0x9c, 0x70, 0x42, 0xc9,
+	0x6e, 0xb4, 0x86, 0x86, 0x0e, 0x15, 0x63, 0xd0, 0x3b, 0x9d, 0x4d, 0x2f, 0xe6, 0xa7, 0xee, 0xed,
+	0xd5, 0x87, 0xab, 0x8f, 0x3f, 0x5e, 0xd9, 0xcf, 0x4a, 0xd8, 0xf5, 0xf4, 0xe6, 0x06, 0xb3, 0xd0,
+	0x62, 0x2f, 0xe0, 0xff, 0x0c, 0x76, 0xf9, 0xf1, 0x66, 0x7e, 0xf1, 0xa9, 0x50, 0x55, 0x98, 0x0d,
+	0x5d, 0xa3, 0x7a, 0x7f, 0x31, 0xfd, 0xf0, 0xc9, 0xae, 0xb2, 0x5d, 0xd8, 0x31, 0xc8, 0x21, 0xff,
+	0xf8, 0x61, 0x76, 0x65, 0xd7, 0x46, 0xff, 0xaa, 0x41, 0x95, 0x27, 0x0f, 0x5f, 0x1d, 0x1e, 0x7a,
+	0x50, 0x29, 0xfa, 0x65, 0x25, 0x0c, 0x90, 0xef, 0xfa, 0x0a, 0x7a, 0x66, 0xa8, 0xf3, 0x5c, 0x64,
+	0x2f, 0xa0, 0xe5, 0x8b, 0x28, 0x22, 0x5a, 0x6b, 0xca, 0x37, 0x51, 0x46, 0x4e, 0x0f, 0xa0, 0x65,
+	0x7a, 0x13, 0x32, 0x1e, 0x55, 0x85, 0x8c, 0x33, 0xc8, 0x8a, 0x66, 0x17, 0x43, 0x69, 0x23, 0xb1,
+	0xd7, 0xd0, 0xd4, 0xab, 0x9c, 0xc6, 0xcd, 0xb1, 0x9e, 0x71, 0x78, 0x8e, 0xe3, 0xa3, 0x86, 0x3e,
+	0xf2, 0xbc, 0xad, 0x33, 0x8c, 0x04, 0xdc, 0x90, 0x4a, 0xb0, 0x74, 0x40, 0x6f, 0xa8, 0x25, 0xf6,
+	0x7d, 0x5e, 0x70, 0xc3, 0xf8, 0x2e, 0x21, 0x72, 0x76, 0x26, 0xb0, 0x2d, 0xb8, 0xa6, 0xcc, 0xe2,
+	0x12, 0x6b, 0x4e, 0x26, 0x45, 0xea, 0x9a, 0xa6, 0xb1, 0xa1, 0x06, 0xd3, 0xe6, 0x5d, 0x04, 0x4d,
+	0x4d, 0xdd, 0xb0, 0x6f, 0xa0, 0x8d, 0xaf, 0x1b, 0xc6, 0x42, 0x6a, 0x72, 0x56, 0xf8, 0x16, 0xc0,
+	0x94, 0x35, 0x57, 0x74, 0xf3, 0xe1, 0xab, 0x47, 0xf1, 0xea, 0x19, 0xf8, 0x4c, 0xa3, 0xe8, 0xcb,
+	0x4b, 0x55, 0x78, 0xe7, 0xf9, 0x0a, 0x5b, 0x6a, 0xde, 0x6a, 0xba, 0x39, 0x78, 0x9b, 0x46, 0x92,
+	0x1d, 0x80, 0x1d, 0x78, 0x1b, 0xe9, 0xca, 0x30, 0xf6, 0x85, 0xbb, 0x4c, 0x85, 0x88, 0xa9, 0xdd,
+	0x58, 0xbc, 0x87, 0xf8, 0x0d, 0xc2, 0x27, 0x88, 0xb2, 0x3f, 0x02, 0xd3, 0xe1, 0x71, 0x53, 0xb1,
+	0xc4, 0xaa, 0x40, 0x85, 0x60, 0x97, 0x22, 0xb8, 0x9b, 0x47, 0xb0, 0xd0, 0xf0, 0xdd, 0xd5, 0x13,
+	0x44, 0x62, 0x8f, 0x89, 0x3c, 0xa9, 0x5c, 0x1d, 0x2c, 0x3f, 0x4a, 0xa4, 0x08, 0xa8, 0x3f, 0x59,
+	0xbc, 0x8f, 0x0a, 0x8a, 0xd8, 0x11, 0xc1, 0xdb, 0xba, 0xbb, 0x57, 0xae, 0xbb, 0xdf, 0x40, 0x7b,
+	0x5b, 0xae, 0x9f, 0x93, 0x66, 0x0b, 0x9c, 0xd7, 0x5a, 0x0d, 0xbb, 0x39, 0xfa, 0x8b, 0x05, 0xf6,
+	0xd3, 0xd3, 0x94, 0xb8, 0xa0, 0x29, 0x68, 0xa4, 0x6d, 0x39, 0xae, 0x94, 0xcb, 0x71, 0x91, 0xd3,
+	0xba, 0xf0, 0x6a, 0x01, 0xb9, 0xb6, 0xf0, 0xa4, 0x88, 0xc2, 0x58, 0x50, 0x7e, 0x59, 0xbc, 0x90,
+	0x91, 0xbc, 0xf9, 0x8c, 0xa4, 0x0b, 0x6f, 0x2e, 0x8e, 0xfe, 0x59, 0x85, 0xda, 0x49, 0x1a, 0x06,
+	0x48, 0x3b, 0x9f, 0xea, 0x95, 0x34, 0xb3, 0x68, 0xd3, 0xd4, 0x2f, 0x9e, 0xe3, 0xcc, 0x81, 0x5a,
+	0x9a, 0x3c, 0xe8, 0x61, 0xba, 0x33, 0xa9, 0x8d, 0x79, 0xf2, 0xc0, 0x09, 0x61, 0x23, 0x68, 0xe8,
+	0xb9, 0xdc, 0xa9, 0x19, 0x7a, 0x61, 0x9f, 0x3b, 0xc1, 0xaa, 0xc6, 0x8d, 0xa6, 0x08, 0x2f, 0x0e,
+	0x7a, 0xae, 0x9e, 0x6a, 0x03, 0xa7, 0xb1, 0x0d, 0x2f, 0x0e, 0x75, 0x7a, 0xfa, 0x0d, 0xd8, 0xcf,
+	0xa1, 0xa3, 0x2d, 0x34, 0x67, 0x75, 0x1e, 0x74, 0xc6, 0xdb, 0x21, 0x9a, 0x43, 0x56, 0xac, 0xd9,
+	0x04, 0x76, 0xa8, 0x8d, 0xae, 0x4c, 0x5f, 0xa5, 0xb4, 0xc0, 0x42, 0x5e, 0x6e, 0xb6, 0xbc, 0xab,
+	0x4a, 0x12, 0x1b, 0x41, 0xd3, 0x8f, 0x32, 0xa9, 0xa8, 0x96, 0xa3, 0x75, 0x6b, 0x7c, 0xa4, 0x65,
+	0x9e, 0x2b, 0xd8, 0x14, 0x5e, 0xae, 0x12, 0xa9, 0xdc, 0x54, 0xf8, 0x22, 0x56, 0xae, 0x81, 0xdd,
+	0xe2, 0xcf, 0x09, 0xe5, 0x92, 0xc5, 0x07, 0x68, 0xc4, 0xc9, 0xc6, 0x6c, 0x51, 0x8c, 0xab, 0x48,
+	0xf2, 0x3c, 0x1b, 0x94, 0xb7, 0x88, 0x44, 0x9e, 0x50, 0x06, 0x9c, 0x23, 0xc6, 0xc6, 0xd0, 0xd5,
+	0x9c, 0xa3, 0x36, 0xa0, 0x07, 0x33, 0xbc, 0x2e, 0x11, 0x4e, 0x07, 0xb1, 0xe3, 0x15, 0x6b, 0x79,
+	0x5e, 0x6b, 0x55, 0xed, 0xda, 0x79, 0xad, 0x55, 0xb7, 0x1b, 0xe7, 0xb5, 0x56, 0xd3, 0x6e, 0x8d,
+	0x0e, 0x01, 0xb6, 0xc6, 0x6c, 0x08, 0x75, 0x32, 0x77, 0x2c, 0xf3, 0x18, 0xdb, 0x5c, 0xd7, 0x0a,
+	0x2c, 0x78, 0xc5, 0x4b, 0xb6, 0xf5, 0x1b, 0x8e, 0xfe, 0x5e, 0x81, 0x36, 0x32, 0xe1, 0x58, 0x44,
+	0x8a, 0xda, 0xf5, 0x82, 0xfe, 0xd6, 0xdc, 0x7b, 0x93, 0x5f, 0xfd, 0xda, 0xd0, 0x12, 0x10, 0xba,
+	0x21, 0x84, 0xfd, 0x72, 0xcb, 0x17, 0xcd, 0x87, 0xfd, 0x71, 0xf1, 0xb5, 0x61, 0xce, 0xb5, 0xa7,
+	0xfc, 0xfb, 0x2d, 0x7d, 0xbe, 0x33, 0x4e, 0xab, 0x64, 0xbe, 0x57, 0x32, 0xe7, 0xc9, 0x83, 0xb6,
+	0xd5, 0x6c, 0x7a, 0x01, 0xb5, 0x65, 0x6a, 0xfe, 0x50, 0x74, 0x26, 0x75, 0x32, 0xe4, 0x04, 0x0d,
+	0x2e, 0xa1, 0x53, 0xda, 0x9b, 0x31, 0xa8, 0x26, 0x66, 0x58, 0xa9, 0x9f, 0x3e, 0xe3, 0x28, 0xb0,
+	0xd7, 0xc8, 0x45, 0x34, 0xa1, 0xa4, 0xd9, 0xf2, 0xf8, 0xf4, 0x19, 0x37, 0x8a, 0xc3, 0x26, 0xd4,
+	0xd7, 0xf8, 0xfd, 0x60, 0x0a, 0xad, 0xdc, 0xf7, 0x57, 0xf7, 0x72, 0xa0, 0x9a, 0x26, 0x0f, 0x66,
+	0x23, 0x22, 0x3c, 0x6a, 0xd2, 0xe4, 0xa1, 0xd8, 0x62, 0x94, 0x42, 0xd3, 0xbc, 0x3a, 0xc6, 0x8c,
+	0x78, 0x28, 0x95, 0xa7, 0x32, 0x69, 0x1a, 0x29, 0x20, 0x74, 0x43, 0x48, 0x39, 0x0d, 0x2b, 0x8f,
+	0xd2, 0x10, 0x09, 0x9f, 0xd3, 0x0b, 0x1d, 0x56, 0x0d, 0x03, 0x72, 0x4a, 0x26, 0x0f, 0x1c, 0xfc,
+	0x62, 0x3d, 0x9a, 0x01, 0x6c, 0x35, 0xec, 0x35, 0x74, 0x83, 0x50, 0xae, 0x23, 0x6f, 0x53, 0x1e,
+	0x24, 0x3b, 0x06, 0xa3, 0x59, 0x12, 0x1b, 0x46, 0x1c, 0x88, 0x2f, 0xe6, 0x7f, 0xb0, 0x16, 0x16,
+	0x0d, 0xfa, 0x7f, 0xf5, 0xc3, 0x7f, 0x06, 0x00, 0xbe, 0xaa, 0x8d, 0x16, 0x8c, 0x0f, 0x00, 0x00,
 }
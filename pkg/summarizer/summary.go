@@ -18,7 +18,6 @@ limitations under the License.
 package summarizer
 
 import (
-	"compress/zlib"
 	"context"
 	"errors"
 	"fmt"
@@ -400,19 +399,15 @@ func readGrid(ctx context.Context, reader gridReader) (*statepb.Grid, time.Time,
 		return nil, t, 0, fmt.Errorf("open: %w", err)
 	}
 	defer r.Close()
-	zlibReader, err := zlib.NewReader(r)
-	if err != nil {
-		return nil, t, 0, fmt.Errorf("decompress: %v", err)
-	}
-	buf, err := ioutil.ReadAll(zlibReader)
+	buf, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, t, 0, fmt.Errorf("read: %v", err)
 	}
-	var g statepb.Grid
-	if err = proto.Unmarshal(buf, &g); err != nil {
+	g, err := gcs.UnmarshalGrid(buf)
+	if err != nil {
 		return nil, t, 0, fmt.Errorf("parse: %v", err)
 	}
-	return &g, mod, gen, nil
+	return g, mod, gen, nil
 }
 
 // recentColumns returns the configured number of recent columns to summarize, or 5.
@@ -384,11 +384,26 @@ func TestReadGrid(t *testing.T) {
 			expectErr: true,
 		},
 		{
-			name: "return error when state is not compressed",
+			name: "parse uncompressed grid",
 			reader: bytes.NewBuffer(gridBuf(&statepb.Grid{
 				LastTimeUpdated: 444,
 			})),
-			expectErr: true,
+			expectedGrid: &statepb.Grid{
+				LastTimeUpdated: 444,
+			},
+		},
+		{
+			name: "parse gzip compressed grid",
+			reader: bytes.NewBuffer(func() []byte {
+				buf, err := gcs.MarshalGrid(&statepb.Grid{LastTimeUpdated: 666}, gcs.Gzip)
+				if err != nil {
+					panic(err)
+				}
+				return buf
+			}()),
+			expectedGrid: &statepb.Grid{
+				LastTimeUpdated: 666,
+			},
 		},
 		{
 			name:      "return error when compressed object is not a grid proto",
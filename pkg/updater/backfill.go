@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// BackfillRange writes the grid for the group as it would have looked at each step from start through end.
+//
+// Columns are read once and then filtered for each asof time. Builds which
+// finished after a step appear as still running in the grid for that step.
+// Each grid is written next to gridPath with the asof time appended,
+// for example gs://bucket/grid/foo-2020-06-01. Returns the paths of each grid.
+func BackfillRange(ctx context.Context, log logrus.FieldLogger, client gcs.Uploader, tg *configpb.TestGroup, gridPath gcs.Path, write bool, readCols ColumnReader, sortCols ColumnSorter, start, end time.Time, step time.Duration) ([]gcs.Path, error) {
+	if step <= 0 {
+		return nil, errors.New("step must be positive")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end %s before start %s", end, start)
+	}
+
+	var dur time.Duration
+	if tg.DaysOfResults > 0 {
+		dur = days(float64(tg.DaysOfResults))
+	} else {
+		dur = days(7)
+	}
+
+	all, err := readCols(ctx, log, tg, nil, start.Add(-dur))
+	if err != nil {
+		return nil, fmt.Errorf("read columns: %w", err)
+	}
+	overrideBuild(tg, all)
+	all = groupColumns(tg, all)
+
+	var paths []gcs.Path
+	for asof := start; !asof.After(end); asof = asof.Add(step) {
+		if err := ctx.Err(); err != nil {
+			return paths, err
+		}
+		p, err := backfillPath(gridPath, asof, step)
+		if err != nil {
+			return paths, fmt.Errorf("%s: %w", asof, err)
+		}
+		cols := columnsAsOf(all, asof.Add(-dur), asof)
+		sortCols(tg, cols)
+		grid := ConstructGrid(log, tg, cols, nil, nil)
+		finishAlerts(tg, nil, grid)
+		buf, err := gcs.MarshalGrid(grid, gridCodec(tg))
+		if err != nil {
+			return paths, fmt.Errorf("%s: marshal grid: %w", asof, err)
+		}
+		log := log.WithField("url", p).WithField("bytes", len(buf))
+		if !write {
+			log.Debug("Skipping backfill write")
+		} else if _, err := client.Upload(ctx, *p, buf, gridACL(tg), "no-cache"); err != nil {
+			return paths, fmt.Errorf("%s: upload: %w", asof, err)
+		}
+		log.WithFields(logrus.Fields{
+			"asof": asof,
+			"cols": len(grid.Columns),
+			"rows": len(grid.Rows),
+		}).Info("Backfilled grid")
+		paths = append(paths, *p)
+	}
+	return paths, nil
+}
+
+// backfillPath returns the dated path for the grid as of the specified time.
+//
+// Includes the time of day when steps are shorter than a day.
+func backfillPath(gridPath gcs.Path, asof time.Time, step time.Duration) (*gcs.Path, error) {
+	layout := "2006-01-02"
+	if step%days(1) != 0 {
+		layout = "2006-01-02T15-04-05"
+	}
+	return gcs.NewPath(gridPath.String() + "-" + asof.UTC().Format(layout))
+}
+
+// columnsAsOf returns a copy of the columns which started after since but not after asof.
+//
+// Columns which finished after asof were still running, so only their overall row remains.
+func columnsAsOf(cols []InflatedColumn, since, asof time.Time) []InflatedColumn {
+	low := float64(since.Unix() * 1000)
+	high := float64(asof.Unix() * 1000)
+	var out []InflatedColumn
+	for _, col := range cols {
+		if col.Column.Started <= low || col.Column.Started > high {
+			continue
+		}
+		if finished, ok := columnFinished(col); ok && finished > high {
+			col = runningColumn(col)
+		}
+		out = append(out, col)
+	}
+	return out
+}
+
+// columnFinished returns when the column finished in milliseconds since the epoch, if known.
+func columnFinished(col InflatedColumn) (float64, bool) {
+	if d := col.Column.DurationSeconds; d > 0 {
+		return col.Column.Started + d*1000, true
+	}
+	if elapsed, ok := col.Cells[overallRow].Metrics[ElapsedKey]; ok {
+		return col.Column.Started + elapsed*60*1000, true
+	}
+	return 0, false
+}
+
+// runningColumn returns a copy of the column as it looked before it finished.
+func runningColumn(col InflatedColumn) InflatedColumn {
+	column := proto.Clone(col.Column).(*statepb.Column)
+	column.DurationSeconds = 0
+	return InflatedColumn{
+		Column: column,
+		Cells: map[string]Cell{
+			overallRow: {
+				Result:  statuspb.TestStatus_RUNNING,
+				Message: "Build still running...",
+				Icon:    "R",
+			},
+		},
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestBackfillRange(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) float64 {
+		return float64(t.Unix() * 1000)
+	}
+	gridPath := newPathOrDie("gs://bucket/grid/foo")
+	pass := cell{Result: statuspb.TestStatus_PASS, ID: "hello"}
+	col := func(build string, when time.Time) InflatedColumn {
+		return InflatedColumn{
+			Column: &statepb.Column{Build: build, Hint: build, Started: millis(when)},
+			Cells:  map[string]cell{"hello": pass},
+		}
+	}
+	cols := []InflatedColumn{
+		col("3", start.Add(day+12*time.Hour)),
+		col("2", start.Add(12*time.Hour)),
+		col("1", start.Add(-12*time.Hour)),
+		col("0", start.Add(-3*day)), // too old
+	}
+	grid := func(cols ...InflatedColumn) *statepb.Grid {
+		var grid statepb.Grid
+		row := &statepb.Row{Name: "hello", Id: "hello"}
+		var cells []cell
+		for _, c := range cols {
+			grid.Columns = append(grid.Columns, c.Column)
+			cells = append(cells, pass)
+		}
+		grid.Rows = append(grid.Rows, setupRow(row, cells...))
+		return &grid
+	}
+
+	cases := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		step      time.Duration
+		skipWrite bool
+		expected  fakeUploader
+		paths     []gcs.Path
+		err       bool
+	}{
+		{
+			name:  "reject non-positive step",
+			start: start,
+			end:   start.Add(day),
+			err:   true,
+		},
+		{
+			name:  "reject end before start",
+			start: start,
+			end:   start.Add(-day),
+			step:  day,
+			err:   true,
+		},
+		{
+			name:  "one grid per day",
+			start: start,
+			end:   start.Add(2 * day),
+			step:  day,
+			expected: fakeUploader{
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01"): {
+					Buf:          mustGrid(grid(cols[2])),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+				},
+				newPathOrDie("gs://bucket/grid/foo-2020-06-02"): {
+					Buf:          mustGrid(grid(cols[1], cols[2])),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+				},
+				newPathOrDie("gs://bucket/grid/foo-2020-06-03"): {
+					Buf:          mustGrid(grid(cols[0], cols[1])), // cols[2] older than 2 days
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01"),
+				newPathOrDie("gs://bucket/grid/foo-2020-06-02"),
+				newPathOrDie("gs://bucket/grid/foo-2020-06-03"),
+			},
+		},
+		{
+			name:  "include time for sub-day steps",
+			start: start,
+			end:   start.Add(12 * time.Hour),
+			step:  12 * time.Hour,
+			expected: fakeUploader{
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01T00-00-00"): {
+					Buf:          mustGrid(grid(cols[2])),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+				},
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01T12-00-00"): {
+					Buf:          mustGrid(grid(cols[1], cols[2])),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01T00-00-00"),
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01T12-00-00"),
+			},
+		},
+		{
+			name:      "do not write when requested",
+			start:     start,
+			end:       start.Add(day),
+			step:      day,
+			skipWrite: true,
+			expected:  fakeUploader{},
+			paths: []gcs.Path{
+				newPathOrDie("gs://bucket/grid/foo-2020-06-01"),
+				newPathOrDie("gs://bucket/grid/foo-2020-06-02"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeUploader{}
+			readCols := func(_ context.Context, _ logrus.FieldLogger, _ *configpb.TestGroup, _ []InflatedColumn, stop time.Time) ([]InflatedColumn, error) {
+				var out []InflatedColumn
+				for _, c := range cols {
+					if c.Column.Started < millis(stop) {
+						continue
+					}
+					out = append(out, c)
+				}
+				return out, nil
+			}
+			group := configpb.TestGroup{DaysOfResults: 2}
+
+			paths, err := BackfillRange(context.Background(), logrus.WithField("name", tc.name), client, &group, gridPath, !tc.skipWrite, readCols, SortStarted, tc.start, tc.end, tc.step)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("BackfillRange() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("BackfillRange() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.paths, paths, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+					t.Errorf("BackfillRange() got unexpected path diff (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.expected, client, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+					t.Errorf("BackfillRange() got unexpected upload diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnsAsOf(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) float64 {
+		return float64(t.Unix() * 1000)
+	}
+	overall := cell{
+		Result:  statuspb.TestStatus_PASS,
+		Metrics: setElapsed(nil, (2 * time.Hour).Seconds()),
+	}
+	finished := InflatedColumn{
+		Column: &statepb.Column{Build: "1", Hint: "1", Started: millis(start.Add(time.Hour))},
+		Cells: map[string]cell{
+			overallRow: overall,
+			"hello":    {Result: statuspb.TestStatus_PASS},
+		},
+	}
+	running := InflatedColumn{
+		Column: &statepb.Column{Build: "1", Hint: "1", Started: millis(start.Add(time.Hour))},
+		Cells: map[string]cell{
+			overallRow: {
+				Result:  statuspb.TestStatus_RUNNING,
+				Message: "Build still running...",
+				Icon:    "R",
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		asof     time.Time
+		expected []InflatedColumn
+	}{
+		{
+			name: "before the build starts",
+			asof: start,
+		},
+		{
+			name:     "build is running between steps",
+			asof:     start.Add(2 * time.Hour),
+			expected: []InflatedColumn{running},
+		},
+		{
+			name:     "build finished by the next step",
+			asof:     start.Add(4 * time.Hour),
+			expected: []InflatedColumn{finished},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := columnsAsOf([]InflatedColumn{finished}, start.Add(-24*time.Hour), tc.asof)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("columnsAsOf() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// deltaSuffix is appended to the grid path of each group to locate its delta.
+const deltaSuffix = ".delta"
+
+// GridDelta returns the changes which patch the old grid into the new one, see ApplyDelta.
+//
+// Columns and rows identical to one in the old grid reference it by index,
+// everything else is copied. A nil grid has no rows or columns.
+func GridDelta(old, grid *statepb.Grid) (*statepb.GridDelta, error) {
+	if old == nil {
+		old = &statepb.Grid{}
+	}
+	if grid == nil {
+		grid = &statepb.Grid{}
+	}
+	base, err := gcs.HashGrid(old)
+	if err != nil {
+		return nil, fmt.Errorf("hash old grid: %w", err)
+	}
+	delta := statepb.GridDelta{
+		BaseSha256: base,
+		Columns:    make([]*statepb.GridDelta_ColumnPatch, 0, len(grid.Columns)),
+		Rows:       make([]*statepb.GridDelta_RowPatch, 0, len(grid.Rows)),
+	}
+
+	oldCols := map[string][]int{}
+	for i, col := range old.Columns {
+		oldCols[col.Build] = append(oldCols[col.Build], i)
+	}
+	for _, col := range grid.Columns {
+		patch := statepb.GridDelta_ColumnPatch{
+			Patch: &statepb.GridDelta_ColumnPatch_Column{Column: col},
+		}
+		for _, i := range oldCols[col.Build] {
+			if proto.Equal(old.Columns[i], col) {
+				patch.Patch = &statepb.GridDelta_ColumnPatch_Old{Old: int32(i)}
+				break
+			}
+		}
+		delta.Columns = append(delta.Columns, &patch)
+	}
+
+	oldRows := make(map[string]int, len(old.Rows))
+	for i, row := range old.Rows {
+		oldRows[row.Name] = i
+	}
+	for _, row := range grid.Rows {
+		patch := statepb.GridDelta_RowPatch{
+			Patch: &statepb.GridDelta_RowPatch_Row{Row: row},
+		}
+		if i, ok := oldRows[row.Name]; ok && proto.Equal(old.Rows[i], row) {
+			patch.Patch = &statepb.GridDelta_RowPatch_Old{Old: int32(i)}
+		}
+		delta.Rows = append(delta.Rows, &patch)
+	}
+
+	rest := proto.Clone(grid).(*statepb.Grid)
+	rest.Columns = nil
+	rest.Rows = nil
+	delta.Grid = rest
+	return &delta, nil
+}
+
+// ApplyDelta returns the new grid described by patching the old grid with the delta.
+//
+// The old grid must be the one the delta was created from, as read by gcs.DownloadGrid.
+func ApplyDelta(old *statepb.Grid, delta *statepb.GridDelta) (*statepb.Grid, error) {
+	if old == nil {
+		old = &statepb.Grid{}
+	}
+	base, err := gcs.HashGrid(old)
+	if err != nil {
+		return nil, fmt.Errorf("hash old grid: %w", err)
+	}
+	if base != delta.BaseSha256 {
+		return nil, fmt.Errorf("delta patches grid %s, not %s", delta.BaseSha256, base)
+	}
+
+	grid := &statepb.Grid{}
+	if delta.Grid != nil {
+		grid = proto.Clone(delta.Grid).(*statepb.Grid)
+	}
+	for i, patch := range delta.Columns {
+		switch p := patch.Patch.(type) {
+		case *statepb.GridDelta_ColumnPatch_Old:
+			if p.Old < 0 || int(p.Old) >= len(old.Columns) {
+				return nil, fmt.Errorf("column %d: old index %d out of range", i, p.Old)
+			}
+			grid.Columns = append(grid.Columns, proto.Clone(old.Columns[p.Old]).(*statepb.Column))
+		case *statepb.GridDelta_ColumnPatch_Column:
+			grid.Columns = append(grid.Columns, proto.Clone(p.Column).(*statepb.Column))
+		default:
+			return nil, fmt.Errorf("column %d: empty patch", i)
+		}
+	}
+	for i, patch := range delta.Rows {
+		switch p := patch.Patch.(type) {
+		case *statepb.GridDelta_RowPatch_Old:
+			if p.Old < 0 || int(p.Old) >= len(old.Rows) {
+				return nil, fmt.Errorf("row %d: old index %d out of range", i, p.Old)
+			}
+			grid.Rows = append(grid.Rows, proto.Clone(old.Rows[p.Old]).(*statepb.Row))
+		case *statepb.GridDelta_RowPatch_Row:
+			grid.Rows = append(grid.Rows, proto.Clone(p.Row).(*statepb.Row))
+		default:
+			return nil, fmt.Errorf("row %d: empty patch", i)
+		}
+	}
+	return grid, nil
+}
+
+// deltaPath returns the path of the delta beside this grid, such as gs://bucket/grid/foo.delta.
+func deltaPath(gridPath gcs.Path) (*gcs.Path, error) {
+	u := url.URL{Path: path.Base(gridPath.Object()) + deltaSuffix}
+	p, err := gridPath.ResolveReference(&u)
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	return p, nil
+}
+
+// MarshalDelta serializes the delta into zlib-compressed bytes.
+func MarshalDelta(delta *statepb.GridDelta, level int) ([]byte, error) {
+	if err := gcs.ValidateCompressionLevel(level); err != nil {
+		return nil, err
+	}
+	buf, err := proto.Marshal(delta)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	var out bytes.Buffer
+	zw, err := zlib.NewWriterLevel(&out, level)
+	if err != nil {
+		return nil, fmt.Errorf("create writer: %w", err)
+	}
+	if _, err := zw.Write(buf); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// UnmarshalDelta decompresses and deserializes a delta written by MarshalDelta.
+func UnmarshalDelta(buf []byte) (*statepb.GridDelta, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer zr.Close()
+	var out bytes.Buffer
+	if _, err := out.ReadFrom(zr); err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	var delta statepb.GridDelta
+	if err := proto.Unmarshal(out.Bytes(), &delta); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &delta, nil
+}
+
+// writeDelta uploads the changes between the old and new grid beside the grid.
+func writeDelta(ctx context.Context, client gcs.Uploader, gridPath gcs.Path, old, grid *statepb.Grid, level int, worldRead bool) error {
+	delta, err := GridDelta(old, grid)
+	if err != nil {
+		return err
+	}
+	buf, err := MarshalDelta(delta, level)
+	if err != nil {
+		return err
+	}
+	p, err := deltaPath(gridPath)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Upload(ctx, *p, buf, worldRead, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestGridDelta(t *testing.T) {
+	const (
+		pass = statuspb.TestStatus_PASS
+		fail = statuspb.TestStatus_FAIL
+	)
+	row := func(name string, results ...statuspb.TestStatus) *statepb.Row {
+		var cells []cell
+		for _, r := range results {
+			cells = append(cells, cell{Result: r, Message: name})
+		}
+		return setupRow(&statepb.Row{Name: name, Id: name}, cells...)
+	}
+	columns := func(builds ...string) []*statepb.Column {
+		var out []*statepb.Column
+		for _, b := range builds {
+			out = append(out, &statepb.Column{Build: b, Started: float64(len(b))})
+		}
+		return out
+	}
+
+	cases := []struct {
+		name       string
+		old        *statepb.Grid
+		grid       *statepb.Grid
+		reusedCols int
+		reusedRows int
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "everything is new",
+			grid: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows: []*statepb.Row{
+					row("hello", pass, fail),
+					row("world", pass, pass),
+				},
+			},
+		},
+		{
+			name: "unchanged",
+			old: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail)},
+			},
+			reusedCols: 2,
+			reusedRows: 1,
+		},
+		{
+			name: "added and removed columns",
+			old: &statepb.Grid{
+				Columns: columns("3", "2", "1"),
+				Rows: []*statepb.Row{
+					row("hello", pass, fail, pass),
+					row("gone", fail, fail, fail),
+				},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("5", "4", "3", "2"),
+				Rows: []*statepb.Row{
+					row("hello", fail, pass, pass, fail),
+					row("new", pass, pass, pass, pass),
+				},
+			},
+			reusedCols: 2,
+		},
+		{
+			name: "changed cells",
+			old: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows: []*statepb.Row{
+					row("world", pass, pass),
+					row("hello", pass, fail),
+				},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows: []*statepb.Row{
+					row("hello", pass, pass),
+					row("world", pass, pass),
+				},
+			},
+			reusedCols: 2,
+			reusedRows: 1,
+		},
+		{
+			name: "changed columns",
+			old: &statepb.Grid{
+				Columns: columns("2", "1"),
+			},
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2", Started: 1, Hint: "different"},
+					{Build: "1", Started: 1},
+				},
+			},
+			reusedCols: 1,
+		},
+		{
+			name: "carry other fields",
+			old: &statepb.Grid{
+				Columns:         columns("1"),
+				LastTimeUpdated: 1,
+			},
+			grid: &statepb.Grid{
+				Columns:         columns("1"),
+				Config:          &configpb.TestGroup{Name: "hello"},
+				LastTimeUpdated: 2,
+				MessageTable:    []string{"hi"},
+			},
+			reusedCols: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			delta, err := GridDelta(tc.old, tc.grid)
+			if err != nil {
+				t.Fatalf("GridDelta() got unexpected error: %v", err)
+			}
+			var cols, rows int
+			for _, p := range delta.Columns {
+				if _, ok := p.Patch.(*statepb.GridDelta_ColumnPatch_Old); ok {
+					cols++
+				}
+			}
+			for _, p := range delta.Rows {
+				if _, ok := p.Patch.(*statepb.GridDelta_RowPatch_Old); ok {
+					rows++
+				}
+			}
+			if cols != tc.reusedCols {
+				t.Errorf("GridDelta() reused %d columns, want %d", cols, tc.reusedCols)
+			}
+			if rows != tc.reusedRows {
+				t.Errorf("GridDelta() reused %d rows, want %d", rows, tc.reusedRows)
+			}
+
+			buf, err := MarshalDelta(delta, gcs.DefaultCompression)
+			if err != nil {
+				t.Fatalf("MarshalDelta() got unexpected error: %v", err)
+			}
+			if delta, err = UnmarshalDelta(buf); err != nil {
+				t.Fatalf("UnmarshalDelta() got unexpected error: %v", err)
+			}
+
+			actual, err := ApplyDelta(tc.old, delta)
+			if err != nil {
+				t.Fatalf("ApplyDelta() got unexpected error: %v", err)
+			}
+			expected := tc.grid
+			if expected == nil {
+				expected = &statepb.Grid{}
+			}
+			if diff := cmp.Diff(expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("ApplyDelta() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyDelta(t *testing.T) {
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows:    []*statepb.Row{{Name: "hello"}},
+	}
+	base, err := gcs.HashGrid(old)
+	if err != nil {
+		t.Fatalf("HashGrid() got unexpected error: %v", err)
+	}
+	cases := []struct {
+		name  string
+		delta *statepb.GridDelta
+		err   bool
+	}{
+		{
+			name: "basically works",
+			delta: &statepb.GridDelta{
+				BaseSha256: base,
+				Columns: []*statepb.GridDelta_ColumnPatch{
+					{Patch: &statepb.GridDelta_ColumnPatch_Column{Column: &statepb.Column{Build: "2"}}},
+					{Patch: &statepb.GridDelta_ColumnPatch_Old{Old: 0}},
+				},
+				Rows: []*statepb.GridDelta_RowPatch{
+					{Patch: &statepb.GridDelta_RowPatch_Old{Old: 0}},
+				},
+			},
+		},
+		{
+			name: "reject a different base",
+			delta: &statepb.GridDelta{
+				BaseSha256: "something else",
+			},
+			err: true,
+		},
+		{
+			name: "reject missing columns",
+			delta: &statepb.GridDelta{
+				BaseSha256: base,
+				Columns: []*statepb.GridDelta_ColumnPatch{
+					{Patch: &statepb.GridDelta_ColumnPatch_Old{Old: 1}},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "reject missing rows",
+			delta: &statepb.GridDelta{
+				BaseSha256: base,
+				Rows: []*statepb.GridDelta_RowPatch{
+					{Patch: &statepb.GridDelta_RowPatch_Old{Old: -1}},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "reject empty patches",
+			delta: &statepb.GridDelta{
+				BaseSha256: base,
+				Rows:       []*statepb.GridDelta_RowPatch{{}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ApplyDelta(old, tc.delta)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("ApplyDelta() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("ApplyDelta() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestDeltaPath(t *testing.T) {
+	actual, err := deltaPath(newPathOrDie("gs://bucket/grid/hello"))
+	if err != nil {
+		t.Fatalf("deltaPath() got unexpected error: %v", err)
+	}
+	expected := newPathOrDie("gs://bucket/grid/hello.delta")
+	if diff := cmp.Diff(expected, *actual, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+		t.Errorf("deltaPath() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteDelta(t *testing.T) {
+	gridPath := newPathOrDie("gs://bucket/grid/hello")
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows:    []*statepb.Row{{Name: "hello", Results: []int32{1, 1}}},
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows:    []*statepb.Row{{Name: "hello", Results: []int32{1, 2}}},
+	}
+	client := fakeUploader{}
+	if err := writeDelta(context.Background(), client, gridPath, old, grid, gcs.DefaultCompression, gcs.DefaultACL); err != nil {
+		t.Fatalf("writeDelta() got unexpected error: %v", err)
+	}
+	up, ok := client[newPathOrDie("gs://bucket/grid/hello.delta")]
+	if !ok {
+		t.Fatal("writeDelta() failed to upload a delta")
+	}
+	delta, err := UnmarshalDelta(up.Buf)
+	if err != nil {
+		t.Fatalf("UnmarshalDelta() got unexpected error: %v", err)
+	}
+	actual, err := ApplyDelta(old, delta)
+	if err != nil {
+		t.Fatalf("ApplyDelta() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(grid, actual, protocmp.Transform()); diff != "" {
+		t.Errorf("ApplyDelta() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
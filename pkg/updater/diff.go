@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// GridChanges describes how a grid changed.
+//
+// Rows are identified by name and columns by build id.
+type GridChanges struct {
+	// AddedRows lists the rows only in the new grid, sorted by name.
+	AddedRows []string `json:"added_rows,omitempty"`
+	// RemovedRows lists the rows only in the old grid, sorted by name.
+	RemovedRows []string `json:"removed_rows,omitempty"`
+	// AddedColumns lists the columns only in the new grid, in column order.
+	AddedColumns []string `json:"added_columns,omitempty"`
+	// RemovedColumns lists the columns only in the old grid, in column order.
+	RemovedColumns []string `json:"removed_columns,omitempty"`
+	// Cells lists the results which changed in rows and columns of both grids,
+	// sorted by row and then in the column order of the new grid.
+	Cells []CellChange `json:"cells,omitempty"`
+}
+
+// CellChange describes how the result of a row changed in a column.
+type CellChange struct {
+	Row    string              `json:"row"`
+	Column string              `json:"column"`
+	Old    statuspb.TestStatus `json:"old"`
+	New    statuspb.TestStatus `json:"new"`
+}
+
+// Empty returns true when neither the rows, the columns nor their results changed.
+func (c GridChanges) Empty() bool {
+	return len(c.AddedRows)+len(c.RemovedRows)+len(c.AddedColumns)+len(c.RemovedColumns)+len(c.Cells) == 0
+}
+
+// GridDiff reports the rows, columns and cell results which changed between the old and new grid.
+//
+// A nil grid has no rows or columns. Only the first column of each build id counts.
+func GridDiff(old, grid *statepb.Grid) GridChanges {
+	if old == nil {
+		old = &statepb.Grid{}
+	}
+	if grid == nil {
+		grid = &statepb.Grid{}
+	}
+	var changes GridChanges
+
+	oldCols := columnIndices(old.Columns)
+	newCols := columnIndices(grid.Columns)
+	for i, col := range grid.Columns {
+		if idx, ok := newCols[col.Build]; ok && idx == i {
+			if _, ok := oldCols[col.Build]; !ok {
+				changes.AddedColumns = append(changes.AddedColumns, col.Build)
+			}
+		}
+	}
+	for i, col := range old.Columns {
+		if idx, ok := oldCols[col.Build]; ok && idx == i {
+			if _, ok := newCols[col.Build]; !ok {
+				changes.RemovedColumns = append(changes.RemovedColumns, col.Build)
+			}
+		}
+	}
+
+	oldRows := make(map[string]*statepb.Row, len(old.Rows))
+	for _, row := range old.Rows {
+		oldRows[row.Name] = row
+	}
+	newRows := make(map[string]bool, len(grid.Rows))
+	for _, row := range grid.Rows {
+		newRows[row.Name] = true
+		was, ok := oldRows[row.Name]
+		if !ok {
+			changes.AddedRows = append(changes.AddedRows, row.Name)
+			continue
+		}
+		before := rowResults(was, len(old.Columns))
+		after := rowResults(row, len(grid.Columns))
+		for i, col := range grid.Columns {
+			if newCols[col.Build] != i {
+				continue
+			}
+			j, ok := oldCols[col.Build]
+			if !ok || before[j] == after[i] {
+				continue
+			}
+			changes.Cells = append(changes.Cells, CellChange{
+				Row:    row.Name,
+				Column: col.Build,
+				Old:    before[j],
+				New:    after[i],
+			})
+		}
+	}
+	for _, row := range old.Rows {
+		if !newRows[row.Name] {
+			changes.RemovedRows = append(changes.RemovedRows, row.Name)
+		}
+	}
+
+	sort.Strings(changes.AddedRows)
+	sort.Strings(changes.RemovedRows)
+	sort.SliceStable(changes.Cells, func(i, j int) bool {
+		return changes.Cells[i].Row < changes.Cells[j].Row
+	})
+	return changes
+}
+
+// columnIndices returns the index of the first column of each build id.
+func columnIndices(cols []*statepb.Column) map[string]int {
+	out := make(map[string]int, len(cols))
+	for i, col := range cols {
+		if _, ok := out[col.Build]; !ok {
+			out[col.Build] = i
+		}
+	}
+	return out
+}
+
+// rowResults decodes the run-length encoded results of the row into n columns.
+func rowResults(row *statepb.Row, n int) []statuspb.TestStatus {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make([]statuspb.TestStatus, n)
+	ch := result.Iter(ctx, row.Results)
+	for i := range out {
+		out[i] = <-ch
+	}
+	return out
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestGridDiff(t *testing.T) {
+	const (
+		pass = statuspb.TestStatus_PASS
+		fail = statuspb.TestStatus_FAIL
+		none = statuspb.TestStatus_NO_RESULT
+	)
+	row := func(name string, results ...statuspb.TestStatus) *statepb.Row {
+		var cells []cell
+		for _, r := range results {
+			cells = append(cells, cell{Result: r})
+		}
+		return setupRow(&statepb.Row{Name: name, Id: name}, cells...)
+	}
+	columns := func(builds ...string) []*statepb.Column {
+		var out []*statepb.Column
+		for _, b := range builds {
+			out = append(out, &statepb.Column{Build: b})
+		}
+		return out
+	}
+
+	cases := []struct {
+		name     string
+		old      *statepb.Grid
+		grid     *statepb.Grid
+		expected GridChanges
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "unchanged",
+			old: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail)},
+			},
+		},
+		{
+			name: "everything is new",
+			grid: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows: []*statepb.Row{
+					row("world", pass, pass),
+					row("hello", pass, fail),
+				},
+			},
+			expected: GridChanges{
+				AddedRows:    []string{"hello", "world"},
+				AddedColumns: []string{"2", "1"},
+			},
+		},
+		{
+			name: "added rows",
+			old: &statepb.Grid{
+				Columns: columns("1"),
+				Rows:    []*statepb.Row{row("hello", pass)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("1"),
+				Rows: []*statepb.Row{
+					row("hello", pass),
+					row("new", fail),
+				},
+			},
+			expected: GridChanges{
+				AddedRows: []string{"new"},
+			},
+		},
+		{
+			name: "removed rows",
+			old: &statepb.Grid{
+				Columns: columns("1"),
+				Rows: []*statepb.Row{
+					row("hello", pass),
+					row("gone", fail),
+				},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("1"),
+				Rows:    []*statepb.Row{row("hello", pass)},
+			},
+			expected: GridChanges{
+				RemovedRows: []string{"gone"},
+			},
+		},
+		{
+			name: "added and removed columns",
+			old: &statepb.Grid{
+				Columns: columns("3", "2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail, pass)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("5", "4", "3", "2"),
+				Rows:    []*statepb.Row{row("hello", fail, pass, pass, fail)},
+			},
+			expected: GridChanges{
+				AddedColumns:   []string{"5", "4"},
+				RemovedColumns: []string{"1"},
+			},
+		},
+		{
+			name: "flipped cells",
+			old: &statepb.Grid{
+				Columns: columns("3", "2", "1"),
+				Rows: []*statepb.Row{
+					row("world", pass, pass, pass),
+					row("hello", none, fail, pass),
+				},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("3", "2", "1"),
+				Rows: []*statepb.Row{
+					row("hello", fail, pass, pass),
+					row("world", pass, pass, fail),
+				},
+			},
+			expected: GridChanges{
+				Cells: []CellChange{
+					{Row: "hello", Column: "3", Old: none, New: fail},
+					{Row: "hello", Column: "2", Old: fail, New: pass},
+					{Row: "world", Column: "1", Old: pass, New: fail},
+				},
+			},
+		},
+		{
+			name: "compare cells by build rather than position",
+			old: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", fail, pass)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("3", "2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, pass, pass)},
+			},
+			expected: GridChanges{
+				AddedColumns: []string{"3"},
+				Cells: []CellChange{
+					{Row: "hello", Column: "2", Old: fail, New: pass},
+				},
+			},
+		},
+		{
+			name: "short rows have no results",
+			old: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("2", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail)},
+			},
+			expected: GridChanges{
+				Cells: []CellChange{
+					{Row: "hello", Column: "1", Old: none, New: fail},
+				},
+			},
+		},
+		{
+			name: "only the first column of a build counts",
+			old: &statepb.Grid{
+				Columns: columns("1", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, fail)},
+			},
+			grid: &statepb.Grid{
+				Columns: columns("1", "1"),
+				Rows:    []*statepb.Row{row("hello", pass, pass)},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := GridDiff(tc.old, tc.grid)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("GridDiff() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if got, want := actual.Empty(), cmp.Equal(tc.expected, GridChanges{}); got != want {
+				t.Errorf("Empty() got %t, want %t", got, want)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package updater
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,6 +30,7 @@ import (
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
@@ -215,6 +218,31 @@ func MergeCells(flaky bool, cells ...Cell) Cell {
 	return out
 }
 
+// coalesceCells combines multiple results for the same row according to the policy.
+//
+// Cells are in the order they were read, so the last cell is the final retry.
+func coalesceCells(policy configpb.TestGroup_RetryPolicy, cells ...Cell) Cell {
+	switch policy {
+	case configpb.TestGroup_RETRY_POLICY_ANY_PASS:
+		out := MergeCells(false, cells...)
+		for _, c := range cells {
+			if result.Passing(c.Result) {
+				out.Result = c.Result
+				break
+			}
+		}
+		return out
+	case configpb.TestGroup_RETRY_POLICY_LAST_RESULT:
+		out := MergeCells(false, cells...)
+		out.Result = cells[len(cells)-1].Result
+		return out
+	case configpb.TestGroup_RETRY_POLICY_WORST_RESULT:
+		return MergeCells(false, cells...)
+	default:
+		return MergeCells(true, cells...)
+	}
+}
+
 // SplitCells appends a unique suffix to each cell.
 //
 // When an excessive number of cells contain the same name
@@ -252,6 +280,18 @@ func SplitCells(originalName string, cells ...Cell) map[string]Cell {
 	return out
 }
 
+// dropSkipped returns true when a skipped result with this message adds no cell.
+func dropSkipped(policy configpb.TestGroup_SkippedResult, msg string) bool {
+	switch policy {
+	case configpb.TestGroup_SKIPPED_RESULT_DROP:
+		return true
+	case configpb.TestGroup_SKIPPED_RESULT_NO_RESULT:
+		return false
+	default:
+		return msg == ""
+	}
+}
+
 // convertResult returns an InflatedColumn representation of the GCS result.
 func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, headers []string, result gcsResult, opt groupOptions) InflatedColumn {
 	cells := map[string][]Cell{}
@@ -266,9 +306,15 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 	version := metadata.Version(result.started.Started, result.finished.Finished)
 
 	// Append each result into the column
-	for _, suite := range result.suites {
+	shardOf := map[string]int{}  // Shard of the first cell of each name.
+	sharded := map[string]bool{} // Names with cells from multiple shards.
+	for shard, suite := range result.suites {
+		suiteMeta := suite.Metadata
+		if opt.mergeShards {
+			suiteMeta = withoutShard(suiteMeta)
+		}
 		for _, r := range flattenResults(suite.Suites.Suites...) {
-			if r.Skipped != nil && *r.Skipped == "" {
+			if r.Skipped != nil && dropSkipped(opt.skipped, *r.Skipped) {
 				continue
 			}
 			c := Cell{CellID: cellID}
@@ -300,6 +346,9 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 				if c.Message != "" {
 					c.Icon = "F"
 				}
+			case r.Skipped != nil && opt.skipped == configpb.TestGroup_SKIPPED_RESULT_NO_RESULT:
+				c.Result = statuspb.TestStatus_NO_RESULT
+				c.Icon = "S"
 			case r.Skipped != nil:
 				c.Result = statuspb.TestStatus_PASS_WITH_SKIPS
 				c.Icon = "S"
@@ -315,7 +364,14 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 				c.UserProperty = values[0]
 			}
 
-			name := nameCfg.render(result.job, r.Name, first(props), suite.Metadata, meta)
+			c.ArtifactURL = artifactURL(opt.artifactURL, result.job, id, r.Name, meta)
+
+			name := nameCfg.render(result.job, r.Name, first(props), suiteMeta, meta)
+			if prev, ok := shardOf[name]; !ok {
+				shardOf[name] = shard
+			} else if prev != shard {
+				sharded[name] = true
+			}
 			cells[name] = append(cells[name], c)
 		}
 	}
@@ -352,6 +408,7 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 
 	for name, c := range injectedCells {
 		c.CellID = cellID
+		c.ArtifactURL = artifactURL(opt.artifactURL, result.job, id, name, meta)
 		if nameCfg.multiJob {
 			jobName := result.job + "." + name
 			cells[jobName] = append([]Cell{c}, cells[jobName]...)
@@ -367,11 +424,14 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 		},
 		Cells: map[string]Cell{},
 	}
+	if opt.columnDuration {
+		out.Column.DurationSeconds = buildDuration(result)
+	}
 
 	for name, cells := range cells {
 		switch {
-		case opt.merge:
-			out.Cells[name] = MergeCells(true, cells...)
+		case opt.merge, opt.mergeShards && sharded[name]:
+			out.Cells[name] = coalesceCells(opt.retry, cells...)
 		default:
 			for n, c := range SplitCells(name, cells...) {
 				out.Cells[n] = c
@@ -404,6 +464,23 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 	return out
 }
 
+// shardKey is the junit filename metadata which identifies the shard of a run.
+const shardKey = "Thread"
+
+// withoutShard returns a copy of the junit filename metadata without the shard.
+func withoutShard(meta map[string]string) map[string]string {
+	if _, ok := meta[shardKey]; !ok {
+		return meta
+	}
+	out := make(map[string]string, len(meta))
+	for k, v := range meta {
+		if k != shardKey {
+			out[k] = v
+		}
+	}
+	return out
+}
+
 func podInfoCell(podInfo gcs.PodInfo) Cell {
 	pass, msg := podInfo.Summarize()
 	var status statuspb.TestStatus
@@ -430,6 +507,20 @@ func podInfoCell(podInfo gcs.PodInfo) Cell {
 	}
 }
 
+// buildDuration returns the seconds between the start and finish of the build.
+//
+// Returns zero when the build has not finished, or finished before it started.
+func buildDuration(result gcsResult) float64 {
+	if result.finished.Timestamp == nil {
+		return 0
+	}
+	finished, started := *result.finished.Timestamp, result.started.Timestamp
+	if finished <= 0 || finished < started {
+		return 0
+	}
+	return float64(finished - started)
+}
+
 // overallCell generates the overall cell for this GCS result.
 func overallCell(result gcsResult) Cell {
 	var c Cell
@@ -474,6 +565,32 @@ func overallCell(result gcsResult) Cell {
 	return c
 }
 
+var artifactToken = regexp.MustCompile(`<([^<>]+)>`)
+
+// artifactURL expands the tokens in the artifact url template of a cell.
+//
+// Expands <job>, <build> and <test-name>, or else the matching metadata value.
+// Values are path escaped and unknown tokens are empty.
+func artifactURL(tmpl, job, build, test string, meta map[string]string) string {
+	if tmpl == "" {
+		return ""
+	}
+	return artifactToken.ReplaceAllStringFunc(tmpl, func(token string) string {
+		var val string
+		switch key := token[1 : len(token)-1]; key {
+		case "job":
+			val = job
+		case "build":
+			val = build
+		case "test-name":
+			val = test
+		default:
+			val = meta[key]
+		}
+		return url.PathEscape(val)
+	})
+}
+
 // ElapsedKey is the key for the test duration metric.
 const ElapsedKey = "test-duration-minutes"
 
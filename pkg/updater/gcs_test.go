@@ -19,6 +19,7 @@ package updater
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
@@ -313,6 +315,122 @@ func TestConvertResult(t *testing.T) {
 	}
 	yes := true
 	now := time.Now().Unix()
+	retried := gcsResult{
+		started: gcs.Started{
+			Started: metadata.Started{
+				Timestamp: now,
+			},
+		},
+		finished: gcs.Finished{
+			Finished: metadata.Finished{
+				Timestamp: pint(now + 1),
+				Passed:    &yes,
+			},
+		},
+		suites: []gcs.SuitesMeta{
+			{
+				Suites: junit.Suites{
+					Suites: []junit.Suite{
+						{
+							Results: []junit.Result{
+								{
+									Name:    "retried",
+									Time:    1,
+									Failure: pstr("ugh"),
+								},
+								{
+									Name: "retried",
+									Time: 2,
+								},
+								{
+									Name:    "retried",
+									Time:    3,
+									Failure: pstr("boom"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	sharded := gcsResult{
+		started: gcs.Started{
+			Started: metadata.Started{
+				Timestamp: now,
+			},
+		},
+		finished: gcs.Finished{
+			Finished: metadata.Finished{
+				Timestamp: pint(now + 1),
+				Passed:    &yes,
+			},
+		},
+		suites: []gcs.SuitesMeta{
+			{
+				Metadata: map[string]string{"Thread": "01"},
+				Suites: junit.Suites{
+					Suites: []junit.Suite{
+						{
+							Results: []junit.Result{
+								{Name: "first", Time: 1},
+								{Name: "shared", Time: 1},
+							},
+						},
+					},
+				},
+			},
+			{
+				Metadata: map[string]string{"Thread": "02"},
+				Suites: junit.Suites{
+					Suites: []junit.Suite{
+						{
+							Results: []junit.Result{
+								{Name: "second", Time: 2},
+								{Name: "shared", Time: 3, Failure: pstr("boom")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	shardNames := nameConfig{
+		format: "%s%s",
+		parts:  []string{testsName, "Thread"},
+	}
+	skipped := gcsResult{
+		started: gcs.Started{
+			Started: metadata.Started{
+				Timestamp: now,
+			},
+		},
+		finished: gcs.Finished{
+			Finished: metadata.Finished{
+				Timestamp: pint(now + 1),
+				Passed:    &yes,
+			},
+		},
+		suites: []gcs.SuitesMeta{
+			{
+				Suites: junit.Suites{
+					Suites: []junit.Suite{
+						{
+							Results: []junit.Result{
+								{Name: "pass"},
+								{Name: "bare skip", Skipped: pstr("")},
+								{Name: "skip", Skipped: pstr("not today")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	testNames := nameConfig{
+		format: "%s",
+		parts:  []string{testsName},
+	}
 	cases := []struct {
 		name     string
 		nameCfg  nameConfig
@@ -1203,6 +1321,225 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "renamed rows are merged",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+				renames: []renameRule{
+					{re: regexp.MustCompile(`-[0-9a-f]{8}$`)},
+				},
+			},
+			opt: groupOptions{
+				merge: true,
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name: "pod-1b4e28ba",
+											Time: 1,
+										},
+										{
+											Name:    "pod-deadbeef",
+											Time:    3,
+											Failure: pstr("ugh"),
+										},
+										{
+											Name: "other",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"pod": {
+						Result:  statuspb.TestStatus_FLAKY,
+						Icon:    "1/2",
+						Message: "1/2 runs passed: ugh",
+						Metrics: setElapsed(nil, 2), // mean
+					},
+					"other": {
+						Result: statuspb.TestStatus_PASS,
+					},
+				},
+			},
+		},
+		{
+			name: "retries with mixed results are flaky",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			opt: groupOptions{
+				merge: true,
+				retry: configpb.TestGroup_RETRY_POLICY_FLAKY_IF_MIXED,
+			},
+			result: retried,
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"retried": {
+						Result:  statuspb.TestStatus_FLAKY,
+						Icon:    "1/3",
+						Message: "1/3 runs passed: boom",
+						Metrics: setElapsed(nil, 2), // mean
+					},
+				},
+			},
+		},
+		{
+			name: "retries pass when any run passes",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			opt: groupOptions{
+				merge: true,
+				retry: configpb.TestGroup_RETRY_POLICY_ANY_PASS,
+			},
+			result: retried,
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"retried": {
+						Result:  statuspb.TestStatus_PASS,
+						Icon:    "1/3",
+						Message: "1/3 runs passed: boom",
+						Metrics: setElapsed(nil, 2), // mean
+					},
+				},
+			},
+		},
+		{
+			name: "retries use the last result",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			opt: groupOptions{
+				merge: true,
+				retry: configpb.TestGroup_RETRY_POLICY_LAST_RESULT,
+			},
+			result: retried,
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"retried": {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "1/3",
+						Message: "1/3 runs passed: boom",
+						Metrics: setElapsed(nil, 2), // mean
+					},
+				},
+			},
+		},
+		{
+			name: "parameterized tests use the worst result",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			opt: groupOptions{
+				merge: true,
+				retry: configpb.TestGroup_RETRY_POLICY_WORST_RESULT,
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "param",
+											Time:    1,
+											Failure: pstr("bad input"),
+										},
+										{
+											Name: "param",
+											Time: 3,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"param": {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "1/2",
+						Message: "1/2 runs passed: bad input",
+						Metrics: setElapsed(nil, 2), // mean
+					},
+				},
+			},
+		},
 		{
 			name: "duplicate row names can be disambiguated",
 			nameCfg: nameConfig{
@@ -1550,6 +1887,243 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "artifact urls",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			opt: groupOptions{
+				artifactURL: "https://artifacts/<job>/<build>/<test-name>",
+			},
+			result: gcsResult{
+				job: "ci-job",
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name:    "bad",
+											Failure: pstr("boom"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			id: "123",
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+					Build:   "123",
+					Hint:    "123",
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:      statuspb.TestStatus_PASS,
+						Metrics:     setElapsed(nil, 1),
+						ArtifactURL: "https://artifacts/ci-job/123/Overall",
+					},
+					"bad": {
+						Result:      statuspb.TestStatus_FAIL,
+						Icon:        "F",
+						Message:     "boom",
+						ArtifactURL: "https://artifacts/ci-job/123/bad",
+					},
+				},
+			},
+		},
+		{
+			name:    "each shard has its own rows by default",
+			nameCfg: shardNames,
+			result:  sharded,
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"first01": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"shared01": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"second02": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 2),
+					},
+					"shared02": {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "F",
+						Message: "boom",
+						Metrics: setElapsed(nil, 3),
+					},
+				},
+			},
+		},
+		{
+			name:    "merge shards into one set of rows",
+			nameCfg: shardNames,
+			result:  sharded,
+			opt: groupOptions{
+				mergeShards: true,
+			},
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"first": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"second": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 2),
+					},
+					"shared": {
+						Result:  statuspb.TestStatus_FLAKY,
+						Icon:    "1/2",
+						Message: "1/2 runs passed: boom",
+						Metrics: setElapsed(nil, 2), // mean
+					},
+				},
+			},
+		},
+		{
+			name:    "skips with a message pass with skips by default",
+			nameCfg: testNames,
+			result:  skipped,
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"pass": {
+						Result: statuspb.TestStatus_PASS,
+					},
+					"skip": {
+						Result:  statuspb.TestStatus_PASS_WITH_SKIPS,
+						Icon:    "S",
+						Message: "not today",
+					},
+				},
+			},
+		},
+		{
+			name:    "skips have no result",
+			nameCfg: testNames,
+			result:  skipped,
+			opt: groupOptions{
+				skipped: configpb.TestGroup_SKIPPED_RESULT_NO_RESULT,
+			},
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"pass": {
+						Result: statuspb.TestStatus_PASS,
+					},
+					"bare skip": {
+						Result: statuspb.TestStatus_NO_RESULT,
+						Icon:   "S",
+					},
+					"skip": {
+						Result:  statuspb.TestStatus_NO_RESULT,
+						Icon:    "S",
+						Message: "not today",
+					},
+				},
+			},
+		},
+		{
+			name:    "drop skips",
+			nameCfg: testNames,
+			result:  skipped,
+			opt: groupOptions{
+				skipped: configpb.TestGroup_SKIPPED_RESULT_DROP,
+			},
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"pass": {
+						Result: statuspb.TestStatus_PASS,
+					},
+				},
+			},
+		},
+		{
+			name:    "record column duration",
+			nameCfg: testNames,
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 90),
+						Passed:    &yes,
+					},
+				},
+			},
+			opt: groupOptions{
+				columnDuration: true,
+			},
+			expected: InflatedColumn{
+				Column: &statepb.Column{
+					Started:         float64(now * 1000),
+					DurationSeconds: 90,
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 90),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1620,6 +2194,58 @@ func TestPodInfoCell(t *testing.T) {
 	}
 }
 
+func TestBuildDuration(t *testing.T) {
+	pint := func(v int64) *int64 {
+		return &v
+	}
+	cases := []struct {
+		name     string
+		started  int64
+		finished *int64
+		expected float64
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "finished build",
+			started:  1000,
+			finished: pint(1150),
+			expected: 150,
+		},
+		{
+			name:    "missing finished time",
+			started: 1000,
+		},
+		{
+			name:     "zero finished time",
+			started:  1000,
+			finished: pint(0),
+		},
+		{
+			name:     "finished before started",
+			started:  1000,
+			finished: pint(900),
+		},
+		{
+			name:     "finished immediately",
+			started:  1000,
+			finished: pint(1000),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result gcsResult
+			result.started.Timestamp = tc.started
+			result.finished.Timestamp = tc.finished
+			if actual := buildDuration(result); actual != tc.expected {
+				t.Errorf("buildDuration() got %f, want %f", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestOverallCell(t *testing.T) {
 	pint := func(v int64) *int64 {
 		return &v
@@ -1785,6 +2411,66 @@ func TestOverallCell(t *testing.T) {
 	}
 }
 
+func TestArtifactURL(t *testing.T) {
+	cases := []struct {
+		name     string
+		tmpl     string
+		job      string
+		build    string
+		test     string
+		meta     map[string]string
+		expected string
+	}{
+		{
+			name:  "empty without a template",
+			job:   "ci-job",
+			build: "123",
+			test:  "hello",
+		},
+		{
+			name:     "first build",
+			tmpl:     "https://artifacts/<job>/<build>/<test-name>/log.txt",
+			job:      "ci-job",
+			build:    "123",
+			test:     "hello",
+			expected: "https://artifacts/ci-job/123/hello/log.txt",
+		},
+		{
+			name:     "second build",
+			tmpl:     "https://artifacts/<job>/<build>/<test-name>/log.txt",
+			job:      "ci-job",
+			build:    "124",
+			test:     "world",
+			expected: "https://artifacts/ci-job/124/world/log.txt",
+		},
+		{
+			name:  "metadata values",
+			tmpl:  "https://artifacts/<region>/<build>?shot=<screenshot>",
+			build: "123",
+			meta: map[string]string{
+				"region":     "us-east1",
+				"screenshot": "page.png",
+			},
+			expected: "https://artifacts/us-east1/123?shot=page.png",
+		},
+		{
+			name:     "escape values and drop unknown tokens",
+			tmpl:     "https://artifacts/<build>/<test-name>/<missing>",
+			build:    "123",
+			test:     "[sig-foo] bar/baz",
+			expected: "https://artifacts/123/%5Bsig-foo%5D%20bar%2Fbaz/",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := artifactURL(tc.tmpl, tc.job, tc.build, tc.test, tc.meta); actual != tc.expected {
+				t.Errorf("artifactURL(%q) got %q, want %q", tc.tmpl, actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestSetElapsed(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// maxHealthColumns is the number of update runs kept in the health grid.
+const maxHealthColumns = 100
+
+// healthAggregator collects the health of each group during an update run.
+//
+// A run is complete once every expected group reports.
+type healthAggregator struct {
+	lock     sync.Mutex
+	expected map[string]bool
+	results  map[string]Cell
+}
+
+func newHealthAggregator() *healthAggregator {
+	return &healthAggregator{
+		expected: map[string]bool{},
+		results:  map[string]Cell{},
+	}
+}
+
+// expect sets the groups which must report to complete a run.
+func (h *healthAggregator) expect(names []string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.expected = make(map[string]bool, len(names))
+	for _, n := range names {
+		h.expected[n] = true
+	}
+}
+
+// record the health of the group, returning the results when this completes the run.
+func (h *healthAggregator) record(name string, c Cell) map[string]Cell {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.results[name] = c
+	for n := range h.expected {
+		if _, ok := h.results[n]; !ok {
+			return nil
+		}
+	}
+	out := h.results
+	h.results = map[string]Cell{}
+	return out
+}
+
+// groupHealth summarizes the grid of a group.
+//
+// Red (FAIL) when the overall row alerts, yellow (FLAKY) when other rows alert,
+// and green (PASS) otherwise.
+func groupHealth(grid *statepb.Grid) Cell {
+	var alerts int
+	for _, row := range grid.Rows {
+		if row.AlertInfo == nil {
+			continue
+		}
+		if row.Name == overallRow {
+			return Cell{
+				Result:  statuspb.TestStatus_FAIL,
+				Icon:    "F",
+				Message: "Overall row is alerting",
+			}
+		}
+		alerts++
+	}
+	if alerts > 0 {
+		return Cell{
+			Result:  statuspb.TestStatus_FLAKY,
+			Icon:    fmt.Sprintf("%d", alerts),
+			Message: fmt.Sprintf("%d alerting rows", alerts),
+		}
+	}
+	return Cell{Result: statuspb.TestStatus_PASS}
+}
+
+// failedHealth describes a group that failed to update.
+func failedHealth(err error) Cell {
+	return Cell{
+		Result:  statuspb.TestStatus_FAIL,
+		Icon:    "E",
+		Message: fmt.Sprintf("Failed to update: %v", err),
+	}
+}
+
+// writeHealth adds a column with the run results to the front of the health grid.
+func writeHealth(ctx context.Context, log logrus.FieldLogger, client gcs.Client, path gcs.Path, when time.Time, results map[string]Cell) error {
+	var cols []InflatedColumn
+	old, _, err := gcs.DownloadGrid(ctx, client, path)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+	case err != nil:
+		return fmt.Errorf("download: %w", err)
+	case old != nil:
+		cols, _ = InflateGrid(old, time.Time{}, time.Unix(math.MaxInt64>>1, 0))
+	}
+
+	col := InflatedColumn{
+		Column: &statepb.Column{
+			Build:   when.UTC().Format(time.RFC3339),
+			Started: float64(when.UnixNano() / int64(time.Millisecond)),
+		},
+		Cells: results,
+	}
+	cols = append([]InflatedColumn{col}, cols...)
+	if len(cols) > maxHealthColumns {
+		cols = cols[:maxHealthColumns]
+	}
+
+	grid := ConstructGrid(log, &configpb.TestGroup{}, cols, nil, nil)
+	buf, err := gcs.MarshalGrid(grid, gcs.Zlib)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if _, err := client.Upload(ctx, path, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestGroupHealth(t *testing.T) {
+	alert := &statepb.AlertInfo{FailCount: 3}
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		expected Cell
+	}{
+		{
+			name:     "empty grid is healthy",
+			grid:     &statepb.Grid{},
+			expected: Cell{Result: statuspb.TestStatus_PASS},
+		},
+		{
+			name: "no alerts is healthy",
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: overallRow},
+					{Name: "hello"},
+				},
+			},
+			expected: Cell{Result: statuspb.TestStatus_PASS},
+		},
+		{
+			name: "alerting rows are yellow",
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: overallRow},
+					{Name: "hello", AlertInfo: alert},
+					{Name: "world", AlertInfo: alert},
+				},
+			},
+			expected: Cell{
+				Result:  statuspb.TestStatus_FLAKY,
+				Icon:    "2",
+				Message: "2 alerting rows",
+			},
+		},
+		{
+			name: "alerting overall row is red",
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "hello", AlertInfo: alert},
+					{Name: overallRow, AlertInfo: alert},
+				},
+			},
+			expected: Cell{
+				Result:  statuspb.TestStatus_FAIL,
+				Icon:    "F",
+				Message: "Overall row is alerting",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, groupHealth(tc.grid)); diff != "" {
+				t.Errorf("groupHealth() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthAggregator(t *testing.T) {
+	pass := Cell{Result: statuspb.TestStatus_PASS}
+	fail := failedHealth(errors.New("boom"))
+
+	h := newHealthAggregator()
+	h.expect([]string{"hello", "world"})
+	if got := h.record("hello", pass); got != nil {
+		t.Fatalf("record(hello) completed the run early: %v", got)
+	}
+	if got := h.record("hello", fail); got != nil {
+		t.Fatalf("record(hello) again completed the run early: %v", got)
+	}
+	want := map[string]Cell{"hello": fail, "world": pass}
+	if diff := cmp.Diff(want, h.record("world", pass)); diff != "" {
+		t.Errorf("record(world) got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// The next run starts empty
+	h.expect([]string{"world"})
+	want = map[string]Cell{"world": fail}
+	if diff := cmp.Diff(want, h.record("world", fail)); diff != "" {
+		t.Errorf("record(world) in the next run got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteHealth(t *testing.T) {
+	path := newPathOrDie("gs://bucket/health")
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	started := float64(now.Unix() * 1000)
+	green := Cell{Result: statuspb.TestStatus_PASS}
+	yellow := Cell{Result: statuspb.TestStatus_FLAKY, Icon: "1", Message: "1 alerting rows"}
+	red := Cell{Result: statuspb.TestStatus_FAIL, Icon: "F", Message: "Overall row is alerting"}
+	cases := []struct {
+		name     string
+		current  *fakeObject
+		results  map[string]Cell
+		expected *statepb.Grid
+		err      bool
+	}{
+		{
+			name: "create health grid",
+			results: map[string]Cell{
+				"green":  green,
+				"yellow": yellow,
+				"red":    red,
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2020-06-01T12:00:00Z", Started: started},
+				},
+				Rows: []*statepb.Row{
+					setupRow(&statepb.Row{Name: "green", Id: "green"}, green),
+					setupRow(&statepb.Row{Name: "red", Id: "red"}, red),
+					setupRow(&statepb.Row{Name: "yellow", Id: "yellow"}, yellow),
+				},
+			},
+		},
+		{
+			name: "prepend run to existing grid",
+			current: &fakeObject{
+				Data: string(mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{Build: "earlier", Started: started - 1000},
+					},
+					Rows: []*statepb.Row{
+						setupRow(&statepb.Row{Name: "green", Id: "green"}, green),
+						setupRow(&statepb.Row{Name: "removed", Id: "removed"}, red),
+					},
+				})),
+			},
+			results: map[string]Cell{
+				"green": yellow,
+				"added": green,
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2020-06-01T12:00:00Z", Started: started},
+					{Build: "earlier", Hint: "earlier", Started: started - 1000},
+				},
+				Rows: []*statepb.Row{
+					setupRow(&statepb.Row{Name: "added", Id: "added"}, green, emptyCell),
+					setupRow(&statepb.Row{Name: "green", Id: "green"}, yellow, green),
+					setupRow(&statepb.Row{Name: "removed", Id: "removed"}, emptyCell, red),
+				},
+			},
+		},
+		{
+			name: "do not clobber unreadable grid",
+			current: &fakeObject{
+				OpenErr: errors.New("injected"),
+			},
+			results: map[string]Cell{"green": green},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			if tc.current != nil {
+				client.Opener[path] = *tc.current
+			}
+			err := writeHealth(context.Background(), logrus.WithField("name", tc.name), client, path, now, tc.results)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("writeHealth() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("writeHealth() failed to return an error")
+			}
+			actual, err := gcs.UnmarshalGrid(client.Uploader[path].Buf)
+			if err != nil {
+				t.Fatalf("gcs.UnmarshalGrid() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("writeHealth() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateReportsBadPathHealth(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	defer func(orig func(gcs.Path, string, string) (*gcs.Path, error)) { resolveGroupPath = orig }(resolveGroupPath)
+	resolveGroupPath = func(g gcs.Path, gridPrefix, groupName string) (*gcs.Path, error) {
+		if groupName == "bad" {
+			return nil, errors.New("injected")
+		}
+		return testGroupPath(g, gridPrefix, groupName)
+	}
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	healthPath := newPathOrDie("gs://bucket/path/to/health")
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "hello", GcsPrefix: "bucket/path/to/hello"},
+			{Name: "bad", GcsPrefix: "bucket/path/to/bad"},
+		},
+	}
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fakeLister{},
+			Opener: fakeOpener{},
+		},
+		Stater: fakeStater{},
+	}
+	updateGroup := func(_ context.Context, _ logrus.FieldLogger, _ gcs.Client, _ *configpb.TestGroup, _ gcs.Path) error {
+		return errors.New("boom")
+	}
+	if err := Update(context.Background(), client, nil, configPath, StaticConfig(cfg), "", nil, 1, 0, nil, updateGroup, true, 0, 0, &healthPath, false, nil); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	buf, ok := client.Uploader[healthPath]
+	if !ok {
+		t.Fatal("Update() failed to write the health grid")
+	}
+	grid, err := gcs.UnmarshalGrid(buf.Buf)
+	if err != nil {
+		t.Fatalf("gcs.UnmarshalGrid() got unexpected error: %v", err)
+	}
+	var names []string
+	for _, row := range grid.Rows {
+		names = append(names, row.Name)
+		if got, want := row.Results[0], int32(statuspb.TestStatus_FAIL); got != want {
+			t.Errorf("Update() reported %s as %d, want %d", row.Name, got, want)
+		}
+	}
+	if diff := cmp.Diff([]string{"bad", "hello"}, names); diff != "" {
+		t.Errorf("Update() got unexpected health rows (-want +got):\n%s", diff)
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 )
 
 // InflatedColumn holds all the entries for a given column.
@@ -60,6 +61,9 @@ type Cell struct {
 	// runtime flexibility in generating links to click on.
 	UserProperty string
 
+	// ArtifactURL links to the build artifacts of this cell, such as logs.
+	ArtifactURL string
+
 	// Issues relevant to this cell
 	// TODO(fejta): persist cell association, currently gets written out as a row-association.
 	// TODO(fejta): support issue association when parsing prow job results.
@@ -77,9 +81,15 @@ func InflateGrid(grid *statepb.Grid, earliest, latest time.Time) ([]InflatedColu
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	gcs.ExpandMessages(grid)
 	rows := make(map[string]<-chan Cell, len(grid.Rows))
 	issues := make(map[string][]string, len(grid.Rows))
 	for _, row := range grid.Rows {
+		for _, m := range row.Metrics {
+			if validateMetric(m) != nil {
+				repairMetric(m) // Salvage what we can rather than panic on corrupt indices.
+			}
+		}
 		rows[row.Name] = inflateRow(ctx, row)
 		if len(row.Issues) > 0 {
 			issues[row.Name] = row.Issues
@@ -99,6 +109,9 @@ func InflateGrid(grid *statepb.Grid, earliest, latest time.Time) ([]InflatedColu
 		for rowName, rowCells := range rows {
 			item.Cells[rowName] = <-rowCells
 		}
+		if col.Placeholder {
+			continue
+		}
 		when := int64(col.Started / 1000)
 		if when > latest.Unix() {
 			continue
@@ -158,6 +171,9 @@ func inflateRow(parent context.Context, row *statepb.Row) <-chan Cell {
 				if n := len(row.UserProperty); n > filledIdx {
 					c.UserProperty = row.UserProperty[filledIdx]
 				}
+				if n := len(row.ArtifactUrls); n > filledIdx {
+					c.ArtifactURL = row.ArtifactUrls[filledIdx]
+				}
 				filledIdx++
 			}
 			select {
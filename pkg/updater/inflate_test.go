@@ -541,6 +541,112 @@ func TestInflateGrid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "expand interned messages",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{
+						Build:   "second",
+						Started: millis(hours[1]),
+					},
+					{
+						Build:   "first",
+						Started: millis(hours[0]),
+					},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:           "hello",
+						Results:        []int32{int32(statuspb.TestStatus_FAIL), 2},
+						MessageIndices: []int32{1, 0},
+						Icons:          []string{"F", "F"},
+						CellIds:        blank(2),
+					},
+				},
+				MessageTable: []string{"timeout", "boom"},
+			},
+			latest: hours[23],
+			expected: []inflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "second",
+						Hint:    "second",
+						Started: millis(hours[1]),
+					},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_FAIL, Message: "boom", Icon: "F"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "first",
+						Hint:    "first",
+						Started: millis(hours[0]),
+					},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_FAIL, Message: "timeout", Icon: "F"},
+					},
+				},
+			},
+		},
+		{
+			name: "repair corrupt metric",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{
+						Build:   "second",
+						Started: millis(hours[1]),
+					},
+					{
+						Build:   "first",
+						Started: millis(hours[0]),
+					},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:     "hello",
+						Results:  []int32{int32(statuspb.TestStatus_PASS), 2},
+						Messages: blank(2),
+						Icons:    blank(2),
+						CellIds:  blank(2),
+						Metric:   []string{"too-short"},
+						Metrics: []*statepb.Metric{
+							{
+								Name:    "too-short",
+								Indices: []int32{0, 2},
+								Values:  []float64{7},
+							},
+						},
+					},
+				},
+			},
+			latest: hours[23],
+			expected: []inflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "second",
+						Hint:    "second",
+						Started: millis(hours[1]),
+					},
+					Cells: map[string]cell{
+						"hello": {
+							Result:  statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{"too-short": 7},
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "first",
+						Hint:    "first",
+						Started: millis(hours[0]),
+					},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -569,6 +675,36 @@ func TestInflateRow(t *testing.T) {
 		{
 			name: "basically works",
 		},
+		{
+			name: "preserve artifact urls",
+			row: statepb.Row{
+				CellIds:      blank(3),
+				Icons:        blank(3),
+				Messages:     blank(3),
+				ArtifactUrls: []string{"https://artifacts/3", "https://artifacts/2"},
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+			},
+			expected: []cell{
+				{
+					Result:      statuspb.TestStatus_FAIL,
+					ArtifactURL: "https://artifacts/3",
+				},
+				{
+					Result:      statuspb.TestStatus_FAIL,
+					ArtifactURL: "https://artifacts/2",
+				},
+				{
+					Result: statuspb.TestStatus_NO_RESULT,
+				},
+				{
+					Result: statuspb.TestStatus_PASS,
+				},
+			},
+		},
 		{
 			name: "preserve cell ids",
 			row: statepb.Row{
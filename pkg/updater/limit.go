@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// UploadLimiter is a token bucket which spaces out grid uploads to respect write quotas.
+//
+// A single limiter is shared by every group worker. A nil limiter never waits.
+type UploadLimiter struct {
+	lock   sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+// NewUploadLimiter allows perSecond uploads on average, and up to burst at once.
+func NewUploadLimiter(perSecond float64, burst int) *UploadLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &UploadLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+// Wait blocks until an upload may proceed, or the context is done.
+func (l *UploadLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+	l.lock.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens-- // reserve our token, possibly from the future
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.lock.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	if err := l.sleep(ctx, wait); err != nil {
+		l.lock.Lock()
+		l.tokens++ // return the unused reservation
+		l.lock.Unlock()
+		return err
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// openLimiter bounds the number of readers open at once, such as to avoid
+// exhausting file descriptors.
+type openLimiter struct {
+	gcs.Downloader
+	slots chan struct{}
+}
+
+// limitOpen returns a downloader which allows at most max open readers, or d when max is zero.
+//
+// Opening another reader waits until an open one closes.
+func limitOpen(d gcs.Downloader, max int) gcs.Downloader {
+	if max <= 0 {
+		return d
+	}
+	return openLimiter{
+		Downloader: d,
+		slots:      make(chan struct{}, max),
+	}
+}
+
+// Open waits for a free slot, which the reader holds until it closes.
+func (l openLimiter) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case l.slots <- struct{}{}:
+	}
+	r, attrs, err := l.Downloader.Open(ctx, path)
+	if err != nil {
+		<-l.slots
+		return nil, nil, err
+	}
+	return &slotReader{ReadCloser: r, release: func() { <-l.slots }}, attrs, nil
+}
+
+// slotReader frees its slot when it first closes.
+type slotReader struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *slotReader) Close() error {
+	defer r.once.Do(r.release)
+	return r.ReadCloser.Close()
+}
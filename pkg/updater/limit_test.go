@@ -0,0 +1,294 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+// fakeClock advances only when the limiter sleeps or the test ticks it.
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	fc.now = fc.now.Add(d)
+	return nil
+}
+
+func TestUploadLimiter(t *testing.T) {
+	cases := []struct {
+		name     string
+		rate     float64
+		burst    int
+		uploads  int
+		pauses   []time.Duration // before each upload after the first
+		expected []time.Duration
+	}{
+		{
+			name:     "zero rate is unlimited",
+			uploads:  3,
+			expected: []time.Duration{0, 0, 0},
+		},
+		{
+			name:     "space out uploads",
+			rate:     2,
+			burst:    1,
+			uploads:  4,
+			expected: []time.Duration{0, 500 * time.Millisecond, time.Second, 1500 * time.Millisecond},
+		},
+		{
+			name:     "burst then space out",
+			rate:     2,
+			burst:    2,
+			uploads:  4,
+			expected: []time.Duration{0, 0, 500 * time.Millisecond, time.Second},
+		},
+		{
+			name:     "invalid burst allows one",
+			rate:     1,
+			uploads:  2,
+			expected: []time.Duration{0, time.Second},
+		},
+		{
+			name:     "slow uploads do not wait",
+			rate:     1,
+			burst:    1,
+			uploads:  3,
+			pauses:   []time.Duration{2 * time.Second, 2 * time.Second},
+			expected: []time.Duration{0, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:     "idle time does not exceed burst",
+			rate:     1,
+			burst:    2,
+			uploads:  4,
+			pauses:   []time.Duration{10 * time.Second},
+			expected: []time.Duration{0, 10 * time.Second, 10 * time.Second, 11 * time.Second},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+			clock := fakeClock{now: start}
+			l := NewUploadLimiter(tc.rate, tc.burst)
+			l.now = clock.Now
+			l.sleep = clock.Sleep
+
+			var actual []time.Duration
+			for i := 0; i < tc.uploads; i++ {
+				if i > 0 && i <= len(tc.pauses) {
+					clock.now = clock.now.Add(tc.pauses[i-1])
+				}
+				if err := l.Wait(context.Background()); err != nil {
+					t.Fatalf("Wait() got unexpected error: %v", err)
+				}
+				actual = append(actual, clock.now.Sub(start))
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Wait() got unexpected upload times (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUploadLimiterNil(t *testing.T) {
+	var l *UploadLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() got unexpected error: %v", err)
+	}
+}
+
+func TestUploadLimiterCancel(t *testing.T) {
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := fakeClock{now: start}
+	l := NewUploadLimiter(1, 1)
+	l.now = clock.Now
+	l.sleep = clock.Sleep
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() got unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("Wait() with canceled context failed to return an error")
+	}
+	// The canceled reservation is returned, so the next upload waits only one period.
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() got unexpected error: %v", err)
+	}
+	if got, want := clock.now.Sub(start), time.Second; got != want {
+		t.Errorf("Wait() after cancel uploaded at %s, want %s", got, want)
+	}
+}
+
+// countingDownloader tracks how many of its readers are open at once.
+type countingDownloader struct {
+	gcs.Downloader
+	lock *sync.Mutex
+	open *int
+	max  *int
+}
+
+func newCountingDownloader(d gcs.Downloader) countingDownloader {
+	return countingDownloader{
+		Downloader: d,
+		lock:       &sync.Mutex{},
+		open:       new(int),
+		max:        new(int),
+	}
+}
+
+func (d countingDownloader) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	r, attrs, err := d.Downloader.Open(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	*d.open++
+	if *d.open > *d.max {
+		*d.max = *d.open
+	}
+	return &countingReader{ReadCloser: r, d: d}, attrs, nil
+}
+
+type countingReader struct {
+	io.ReadCloser
+	d    countingDownloader
+	once sync.Once
+}
+
+// Close counts the reader as closed the first time, as readers may close more than once.
+func (r *countingReader) Close() error {
+	r.once.Do(func() {
+		time.Sleep(time.Millisecond) // Hold the reader open long enough to overlap others.
+		r.d.lock.Lock()
+		defer r.d.lock.Unlock()
+		*r.d.open--
+	})
+	return r.ReadCloser.Close()
+}
+
+func TestLimitOpen(t *testing.T) {
+	now := time.Now().Unix()
+	path := newPathOrDie("gs://bucket/path/to/build/")
+	var builds []fakeBuild
+	for i := 20; i > 0; i-- {
+		builds = append(builds, fakeBuild{
+			id:       strconv.Itoa(i),
+			started:  jsonStarted(now + int64(i)),
+			finished: jsonFinished(now+int64(i)+1, true, nil),
+			podInfo:  podInfoSuccess,
+			passed:   []string{"good"},
+			failed:   []string{"bad"},
+		})
+	}
+	cases := []struct {
+		name string
+		max  int
+	}{
+		{
+			name: "one reader at a time",
+			max:  1,
+		},
+		{
+			name: "limit open readers",
+			max:  3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeClient{
+				Lister: fake.Lister{},
+				Opener: fake.Opener{},
+			}
+			listed := addBuilds(&client, path, builds...)
+			counter := newCountingDownloader(client)
+			group := &configpb.TestGroup{GcsPrefix: "bucket/path/to/build/"}
+			cols, err := readColumns(context.Background(), limitOpen(counter, tc.max), group, listed, time.Unix(now, 0), len(builds), time.Minute, 10)
+			if err != nil {
+				t.Fatalf("readColumns() got unexpected error: %v", err)
+			}
+			if got, want := len(cols), len(builds); got != want {
+				t.Errorf("readColumns() got %d columns, want %d", got, want)
+			}
+			if *counter.max > tc.max {
+				t.Errorf("readColumns() opened %d readers at once, want at most %d", *counter.max, tc.max)
+			}
+			if *counter.open != 0 {
+				t.Errorf("readColumns() left %d readers open", *counter.open)
+			}
+		})
+	}
+}
+
+func TestLimitOpenReleases(t *testing.T) {
+	good := newPathOrDie("gs://bucket/good")
+	opener := fake.Opener{
+		good: fake.Object{Data: "hello"},
+	}
+	d := limitOpen(fakeClient{Lister: fake.Lister{}, Opener: opener}, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A failed open frees its slot.
+	if _, _, err := d.Open(ctx, newPathOrDie("gs://bucket/missing")); err == nil {
+		t.Fatal("Open() of a missing object failed to return an error")
+	}
+	r, _, err := d.Open(ctx, good)
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	// Closing twice only frees one slot.
+	r.Close()
+	r.Close()
+	r, _, err = d.Open(ctx, good)
+	if err != nil {
+		t.Fatalf("Open() after close got unexpected error: %v", err)
+	}
+	defer r.Close()
+
+	// Opening past the limit waits for a free slot.
+	waitCtx, waitCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer waitCancel()
+	if _, _, err := d.Open(waitCtx, good); err == nil {
+		t.Error("Open() past the limit failed to wait for a free slot")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// MergeGrids combines several grids into a single grid for the group.
+//
+// Rows with the same name are unioned together, filling columns from
+// grids without the row with NO_RESULT. Columns from every grid are
+// interleaved in descending start time order.
+//
+// Alerts are recomputed using the group's configuration.
+func MergeGrids(log logrus.FieldLogger, group *configpb.TestGroup, grids ...*statepb.Grid) *statepb.Grid {
+	forever := time.Unix(math.MaxInt64>>1, 0)
+	var cols []InflatedColumn
+	issues := map[string][]string{}
+	for _, grid := range grids {
+		if grid == nil {
+			continue
+		}
+		inflated, rowIssues := InflateGrid(grid, time.Time{}, forever)
+		cols = append(cols, inflated...)
+		for name, is := range rowIssues {
+			issues[name] = append(issues[name], is...)
+		}
+	}
+	SortStarted(group, cols)
+	grid := ConstructGrid(log, group, cols, issues, nil)
+	finishAlerts(group, nil, grid)
+	return grid
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestMergeGrids(t *testing.T) {
+	pass := cell{Result: statuspb.TestStatus_PASS}
+	fail := cell{Result: statuspb.TestStatus_FAIL, Message: "boom", Icon: "F"}
+	cases := []struct {
+		name     string
+		group    configpb.TestGroup
+		grids    []*statepb.Grid
+		expected *statepb.Grid
+	}{
+		{
+			name:     "basically works",
+			expected: &statepb.Grid{},
+		},
+		{
+			name: "single grid",
+			grids: []*statepb.Grid{
+				{
+					Columns: []*statepb.Column{
+						{Build: "1", Hint: "1", Started: 10},
+					},
+					Rows: []*statepb.Row{
+						setupRow(&statepb.Row{Name: "hello", Id: "hello"}, pass),
+					},
+				},
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "1", Hint: "1", Started: 10},
+				},
+				Rows: []*statepb.Row{
+					setupRow(&statepb.Row{Name: "hello", Id: "hello"}, pass),
+				},
+			},
+		},
+		{
+			name: "overlapping and disjoint rows",
+			grids: []*statepb.Grid{
+				{
+					Columns: []*statepb.Column{
+						{Build: "a2", Hint: "a2", Started: 20},
+						{Build: "a1", Hint: "a1", Started: 10},
+					},
+					Rows: []*statepb.Row{
+						setupRow(&statepb.Row{Name: "shared", Id: "shared"}, pass, fail),
+						setupRow(&statepb.Row{Name: "only-a", Id: "only-a"}, fail, pass),
+					},
+				},
+				nil,
+				{
+					Columns: []*statepb.Column{
+						{Build: "b1", Hint: "b1", Started: 15},
+					},
+					Rows: []*statepb.Row{
+						setupRow(&statepb.Row{Name: "shared", Id: "shared"}, pass),
+						setupRow(&statepb.Row{Name: "only-b", Id: "only-b", Issues: []string{"123"}}, fail),
+					},
+				},
+			},
+			expected: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "a2", Hint: "a2", Started: 20},
+					{Build: "b1", Hint: "b1", Started: 15},
+					{Build: "a1", Hint: "a1", Started: 10},
+				},
+				Rows: []*statepb.Row{
+					setupRow(&statepb.Row{Name: "only-a", Id: "only-a"}, fail, emptyCell, pass),
+					setupRow(&statepb.Row{Name: "only-b", Id: "only-b", Issues: []string{"123"}}, emptyCell, fail, emptyCell),
+					setupRow(&statepb.Row{Name: "shared", Id: "shared"}, pass, pass, fail),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := MergeGrids(logrus.WithField("name", tc.name), &tc.group, tc.grids...)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("MergeGrids() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
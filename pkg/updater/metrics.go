@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// MetricGrid holds the numeric metrics of each row, without results, icons or messages.
+type MetricGrid struct {
+	Columns []*statepb.Column
+	// Rows maps each row name to its sparse-encoded metrics, sorted by metric name.
+	Rows map[string][]*statepb.Metric
+}
+
+// ConstructMetrics collects the metrics of each row in the columns.
+//
+// This is a lightweight alternative to ConstructGrid for consumers such as
+// trend exporters, which only need numeric metrics. Metric indices match
+// those ConstructGrid would produce for the same group and columns.
+func ConstructMetrics(log logrus.FieldLogger, group *configpb.TestGroup, cols []InflatedColumn) MetricGrid {
+	if group.PruneEmptyColumns {
+		cols = pruneEmptyColumns(log, cols)
+	}
+
+	var exclude []*regexp.Regexp
+	for _, p := range group.ExcludeRowRegexes {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.WithError(err).WithField("regex", p).Warning("Ignoring bad exclude row regex")
+			continue
+		}
+		exclude = append(exclude, re)
+	}
+
+	mg := MetricGrid{
+		Columns: make([]*statepb.Column, 0, len(cols)),
+		Rows:    map[string][]*statepb.Metric{},
+	}
+	metrics := map[string]map[string]*statepb.Metric{} // row name: metric name: metric
+	for idx, col := range cols {
+		mg.Columns = append(mg.Columns, col.Column)
+		for name, cell := range col.Cells {
+			if len(cell.Metrics) == 0 {
+				continue
+			}
+			row, ok := metrics[name]
+			if !ok {
+				if len(exclude) > 0 && matchesAny(name, exclude) {
+					continue
+				}
+				row = map[string]*statepb.Metric{}
+				metrics[name] = row
+			}
+			for metricName, value := range cell.Metrics {
+				m, ok := row[metricName]
+				if !ok {
+					m = &statepb.Metric{Name: metricName}
+					row[metricName] = m
+				}
+				appendMetric(m, int32(idx), value)
+			}
+		}
+	}
+
+	for name, row := range metrics {
+		series := make([]*statepb.Metric, 0, len(row))
+		for _, m := range row {
+			series = append(series, m)
+		}
+		sort.Slice(series, func(i, j int) bool {
+			return series[i].Name < series[j].Name
+		})
+		mg.Rows[name] = series
+	}
+	return mg
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestConstructMetrics(t *testing.T) {
+	measured := func(metrics map[string]float64) Cell {
+		return Cell{
+			Result:  statuspb.TestStatus_PASS,
+			Message: "ignored",
+			Icon:    "P",
+			Metrics: metrics,
+		}
+	}
+	cols := []InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "empty"},
+			Cells: map[string]Cell{
+				"hello": emptyCell,
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "third"},
+			Cells: map[string]Cell{
+				"hello":    measured(map[string]float64{"elapsed": 3, "coverage": 0.5}),
+				"world":    measured(map[string]float64{"elapsed": 30}),
+				"skip-me":  measured(map[string]float64{"elapsed": 300}),
+				"no-value": measured(nil),
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "second"},
+			Cells: map[string]Cell{
+				"world":    measured(map[string]float64{"elapsed": 20}),
+				"no-value": measured(nil),
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "first"},
+			Cells: map[string]Cell{
+				"hello": measured(map[string]float64{"elapsed": 1}),
+				"world": measured(map[string]float64{"elapsed": 10}),
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		expected MetricGrid
+	}{
+		{
+			name:  "basically works",
+			group: &configpb.TestGroup{},
+			expected: MetricGrid{
+				Columns: []*statepb.Column{
+					{Build: "empty"},
+					{Build: "third"},
+					{Build: "second"},
+					{Build: "first"},
+				},
+				Rows: map[string][]*statepb.Metric{
+					"hello": {
+						{Name: "coverage", Indices: []int32{1, 1}, Values: []float64{0.5}},
+						{Name: "elapsed", Indices: []int32{1, 1, 3, 1}, Values: []float64{3, 1}},
+					},
+					"world": {
+						{Name: "elapsed", Indices: []int32{1, 3}, Values: []float64{30, 20, 10}},
+					},
+					"skip-me": {
+						{Name: "elapsed", Indices: []int32{1, 1}, Values: []float64{300}},
+					},
+				},
+			},
+		},
+		{
+			name: "prune columns and exclude rows",
+			group: &configpb.TestGroup{
+				PruneEmptyColumns: true,
+				ExcludeRowRegexes: []string{"^skip"},
+			},
+			expected: MetricGrid{
+				Columns: []*statepb.Column{
+					{Build: "third"},
+					{Build: "second"},
+					{Build: "first"},
+				},
+				Rows: map[string][]*statepb.Metric{
+					"hello": {
+						{Name: "coverage", Indices: []int32{0, 1}, Values: []float64{0.5}},
+						{Name: "elapsed", Indices: []int32{0, 1, 2, 1}, Values: []float64{3, 1}},
+					},
+					"world": {
+						{Name: "elapsed", Indices: []int32{0, 3}, Values: []float64{30, 20, 10}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			log := logrus.WithField("name", tc.name)
+			actual := ConstructMetrics(log, tc.group, cols)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("ConstructMetrics() got unexpected diff (-want +got):\n%s", diff)
+			}
+
+			// Metrics must match those of the full grid.
+			grid := ConstructGrid(log, tc.group, cols, nil, nil)
+			full := MetricGrid{
+				Columns: grid.Columns,
+				Rows:    map[string][]*statepb.Metric{},
+			}
+			for _, row := range grid.Rows {
+				if len(row.Metrics) == 0 {
+					continue
+				}
+				sort.Slice(row.Metrics, func(i, j int) bool {
+					return row.Metrics[i].Name < row.Metrics[j].Name
+				})
+				full.Rows[row.Name] = row.Metrics
+			}
+			if diff := cmp.Diff(full, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("ConstructMetrics() differs from ConstructGrid() (-grid +metrics):\n%s", diff)
+			}
+		})
+	}
+}
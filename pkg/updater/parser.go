@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// NDJSONParser names the built-in parser for newline-delimited JSON results, see parseNDJSON.
+const NDJSONParser = "ndjson"
+
+var (
+	resultParsersLock sync.RWMutex
+	resultParsers     = map[string]gcs.SuitesParser{
+		NDJSONParser: parseNDJSON,
+	}
+)
+
+// RegisterResultParser makes a parser available to groups with a matching result_parser.
+//
+// Parsers convert custom junit variants into suites, where each result
+// becomes a row and numeric properties become metrics.
+func RegisterResultParser(name string, parser gcs.SuitesParser) {
+	resultParsersLock.Lock()
+	defer resultParsersLock.Unlock()
+	resultParsers[name] = parser
+}
+
+// resultParser returns the named parser, defaulting to the junit parser.
+func resultParser(name string) (gcs.SuitesParser, error) {
+	if name == "" {
+		return junit.ParseStream, nil
+	}
+	resultParsersLock.RLock()
+	defer resultParsersLock.RUnlock()
+	parser, ok := resultParsers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown result parser %q", name)
+	}
+	return parser, nil
+}
+
+// ndjsonResult is a single line of a newline-delimited JSON result stream.
+type ndjsonResult struct {
+	Target     string                 `json:"target"`
+	Status     string                 `json:"status"`
+	Duration   float64                `json:"duration"` // Seconds
+	Message    string                 `json:"message"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// parseNDJSON reads one result object per line, such as:
+//
+//	{"target": "//foo:bar", "status": "PASSED", "duration": 1.5, "properties": {"memory": 12}}
+//	{"target": "//foo:baz", "status": "FAILED", "message": "boom"}
+//
+// Status is one of PASSED, FAILED, ERROR or SKIPPED.
+//
+// Results are decoded one at a time, so memory grows with the number of
+// targets rather than the size of the stream. Repeated lines for a target,
+// such as retries or shards, accumulate into a single result: durations add
+// up, properties append, the first failure or error wins and the target is
+// only skipped when every line skipped.
+func parseNDJSON(r io.Reader) (*junit.Suites, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var suite junit.Suite
+	index := map[string]int{}
+	skips := map[string]bool{}
+	for n := 1; ; n++ {
+		var line ndjsonResult
+		err := dec.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("result %d: %w", n, err)
+		}
+		if line.Target == "" {
+			return nil, fmt.Errorf("result %d: missing target", n)
+		}
+		i, ok := index[line.Target]
+		if !ok {
+			i = len(suite.Results)
+			index[line.Target] = i
+			suite.Results = append(suite.Results, junit.Result{Name: line.Target})
+			skips[line.Target] = true
+		}
+		result := &suite.Results[i]
+		result.Time += line.Duration
+		msg := line.Message
+		switch strings.ToUpper(line.Status) {
+		case "PASSED":
+			skips[line.Target] = false
+		case "FAILED":
+			skips[line.Target] = false
+			if result.Failure == nil && result.Errored == nil {
+				result.Failure = &msg
+			}
+		case "ERROR":
+			skips[line.Target] = false
+			if result.Failure == nil && result.Errored == nil {
+				result.Errored = &msg
+			}
+		case "SKIPPED":
+			if result.Skipped == nil {
+				result.Skipped = &msg
+			}
+		default:
+			return nil, fmt.Errorf("result %d: unknown status %q", n, line.Status)
+		}
+		names := make([]string, 0, len(line.Properties))
+		for name := range line.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if result.Properties == nil {
+				result.Properties = &junit.Properties{}
+			}
+			result.Properties.PropertyList = append(result.Properties.PropertyList, junit.Property{
+				Name:  name,
+				Value: fmt.Sprint(line.Properties[name]),
+			})
+		}
+	}
+	for i, result := range suite.Results {
+		if !skips[result.Name] {
+			suite.Results[i].Skipped = nil
+		}
+	}
+	return &junit.Suites{Suites: []junit.Suite{suite}}, nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+// parseTargets parses a sample junit variant, which reports targets rather than test cases:
+//
+//	<targets>
+//	  <target name="//foo:bar" status="FAILED" duration="1.5">
+//	    <message>boom</message>
+//	    <metric name="memory" value="12"/>
+//	  </target>
+//	</targets>
+func parseTargets(r io.Reader) (*junit.Suites, error) {
+	var doc struct {
+		Targets []struct {
+			Name     string  `xml:"name,attr"`
+			Status   string  `xml:"status,attr"`
+			Duration float64 `xml:"duration,attr"`
+			Message  string  `xml:"message"`
+			Metrics  []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:"value,attr"`
+			} `xml:"metric"`
+		} `xml:"target"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	var suite junit.Suite
+	for _, t := range doc.Targets {
+		result := junit.Result{
+			Name: t.Name,
+			Time: t.Duration,
+		}
+		if t.Status == "FAILED" {
+			msg := t.Message
+			result.Failure = &msg
+		}
+		if len(t.Metrics) > 0 {
+			result.Properties = &junit.Properties{}
+		}
+		for _, m := range t.Metrics {
+			result.Properties.PropertyList = append(result.Properties.PropertyList, junit.Property{
+				Name:  m.Name,
+				Value: m.Value,
+			})
+		}
+		suite.Results = append(suite.Results, result)
+	}
+	return &junit.Suites{Suites: []junit.Suite{suite}}, nil
+}
+
+func TestResultParser(t *testing.T) {
+	RegisterResultParser("targets", parseTargets)
+	const data = `<targets><target name="//foo:bar" status="PASSED"/></targets>`
+	cases := []struct {
+		name     string
+		parser   string
+		expected []string
+		err      bool
+	}{
+		{
+			name:   "default parser reads junit",
+			parser: "",
+			err:    true,
+		},
+		{
+			name:     "registered parser",
+			parser:   "targets",
+			expected: []string{"//foo:bar"},
+		},
+		{
+			name:   "built-in ndjson parser",
+			parser: NDJSONParser,
+			err:    true,
+		},
+		{
+			name:   "unknown parser",
+			parser: "missing",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parse, err := resultParser(tc.parser)
+			var suites *junit.Suites
+			if err == nil {
+				suites, err = parse(strings.NewReader(data))
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("resultParser(%q) got unexpected error: %v", tc.parser, err)
+				}
+			case tc.err:
+				t.Errorf("resultParser(%q) failed to return an error", tc.parser)
+			default:
+				var actual []string
+				for _, r := range flattenResults(suites.Suites...) {
+					actual = append(actual, r.Name)
+				}
+				if diff := cmp.Diff(tc.expected, actual); diff != "" {
+					t.Errorf("resultParser(%q) got unexpected diff (-want +got):\n%s", tc.parser, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNDJSON(t *testing.T) {
+	str := func(s string) *string { return &s }
+	cases := []struct {
+		name     string
+		data     string
+		expected []junit.Result
+		err      bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "read each line",
+			data: `{"target": "//foo:good", "status": "PASSED", "duration": 1.5, "properties": {"memory": 12, "host": "a"}}
+{"target": "//foo:bad", "status": "FAILED", "message": "boom"}
+
+{"target": "//foo:broken", "status": "error", "message": "oops"}
+{"target": "//foo:lazy", "status": "SKIPPED", "message": "later"}
+`,
+			expected: []junit.Result{
+				{
+					Name: "//foo:good",
+					Time: 1.5,
+					Properties: &junit.Properties{
+						PropertyList: []junit.Property{
+							{Name: "host", Value: "a"},
+							{Name: "memory", Value: "12"},
+						},
+					},
+				},
+				{Name: "//foo:bad", Failure: str("boom")},
+				{Name: "//foo:broken", Errored: str("oops")},
+				{Name: "//foo:lazy", Skipped: str("later")},
+			},
+		},
+		{
+			name: "accumulate repeated targets",
+			data: `{"target": "//foo:flaky", "status": "FAILED", "duration": 1, "message": "first", "properties": {"memory": 12}}
+{"target": "//foo:other", "status": "SKIPPED"}
+{"target": "//foo:flaky", "status": "FAILED", "duration": 2, "message": "second", "properties": {"memory": 14}}
+{"target": "//foo:other", "status": "PASSED"}
+{"target": "//foo:flaky", "status": "PASSED", "duration": 3}
+`,
+			expected: []junit.Result{
+				{
+					Name:    "//foo:flaky",
+					Time:    6,
+					Failure: str("first"),
+					Properties: &junit.Properties{
+						PropertyList: []junit.Property{
+							{Name: "memory", Value: "12"},
+							{Name: "memory", Value: "14"},
+						},
+					},
+				},
+				{Name: "//foo:other"},
+			},
+		},
+		{
+			name: "reject malformed lines",
+			data: `{"target": "//foo:good", "status": "PASSED"}
+{"target": "//foo:bad",`,
+			err: true,
+		},
+		{
+			name: "reject missing targets",
+			data: `{"status": "PASSED"}`,
+			err:  true,
+		},
+		{
+			name: "reject unknown statuses",
+			data: `{"target": "//foo:bar", "status": "MAYBE"}`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			suites, err := parseNDJSON(strings.NewReader(tc.data))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("parseNDJSON() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("parseNDJSON() failed to return an error")
+			default:
+				expected := &junit.Suites{Suites: []junit.Suite{{Results: tc.expected}}}
+				if diff := cmp.Diff(expected, suites); diff != "" {
+					t.Errorf("parseNDJSON() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"sort"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// GridEvent describes a grid the updater wrote, allowing downstream pipelines to react.
+type GridEvent struct {
+	Group        string `json:"group"`
+	Path         string `json:"path"`
+	Columns      int    `json:"columns"`
+	Rows         int    `json:"rows"`
+	AlertsOpened int    `json:"alerts_opened"`
+	AlertsClosed int    `json:"alerts_closed"`
+	// Alerts lists how each alert changed since the previous grid, sorted by row.
+	Alerts []AlertChange `json:"alerts,omitempty"`
+}
+
+// AlertTransition describes how the alert of a row changed since the previous grid.
+//
+// Notifiers usually only react to new and resolved alerts.
+type AlertTransition string
+
+const (
+	// AlertNew means the row started alerting.
+	AlertNew AlertTransition = "new"
+	// AlertOngoing means the row was already alerting.
+	AlertOngoing AlertTransition = "ongoing"
+	// AlertResolved means the row stopped alerting, or no longer exists.
+	AlertResolved AlertTransition = "resolved"
+)
+
+// AlertChange describes the alert transition of a row.
+type AlertChange struct {
+	Row        string          `json:"row"`
+	Transition AlertTransition `json:"transition"`
+}
+
+// A Publisher announces each successfully written grid, for example to a Pub/Sub topic.
+//
+// See PubSubPublisher.
+type Publisher interface {
+	Publish(context.Context, GridEvent) error
+}
+
+// AlertNotification describes an alert which opened or resolved when writing a grid.
+type AlertNotification struct {
+	Group      string
+	Row        string
+	Transition AlertTransition
+	// FailBuild and Message describe the new alert, or else the resolved one.
+	FailBuild string
+	Message   string
+}
+
+// An AlertNotifier announces the alerts which opened or resolved, for example to a webhook.
+type AlertNotifier interface {
+	Notify(context.Context, []AlertNotification) error
+}
+
+// gridEvent summarizes the difference between the old and new grid for the group.
+func gridEvent(name string, gridPath gcs.Path, old, grid *statepb.Grid) GridEvent {
+	alerts := alertTransitions(old, grid)
+	var opened, closed int
+	for _, a := range alerts {
+		switch a.Transition {
+		case AlertNew:
+			opened++
+		case AlertResolved:
+			closed++
+		}
+	}
+
+	return GridEvent{
+		Group:        name,
+		Path:         gridPath.String(),
+		Columns:      len(grid.Columns),
+		Rows:         len(grid.Rows),
+		AlertsOpened: opened,
+		AlertsClosed: closed,
+		Alerts:       alerts,
+	}
+}
+
+// alertTransitions compares the alerts of the old and new grid.
+//
+// A row opens an alert when it has AlertInfo in the new grid but not the old one.
+// It resolves when the reverse is true (including when the row disappears).
+func alertTransitions(old, grid *statepb.Grid) []AlertChange {
+	wasAlerting := map[string]bool{}
+	if old != nil {
+		for _, row := range old.Rows {
+			if row.AlertInfo != nil {
+				wasAlerting[row.Name] = true
+			}
+		}
+	}
+
+	var changes []AlertChange
+	for _, row := range grid.Rows {
+		alerting := row.AlertInfo != nil
+		switch {
+		case alerting && wasAlerting[row.Name]:
+			changes = append(changes, AlertChange{row.Name, AlertOngoing})
+		case alerting:
+			changes = append(changes, AlertChange{row.Name, AlertNew})
+		case wasAlerting[row.Name]:
+			changes = append(changes, AlertChange{row.Name, AlertResolved})
+		}
+		delete(wasAlerting, row.Name)
+	}
+	for name := range wasAlerting { // rows that no longer exist
+		changes = append(changes, AlertChange{name, AlertResolved})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Row < changes[j].Row
+	})
+	return changes
+}
+
+// alertNotifications returns the alerts which opened or resolved between the old and new grid.
+func alertNotifications(name string, old, grid *statepb.Grid) []AlertNotification {
+	alerts := func(g *statepb.Grid) map[string]*statepb.AlertInfo {
+		out := map[string]*statepb.AlertInfo{}
+		if g == nil {
+			return out
+		}
+		for _, row := range g.Rows {
+			if row.AlertInfo != nil {
+				out[row.Name] = row.AlertInfo
+			}
+		}
+		return out
+	}
+	was, now := alerts(old), alerts(grid)
+
+	var notes []AlertNotification
+	for _, change := range alertTransitions(old, grid) {
+		var info *statepb.AlertInfo
+		switch change.Transition {
+		case AlertNew:
+			info = now[change.Row]
+		case AlertResolved:
+			info = was[change.Row]
+		default:
+			continue
+		}
+		notes = append(notes, AlertNotification{
+			Group:      name,
+			Row:        change.Row,
+			Transition: change.Transition,
+			FailBuild:  info.FailBuildId,
+			Message:    info.FailureMessage,
+		})
+	}
+	return notes
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+type fakePublisher struct {
+	events []GridEvent
+}
+
+func (fp *fakePublisher) Publish(_ context.Context, event GridEvent) error {
+	fp.events = append(fp.events, event)
+	return nil
+}
+
+func TestGridEvent(t *testing.T) {
+	path := newPathOrDie("gs://bucket/grid/foo")
+	alert := &statepb.AlertInfo{FailCount: 3}
+	cases := []struct {
+		name     string
+		old      *statepb.Grid
+		grid     *statepb.Grid
+		expected GridEvent
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+			expected: GridEvent{
+				Group: "foo",
+				Path:  "gs://bucket/grid/foo",
+			},
+		},
+		{
+			name: "count columns and rows",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+				Rows:    []*statepb.Row{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			},
+			expected: GridEvent{
+				Group:   "foo",
+				Path:    "gs://bucket/grid/foo",
+				Columns: 2,
+				Rows:    3,
+			},
+		},
+		{
+			name: "open new alerts",
+			old: &statepb.Grid{
+				Rows: []*statepb.Row{{Name: "still"}, {Name: "new"}, {Name: "ongoing", AlertInfo: alert}},
+			},
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "still"},
+					{Name: "new", AlertInfo: alert},
+					{Name: "ongoing", AlertInfo: alert},
+					{Name: "added", AlertInfo: alert},
+				},
+			},
+			expected: GridEvent{
+				Group:        "foo",
+				Path:         "gs://bucket/grid/foo",
+				Rows:         4,
+				AlertsOpened: 2,
+				Alerts: []AlertChange{
+					{"added", AlertNew},
+					{"new", AlertNew},
+					{"ongoing", AlertOngoing},
+				},
+			},
+		},
+		{
+			name: "close old alerts",
+			old: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "fixed", AlertInfo: alert},
+					{Name: "ongoing", AlertInfo: alert},
+					{Name: "deleted", AlertInfo: alert},
+				},
+			},
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "fixed"},
+					{Name: "ongoing", AlertInfo: alert},
+				},
+			},
+			expected: GridEvent{
+				Group:        "foo",
+				Path:         "gs://bucket/grid/foo",
+				Rows:         2,
+				AlertsClosed: 2,
+				Alerts: []AlertChange{
+					{"deleted", AlertResolved},
+					{"fixed", AlertResolved},
+					{"ongoing", AlertOngoing},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := gridEvent("foo", path, tc.old, tc.grid)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("gridEvent() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAlertTransitions(t *testing.T) {
+	alert := &statepb.AlertInfo{FailCount: 3}
+	// Successive grids of the same group.
+	grids := []*statepb.Grid{
+		{
+			Rows: []*statepb.Row{
+				{Name: "flaky", AlertInfo: alert},
+				{Name: "broken", AlertInfo: alert},
+				{Name: "fine"},
+			},
+		},
+		{
+			Rows: []*statepb.Row{
+				{Name: "flaky"},
+				{Name: "broken", AlertInfo: alert},
+				{Name: "fine", AlertInfo: alert},
+			},
+		},
+		{
+			Rows: []*statepb.Row{
+				{Name: "flaky", AlertInfo: alert},
+				{Name: "fine"},
+			},
+		},
+	}
+	expected := [][]AlertChange{
+		{
+			{"broken", AlertNew},
+			{"flaky", AlertNew},
+		},
+		{
+			{"broken", AlertOngoing},
+			{"fine", AlertNew},
+			{"flaky", AlertResolved},
+		},
+		{
+			{"broken", AlertResolved},
+			{"fine", AlertResolved},
+			{"flaky", AlertNew},
+		},
+	}
+
+	var old *statepb.Grid
+	for i, grid := range grids {
+		actual := alertTransitions(old, grid)
+		if diff := cmp.Diff(expected[i], actual); diff != "" {
+			t.Errorf("alertTransitions() of update %d got unexpected diff (-want +got):\n%s", i, diff)
+		}
+		old = grid
+	}
+}
+
+func TestAlertNotifications(t *testing.T) {
+	old := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "still-broken", AlertInfo: &statepb.AlertInfo{FailBuildId: "1", FailureMessage: "old"}},
+			{Name: "fixed", AlertInfo: &statepb.AlertInfo{FailBuildId: "2", FailureMessage: "was bad"}},
+			{Name: "deleted", AlertInfo: &statepb.AlertInfo{FailBuildId: "3"}},
+			{Name: "fine"},
+		},
+	}
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "still-broken", AlertInfo: &statepb.AlertInfo{FailBuildId: "1", FailureMessage: "new"}},
+			{Name: "fixed"},
+			{Name: "fine", AlertInfo: &statepb.AlertInfo{FailBuildId: "4", FailureMessage: "now bad"}},
+		},
+	}
+	expected := []AlertNotification{
+		{Group: "hello", Row: "deleted", Transition: AlertResolved, FailBuild: "3"},
+		{Group: "hello", Row: "fine", Transition: AlertNew, FailBuild: "4", Message: "now bad"},
+		{Group: "hello", Row: "fixed", Transition: AlertResolved, FailBuild: "2", Message: "was bad"},
+	}
+	actual := alertNotifications("hello", old, grid)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("alertNotifications() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if actual := alertNotifications("hello", grid, grid); len(actual) != 0 {
+		t.Errorf("alertNotifications() of an unchanged grid got %v, want none", actual)
+	}
+}
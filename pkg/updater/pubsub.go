@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
+)
+
+// PubSubPublisher publishes each GridEvent as JSON to a Pub/Sub topic.
+type PubSubPublisher struct {
+	client *pubsub.Client
+	topic  *pubsub.Topic
+}
+
+// NewPubSubPublisher returns a publisher for the projects/PROJECT/topics/TOPIC topic.
+func NewPubSubPublisher(ctx context.Context, topic string, opts ...option.ClientOption) (*PubSubPublisher, error) {
+	project, id, err := parseTopic(topic)
+	if err != nil {
+		return nil, err
+	}
+	client, err := pubsub.NewClient(ctx, project, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	return &PubSubPublisher{
+		client: client,
+		topic:  client.Topic(id),
+	}, nil
+}
+
+// parseTopic returns the project and id of a projects/PROJECT/topics/TOPIC name.
+func parseTopic(topic string) (string, string, error) {
+	parts := strings.Split(topic, "/")
+	if len(parts) != 4 || parts[0] != "projects" || parts[1] == "" || parts[2] != "topics" || parts[3] == "" {
+		return "", "", fmt.Errorf("topic %q is not projects/PROJECT/topics/TOPIC", topic)
+	}
+	return parts[1], parts[3], nil
+}
+
+// Publish sends the event, waiting until Pub/Sub accepts it.
+//
+// The group attribute of the message holds the group name, which allows
+// subscriptions to filter events.
+func (p *PubSubPublisher) Publish(ctx context.Context, evt GridEvent) error {
+	buf, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	res := p.topic.Publish(ctx, &pubsub.Message{
+		Data:       buf,
+		Attributes: map[string]string{"group": evt.Group},
+	})
+	if _, err := res.Get(ctx); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	return nil
+}
+
+// Close sends any buffered events and closes the client.
+func (p *PubSubPublisher) Close() error {
+	p.topic.Stop()
+	return p.client.Close()
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+func TestParseTopic(t *testing.T) {
+	cases := []struct {
+		name    string
+		topic   string
+		project string
+		id      string
+		err     bool
+	}{
+		{
+			name:    "basically works",
+			topic:   "projects/my-project/topics/grid-events",
+			project: "my-project",
+			id:      "grid-events",
+		},
+		{
+			name:  "reject bare topic",
+			topic: "grid-events",
+			err:   true,
+		},
+		{
+			name:  "reject empty project",
+			topic: "projects//topics/grid-events",
+			err:   true,
+		},
+		{
+			name:  "reject subscription",
+			topic: "projects/my-project/subscriptions/grid-events",
+			err:   true,
+		},
+		{
+			name:  "reject trailing parts",
+			topic: "projects/my-project/topics/grid-events/extra",
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			project, id, err := parseTopic(tc.topic)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("parseTopic() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("parseTopic() failed to return an error")
+			default:
+				if project != tc.project || id != tc.id {
+					t.Errorf("parseTopic() got %q, %q, want %q, %q", project, id, tc.project, tc.id)
+				}
+			}
+		})
+	}
+}
+
+func TestPubSubPublisher(t *testing.T) {
+	evt := GridEvent{
+		Group:        "hello",
+		Path:         "gs://bucket/grid/hello",
+		Columns:      3,
+		Rows:         2,
+		AlertsOpened: 1,
+		Alerts: []AlertChange{
+			{Row: "broken", Transition: AlertNew},
+		},
+	}
+	cases := []struct {
+		name     string
+		create   bool
+		expected []string
+		err      bool
+	}{
+		{
+			name:   "basically works",
+			create: true,
+			expected: []string{
+				`{"group":"hello","path":"gs://bucket/grid/hello","columns":3,"rows":2,"alerts_opened":1,"alerts_closed":0,"alerts":[{"row":"broken","transition":"new"}]}`,
+			},
+		},
+		{
+			name: "missing topic",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			srv := pstest.NewServer()
+			defer srv.Close()
+			conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+			if err != nil {
+				t.Fatalf("Dial() got unexpected error: %v", err)
+			}
+			defer conn.Close()
+
+			pub, err := NewPubSubPublisher(ctx, "projects/my-project/topics/grid-events", option.WithGRPCConn(conn))
+			if err != nil {
+				t.Fatalf("NewPubSubPublisher() got unexpected error: %v", err)
+			}
+			defer pub.Close()
+			if tc.create {
+				if _, err := pub.client.CreateTopic(ctx, "grid-events"); err != nil {
+					t.Fatalf("CreateTopic() got unexpected error: %v", err)
+				}
+			}
+
+			err = pub.Publish(ctx, evt)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Publish() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("Publish() failed to return an error")
+			default:
+				var got []string
+				for _, m := range srv.Messages() {
+					got = append(got, string(m.Data))
+					if group := m.Attributes["group"]; group != evt.Group {
+						t.Errorf("Publish() got group attribute %q, want %q", group, evt.Group)
+					}
+				}
+				if diff := cmp.Diff(tc.expected, got); diff != "" {
+					t.Errorf("Publish() got unexpected messages (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -22,15 +22,20 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/url"
 	"path"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 
 	"github.com/fvbommel/sortorder"
@@ -55,7 +60,13 @@ func hintStarted(cols []InflatedColumn) (string, time.Time) {
 	return hint, when
 }
 
-func gcsColumnReader(client gcs.Client, buildTimeout time.Duration, concurrency int) ColumnReader {
+// gcsColumnReader reads the columns of the builds which lister finds, defaulting to listing them with client.
+//
+// Keeps at most maxOpen readers open at once when non-zero.
+func gcsColumnReader(client gcs.Client, lister BuildLister, buildTimeout time.Duration, concurrency, maxOpen int, afterBuildID string) ColumnReader {
+	if lister == nil {
+		lister = GCSBuildLister{client}
+	}
 	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []InflatedColumn, stop time.Time) ([]InflatedColumn, error) {
 		tgPaths, err := groupPaths(tg)
 		if err != nil {
@@ -73,19 +84,61 @@ func gcsColumnReader(client gcs.Client, buildTimeout time.Duration, concurrency
 			stop = newStop
 		}
 
-		builds, err := listBuilds(ctx, client, since, tgPaths...)
+		var builds []gcs.Build
+		if streamer, ok := lister.(BuildStreamer); ok && streamOldest(tg, tgPaths, since, afterBuildID) {
+			builds, err = streamBuilds(ctx, streamer, since, tgPaths[0], columnCap(tg), stop)
+		} else {
+			builds, err = listBuilds(ctx, lister, since, afterBuildID, tgPaths...)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("list builds: %w", err)
 		}
 		log.WithField("total", len(builds)).Debug("Listed builds")
 
-		builds = truncateBuilds(log, builds, oldCols)
+		builds = truncateBuilds(log, builds, oldCols, tg.ColumnLimit)
 
-		const maxCols = 50
-		return readColumns(ctx, client, tg, builds, stop, maxCols, buildTimeout, concurrency)
+		return readColumns(ctx, limitOpen(client, maxOpen), tg, builds, stop, columnCap(tg), buildTimeout, readConcurrency(tg, concurrency))
 	}
 }
 
+// streamOldest returns true when an update only needs the oldest builds after since, up to its column cap.
+//
+// GCS lists builds in ascending lexical order, so streaming starts at since
+// and stops once it has enough builds. Groups which read the newest builds,
+// merge several paths, read after a specific build, have not updated yet or
+// use sequential build numbers need every build.
+func streamOldest(tg *configpb.TestGroup, tgPaths []gcs.Path, since, afterBuildID string) bool {
+	return tg.ColumnLimit != configpb.TestGroup_COLUMN_LIMIT_NEWEST && len(tgPaths) == 1 && afterBuildID == "" && since != "" && gcs.LexicalOffset(since)
+}
+
+const (
+	// maxCols is the most builds an update reads by default.
+	maxCols = 50
+	// maxTimeCappedCols is the most builds an update reads for groups capping columns by time.
+	maxTimeCappedCols = 1000
+)
+
+// columnCap returns the maximum number of builds to read in an update.
+//
+// Groups capping columns by time read every build since the stop time,
+// up to a ceiling which prevents runaway grids.
+func columnCap(tg *configpb.TestGroup) int {
+	if tg.CapColumnsByTime {
+		return maxTimeCappedCols
+	}
+	return maxCols
+}
+
+// readConcurrency returns the number of builds of the group to read in parallel.
+//
+// Groups may override the concurrency of the updater, which is otherwise the default.
+func readConcurrency(tg *configpb.TestGroup, concurrency int) int {
+	if n := tg.GetReadConcurrency(); n > 0 {
+		return int(n)
+	}
+	return concurrency
+}
+
 // readColumns will list, download and process builds into inflatedColumns.
 func readColumns(parent context.Context, client gcs.Downloader, group *configpb.TestGroup, builds []gcs.Build, stopTime time.Time, max int, buildTimeout time.Duration, concurrency int) ([]InflatedColumn, error) {
 	// Spawn build readers
@@ -100,7 +153,7 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 	defer wg.Wait()
 	var maxLock sync.Mutex
 
-	log := logrus.WithField("group", group.Name).WithField("prefix", "gs://"+group.GcsPrefix)
+	log := util.Logger(parent).WithField("group", group.Name).WithField("prefix", "gs://"+group.GcsPrefix)
 
 	stop := stopTime.Unix() * 1000
 
@@ -108,7 +161,7 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 	defer cancel()
 	if lb := len(builds); lb > max {
 		log.WithField("total", lb).WithField("max", max).Debug("Truncating")
-		builds = builds[lb-max:]
+		builds = limitBuilds(builds, max, group.ColumnLimit)
 	}
 	maxIdx := len(builds)
 	cols := make([]InflatedColumn, maxIdx)
@@ -140,11 +193,12 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 	}
 
 	errs := make([]error, maxIdx)
+	dropped := make([]bool, maxIdx)
 
 	// Concurrently receive indices and read builds
+	nameCfg := makeNameConfig(log, group)
 	wg.Add(concurrency)
 	for i := 0; i < concurrency; i++ {
-		nameCfg := makeNameConfig(group)
 		go func() {
 			defer wg.Done()
 			for {
@@ -164,13 +218,25 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 
 				// use ctx so we finish reading, even if buildCtx is done
 				inner, innerCancel := context.WithTimeout(ctx, buildTimeout)
-				result, err := readResult(inner, client, b)
+				result, err := readBuild(inner, client, group, b)
 				innerCancel()
 				if err != nil {
 					errs[idx] = fmt.Errorf("%s: %w", b, err)
 					continue
 				}
 				id := path.Base(b.Path.Object())
+				if !matchBuildMetadata(group.BuildMetadataFilters, result.finished.Metadata) {
+					dropped[idx] = true
+					continue
+				}
+				if result.started.Timestamp == 0 {
+					when, ok := missingStarted(group.MissingStarted, id)
+					if !ok {
+						dropped[idx] = true
+						continue
+					}
+					result.started.Timestamp = when
+				}
 
 				cols[idx] = convertResult(log, nameCfg, id, heads, *result, makeOptions(group))
 
@@ -215,6 +281,16 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 	}
 	errs = errs[:maxIdx]
 	cols = cols[:maxIdx]
+	builds, cols, errs = dropBuilds(builds[:maxIdx], cols, errs, dropped[:maxIdx])
+	if n := len(cols); n < maxIdx {
+		log.WithField("dropped", maxIdx-n).Info("Dropped builds without a started time or matching metadata")
+		maxIdx = n
+	}
+	builds, cols, errs = dedupBuilds(builds, cols, errs)
+	if n := len(cols); n < maxIdx {
+		log.WithField("duplicates", maxIdx-n).Info("Dropped duplicate builds")
+		maxIdx = n
+	}
 	// did we find anything?
 	var good bool
 	for _, e := range errs {
@@ -251,6 +327,114 @@ func readColumns(parent context.Context, client gcs.Downloader, group *configpb.
 	return cols[0:maxIdx], nil
 }
 
+// matchBuildMetadata returns true when the build metadata matches every filter.
+//
+// Filters without a value only require the key to be present.
+func matchBuildMetadata(filters []*configpb.TestGroup_BuildMetadataFilter, meta metadata.Metadata) bool {
+	for _, f := range filters {
+		val, present := meta.String(f.Key)
+		switch {
+		case !present:
+			return false
+		case f.Value == "":
+			continue
+		case val == nil || *val != f.Value:
+			return false
+		}
+	}
+	return true
+}
+
+// limitBuilds returns at most max of the newest-first builds, keeping the oldest unless the limit keeps the newest.
+func limitBuilds(builds []gcs.Build, max int, limit configpb.TestGroup_ColumnLimit) []gcs.Build {
+	lb := len(builds)
+	if lb <= max {
+		return builds
+	}
+	if limit == configpb.TestGroup_COLUMN_LIMIT_NEWEST {
+		return builds[:max]
+	}
+	return builds[lb-max:]
+}
+
+// missingStarted returns the started time of a build without one, per the policy of the group.
+//
+// Returns false when the build should be dropped.
+func missingStarted(policy configpb.TestGroup_MissingStarted, id string) (int64, bool) {
+	if policy != configpb.TestGroup_MISSING_STARTED_BUILD_ID {
+		return 0, false
+	}
+	return startedFromID(id)
+}
+
+// startedFromID returns the seconds since epoch encoded in a build id.
+//
+// Understands seconds, milliseconds and UTC times such as 20200913-122640.
+func startedFromID(id string) (int64, bool) {
+	if n, err := strconv.ParseInt(id, 10, 64); err == nil && n > 0 {
+		switch len(id) {
+		case 10:
+			return n, true
+		case 13:
+			return n / 1000, true
+		}
+	}
+	for _, layout := range []string{"20060102-150405", "20060102150405"} {
+		if t, err := time.Parse(layout, id); err == nil {
+			return t.Unix(), true
+		}
+	}
+	return 0, false
+}
+
+// dropBuilds removes the builds, columns and errors of each dropped read.
+func dropBuilds(builds []gcs.Build, cols []InflatedColumn, errs []error, dropped []bool) ([]gcs.Build, []InflatedColumn, []error) {
+	outBuilds := make([]gcs.Build, 0, len(builds))
+	outCols := make([]InflatedColumn, 0, len(cols))
+	outErrs := make([]error, 0, len(errs))
+	for i := range builds {
+		if dropped[i] {
+			continue
+		}
+		outBuilds = append(outBuilds, builds[i])
+		outCols = append(outCols, cols[i])
+		outErrs = append(outErrs, errs[i])
+	}
+	return outBuilds, outCols, outErrs
+}
+
+// dedupBuilds drops repeated reads of the same build, which listing may return more than once.
+//
+// The most complete read is kept in the position of the first: a successful read beats a
+// failed one, then more cells beats fewer, with ties going to the most recent read.
+func dedupBuilds(builds []gcs.Build, cols []InflatedColumn, errs []error) ([]gcs.Build, []InflatedColumn, []error) {
+	better := func(i, j int) bool { // is i a better read than j
+		if (errs[i] == nil) != (errs[j] == nil) {
+			return errs[i] == nil
+		}
+		return len(cols[i].Cells) >= len(cols[j].Cells)
+	}
+	first := make(map[gcs.Path]int, len(builds))
+	outBuilds := make([]gcs.Build, 0, len(builds))
+	outCols := make([]InflatedColumn, 0, len(cols))
+	outErrs := make([]error, 0, len(errs))
+	var orig []int // index of the read in each output slot
+	for i, b := range builds {
+		if slot, ok := first[b.Path]; ok {
+			if better(i, orig[slot]) {
+				outCols[slot], outErrs[slot], orig[slot] = cols[i], errs[i], i
+			}
+			continue
+		}
+		first[b.Path] = len(outBuilds)
+		orig = append(orig, i)
+		outBuilds = append(outBuilds, b)
+		outCols = append(outCols, cols[i])
+		outErrs = append(outErrs, errs[i])
+	}
+	return outBuilds, outCols, outErrs
+}
+
 // failedColumns fills info for bad column indices using left and right indices as a reference
 func failedColumns(cols []InflatedColumn, builds []gcs.Build, errs []error, left, right int, bad ...int) {
 	if len(bad) == 0 {
@@ -287,19 +471,29 @@ func failedColumns(cols []InflatedColumn, builds []gcs.Build, errs []error, left
 
 type groupOptions struct {
 	merge          bool
+	mergeShards    bool
+	retry          configpb.TestGroup_RetryPolicy
+	skipped        configpb.TestGroup_SkippedResult
 	analyzeProwJob bool
 	addCellID      bool
 	metricKey      string
 	userKey        string
+	artifactURL    string
+	columnDuration bool
 }
 
 func makeOptions(group *configpb.TestGroup) groupOptions {
 	return groupOptions{
 		merge:          !group.DisableMergedStatus,
+		mergeShards:    group.MergeShards,
+		retry:          group.RetryPolicy,
+		skipped:        group.SkippedResult,
 		analyzeProwJob: !group.DisableProwjobAnalysis,
 		addCellID:      group.BuildOverrideStrftime != "",
 		metricKey:      group.ShortTextMetric,
 		userKey:        group.UserProperty,
+		artifactURL:    group.ArtifactUrlTemplate,
+		columnDuration: group.RecordColumnDuration,
 	}
 }
 
@@ -312,9 +506,16 @@ type nameConfig struct {
 	format   string
 	parts    []string
 	multiJob bool
+	renames  []renameRule
+}
+
+// renameRule replaces each match of re in a row name with replacement.
+type renameRule struct {
+	re          *regexp.Regexp
+	replacement string
 }
 
-// render the metadata into the expect test name format.
+// render the metadata into the expect test name format, and then apply any rename rules.
 //
 // Argument order determines precedence.
 func (nc nameConfig) render(job, test string, metadatas ...map[string]string) string {
@@ -337,18 +538,37 @@ func (nc nameConfig) render(job, test string, metadatas ...map[string]string) st
 		}
 		parsed[i] = s
 	}
-	return fmt.Sprintf(nc.format, parsed...)
+	name := fmt.Sprintf(nc.format, parsed...)
+	for _, r := range nc.renames {
+		name = r.re.ReplaceAllString(name, r.replacement)
+	}
+	return name
 }
 
-func makeNameConfig(group *configpb.TestGroup) nameConfig {
+func makeNameConfig(log logrus.FieldLogger, group *configpb.TestGroup) nameConfig {
 	nameCfg := convertNameConfig(group.TestNameConfig)
 	if strings.Contains(group.GcsPrefix, ",") {
 		nameCfg.multiJob = true
 		ensureJobName(&nameCfg)
 	}
+	nameCfg.renames = makeRenameRules(log, group.RowRenameRules)
 	return nameCfg
 }
 
+// makeRenameRules compiles the rules, skipping any invalid patterns.
+func makeRenameRules(log logrus.FieldLogger, rules []*configpb.TestGroup_RowRenameRule) []renameRule {
+	var out []renameRule
+	for _, r := range rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			log.WithError(err).WithField("regex", r.Pattern).Warning("Ignoring bad row rename regex")
+			continue
+		}
+		out = append(out, renameRule{re: re, replacement: r.Replacement})
+	}
+	return out
+}
+
 func firstFilled(strs ...string) string {
 	for _, s := range strs {
 		if s != "" {
@@ -387,6 +607,27 @@ func ensureJobName(nc *nameConfig) {
 	nc.parts = append([]string{jobName}, nc.parts...)
 }
 
+// readBuild reads the build's result with the group's parser, from inside its archive when the group has one.
+func readBuild(ctx context.Context, client gcs.Downloader, group *configpb.TestGroup, build gcs.Build) (*gcsResult, error) {
+	parser, err := resultParser(group.ResultParser)
+	if err != nil {
+		return nil, err
+	}
+	build.Parser = parser
+	if group.ResultArchive == "" {
+		return readResult(ctx, client, build)
+	}
+	archive, err := build.Path.ResolveReference(&url.URL{Path: group.ResultArchive})
+	if err != nil {
+		return nil, fmt.Errorf("resolve archive: %w", err)
+	}
+	files, err := gcs.ReadArchive(ctx, client, *archive, build.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read archive %s: %w", archive, err)
+	}
+	return readResult(ctx, files, build)
+}
+
 // readResult will download all GCS artifacts in parallel.
 //
 // Specifically download the following files:
@@ -17,13 +17,18 @@ limitations under the License.
 package updater
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"net/url"
 	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,6 +42,8 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/testing/protocmp"
 	core "k8s.io/api/core/v1"
 )
@@ -98,6 +105,23 @@ func makeJunit(passed, failed []string) string {
 	return xmlData(suite)
 }
 
+func makeTar(files map[string]string) string {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			panic(err)
+		}
+		if _, err := tw.Write([]byte(data)); err != nil {
+			panic(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
 func pint64(n int64) *int64 {
 	return &n
 }
@@ -203,6 +227,7 @@ func TestHintStarted(t *testing.T) {
 }
 
 func TestReadColumns(t *testing.T) {
+	RegisterResultParser("targets", parseTargets)
 	now := time.Now().Unix()
 	yes := true
 	var no bool
@@ -292,6 +317,257 @@ func TestReadColumns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "drop builds without a started time",
+			builds: []fakeBuild{
+				{
+					id: "1600000020",
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 30),
+							Passed:    &yes,
+						}),
+					},
+				},
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "10",
+						Hint:    "10",
+						Started: float64(now+10) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+			},
+		},
+		{
+			name: "only read builds with matching metadata",
+			builds: []fakeBuild{
+				{
+					id: "40",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 40}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 50),
+							Passed:    &yes,
+						}),
+					},
+				},
+				{
+					id: "30",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 30}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 40),
+							Passed:    &yes,
+							Metadata:  metadata.Metadata{"cloud": "aws", "release-blocking": "true"},
+						}),
+					},
+				},
+				{
+					id: "20",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 20}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 30),
+							Passed:    &yes,
+							Metadata:  metadata.Metadata{"cloud": "gce", "release-blocking": true},
+						}),
+					},
+				},
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+							Metadata:  metadata.Metadata{"cloud": "gce"},
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				BuildMetadataFilters: []*configpb.TestGroup_BuildMetadataFilter{
+					{Key: "cloud", Value: "gce"},
+					{Key: "release-blocking"},
+				},
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "20",
+						Hint:    "20",
+						Started: float64(now+20) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+			},
+		},
+		{
+			name: "derive the started time from the build id",
+			builds: []fakeBuild{
+				{
+					id: "1600000020",
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(1600000050),
+							Passed:    &yes,
+						}),
+					},
+				},
+				{
+					id: "not-a-time",
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(1600000040),
+							Passed:    &yes,
+						}),
+					},
+				},
+				{
+					id: "20200913-122640",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(1600000030),
+							Passed:    &yes,
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix:      "bucket/path/to/build/",
+				MissingStarted: configpb.TestGroup_MISSING_STARTED_BUILD_ID,
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "1600000020",
+						Hint:    "1600000020",
+						Started: 1600000020 * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 30 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "20200913-122640",
+						Hint:    "20200913-122640",
+						Started: 1600000000 * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 30 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate builds become one column",
+			builds: []fakeBuild{
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+						}),
+					},
+				},
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "10",
+						Hint:    "10",
+						Started: float64(now+10) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+			},
+		},
 		{
 			name: "column headers processed correctly",
 			builds: []fakeBuild{
@@ -426,8 +702,237 @@ func TestReadColumns(t *testing.T) {
 						},
 					},
 				},
-			},
-			expected: []InflatedColumn{
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "10",
+						Hint:    "10",
+						Started: float64(now+10) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoPassCell,
+						"name good - context context-a - thread 33": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"name bad - context context-a - thread 33": {
+							Result:  statuspb.TestStatus_FAIL,
+							Icon:    "F",
+							Message: "bad",
+						},
+						"name good - context context-b - thread 44": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"name bad - context context-b - thread 44": {
+							Result:  statuspb.TestStatus_FAIL,
+							Icon:    "F",
+							Message: "bad",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "read results from an archive",
+			builds: []fakeBuild{
+				{
+					id: "10",
+					artifacts: map[string]fakeObject{
+						"results.tar": {
+							Data: makeTar(map[string]string{
+								"started.json": jsonData(metadata.Started{Timestamp: now + 10}),
+								"finished.json": jsonData(metadata.Finished{
+									Timestamp: pint64(now + 20),
+									Passed:    &yes,
+								}),
+								"artifacts/junit_01.xml": makeJunit([]string{"good"}, []string{"bad"}),
+							}),
+						},
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix:     "bucket/path/to/build/",
+				ResultArchive: "results.tar",
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "10",
+						Hint:    "10",
+						Started: float64(now+10) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+						"good": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"bad": {
+							Result:  statuspb.TestStatus_FAIL,
+							Icon:    "F",
+							Message: "bad",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "read results with a custom parser",
+			builds: []fakeBuild{
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &no,
+						}),
+					},
+					artifacts: map[string]fakeObject{
+						"artifacts/junit_targets.xml": {
+							Data: `<targets>
+								<target name="//foo:good" status="PASSED" duration="120">
+									<metric name="memory" value="12"/>
+									<metric name="memory" value="14"/>
+								</target>
+								<target name="//foo:bad" status="FAILED" duration="60">
+									<message>boom</message>
+								</target>
+							</targets>`,
+						},
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix:    "bucket/path/to/build/",
+				ResultParser: "targets",
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "10",
+						Hint:    "10",
+						Started: float64(now+10) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_FAIL,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+						"//foo:good": {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								ElapsedKey: 2,
+								"memory":   13,
+							},
+						},
+						"//foo:bad": {
+							Result:  statuspb.TestStatus_FAIL,
+							Icon:    "F",
+							Message: "boom",
+							Metrics: map[string]float64{
+								ElapsedKey: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown result parser returns error",
+			builds: []fakeBuild{
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix:    "bucket/path/to/build/",
+				ResultParser: "missing",
+			},
+			err: true,
+		},
+		{
+			name: "stop columns at max",
+			max:  2,
+			builds: []fakeBuild{
+				{
+					id: "12",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 12}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 24),
+							Passed:    &yes,
+						}),
+					},
+				},
+				{
+					id: "11",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 11}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 22),
+							Passed:    &yes,
+						}),
+					},
+					podInfo: podInfoSuccess,
+				},
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 10}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "11",
+						Hint:    "11",
+						Started: float64(now+11) * 1000,
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 11 / 60.0,
+							},
+						},
+						podInfoRow: podInfoPassCell,
+					},
+				},
 				{
 					Column: &statepb.Column{
 						Build:   "10",
@@ -441,29 +946,13 @@ func TestReadColumns(t *testing.T) {
 								"test-duration-minutes": 10 / 60.0,
 							},
 						},
-						podInfoRow: podInfoPassCell,
-						"name good - context context-a - thread 33": {
-							Result: statuspb.TestStatus_PASS,
-						},
-						"name bad - context context-a - thread 33": {
-							Result:  statuspb.TestStatus_FAIL,
-							Icon:    "F",
-							Message: "bad",
-						},
-						"name good - context context-b - thread 44": {
-							Result: statuspb.TestStatus_PASS,
-						},
-						"name bad - context context-b - thread 44": {
-							Result:  statuspb.TestStatus_FAIL,
-							Icon:    "F",
-							Message: "bad",
-						},
+						podInfoRow: podInfoMissingCell,
 					},
 				},
 			},
 		},
 		{
-			name: "stop columns at max",
+			name: "keep the newest columns at max",
 			max:  2,
 			builds: []fakeBuild{
 				{
@@ -505,39 +994,40 @@ func TestReadColumns(t *testing.T) {
 				},
 			},
 			group: configpb.TestGroup{
-				GcsPrefix: "bucket/path/to/build/",
+				GcsPrefix:   "bucket/path/to/build/",
+				ColumnLimit: configpb.TestGroup_COLUMN_LIMIT_NEWEST,
 			},
 			expected: []InflatedColumn{
 				{
 					Column: &statepb.Column{
-						Build:   "11",
-						Hint:    "11",
-						Started: float64(now+11) * 1000,
+						Build:   "12",
+						Hint:    "12",
+						Started: float64(now+12) * 1000,
 					},
 					Cells: map[string]cell{
 						overallRow: {
 							Result: statuspb.TestStatus_PASS,
 							Metrics: map[string]float64{
-								"test-duration-minutes": 11 / 60.0,
+								"test-duration-minutes": 12 / 60.0,
 							},
 						},
-						podInfoRow: podInfoPassCell,
+						podInfoRow: podInfoMissingCell,
 					},
 				},
 				{
 					Column: &statepb.Column{
-						Build:   "10",
-						Hint:    "10",
-						Started: float64(now+10) * 1000,
+						Build:   "11",
+						Hint:    "11",
+						Started: float64(now+11) * 1000,
 					},
 					Cells: map[string]cell{
 						overallRow: {
 							Result: statuspb.TestStatus_PASS,
 							Metrics: map[string]float64{
-								"test-duration-minutes": 10 / 60.0,
+								"test-duration-minutes": 11 / 60.0,
 							},
 						},
-						podInfoRow: podInfoMissingCell,
+						podInfoRow: podInfoPassCell,
 					},
 				},
 			},
@@ -1078,6 +1568,402 @@ func TestReadColumns(t *testing.T) {
 	}
 }
 
+func TestColumnCap(t *testing.T) {
+	now := time.Now().Unix()
+	stop := time.Unix(now-100, 0)
+	// Builds 65 through 6 started within the window, and 5 through 1 before it.
+	// Reading stops after the first build before the window.
+	var builds []fakeBuild
+	for i := 65; i > 0; i-- {
+		started := now - int64(i)
+		if i <= 5 {
+			started = now - 1000 - int64(i)
+		}
+		builds = append(builds, fakeBuild{
+			id:       strconv.Itoa(i),
+			started:  jsonStarted(started),
+			finished: jsonFinished(started+1, true, nil),
+			podInfo:  podInfoSuccess,
+		})
+	}
+	buildRange := func(newest, oldest int) []string {
+		var out []string
+		for i := newest; i >= oldest; i-- {
+			out = append(out, strconv.Itoa(i))
+		}
+		return out
+	}
+
+	cases := []struct {
+		name     string
+		group    configpb.TestGroup
+		expected []string
+	}{
+		{
+			name: "cap columns by count",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			expected: buildRange(50, 5),
+		},
+		{
+			name: "cap columns by time",
+			group: configpb.TestGroup{
+				GcsPrefix:        "bucket/path/to/build/",
+				CapColumnsByTime: true,
+			},
+			expected: buildRange(65, 5),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := newPathOrDie("gs://" + tc.group.GcsPrefix)
+			client := fakeClient{
+				Lister: fake.Lister{},
+				Opener: fake.Opener{},
+			}
+			listed := addBuilds(&client, path, builds...)
+			cols, err := readColumns(context.Background(), client, &tc.group, listed, stop, columnCap(&tc.group), time.Minute, 1)
+			if err != nil {
+				t.Fatalf("readColumns() got unexpected error: %v", err)
+			}
+			var actual []string
+			for _, col := range cols {
+				actual = append(actual, col.Column.Build)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("readColumns() got unexpected builds (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if got, want := columnCap(&configpb.TestGroup{CapColumnsByTime: true}), maxTimeCappedCols; got != want {
+		t.Errorf("columnCap() got %d, want a ceiling of %d", got, want)
+	}
+}
+
+func TestLimitBuilds(t *testing.T) {
+	builds := func(ids ...string) []gcs.Build {
+		var out []gcs.Build
+		for _, id := range ids {
+			out = append(out, gcs.Build{Path: newPathOrDie("gs://bucket/job/" + id + "/")})
+		}
+		return out
+	}
+	cases := []struct {
+		name     string
+		builds   []gcs.Build
+		max      int
+		limit    configpb.TestGroup_ColumnLimit
+		expected []gcs.Build
+	}{
+		{
+			name: "basically works",
+			max:  2,
+		},
+		{
+			name:     "keep everything under the limit",
+			builds:   builds("3", "2", "1"),
+			max:      3,
+			limit:    configpb.TestGroup_COLUMN_LIMIT_NEWEST,
+			expected: builds("3", "2", "1"),
+		},
+		{
+			name:     "keep the oldest by default",
+			builds:   builds("4", "3", "2", "1"),
+			max:      2,
+			expected: builds("2", "1"),
+		},
+		{
+			name:     "keep the newest",
+			builds:   builds("4", "3", "2", "1"),
+			max:      2,
+			limit:    configpb.TestGroup_COLUMN_LIMIT_NEWEST,
+			expected: builds("4", "3"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := limitBuilds(tc.builds, tc.max, tc.limit)
+			if diff := cmp.Diff(tc.expected, actual, cmp.AllowUnexported(gcs.Build{}, gcs.Path{})); diff != "" {
+				t.Errorf("limitBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMatchBuildMetadata(t *testing.T) {
+	meta := metadata.Metadata{
+		"cloud":            "gce",
+		"release-blocking": true,
+		"empty":            "",
+	}
+	cases := []struct {
+		name     string
+		filters  []*configpb.TestGroup_BuildMetadataFilter
+		meta     metadata.Metadata
+		expected bool
+	}{
+		{
+			name:     "basically works",
+			expected: true,
+		},
+		{
+			name:     "equal value",
+			filters:  []*configpb.TestGroup_BuildMetadataFilter{{Key: "cloud", Value: "gce"}},
+			meta:     meta,
+			expected: true,
+		},
+		{
+			name:    "different value",
+			filters: []*configpb.TestGroup_BuildMetadataFilter{{Key: "cloud", Value: "aws"}},
+			meta:    meta,
+		},
+		{
+			name:    "value is not a string",
+			filters: []*configpb.TestGroup_BuildMetadataFilter{{Key: "release-blocking", Value: "true"}},
+			meta:    meta,
+		},
+		{
+			name: "present keys",
+			filters: []*configpb.TestGroup_BuildMetadataFilter{
+				{Key: "release-blocking"},
+				{Key: "empty"},
+			},
+			meta:     meta,
+			expected: true,
+		},
+		{
+			name:    "missing key",
+			filters: []*configpb.TestGroup_BuildMetadataFilter{{Key: "missing"}},
+			meta:    meta,
+		},
+		{
+			name:    "missing metadata",
+			filters: []*configpb.TestGroup_BuildMetadataFilter{{Key: "cloud"}},
+		},
+		{
+			name: "match every filter",
+			filters: []*configpb.TestGroup_BuildMetadataFilter{
+				{Key: "cloud", Value: "gce"},
+				{Key: "missing"},
+			},
+			meta: meta,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := matchBuildMetadata(tc.filters, tc.meta); actual != tc.expected {
+				t.Errorf("matchBuildMetadata() got %t, want %t", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStartedFromID(t *testing.T) {
+	cases := []struct {
+		id       string
+		expected int64
+		ok       bool
+	}{
+		{
+			id: "",
+		},
+		{
+			id:       "1600000000",
+			expected: 1600000000,
+			ok:       true,
+		},
+		{
+			id:       "1600000000123",
+			expected: 1600000000,
+			ok:       true,
+		},
+		{
+			id:       "20200913-122640",
+			expected: 1600000000,
+			ok:       true,
+		},
+		{
+			id:       "20200913122640",
+			expected: 1600000000,
+			ok:       true,
+		},
+		{
+			id: "1234",
+		},
+		{
+			id: "1297893456739782659",
+		},
+		{
+			id: "hello",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.id, func(t *testing.T) {
+			actual, ok := startedFromID(tc.id)
+			if ok != tc.ok {
+				t.Fatalf("startedFromID(%q) got ok %t, want %t", tc.id, ok, tc.ok)
+			}
+			if actual != tc.expected {
+				t.Errorf("startedFromID(%q) got %d, want %d", tc.id, actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReadConcurrency(t *testing.T) {
+	now := time.Now().Unix()
+	cases := []struct {
+		name        string
+		group       configpb.TestGroup
+		concurrency int
+		expected    int
+	}{
+		{
+			name:        "default to the updater concurrency",
+			concurrency: 4,
+			expected:    4,
+		},
+		{
+			name: "group overrides the updater concurrency",
+			group: configpb.TestGroup{
+				ReadConcurrency: 20,
+			},
+			concurrency: 4,
+			expected:    20,
+		},
+		{
+			name: "group reads without an updater concurrency",
+			group: configpb.TestGroup{
+				ReadConcurrency: 2,
+			},
+			expected: 2,
+		},
+		{
+			name: "no readers",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := readConcurrency(&tc.group, tc.concurrency); actual != tc.expected {
+				t.Errorf("readConcurrency() got %d, want %d", actual, tc.expected)
+			}
+
+			tc.group.GcsPrefix = "bucket/path/to/build/"
+			path := newPathOrDie("gs://" + tc.group.GcsPrefix)
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fake.Lister{},
+					Opener: fake.Opener{},
+				},
+			}
+			builds := addBuilds(&client.Client, path, fakeBuild{
+				id:       "1",
+				started:  jsonStarted(now),
+				finished: jsonFinished(now+1, true, nil),
+				podInfo:  podInfoSuccess,
+			})
+			lister := fakeBuildLister{path: builds}
+			readCols := gcsColumnReader(client, lister, time.Minute, tc.concurrency, 0, "")
+			cols, err := readCols(context.Background(), logrus.WithField("name", tc.name), &tc.group, nil, time.Unix(now-100, 0))
+			switch {
+			case tc.expected == 0:
+				if err == nil {
+					t.Error("readCols() failed to return an error without readers")
+				}
+			case err != nil:
+				t.Errorf("readCols() got unexpected error: %v", err)
+			case len(cols) != 1:
+				t.Errorf("readCols() got %d columns, want 1", len(cols))
+			}
+		})
+	}
+}
+
+func TestDedupBuilds(t *testing.T) {
+	path := newPathOrDie("gs://bucket/builds/")
+	build := func(id string) gcs.Build {
+		return gcs.Build{Path: *resolveOrDie(&path, id+"/")}
+	}
+	col := func(id string, rows ...string) InflatedColumn {
+		cells := map[string]cell{}
+		for _, r := range rows {
+			cells[r] = cell{Result: statuspb.TestStatus_PASS}
+		}
+		return InflatedColumn{
+			Column: &statepb.Column{Build: id, Hint: strings.Join(rows, ",")},
+			Cells:  cells,
+		}
+	}
+	bad := errors.New("bad")
+	cases := []struct {
+		name         string
+		builds       []gcs.Build
+		cols         []InflatedColumn
+		errs         []error
+		expectedCols []InflatedColumn
+		expectedErrs []error
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:         "unique builds are unchanged",
+			builds:       []gcs.Build{build("2"), build("1")},
+			cols:         []InflatedColumn{col("2", "a"), col("1", "a")},
+			errs:         []error{nil, nil},
+			expectedCols: []InflatedColumn{col("2", "a"), col("1", "a")},
+			expectedErrs: []error{nil, nil},
+		},
+		{
+			name:         "keep the more complete read",
+			builds:       []gcs.Build{build("2"), build("1"), build("2")},
+			cols:         []InflatedColumn{col("2", "a", "b"), col("1", "a"), col("2", "a")},
+			errs:         []error{nil, nil, nil},
+			expectedCols: []InflatedColumn{col("2", "a", "b"), col("1", "a")},
+			expectedErrs: []error{nil, nil},
+		},
+		{
+			name:         "keep the successful read",
+			builds:       []gcs.Build{build("2"), build("2"), build("1")},
+			cols:         []InflatedColumn{{}, col("2", "a"), col("1", "a")},
+			errs:         []error{bad, nil, nil},
+			expectedCols: []InflatedColumn{col("2", "a"), col("1", "a")},
+			expectedErrs: []error{nil, nil},
+		},
+		{
+			name:         "ties keep the most recent read",
+			builds:       []gcs.Build{build("1"), build("1")},
+			cols:         []InflatedColumn{col("1", "old"), col("1", "new")},
+			errs:         []error{nil, nil},
+			expectedCols: []InflatedColumn{col("1", "new")},
+			expectedErrs: []error{nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			builds, cols, errs := dedupBuilds(tc.builds, tc.cols, tc.errs)
+			if len(builds) != len(cols) {
+				t.Errorf("dedupBuilds() returned %d builds for %d columns", len(builds), len(cols))
+			}
+			if diff := cmp.Diff(tc.expectedCols, cols, cmpopts.EquateEmpty(), protocmp.Transform()); diff != "" {
+				t.Errorf("dedupBuilds() got unexpected column diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedErrs, errs, cmpopts.EquateEmpty(), cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("dedupBuilds() got unexpected error diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRender(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -1086,6 +1972,7 @@ func TestRender(t *testing.T) {
 		job       string
 		test      string
 		metadatas []map[string]string
+		renames   []renameRule
 		expected  string
 	}{
 		{
@@ -1139,13 +2026,31 @@ func TestRender(t *testing.T) {
 			},
 			expected: "test: fancy, job: work, meta: yes",
 		},
+		{
+			name:   "rename rules apply in order",
+			format: "%s",
+			parts:  []string{testsName},
+			test:   "TestFoo/run-1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+			renames: []renameRule{
+				{
+					re:          regexp.MustCompile(`[0-9a-f]{8}(-[0-9a-f]{4}){3}-[0-9a-f]{12}`),
+					replacement: "UUID",
+				},
+				{
+					re:          regexp.MustCompile(`^Test(\w+)/`),
+					replacement: "${1}: ",
+				},
+			},
+			expected: "Foo: run-UUID",
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			nc := nameConfig{
-				format: tc.format,
-				parts:  tc.parts,
+				format:  tc.format,
+				parts:   tc.parts,
+				renames: tc.renames,
 			}
 			actual := nc.render(tc.job, tc.test, tc.metadatas...)
 			if actual != tc.expected {
@@ -1285,12 +2190,40 @@ func TestMakeNameConfig(t *testing.T) {
 				multiJob: true,
 			},
 		},
+		{
+			name: "compile rename rules",
+			group: &configpb.TestGroup{
+				RowRenameRules: []*configpb.TestGroup_RowRenameRule{
+					{
+						Pattern:     "-[0-9]+$",
+						Replacement: "",
+					},
+					{
+						Pattern: "[.*", // skipped
+					},
+					{
+						Pattern:     "^old",
+						Replacement: "new",
+					},
+				},
+			},
+			expected: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+				renames: []renameRule{
+					{re: regexp.MustCompile("-[0-9]+$")},
+					{re: regexp.MustCompile("^old"), replacement: "new"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := makeNameConfig(tc.group)
-			if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(nameConfig{})); diff != "" {
+			actual := makeNameConfig(logrus.WithField("name", tc.name), tc.group)
+			if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(nameConfig{}, renameRule{}), cmp.Comparer(func(x, y *regexp.Regexp) bool {
+				return x.String() == y.String()
+			})); diff != "" {
 				t.Errorf("makeNameConfig() got unexpected diff (-got +want):\n%s", diff)
 			}
 		})
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Readiness tracks the progress of Update, so a long-running updater can serve
+// liveness and readiness checks.
+//
+// A nil Readiness ignores progress.
+type Readiness struct {
+	lock        sync.Mutex
+	started     time.Time
+	lastSuccess time.Time
+	processing  map[string]time.Time
+	succeeded   int
+	failed      int
+}
+
+// NewReadiness returns a Readiness started at the current time.
+func NewReadiness() *Readiness {
+	return &Readiness{
+		started:    time.Now(),
+		processing: map[string]time.Time{},
+	}
+}
+
+// ReadinessStatus is the JSON payload served by Readiness.
+type ReadinessStatus struct {
+	// Ready is true once a group updated successfully.
+	Ready bool `json:"ready"`
+	// Started is when the updater started.
+	Started time.Time `json:"started"`
+	// LastSuccess is when a group most recently updated successfully.
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	// Processing lists the groups currently updating, sorted by name.
+	Processing []string `json:"processing"`
+	// Succeeded is the number of successful group updates.
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of failed group updates.
+	Failed int `json:"failed"`
+}
+
+// begin records that the group started updating.
+func (r *Readiness) begin(name string, when time.Time) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.processing[name] = when
+}
+
+// end records that the group finished updating, failing when err is set.
+func (r *Readiness) end(name string, when time.Time, err error) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.processing, name)
+	if err != nil {
+		r.failed++
+		return
+	}
+	r.succeeded++
+	r.lastSuccess = when
+}
+
+// Status returns the current progress.
+func (r *Readiness) Status() ReadinessStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	status := ReadinessStatus{
+		Ready:      !r.lastSuccess.IsZero(),
+		Started:    r.started.UTC(),
+		Processing: make([]string, 0, len(r.processing)),
+		Succeeded:  r.succeeded,
+		Failed:     r.failed,
+	}
+	if status.Ready {
+		when := r.lastSuccess.UTC()
+		status.LastSuccess = &when
+	}
+	for name := range r.processing {
+		status.Processing = append(status.Processing, name)
+	}
+	sort.Strings(status.Processing)
+	return status
+}
+
+// ServeHTTP writes the status as JSON, with a 503 until a group updates successfully.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status := r.Status()
+	buf, err := json.Marshal(status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(buf)
+}
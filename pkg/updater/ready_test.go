@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestReadiness(t *testing.T) {
+	started := time.Unix(1000, 0)
+	success := time.Unix(2000, 0).UTC()
+	cases := []struct {
+		name     string
+		progress func(*Readiness)
+		code     int
+		expected ReadinessStatus
+	}{
+		{
+			name: "not ready before any update",
+			code: http.StatusServiceUnavailable,
+			expected: ReadinessStatus{
+				Started:    started.UTC(),
+				Processing: []string{},
+			},
+		},
+		{
+			name: "list processing groups",
+			progress: func(r *Readiness) {
+				r.begin("world", started)
+				r.begin("hello", started)
+			},
+			code: http.StatusServiceUnavailable,
+			expected: ReadinessStatus{
+				Started:    started.UTC(),
+				Processing: []string{"hello", "world"},
+			},
+		},
+		{
+			name: "not ready after failures",
+			progress: func(r *Readiness) {
+				r.begin("hello", started)
+				r.end("hello", success, errors.New("boom"))
+			},
+			code: http.StatusServiceUnavailable,
+			expected: ReadinessStatus{
+				Started:    started.UTC(),
+				Processing: []string{},
+				Failed:     1,
+			},
+		},
+		{
+			name: "ready after a success",
+			progress: func(r *Readiness) {
+				r.begin("hello", started)
+				r.begin("world", started)
+				r.begin("fail", started)
+				r.end("fail", started, errors.New("boom"))
+				r.end("hello", success, nil)
+			},
+			code: http.StatusOK,
+			expected: ReadinessStatus{
+				Ready:       true,
+				Started:     started.UTC(),
+				LastSuccess: &success,
+				Processing:  []string{"world"},
+				Succeeded:   1,
+				Failed:      1,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ready := NewReadiness()
+			ready.started = started
+			if tc.progress != nil {
+				tc.progress(ready)
+			}
+			rec := httptest.NewRecorder()
+			ready.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+			if rec.Code != tc.code {
+				t.Errorf("ServeHTTP() got code %d, want %d", rec.Code, tc.code)
+			}
+			var actual ReadinessStatus
+			if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+				t.Fatalf("json.Unmarshal() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateReadiness(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "hello", GcsPrefix: "bucket/path/to/hello"},
+			{Name: "world", GcsPrefix: "bucket/path/to/world"},
+			{Name: "boom", GcsPrefix: "bucket/path/to/boom"},
+		},
+	}
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fakeLister{},
+			Opener: fakeOpener{},
+		},
+	}
+	updateGroup := func(_ context.Context, _ logrus.FieldLogger, _ gcs.Client, tg *configpb.TestGroup, _ gcs.Path) error {
+		if tg.Name == "boom" {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	ready := NewReadiness()
+	before := time.Now().Add(-time.Second)
+	if err := Update(context.Background(), client, nil, configPath, StaticConfig(cfg), "", nil, 1, 0, nil, updateGroup, false, 0, 0, nil, false, ready); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+
+	actual := ready.Status()
+	if !actual.Ready {
+		t.Error("Update() failed to become ready")
+	}
+	if actual.LastSuccess == nil || actual.LastSuccess.Before(before) {
+		t.Errorf("Update() last succeeded at %v, want after %v", actual.LastSuccess, before)
+	}
+	if len(actual.Processing) > 0 {
+		t.Errorf("Update() still processing %v", actual.Processing)
+	}
+	if actual.Succeeded != 2 || actual.Failed != 1 {
+		t.Errorf("Update() got %d successes and %d failures, want 2 and 1", actual.Succeeded, actual.Failed)
+	}
+}
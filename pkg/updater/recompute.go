@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// RecomputeAlerts replaces the alert of each row in the grid using new thresholds.
+//
+// Only the AlertInfo of rows changes, which allows tuning thresholds
+// without reading builds again.
+func RecomputeAlerts(grid *statepb.Grid, failsOpen, passesClose int) {
+	failsOpen, passesClose = resolveAlertThresholds(failsOpen, passesClose)
+	withRowMessages(grid, func() {
+		alertRows(grid.Columns, grid.Rows, failsOpen, passesClose, 0, 0, false, configpb.TestGroup_FLAKY_ALERT_RESET, nil, nil, buildID)
+	})
+}
+
+// RecomputeGroupAlerts returns a GroupUpdater which only recomputes the alerts of an existing grid.
+//
+// Downloads the grid, replaces its alerts according to the current group
+// configuration and then uploads it, without reading any builds.
+// Alerting rows include the number of open bugs from bugs when it is non-nil.
+func RecomputeGroupAlerts(groupTimeout time.Duration, write bool, bugs BugCounter, limiter *UploadLimiter) GroupUpdater {
+	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		if !tg.UseKubernetesClient {
+			log.Debug("Skipping non-kubernetes client group")
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(parent, groupTimeout)
+		defer cancel()
+		return recomputeGroupAlerts(ctx, log, client, tg, gridPath, write, bugs, limiter)
+	}
+}
+
+func recomputeGroupAlerts(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, write bool, bugs BugCounter, limiter *UploadLimiter) error {
+	grid, _, err := gcs.DownloadGrid(ctx, client, gridPath)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+		log.Debug("Skipping missing grid")
+		return nil
+	case err != nil:
+		return fmt.Errorf("download: %w", err)
+	case len(grid.Columns) == 0:
+		log.Debug("Skipping empty grid")
+		return nil
+	}
+
+	var old *statepb.Grid
+	if tg.AlertCooldownMinutes > 0 {
+		old = &statepb.Grid{Rows: make([]*statepb.Row, 0, len(grid.Rows))}
+		for _, row := range grid.Rows {
+			old.Rows = append(old.Rows, &statepb.Row{
+				Name:            row.Name,
+				AlertInfo:       row.AlertInfo,
+				LastAlertClosed: row.LastAlertClosed,
+			})
+		}
+	}
+
+	withRowMessages(grid, func() {
+		alertGrid(log, tg, grid, bugs)
+	})
+	finishAlerts(tg, old, grid)
+	if tg.RecordUpdateTime {
+		stampUpdated(grid, gridClock())
+	}
+
+	buf, err := gcs.MarshalGrid(grid, gridCodec(tg))
+	if err != nil {
+		return fmt.Errorf("marshal grid: %w", err)
+	}
+	hash, err := gcs.HashGrid(grid)
+	if err != nil {
+		return fmt.Errorf("hash grid: %w", err)
+	}
+	log = log.WithField("url", gridPath).WithField("sha256", hash).WithField("bytes", len(buf))
+	if !write {
+		log.Debug("Skipping write")
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("wait to upload: %w", err)
+	}
+	meta, err := gridMetadata(tg, hash)
+	if err != nil {
+		return err
+	}
+	if err := writeGrid(ctx, client, gridPath, bytes.NewReader(buf), gridACL(tg), meta); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	log.Info("Wrote recomputed alerts")
+	return nil
+}
+
+// withRowMessages calls f after temporarily expanding the interned messages of each row.
+//
+// Alerts read the message of the failing cell, whereas the grid must
+// otherwise remain unchanged.
+func withRowMessages(grid *statepb.Grid, f func()) {
+	var expanded []*statepb.Row
+	for _, row := range grid.Rows {
+		if len(row.MessageIndices) == 0 || len(row.Messages) > 0 {
+			continue
+		}
+		row.Messages = make([]string, len(row.MessageIndices))
+		for i, idx := range row.MessageIndices {
+			if idx >= 0 && int(idx) < len(grid.MessageTable) {
+				row.Messages[i] = grid.MessageTable[idx]
+			}
+		}
+		expanded = append(expanded, row)
+	}
+	defer func() {
+		for _, row := range expanded {
+			row.Messages = nil
+		}
+	}()
+	f()
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// alertingGrid returns a grid whose alerts opened after three failures.
+func alertingGrid() *statepb.Grid {
+	fail := func(msg string) cell {
+		return cell{Result: statuspb.TestStatus_FAIL, Message: msg, Icon: "F"}
+	}
+	pass := cell{Result: statuspb.TestStatus_PASS}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "4", Started: 4000},
+			{Build: "3", Started: 3000},
+			{Build: "2", Started: 2000},
+			{Build: "1", Started: 1000},
+		},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "bad", Id: "bad"}, fail("boom"), fail("boom"), fail("bang"), fail("bang")),
+			setupRow(&statepb.Row{Name: "broken", Id: "broken"}, fail("ugh"), fail("ugh"), pass, pass),
+			setupRow(&statepb.Row{Name: "good", Id: "good"}, pass, pass, pass, pass),
+		},
+	}
+	alertRows(grid.Columns, grid.Rows, 3, 1, 0, 0, false, configpb.TestGroup_FLAKY_ALERT_RESET, nil, nil, buildID)
+	return grid
+}
+
+// alertingRows returns the name and failure message of each alerting row.
+func alertingRows(grid *statepb.Grid) map[string]string {
+	out := map[string]string{}
+	for _, row := range grid.Rows {
+		if row.AlertInfo != nil {
+			out[row.Name] = row.AlertInfo.FailureMessage
+		}
+	}
+	return out
+}
+
+// withoutAlerts returns a copy of the grid without any alerts.
+func withoutAlerts(grid *statepb.Grid) *statepb.Grid {
+	out := proto.Clone(grid).(*statepb.Grid)
+	for _, row := range out.Rows {
+		row.AlertInfo = nil
+	}
+	return out
+}
+
+func TestRecomputeAlerts(t *testing.T) {
+	cases := []struct {
+		name        string
+		failsOpen   int
+		passesClose int
+		intern      bool
+		expected    map[string]string
+	}{
+		{
+			name:      "same thresholds",
+			failsOpen: 3,
+			expected: map[string]string{
+				"bad": "boom",
+			},
+		},
+		{
+			name:      "fewer failures opens more alerts",
+			failsOpen: 2,
+			expected: map[string]string{
+				"bad":    "boom",
+				"broken": "ugh",
+			},
+		},
+		{
+			name:      "more failures closes alerts",
+			failsOpen: 5,
+			expected:  map[string]string{},
+		},
+		{
+			name:      "interned messages",
+			failsOpen: 2,
+			intern:    true,
+			expected: map[string]string{
+				"bad":    "boom",
+				"broken": "ugh",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := alertingGrid()
+			if tc.intern {
+				gcs.InternMessages(grid)
+			}
+			original := proto.Clone(grid).(*statepb.Grid)
+			RecomputeAlerts(grid, tc.failsOpen, tc.passesClose)
+			if diff := cmp.Diff(tc.expected, alertingRows(grid)); diff != "" {
+				t.Errorf("RecomputeAlerts() got unexpected alerts (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(withoutAlerts(original), withoutAlerts(grid), protocmp.Transform()); diff != "" {
+				t.Errorf("RecomputeAlerts() changed more than alerts (-was +now):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecomputeGroupAlerts(t *testing.T) {
+	path := newPathOrDie("gs://bucket/grid/group")
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		missing  bool
+		write    bool
+		expected map[string]string
+	}{
+		{
+			name: "recompute and upload",
+			group: &configpb.TestGroup{
+				UseKubernetesClient: true,
+				NumFailuresToAlert:  2,
+			},
+			write: true,
+			expected: map[string]string{
+				"bad":    "boom",
+				"broken": "ugh",
+			},
+		},
+		{
+			name: "skip write",
+			group: &configpb.TestGroup{
+				UseKubernetesClient: true,
+				NumFailuresToAlert:  2,
+			},
+		},
+		{
+			name: "skip non-kubernetes groups",
+			group: &configpb.TestGroup{
+				NumFailuresToAlert: 2,
+			},
+			write: true,
+		},
+		{
+			name: "skip missing grids",
+			group: &configpb.TestGroup{
+				UseKubernetesClient: true,
+				NumFailuresToAlert:  2,
+			},
+			missing: true,
+			write:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := alertingGrid()
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			if !tc.missing {
+				client.Opener[path] = fakeObject{Data: string(mustGrid(original))}
+			}
+
+			updateGroup := RecomputeGroupAlerts(time.Minute, tc.write, nil, nil)
+			if err := updateGroup(context.Background(), logrus.WithField("name", tc.name), client, tc.group, path); err != nil {
+				t.Fatalf("RecomputeGroupAlerts() got unexpected error: %v", err)
+			}
+
+			up, ok := client.Uploader[path]
+			if tc.expected == nil {
+				if ok {
+					t.Fatal("RecomputeGroupAlerts() unexpectedly uploaded a grid")
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("RecomputeGroupAlerts() failed to upload the grid")
+			}
+			grid, err := gcs.UnmarshalGrid(up.Buf)
+			if err != nil {
+				t.Fatalf("gcs.UnmarshalGrid() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, alertingRows(grid)); diff != "" {
+				t.Errorf("RecomputeGroupAlerts() got unexpected alerts (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(withoutAlerts(original), withoutAlerts(grid), protocmp.Transform()); diff != "" {
+				t.Errorf("RecomputeGroupAlerts() changed more than alerts (-was +now):\n%s", diff)
+			}
+			if diff := cmp.Diff(mustHashMeta(tc.group, grid), up.Metadata); diff != "" {
+				t.Errorf("RecomputeGroupAlerts() got unexpected metadata (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecomputeGroupAlertsCooldownGroups(t *testing.T) {
+	path := newPathOrDie("gs://bucket/grid/group")
+	fail := cell{Result: statuspb.TestStatus_FAIL, Message: "boom", Icon: "F"}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "3", Started: 3000},
+			{Build: "2", Started: 2000},
+			{Build: "1", Started: 1000},
+		},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "cooling", Id: "cooling"}, fail, fail, fail),
+			setupRow(&statepb.Row{Name: "first", Id: "first"}, fail, fail, fail),
+			setupRow(&statepb.Row{Name: "second", Id: "second"}, fail, fail, fail),
+		},
+	}
+	alertRows(grid.Columns, grid.Rows, 3, 1, 0, 0, false, configpb.TestGroup_FLAKY_ALERT_RESET, nil, nil, buildID)
+	grid.Rows[0].AlertInfo = nil
+	grid.Rows[0].LastAlertClosed = 2500
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fakeLister{},
+			Opener: fakeOpener{
+				path: {Data: string(mustGrid(grid))},
+			},
+		},
+	}
+	group := &configpb.TestGroup{
+		UseKubernetesClient:  true,
+		NumFailuresToAlert:   3,
+		AlertCooldownMinutes: 60,
+		AlertGroupMinRows:    2,
+	}
+
+	updateGroup := RecomputeGroupAlerts(time.Minute, true, nil, nil)
+	if err := updateGroup(context.Background(), logrus.WithField("name", "cooldown"), client, group, path); err != nil {
+		t.Fatalf("RecomputeGroupAlerts() got unexpected error: %v", err)
+	}
+	actual, err := gcs.UnmarshalGrid(client.Uploader[path].Buf)
+	if err != nil {
+		t.Fatalf("gcs.UnmarshalGrid() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"first": "boom", "second": "boom"}, alertingRows(actual)); diff != "" {
+		t.Errorf("RecomputeGroupAlerts() got unexpected alerts (-want +got):\n%s", diff)
+	}
+	if len(actual.AlertGroups) != 1 {
+		t.Fatalf("RecomputeGroupAlerts() got %d alert groups, want 1", len(actual.AlertGroups))
+	}
+	if diff := cmp.Diff([]string{"first", "second"}, actual.AlertGroups[0].Rows); diff != "" {
+		t.Errorf("RecomputeGroupAlerts() got unexpected alert group rows (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// statusSuffix is appended to the grid path of each group to locate its status.
+const statusSuffix = ".status.json"
+
+// GroupStatus describes the latest update of a group, for health monitoring.
+type GroupStatus struct {
+	// Group is the name of the group.
+	Group string `json:"group"`
+	// Updated is when the update finished.
+	Updated time.Time `json:"updated"`
+	// Builds is the number of unique builds in the grid.
+	Builds int `json:"builds"`
+	// Columns is the number of columns in the grid.
+	Columns int `json:"columns"`
+	// Rows is the number of rows in the grid.
+	Rows int `json:"rows"`
+	// Alerts is the number of rows with an open alert.
+	Alerts int `json:"alerts"`
+	// SHA256 is the stable digest of the uncompressed grid, see gcs.HashGrid.
+	//
+	// Equal grids have equal digests, so callers may use it as a cache key.
+	SHA256 string `json:"sha256,omitempty"`
+	// Error describes why the update failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// groupStatus summarizes the grid of a group, or the error updating it.
+func groupStatus(name string, grid *statepb.Grid, when time.Time, err error) (GroupStatus, error) {
+	status := GroupStatus{
+		Group:   name,
+		Updated: when.UTC(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status, nil
+	}
+	if grid == nil {
+		return status, nil
+	}
+	hash, err := gcs.HashGrid(grid)
+	if err != nil {
+		return status, fmt.Errorf("hash grid: %w", err)
+	}
+	status.SHA256 = hash
+	builds := map[string]bool{}
+	for _, col := range grid.Columns {
+		builds[col.Build] = true
+	}
+	status.Builds = len(builds)
+	status.Columns = len(grid.Columns)
+	status.Rows = len(grid.Rows)
+	for _, row := range grid.Rows {
+		if row.AlertInfo != nil {
+			status.Alerts++
+		}
+	}
+	return status, nil
+}
+
+// statusPath returns the path of the status of the group with this grid, such as gs://bucket/grid/foo.status.json.
+func statusPath(gridPath gcs.Path) (*gcs.Path, error) {
+	u := url.URL{Path: path.Base(gridPath.Object()) + statusSuffix}
+	p, err := gridPath.ResolveReference(&u)
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	return p, nil
+}
+
+// writeGroupStatus uploads the status of the group with this grid.
+//
+// Reads the grid to summarize it unless the update failed.
+func writeGroupStatus(ctx context.Context, client gcs.Client, gridPath gcs.Path, name string, when time.Time, updateErr error) error {
+	var grid *statepb.Grid
+	if updateErr == nil {
+		var err error
+		if grid, _, err = gcs.DownloadGrid(ctx, client, gridPath); err != nil {
+			return fmt.Errorf("download grid: %w", err)
+		}
+	}
+	status, err := groupStatus(name, grid, when, updateErr)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	p, err := statusPath(gridPath)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Upload(ctx, *p, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
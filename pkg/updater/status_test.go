@@ -0,0 +1,274 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestGroupStatus(t *testing.T) {
+	when := time.Unix(1000, 0)
+	alert := &statepb.AlertInfo{FailCount: 3}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Name: "first"},
+			{Build: "2", Name: "second"},
+			{Build: "1"},
+		},
+		Rows: []*statepb.Row{
+			{Name: overallRow},
+			{Name: "hello", AlertInfo: alert},
+			{Name: "world", AlertInfo: alert},
+		},
+	}
+	hash, err := gcs.HashGrid(grid)
+	if err != nil {
+		t.Fatalf("HashGrid() got unexpected error: %v", err)
+	}
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		err      error
+		expected GroupStatus
+	}{
+		{
+			name: "basically works",
+			expected: GroupStatus{
+				Group:   "hello",
+				Updated: when.UTC(),
+			},
+		},
+		{
+			name: "summarize the grid",
+			grid: grid,
+			expected: GroupStatus{
+				Group:   "hello",
+				Updated: when.UTC(),
+				Builds:  2,
+				Columns: 3,
+				Rows:    3,
+				Alerts:  2,
+				SHA256:  hash,
+			},
+		},
+		{
+			name: "describe the error",
+			err:  errors.New("boom"),
+			expected: GroupStatus{
+				Group:   "hello",
+				Updated: when.UTC(),
+				Error:   "boom",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := groupStatus("hello", tc.grid, when, tc.err)
+			if err != nil {
+				t.Fatalf("groupStatus() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("groupStatus() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupStatusDigest(t *testing.T) {
+	when := time.Unix(1000, 0)
+	// Build equal grids with maps populated in a different order.
+	makeGrid := func(keys ...string) *statepb.Grid {
+		col := &statepb.Column{Build: "1", Annotations: map[string]string{}}
+		alert := &statepb.AlertInfo{Properties: map[string]string{}}
+		row := &statepb.Row{Name: "hello", AlertInfo: alert}
+		for _, k := range keys {
+			col.Annotations[k] = "note on " + k
+			alert.Properties[k] = "value of " + k
+		}
+		return &statepb.Grid{
+			Columns: []*statepb.Column{col},
+			Rows:    []*statepb.Row{row},
+		}
+	}
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	reversed := make([]string, len(keys))
+	for i, k := range keys {
+		reversed[len(keys)-1-i] = k
+	}
+
+	first, err := groupStatus("hello", makeGrid(keys...), when, nil)
+	if err != nil {
+		t.Fatalf("groupStatus() got unexpected error: %v", err)
+	}
+	if first.SHA256 == "" {
+		t.Fatal("groupStatus() failed to digest the grid")
+	}
+	for i := 0; i < 10; i++ {
+		same, err := groupStatus("hello", makeGrid(reversed...), when, nil)
+		if err != nil {
+			t.Fatalf("groupStatus() got unexpected error: %v", err)
+		}
+		if same.SHA256 != first.SHA256 {
+			t.Fatalf("groupStatus() got digest %s for an equal grid, want %s", same.SHA256, first.SHA256)
+		}
+	}
+	different, err := groupStatus("hello", makeGrid(keys[1:]...), when, nil)
+	if err != nil {
+		t.Fatalf("groupStatus() got unexpected error: %v", err)
+	}
+	if different.SHA256 == first.SHA256 {
+		t.Errorf("groupStatus() got the same digest %s for a different grid", different.SHA256)
+	}
+}
+
+func TestStatusPath(t *testing.T) {
+	cases := []struct {
+		name     string
+		grid     gcs.Path
+		expected gcs.Path
+	}{
+		{
+			name:     "basically works",
+			grid:     newPathOrDie("gs://bucket/grid/hello"),
+			expected: newPathOrDie("gs://bucket/grid/hello.status.json"),
+		},
+		{
+			name:     "top-level grid",
+			grid:     newPathOrDie("gs://bucket/hello"),
+			expected: newPathOrDie("gs://bucket/hello.status.json"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := statusPath(tc.grid)
+			if err != nil {
+				t.Fatalf("statusPath() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, *actual, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+				t.Errorf("statusPath() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateWritesStatus(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	gridPath := newPathOrDie("gs://bucket/path/to/hello")
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "fine"},
+			{Name: "broken", AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+		},
+	}
+	hash, err := gcs.HashGrid(grid)
+	if err != nil {
+		t.Fatalf("HashGrid() got unexpected error: %v", err)
+	}
+	cases := []struct {
+		name     string
+		err      error
+		write    bool
+		expected *GroupStatus
+	}{
+		{
+			name:  "successful update",
+			write: true,
+			expected: &GroupStatus{
+				Group:   "hello",
+				Builds:  2,
+				Columns: 2,
+				Rows:    2,
+				Alerts:  1,
+				SHA256:  hash,
+			},
+		},
+		{
+			name:  "failed update",
+			err:   errors.New("boom"),
+			write: true,
+			expected: &GroupStatus{
+				Group: "hello",
+				Error: "boom",
+			},
+		},
+		{
+			name: "skip write",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "hello", GcsPrefix: "bucket/path/to/job"},
+				},
+			}
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			client.Opener[gridPath] = fakeObject{Data: string(mustGrid(grid))}
+			updateGroup := func(context.Context, logrus.FieldLogger, gcs.Client, *configpb.TestGroup, gcs.Path) error {
+				return tc.err
+			}
+			before := time.Now().Add(-time.Second)
+			if err := Update(context.Background(), client, nil, configPath, StaticConfig(cfg), "", nil, 1, 0, nil, updateGroup, tc.write, 0, 0, nil, true, nil); err != nil {
+				t.Fatalf("Update() got unexpected error: %v", err)
+			}
+
+			up, ok := client.Uploader[newPathOrDie("gs://bucket/path/to/hello.status.json")]
+			if tc.expected == nil {
+				if ok {
+					t.Fatal("Update() unexpectedly wrote a status")
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("Update() failed to write a status")
+			}
+			var actual GroupStatus
+			if err := json.Unmarshal(up.Buf, &actual); err != nil {
+				t.Fatalf("json.Unmarshal() got unexpected error: %v", err)
+			}
+			if actual.Updated.Before(before) {
+				t.Errorf("Update() wrote a status updated at %v, want after %v", actual.Updated, before)
+			}
+			if diff := cmp.Diff(*tc.expected, actual, cmpopts.IgnoreFields(GroupStatus{}, "Updated")); diff != "" {
+				t.Errorf("Update() wrote an unexpected status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,29 +18,47 @@ limitations under the License.
 package updater
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"cloud.google.com/go/storage"
 	"github.com/GoogleCloudPlatform/testgrid/config"
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 	"github.com/GoogleCloudPlatform/testgrid/util/metrics"
 	"github.com/fvbommel/sortorder"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	protov2 "google.golang.org/protobuf/proto"
 )
 
 const componentName = "updater"
@@ -111,8 +129,20 @@ func (mets *Metrics) delay(dur time.Duration) {
 // the proto to GCS.
 type GroupUpdater func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error
 
+// A BugCounter returns the number of open bugs associated with a row.
+type BugCounter func(id, name string) int32
+
 // GCS returns a GCS-based GroupUpdater, which knows how to process result data stored in GCS.
-func GCS(groupTimeout, buildTimeout time.Duration, concurrency int, write bool, sortCols ColumnSorter) GroupUpdater {
+//
+// Announces each written grid to publisher when it is non-nil.
+// Alerting rows include the number of open bugs from bugs when it is non-nil.
+// Uploads wait for the limiter, which every group shares.
+// Sends the alerts which opened or resolved to notifier when it is non-nil.
+// Only reads builds at or after afterBuildID when set, see InflateDropAppend.
+// Compresses grids at compressionLevel, see gcs.ValidateCompressionLevel.
+// Finds builds with lister, defaulting to listing them in GCS when nil.
+// Keeps at most maxOpen readers open at once for each group when non-zero.
+func GCS(groupTimeout, buildTimeout time.Duration, concurrency, maxOpen int, write bool, sortCols ColumnSorter, publisher Publisher, verify bool, bugs BugCounter, limiter *UploadLimiter, notifier AlertNotifier, afterBuildID string, compressionLevel int, lister BuildLister) GroupUpdater {
 	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
 		if !tg.UseKubernetesClient {
 			log.Debug("Skipping non-kubernetes client group")
@@ -120,9 +150,9 @@ func GCS(groupTimeout, buildTimeout time.Duration, concurrency int, write bool,
 		}
 		ctx, cancel := context.WithTimeout(parent, groupTimeout)
 		defer cancel()
-		gcsColReader := gcsColumnReader(client, buildTimeout, concurrency)
+		gcsColReader := gcsColumnReader(client, lister, buildTimeout, concurrency, maxOpen, afterBuildID)
 		reprocess := 20 * time.Minute // allow 20m for prow to finish uploading artifacts
-		return InflateDropAppend(ctx, log, client, tg, gridPath, write, gcsColReader, sortCols, reprocess)
+		return InflateDropAppend(ctx, log, client, tg, gridPath, write, gcsColReader, sortCols, reprocess, publisher, verify, bugs, limiter, notifier, afterBuildID, compressionLevel)
 	}
 }
 
@@ -149,7 +179,7 @@ func lockGroup(ctx context.Context, client gcs.ConditionalClient, path gcs.Path,
 	if generation == 0 {
 		var grid statepb.Grid
 		var err error
-		if buf, err = gcs.MarshalGrid(&grid); err != nil {
+		if buf, err = gcs.MarshalGrid(&grid, gcs.Zlib); err != nil {
 			return nil, fmt.Errorf("marshal: %w", err)
 		}
 	}
@@ -168,51 +198,256 @@ func isPreconditionFailed(err error) bool {
 	return e.Code == http.StatusPreconditionFailed
 }
 
-func update(ctx context.Context, client gcs.ConditionalClient, log logrus.FieldLogger, tg *configpb.TestGroup, tgp gcs.Path, updateGroup GroupUpdater, write bool, gen int64, fin *finish) error {
+// groupLogger returns a logger for the group, which logs at the log_level of the group when set.
+//
+// Other groups continue to log at the level of the shared logger.
+func groupLogger(log logrus.FieldLogger, tg *configpb.TestGroup) logrus.FieldLogger {
+	if tg.LogLevel == "" {
+		return log
+	}
+	entry, ok := log.(*logrus.Entry)
+	if !ok {
+		return log
+	}
+	level, err := logrus.ParseLevel(tg.LogLevel)
+	if err != nil {
+		log.WithError(err).Warning("Ignoring bad log level")
+		return log
+	}
+	base := entry.Logger
+	logger := &logrus.Logger{
+		Out:          base.Out,
+		Hooks:        base.Hooks,
+		Formatter:    base.Formatter,
+		ReportCaller: base.ReportCaller,
+		Level:        level,
+		ExitFunc:     base.ExitFunc,
+	}
+	return logrus.NewEntry(logger).WithFields(entry.Data)
+}
+
+// update the group once, returning true when another updater holds the group lock.
+func update(ctx context.Context, client gcs.ConditionalClient, log logrus.FieldLogger, tg *configpb.TestGroup, tgp gcs.Path, replicas []gcs.Path, updateGroup GroupUpdater, write, status bool, gen int64) (bool, error) {
 	log.Debug("Starting update")
+	copier := client // Replicas do not share the generation of the primary grid.
 	if write && gen >= 0 {
 		if attrs, err := lockGroup(ctx, client, tgp, gen); err != nil {
 			if !isPreconditionFailed(err) {
-				fin.fail()
-				return fmt.Errorf("lock: %v", err)
+				return false, fmt.Errorf("lock: %v", err)
 			}
-			fin.skip()
-			return nil
+			return true, nil
 		} else if gen := attrs.Generation; gen > 0 {
 			cond := storage.Conditions{GenerationMatch: gen}
 			client = client.If(&cond, &cond)
 		}
 		log.Debug("Acquired update lock")
 	}
-	if err := updateGroup(ctx, log, client, tg, tgp); err != nil {
-		fin.fail()
-		return err
+	updateErr := updateGroup(ctx, log, client, tg, tgp)
+	if write && status {
+		if err := writeGroupStatus(ctx, copier, tgp, tg.Name, time.Now(), updateErr); err != nil {
+			log.WithError(err).Warning("Failed to write status")
+		}
+	}
+	if updateErr != nil {
+		return false, updateErr
+	}
+	if write && len(replicas) > 0 {
+		if err := replicate(ctx, copier, tgp, replicas); err != nil {
+			return false, fmt.Errorf("replicate: %w", err)
+		}
+		log.WithField("replicas", len(replicas)).Debug("Replicated grid")
+	}
+	return false, nil
+}
+
+// replicate copies the grid to each replica in parallel, returning any errors together.
+func replicate(ctx context.Context, client gcs.Copier, from gcs.Path, replicas []gcs.Path) error {
+	errCh := make(chan error, len(replicas))
+	for _, to := range replicas {
+		go func(to gcs.Path) {
+			if _, err := client.Copy(ctx, from, to); err != nil {
+				errCh <- fmt.Errorf("%s: %w", to, err)
+				return
+			}
+			errCh <- nil
+		}(to)
+	}
+	var errs []string
+	for range replicas {
+		if err := <-errCh; err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if n := len(errs); n > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("failed to copy to %d replicas: %s", n, strings.Join(errs, ", "))
 	}
-	fin.success()
 	return nil
 }
 
+// replicaPaths returns the path of the group's grid under each replica gs://bucket/prefix.
+func replicaPaths(replicas []gcs.Path, groupName string) ([]gcs.Path, error) {
+	out := make([]gcs.Path, 0, len(replicas))
+	for _, replica := range replicas {
+		u := replica.URL()
+		u.Path = path.Join("/", u.Path, groupName)
+		var p gcs.Path
+		if err := p.SetURL(&u); err != nil {
+			return nil, fmt.Errorf("replica %s: %w", replica, err)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
 type testGroupClient interface {
 	gcs.Opener
 	gcs.Stater
 }
 
-func updateTestGroups(ctx context.Context, client testGroupClient, q *config.TestGroupQueue, configPath gcs.Path, gridPrefix string, groupNames []string, freq time.Duration) (int64, map[string]int64, error) {
+// ConfigSource provides the configuration of the groups to update.
+type ConfigSource interface {
+	// ReadConfig returns the configuration and its generation.
+	//
+	// The opener may include preconditions, in which case an unchanged
+	// configuration returns a precondition failure.
+	ReadConfig(ctx context.Context, log logrus.FieldLogger, opener gcs.Opener) (*configpb.Configuration, int64, error)
+}
+
+// GCSConfig reads the configuration at path, caching it to the local cachePath when set.
+//
+// See readConfig.
+func GCSConfig(path gcs.Path, cachePath string) ConfigSource {
+	return gcsConfig{path: path, cachePath: cachePath}
+}
+
+type gcsConfig struct {
+	path      gcs.Path
+	cachePath string
+}
+
+func (gc gcsConfig) ReadConfig(ctx context.Context, log logrus.FieldLogger, opener gcs.Opener) (*configpb.Configuration, int64, error) {
+	return readConfig(ctx, log, opener, gc.path, gc.cachePath)
+}
+
+// FileConfig reads the configuration from a local path, such as during testing.
+func FileConfig(path string) ConfigSource {
+	return fileConfig(path)
+}
+
+type fileConfig string
+
+func (fc fileConfig) ReadConfig(_ context.Context, _ logrus.FieldLogger, _ gcs.Opener) (*configpb.Configuration, int64, error) {
+	cfg, err := config.ReadPath(string(fc))
+	if err != nil {
+		return nil, 0, fmt.Errorf("read %s: %w", fc, err)
+	}
+	return cfg, 0, nil
+}
+
+// StaticConfig provides a configuration already in memory.
+func StaticConfig(cfg *configpb.Configuration) ConfigSource {
+	return staticConfig{cfg}
+}
+
+type staticConfig struct {
+	cfg *configpb.Configuration
+}
+
+func (sc staticConfig) ReadConfig(_ context.Context, _ logrus.FieldLogger, _ gcs.Opener) (*configpb.Configuration, int64, error) {
+	return sc.cfg, 0, nil
+}
+
+// OverlayConfig merges the configuration of each source, where later sources override earlier ones.
+//
+// See config.Overlay. The merged configuration has no generation, so it is reread each time.
+func OverlayConfig(sources ...ConfigSource) ConfigSource {
+	return overlayConfig(sources)
+}
+
+type overlayConfig []ConfigSource
+
+func (oc overlayConfig) ReadConfig(ctx context.Context, log logrus.FieldLogger, opener gcs.Opener) (*configpb.Configuration, int64, error) {
+	cfgs := make([]*configpb.Configuration, 0, len(oc))
+	for i, source := range oc {
+		cfg, _, err := source.ReadConfig(ctx, log, opener)
+		if err != nil {
+			return nil, 0, fmt.Errorf("overlay %d: %w", i, err)
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return config.Overlay(cfgs...), 0, nil
+}
+
+// readConfig reads the configuration at configPath, returning its generation.
+//
+// When cachePath is set, saves each successful read to this local file and
+// falls back to this last-known-good configuration when a fresh read fails.
+// Unchanged configurations (failed preconditions) never use the cache.
+func readConfig(ctx context.Context, log logrus.FieldLogger, client gcs.Opener, configPath gcs.Path, cachePath string) (*configpb.Configuration, int64, error) {
+	cfg, buf, gen, err := openConfig(ctx, client, configPath)
+	switch {
+	case err == nil:
+		if cachePath != "" {
+			if err := writeConfigCache(buf, cachePath); err != nil {
+				log.WithError(err).WithField("cache", cachePath).Warning("Failed to cache config")
+			}
+		}
+		return cfg, gen, nil
+	case cachePath == "" || isPreconditionFailed(err):
+		return nil, 0, err
+	}
+	cached, cacheErr := config.ReadPath(cachePath)
+	if cacheErr != nil {
+		log.WithError(cacheErr).WithField("cache", cachePath).Warning("Failed to read cached config")
+		return nil, 0, err
+	}
+	log.WithError(err).WithField("cache", cachePath).Warning("FAILED TO READ CONFIG, USING LAST-KNOWN-GOOD CACHED CONFIG")
+	return cached, 0, nil
+}
+
+// openConfig returns the parsed config along with its serialized bytes and generation.
+func openConfig(ctx context.Context, client gcs.Opener, configPath gcs.Path) (*configpb.Configuration, []byte, int64, error) {
 	r, attrs, err := client.Open(ctx, configPath)
 	if err != nil {
 		if !isPreconditionFailed(err) {
 			err = fmt.Errorf("read: %v", err)
 		}
-		return 0, nil, err
+		return nil, nil, 0, err
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("read: %v", err)
 	}
-	cfg, err := config.Unmarshal(r)
+	cfg, err := config.Unmarshal(bytes.NewReader(buf))
 	if err != nil {
-		return 0, nil, fmt.Errorf("unmarshal: %v", err)
+		return nil, nil, 0, fmt.Errorf("unmarshal: %v", err)
 	}
 	var configGen int64
 	if attrs != nil {
 		configGen = attrs.Generation
 	}
+	return cfg, buf, configGen, nil
+}
+
+// writeConfigCache atomically replaces the cached config at path.
+func writeConfigCache(buf []byte, path string) error {
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf, 0644); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+	return nil
+}
+
+func updateTestGroups(ctx context.Context, log logrus.FieldLogger, client testGroupClient, q *config.TestGroupQueue, source ConfigSource, configPath gcs.Path, gridPrefix string, groupNames []string, freq time.Duration) (int64, map[string]int64, error) {
+	cfg, configGen, err := source.ReadConfig(ctx, log, client)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	var groups []*configpb.TestGroup
 	if len(groupNames) != 0 { // Just specific groups
@@ -226,6 +461,10 @@ func updateTestGroups(ctx context.Context, client testGroupClient, q *config.Tes
 	} else { // All groups
 		groups = cfg.TestGroups
 	}
+	groups = applySilences(log, groups, cfg.AlertSilences, time.Now())
+	if groups, err = resolveAliases(groups, cfg.TestGroups); err != nil {
+		return 0, nil, fmt.Errorf("resolve aliases: %w", err)
+	}
 
 	generations := make(map[string]int64, len(groups))
 
@@ -257,23 +496,164 @@ func updateTestGroups(ctx context.Context, client testGroupClient, q *config.Tes
 	return configGen, generations, nil
 }
 
+// resolveAliases reads the builds of each alias group from the gcs_prefix of the group it aliases.
+//
+// Aliases may refer to other aliases, which must not form a cycle.
+// Returns a copy of each alias group, leaving the configuration unchanged.
+func resolveAliases(groups, all []*configpb.TestGroup) ([]*configpb.TestGroup, error) {
+	byName := make(map[string]*configpb.TestGroup, len(all))
+	for _, tg := range all {
+		byName[tg.Name] = tg
+	}
+	out := make([]*configpb.TestGroup, 0, len(groups))
+	for _, tg := range groups {
+		if tg.GcsPrefix != "" || tg.AliasOf == "" {
+			out = append(out, tg)
+			continue
+		}
+		seen := map[string]bool{tg.Name: true}
+		target := tg
+		for target.GcsPrefix == "" {
+			name := target.AliasOf
+			if name == "" {
+				return nil, fmt.Errorf("%s: %s has neither a gcs_prefix nor alias_of", tg.Name, target.Name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("%s: alias cycle at %s", tg.Name, name)
+			}
+			seen[name] = true
+			if target = byName[name]; target == nil {
+				return nil, fmt.Errorf("%s: alias of unknown group %s", tg.Name, name)
+			}
+		}
+		tg = proto.Clone(tg).(*configpb.TestGroup)
+		tg.GcsPrefix = target.GcsPrefix
+		out = append(out, tg)
+	}
+	return out, nil
+}
+
+// applySilences silences the alerts of groups matching any active silence.
+//
+// Returns a copy of each silenced group, leaving the configuration unchanged.
+func applySilences(log logrus.FieldLogger, groups []*configpb.TestGroup, silences []*configpb.AlertSilence, now time.Time) []*configpb.TestGroup {
+	type silence struct {
+		re    *regexp.Regexp
+		until int64
+	}
+	var active []silence
+	for _, s := range silences {
+		if s.Until <= now.Unix() {
+			continue
+		}
+		re, err := regexp.Compile(s.GroupPattern)
+		if err != nil {
+			log.WithError(err).WithField("regex", s.GroupPattern).Warning("Ignoring bad silence group pattern")
+			continue
+		}
+		active = append(active, silence{re, s.Until})
+	}
+	if len(active) == 0 {
+		return groups
+	}
+
+	out := make([]*configpb.TestGroup, 0, len(groups))
+	for _, tg := range groups {
+		until := tg.SilenceAlertsUntil
+		for _, s := range active {
+			if s.until > until && s.re.MatchString(tg.Name) {
+				until = s.until
+			}
+		}
+		if until != tg.SilenceAlertsUntil {
+			tg = proto.Clone(tg).(*configpb.TestGroup)
+			tg.SilenceAlertsUntil = until
+		}
+		out = append(out, tg)
+	}
+	return out
+}
+
 // Update test groups with the specified freq.
 //
 // Filters down to a single group when set.
 // Returns after all groups updated once if freq is zero.
-func Update(parent context.Context, client gcs.ConditionalClient, mets *Metrics, configPath gcs.Path, gridPrefix string, groupConcurrency int, groupNames []string, updateGroup GroupUpdater, write bool, freq time.Duration) error {
+//
+// Reads the configuration from source, or from configPath when nil.
+// Grids are stored relative to configPath, see testGroupPath.
+// Each written grid is also copied to gs://bucket/prefix/group for each of the
+// replicas, which may be in other buckets.
+//
+// Writes a GroupStatus of each group to <grid>.status.json when writeStatus is set,
+// including failed updates.
+//
+// Retries groups which fail with a transient error up to groupRetries times,
+// with exponential backoff. See isTransient.
+//
+// Logs with the run and trace ids of the context, generating a run id when unset.
+//
+// Writes a health grid to healthPath when set, where each row is a group and each
+// column is a run which updated every group once. This reads each grid after its update.
+//
+// Stops sending groups to update after runTimeout when set, allowing in-flight
+// updates to finish and returning without error.
+//
+// Records the progress of each group in ready when set, see Readiness.
+func Update(parent context.Context, client gcs.ConditionalClient, mets *Metrics, configPath gcs.Path, source ConfigSource, gridPrefix string, replicas []gcs.Path, groupConcurrency, groupRetries int, groupNames []string, updateGroup GroupUpdater, write bool, freq, runTimeout time.Duration, healthPath *gcs.Path, writeStatus bool, ready *Readiness) error {
+	if util.RunID(parent) == "" {
+		parent = util.WithRunID(parent, uuid.New().String())
+	}
 	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
-	log := logrus.WithField("config", configPath)
+	log := util.Logger(ctx).WithField("config", configPath)
+	if source == nil {
+		source = GCSConfig(configPath, "")
+	}
 
 	var q config.TestGroupQueue
 
-	gen, generations, err := updateTestGroups(ctx, client, &q, configPath, gridPrefix, groupNames, freq)
+	gen, generations, err := updateTestGroups(ctx, log, client, &q, source, configPath, gridPrefix, groupNames, freq)
 	if err != nil {
 		return err
 	}
+	var health *healthAggregator
+	if healthPath != nil {
+		health = newHealthAggregator()
+		health.expect(groupNamesOf(generations))
+	}
+	recordHealth := func(log logrus.FieldLogger, name string, c Cell) {
+		if health == nil {
+			return
+		}
+		results := health.record(name, c)
+		if results == nil || !write {
+			return
+		}
+		if err := writeHealth(ctx, log, client, *healthPath, time.Now(), results); err != nil {
+			log.WithError(err).WithField("path", healthPath).Error("Failed to write health grid")
+		}
+	}
+	reportHealth := func(log logrus.FieldLogger, name string, tgp gcs.Path, updateErr error) {
+		if health == nil {
+			return
+		}
+		c := failedHealth(updateErr)
+		if updateErr == nil {
+			grid, _, err := gcs.DownloadGrid(ctx, client, tgp)
+			if err != nil {
+				c = failedHealth(fmt.Errorf("read grid: %w", err))
+			} else {
+				c = groupHealth(grid)
+			}
+		}
+		recordHealth(log, name, c)
+	}
 	var lock sync.RWMutex
 	var wg sync.WaitGroup
+	var processed int64
+	defer func() {
+		log.WithField("processed", atomic.LoadInt64(&processed)).Info("Finished updating groups")
+	}()
 	wg.Add(groupConcurrency)
 	defer wg.Wait()
 	channel := make(chan *configpb.TestGroup) // TODO(fejta): pass into this function to allow multi-writers
@@ -283,11 +663,19 @@ func Update(parent context.Context, client gcs.ConditionalClient, mets *Metrics,
 			defer wg.Done()
 			for tg := range channel {
 				fin := mets.start()
-				log := log.WithField("group", tg.Name)
-				tgp, err := testGroupPath(configPath, gridPrefix, tg.Name)
+				log := groupLogger(log.WithField("group", tg.Name), tg)
+				tgp, err := resolveGroupPath(configPath, gridPrefix, tg.Name)
 				if err != nil {
 					fin.fail()
 					log.WithError(err).Error("Bad path")
+					recordHealth(log, tg.Name, failedHealth(err))
+					continue
+				}
+				reps, err := replicaPaths(replicas, tg.Name)
+				if err != nil {
+					fin.fail()
+					log.WithError(err).Error("Bad replica path")
+					recordHealth(log, tg.Name, failedHealth(err))
 					continue
 				}
 				lock.RLock()
@@ -296,7 +684,31 @@ func Update(parent context.Context, client gcs.ConditionalClient, mets *Metrics,
 				if !ok {
 					gen = -1
 				}
-				if err := update(ctx, client, log, tg, *tgp, updateGroup, write, gen, fin); err != nil {
+				ready.begin(tg.Name, time.Now())
+				var skipped bool
+				err = retryTransient(ctx, log, groupRetries, func(attempt int) error {
+					if attempt > 0 && write && gen >= 0 {
+						// The failed attempt may have locked the group.
+						if attrs, err := client.Stat(ctx, *tgp); err == nil {
+							gen = attrs.Generation
+						}
+					}
+					var err error
+					skipped, err = update(ctx, client, log, tg, *tgp, reps, updateGroup, write, writeStatus, gen)
+					return err
+				})
+				switch {
+				case err != nil:
+					fin.fail()
+				case skipped:
+					fin.skip()
+				default:
+					fin.success()
+				}
+				atomic.AddInt64(&processed, 1)
+				ready.end(tg.Name, time.Now(), err)
+				reportHealth(log, tg.Name, *tgp, err)
+				if err != nil {
 					log.WithError(err).Error("Error updating group")
 					continue
 				}
@@ -333,18 +745,88 @@ func Update(parent context.Context, client gcs.ConditionalClient, mets *Metrics,
 				ticker.Stop()
 				return
 			case <-ticker.C:
-				if gen, _, err := updateTestGroups(ctx, client, &q, configPath, gridPrefix, groupNames, freq); err != nil {
+				if gen, generations, err := updateTestGroups(ctx, log, client, &q, source, configPath, gridPrefix, groupNames, freq); err != nil {
 					log.WithError(err).Error("Failed to update configuration")
 				} else {
 					cond.GenerationNotMatch = gen
+					if health != nil && generations != nil {
+						health.expect(groupNamesOf(generations))
+					}
 				}
 			}
 		}
 	}()
 
-	return q.Send(ctx, channel, freq)
+	sendCtx := ctx
+	if runTimeout > 0 {
+		var cancelSend context.CancelFunc
+		sendCtx, cancelSend = context.WithTimeout(ctx, runTimeout)
+		defer cancelSend()
+	}
+	err = q.Send(sendCtx, channel, freq)
+	if runTimeout > 0 && err == context.DeadlineExceeded && ctx.Err() == nil {
+		log.WithField("timeout", runTimeout).Warning("Run timed out, waiting for in-flight groups")
+		return nil
+	}
+	return err
+}
+
+// groupRetryBackoff is the delay before the first retry of a group, which doubles after each retry.
+var groupRetryBackoff = time.Second
+
+// retryTransient calls f until it succeeds, fails with a permanent error or
+// has retried retries times, returning the last error.
+func retryTransient(ctx context.Context, log logrus.FieldLogger, retries int, f func(attempt int) error) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			delay := groupRetryBackoff << (attempt - 1)
+			log.WithError(err).WithField("delay", delay).Warning("Retrying transient failure")
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w (after %v)", ctx.Err(), err)
+			case <-time.After(delay):
+			}
+		}
+		if err = f(attempt); err == nil || !isTransient(err) {
+			return err
+		}
+	}
+	if retries == 0 {
+		return err
+	}
+	return fmt.Errorf("%d attempts: %w", retries+1, err)
+}
+
+// isTransient returns true for errors which may succeed when retried.
+//
+// These include rate limiting and server errors from the storage API,
+// as well as network timeouts. Other errors, such as invalid configuration,
+// are permanent.
+func isTransient(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= 500
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// groupNamesOf returns the group names keying the map.
+func groupNamesOf(generations map[string]int64) []string {
+	names := make([]string, 0, len(generations))
+	for name := range generations {
+		names = append(names, name)
+	}
+	return names
 }
 
+// resolveGroupPath returns the grid path of each group sent to a worker, which tests may override.
+var resolveGroupPath = testGroupPath
+
 // testGroupPath() returns the path to a test_group proto given this proto
 func testGroupPath(g gcs.Path, gridPrefix, groupName string) (*gcs.Path, error) {
 	name := path.Join(gridPrefix, groupName)
@@ -451,7 +933,10 @@ func growMaxUpdateArea() {
 	updateAreaLock.Unlock()
 }
 
-func truncateBuilds(log logrus.FieldLogger, builds []gcs.Build, cols []InflatedColumn) []gcs.Build {
+// truncateBuilds limits the builds to the columns which fit in the update area, given the rows of the existing columns.
+//
+// Keeps the oldest builds unless the group limits columns to the newest.
+func truncateBuilds(log logrus.FieldLogger, builds []gcs.Build, cols []InflatedColumn, limit configpb.TestGroup_ColumnLimit) []gcs.Build {
 	// determine the average number of rows per column
 	var rows int
 	for _, c := range cols {
@@ -481,23 +966,101 @@ func truncateBuilds(log logrus.FieldLogger, builds []gcs.Build, cols []InflatedC
 			"delayed": n - nCols,
 			"old":     len(cols),
 		}).Info("Truncated update")
-		return builds[n-nCols:]
+		return limitBuilds(builds, nCols, limit)
 	}
 	return builds
 }
 
-func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...gcs.Path) ([]gcs.Build, error) {
+// A BuildLister finds the builds of a group, such as from a database or index rather than GCS.
+type BuildLister interface {
+	// ListBuilds returns the builds under path, sorted in monotonically decreasing order.
+	//
+	// Only returns builds after offset when it is non-nil, see gcs.ListBuilds.
+	ListBuilds(ctx context.Context, path gcs.Path, offset *gcs.Path) ([]gcs.Build, error)
+}
+
+// GCSBuildLister lists the builds stored in GCS, which is the default BuildLister.
+type GCSBuildLister struct {
+	gcs.Lister
+}
+
+// ListBuilds returns the builds under path, see gcs.ListBuilds.
+func (l GCSBuildLister) ListBuilds(ctx context.Context, path gcs.Path, offset *gcs.Path) ([]gcs.Build, error) {
+	return gcs.ListBuilds(ctx, l.Lister, path, offset)
+}
+
+// buildPageSize is the number of objects GCSBuildLister lists at a time, which is the most GCS returns at once.
+const buildPageSize = 1000
+
+// StreamBuilds returns an iterator which lists the builds under path a page at a time, see gcs.StreamBuilds.
+func (l GCSBuildLister) StreamBuilds(ctx context.Context, path gcs.Path, offset *gcs.Path) BuildIterator {
+	return gcs.StreamBuilds(ctx, l.Lister, path, offset, buildPageSize)
+}
+
+// A BuildIterator yields builds in ascending lexical order, which is the order GCS lists them.
+//
+// Next returns iterator.Done after the last build.
+type BuildIterator interface {
+	Next() (gcs.Build, error)
+}
+
+// A BuildStreamer is a BuildLister which also yields builds one at a time,
+// so updates which only need the oldest new builds can stop listing early.
+type BuildStreamer interface {
+	BuildLister
+	// StreamBuilds returns an iterator over the builds under path after offset.
+	StreamBuilds(ctx context.Context, path gcs.Path, offset *gcs.Path) BuildIterator
+}
+
+// sinceOffset returns the path of the since build under tgPath, or nil when since is empty.
+func sinceOffset(tgPath gcs.Path, since string) (*gcs.Path, error) {
+	if since == "" {
+		return nil, nil
+	}
+	offset, err := tgPath.ResolveReference(&url.URL{Path: since})
+	if err != nil {
+		return nil, fmt.Errorf("resolve since: %w", err)
+	}
+	return offset, nil
+}
+
+// streamBuilds returns the oldest max builds after since which started inside the window, newest first.
+//
+// Stops listing once it has enough builds. Builds whose ids encode a time
+// before stop are outside the window and do not count, see startedFromID.
+func streamBuilds(ctx context.Context, streamer BuildStreamer, since string, tgPath gcs.Path, max int, stop time.Time) ([]gcs.Build, error) {
+	offset, err := sinceOffset(tgPath, since)
+	if err != nil {
+		return nil, err
+	}
+	it := streamer.StreamBuilds(ctx, tgPath, offset)
+	var out []gcs.Build
+	for len(out) < max {
+		b, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tgPath, err)
+		}
+		if when, ok := startedFromID(path.Base(b.Path.Object())); ok && when < stop.Unix() {
+			continue
+		}
+		out = append(out, b)
+	}
+	gcs.Sort(out)
+	return out, nil
+}
+
+func listBuilds(ctx context.Context, lister BuildLister, since, after string, paths ...gcs.Path) ([]gcs.Build, error) {
 	var out []gcs.Build
 
 	for idx, tgPath := range paths {
-		var offset *gcs.Path
-		var err error
-		if since != "" {
-			if offset, err = tgPath.ResolveReference(&url.URL{Path: since}); err != nil {
-				return nil, fmt.Errorf("resolve since: %w", err)
-			}
+		offset, err := sinceOffset(tgPath, since)
+		if err != nil {
+			return nil, err
 		}
-		builds, err := gcs.ListBuilds(ctx, client, tgPath, offset)
+		builds, err := lister.ListBuilds(ctx, tgPath, offset)
 		if err != nil {
 			return nil, fmt.Errorf("%d: %s: %w", idx, tgPath, err)
 		}
@@ -508,9 +1071,46 @@ func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...g
 		gcs.Sort(out)
 	}
 
+	if after != "" {
+		out = buildsAfter(out, after)
+	}
+
 	return out, nil
 }
 
+// ListGroupBuilds returns the builds of the group, without reading them or updating its grid.
+//
+// Lists the same gcs_prefix paths as an update, in the same order.
+func ListGroupBuilds(ctx context.Context, client gcs.Lister, tg *configpb.TestGroup) ([]gcs.Build, error) {
+	tgPaths, err := groupPaths(tg)
+	if err != nil {
+		return nil, fmt.Errorf("group path: %w", err)
+	}
+	return listBuilds(ctx, GCSBuildLister{client}, "", "", tgPaths...)
+}
+
+// buildsAfter returns the builds at or after the build id, preserving their order.
+func buildsAfter(builds []gcs.Build, id string) []gcs.Build {
+	out := make([]gcs.Build, 0, len(builds))
+	for _, b := range builds {
+		if !sortorder.NaturalLess(b.Build(), id) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// columnsBefore returns the columns of builds before the build id, preserving their order.
+func columnsBefore(cols []InflatedColumn, id string) []InflatedColumn {
+	out := make([]InflatedColumn, 0, len(cols))
+	for _, c := range cols {
+		if sortorder.NaturalLess(c.Column.Hint, id) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
 // A ColumnReader will find, process and return new columns to insert into the front of grid state.
 type ColumnReader func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []InflatedColumn, stop time.Time) ([]InflatedColumn, error)
 
@@ -525,7 +1125,22 @@ func SortStarted(_ *configpb.TestGroup, cols []InflatedColumn) {
 }
 
 // InflateDropAppend updates groups by downloading the existing grid, dropping old rows and appending new ones.
-func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, write bool, readCols ColumnReader, sortCols ColumnSorter, reprocess time.Duration) error {
+//
+// After a successful write, re-downloads and verifies the grid when verify is set,
+// and then publishes a GridEvent when publisher is non-nil.
+//
+// When afterBuildID is set, keeps every existing column before that build
+// rather than reprocessing recent and running ones, since readCols only
+// returns builds at or after it.
+//
+// Compresses the grid at compressionLevel, see gcs.ValidateCompressionLevel.
+//
+// Leaves the existing grid untouched when reading finds fewer than MinBuilds builds,
+// not counting the columns kept from the existing grid.
+//
+// Logs when the existing grid was written under a different config,
+// discarding its columns when the group sets RebuildOnConfigChange.
+func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, write bool, readCols ColumnReader, sortCols ColumnSorter, reprocess time.Duration, publisher Publisher, verify bool, bugs BugCounter, limiter *UploadLimiter, notifier AlertNotifier, afterBuildID string, compressionLevel int) error {
 	var dur time.Duration
 	if tg.DaysOfResults > 0 {
 		dur = days(float64(tg.DaysOfResults))
@@ -543,7 +1158,30 @@ func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.C
 	if err != nil {
 		log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
 	}
-	if old != nil {
+	var base *statepb.Grid
+	if tg.WriteGridDelta && old != nil {
+		base = proto.Clone(old).(*statepb.Grid) // Inflating the grid modifies it.
+	}
+	var rebuild bool
+	if old != nil && len(old.Columns) > 0 {
+		drifted, err := configDrift(ctx, client, gridPath, tg)
+		switch {
+		case err != nil:
+			log.WithError(err).Warning("Failed to compare the grid config")
+		case drifted && tg.RebuildOnConfigChange && afterBuildID == "":
+			log.Info("Rebuilding grid written under a different config")
+			rebuild = true
+		case drifted:
+			log.Info("Grid was written under a different config")
+		}
+	}
+	if old != nil && !rebuild && afterBuildID != "" {
+		var cols []InflatedColumn
+		forever := time.Unix(math.MaxInt64>>1, 0)
+		cols, issues = InflateGrid(old, stop, forever)
+		SortStarted(tg, cols)
+		oldCols = columnsBefore(cols, afterBuildID)
+	} else if old != nil && !rebuild {
 		var cols []InflatedColumn
 		cols, issues = InflateGrid(old, stop, time.Now().Add(-reprocess))
 		SortStarted(tg, cols) // Our processing requires descending start time.
@@ -556,25 +1194,81 @@ func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.C
 	}
 
 	overrideBuild(tg, cols)
+	if n := len(cols); n < int(tg.MinBuilds) {
+		log.WithFields(logrus.Fields{
+			"builds":     n,
+			"min-builds": tg.MinBuilds,
+		}).Warning("Too few builds, preserving the existing grid")
+		return nil
+	}
 	cols = append(cols, oldCols...)
+	checkColumnOrder(log, tg, cols)
 	cols = groupColumns(tg, cols)
+	if tg.PreserveColumnAnnotations {
+		carryAnnotations(old, cols)
+	}
 
 	sortCols(tg, cols)
 
-	grid := ConstructGrid(log, tg, cols, issues)
-	buf, err := gcs.MarshalGrid(grid)
+	grid := ConstructGrid(log, tg, cols, issues, bugs)
+	finishAlerts(tg, old, grid)
+	if tg.RecordUpdateTime {
+		stampUpdated(grid, gridClock())
+	}
+	var buf []byte
+	if !tg.StreamUpload {
+		if buf, err = gcs.MarshalGridLevel(grid, gridCodec(tg), compressionLevel); err != nil {
+			return fmt.Errorf("marshal grid: %w", err)
+		}
+		log = log.WithField("bytes", len(buf))
+	}
+	hash, err := gcs.HashGrid(grid)
 	if err != nil {
-		return fmt.Errorf("marshal grid: %w", err)
+		return fmt.Errorf("hash grid: %w", err)
 	}
-	log = log.WithField("url", gridPath).WithField("bytes", len(buf))
+	log = log.WithField("url", gridPath).WithField("sha256", hash)
 	if !write {
 		log.Debug("Skipping write")
 	} else {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("wait to upload: %w", err)
+		}
 		log.Debug("Writing")
+		meta, err := gridMetadata(tg, hash)
+		if err != nil {
+			return err
+		}
 		// TODO(fejta): configurable cache value
-		if _, err := client.Upload(ctx, gridPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		if tg.StreamUpload {
+			err = streamGrid(ctx, client, gridPath, grid, gridCodec(tg), compressionLevel, gridACL(tg), meta)
+		} else {
+			err = writeGrid(ctx, client, gridPath, bytes.NewReader(buf), gridACL(tg), meta)
+		}
+		if err != nil {
 			return fmt.Errorf("upload: %w", err)
 		}
+		if verify {
+			if err := verifyUpload(ctx, client, gridPath, grid); err != nil {
+				return fmt.Errorf("verify: %w", err)
+			}
+		}
+		if publisher != nil {
+			if err := publisher.Publish(ctx, gridEvent(tg.Name, gridPath, old, grid)); err != nil {
+				log.WithError(err).Warning("Failed to publish grid event")
+			}
+		}
+		if notifier != nil {
+			if notes := alertNotifications(tg.Name, old, grid); len(notes) > 0 {
+				if err := notifier.Notify(ctx, notes); err != nil {
+					log.WithError(err).Warning("Failed to notify alert changes")
+				}
+			}
+		}
+		if tg.WriteGridDelta {
+			if err := writeDelta(ctx, client, gridPath, base, grid, compressionLevel, gridACL(tg)); err != nil {
+				log.WithError(err).Warning("Failed to write grid delta")
+			}
+		}
 	}
 	log.WithFields(logrus.Fields{
 		"cols": len(grid.Columns),
@@ -583,12 +1277,173 @@ func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.C
 	return nil
 }
 
-// formatStrftime replaces python codes with what go expects.
+// gridClock returns the time at which grids are constructed and written, which tests may override.
+var gridClock = time.Now
+
+// stampUpdated records when the grid was written, in seconds since epoch.
+func stampUpdated(grid *statepb.Grid, when time.Time) {
+	grid.LastTimeUpdated = float64(when.UnixNano()) / float64(time.Second)
+}
+
+// checkColumnOrder warns about columns which started after newer builds, such as from clock skew.
 //
-// aka %Y-%m-%d becomes 2006-01-02
-func formatStrftime(in string) string {
-	replacements := map[string]string{
-		"%p": "PM",
+// Re-sorts the columns by start time when the group enables it.
+func checkColumnOrder(log logrus.FieldLogger, tg *configpb.TestGroup, cols []InflatedColumn) {
+	skewed := skewedColumns(cols)
+	if len(skewed) == 0 {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"builds": skewed,
+		"sort":   tg.SortSkewedColumns,
+	}).Warning("Columns started out of order")
+	if tg.SortSkewedColumns {
+		SortStarted(tg, cols)
+	}
+}
+
+// skewedColumns returns the build of each column which started after the column before it.
+func skewedColumns(cols []InflatedColumn) []string {
+	var out []string
+	for i := 1; i < len(cols); i++ {
+		if cols[i].Column.Started > cols[i-1].Column.Started {
+			out = append(out, cols[i].Column.Build)
+		}
+	}
+	return out
+}
+
+// writeGrid stages the grid in a temporary object before moving it into place.
+//
+// Readers therefore never observe a partially written grid: a failure in
+// either phase leaves the existing grid unchanged.
+//
+// Clients which cannot stage objects upload the grid directly instead,
+// including the metadata when they are a gcs.MetadataUploader.
+func writeGrid(ctx context.Context, client gcs.Uploader, path gcs.Path, r io.Reader, worldRead bool, meta map[string]string) error {
+	stager, ok := client.(gcs.StagingUploader)
+	if !ok {
+		return uploadGrid(ctx, client, path, r, worldRead, meta)
+	}
+	staged, err := stager.Stage(ctx, path, r, worldRead, "no-cache", meta)
+	if err != nil {
+		return fmt.Errorf("stage: %w", err)
+	}
+	if _, err := stager.Finalize(ctx, staged, path, worldRead); err != nil {
+		return fmt.Errorf("finalize %s: %w", staged, err)
+	}
+	return nil
+}
+
+// uploadGrid writes the grid to path in a single upload.
+func uploadGrid(ctx context.Context, client gcs.Uploader, path gcs.Path, r io.Reader, worldRead bool, meta map[string]string) error {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	if mu, ok := client.(gcs.MetadataUploader); ok {
+		_, err = mu.UploadWithMetadata(ctx, path, buf, worldRead, "no-cache", meta)
+	} else {
+		_, err = client.Upload(ctx, path, buf, worldRead, "no-cache")
+	}
+	return err
+}
+
+// streamGrid compresses the grid directly into the uploaded object.
+func streamGrid(ctx context.Context, client gcs.Uploader, path gcs.Path, grid *statepb.Grid, codec gcs.Codec, level int, worldRead bool, meta map[string]string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(gcs.WriteGridLevel(pw, grid, codec, level))
+	}()
+	err := writeGrid(ctx, client, path, pr, worldRead, meta)
+	pr.CloseWithError(err) // Unblock the writer if the upload failed early.
+	return err
+}
+
+// gridACL returns whether to upload the grid of the group as publicly readable.
+func gridACL(tg *configpb.TestGroup) bool {
+	if tg.WorldReadable {
+		return gcs.PublicRead
+	}
+	return gcs.DefaultACL
+}
+
+const (
+	// ConfigDigestKey is the object metadata key holding the digest of the group config which produced a grid.
+	ConfigDigestKey = "testgrid-config-sha256"
+	// VersionKey is the object metadata key holding the Version of the updater which produced a grid.
+	VersionKey = "testgrid-updater-version"
+)
+
+// Version identifies the updater build, which bazel stamps with the git commit.
+var Version = "unknown"
+
+// gridMetadata returns the object metadata annotating the uploaded grid.
+//
+// Readers can detect a grid produced under stale config by comparing the
+// ConfigDigestKey value to the digest of the current config.
+func gridMetadata(tg *configpb.TestGroup, hash string) (map[string]string, error) {
+	digest, err := ConfigDigest(tg)
+	if err != nil {
+		return nil, fmt.Errorf("digest config: %w", err)
+	}
+	return map[string]string{
+		gcs.GridHashKey: hash,
+		ConfigDigestKey: digest,
+		VersionKey:      Version,
+	}, nil
+}
+
+// ConfigDigest returns the hex-encoded SHA-256 of the serialized group config.
+func ConfigDigest(tg *configpb.TestGroup) (string, error) {
+	buf, err := protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(tg))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// configDrift returns true when the grid at gridPath was written under a different group config.
+//
+// Missing grids, and grids without a config digest such as those written by
+// older updaters, never drift.
+func configDrift(ctx context.Context, stater gcs.Stater, gridPath gcs.Path, tg *configpb.TestGroup) (bool, error) {
+	attrs, err := stater.Stat(ctx, gridPath)
+	if err == storage.ErrObjectNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("stat: %w", err)
+	}
+	stored, ok := attrs.Metadata[ConfigDigestKey]
+	if !ok {
+		return false, nil
+	}
+	digest, err := ConfigDigest(tg)
+	if err != nil {
+		return false, fmt.Errorf("digest config: %w", err)
+	}
+	return stored != digest, nil
+}
+
+// gridCodec returns the codec to compress the group's grid.
+func gridCodec(tg *configpb.TestGroup) gcs.Codec {
+	switch tg.GridCompression {
+	case configpb.TestGroup_GRID_COMPRESSION_GZIP:
+		return gcs.Gzip
+	case configpb.TestGroup_GRID_COMPRESSION_NONE:
+		return gcs.Uncompressed
+	}
+	return gcs.Zlib
+}
+
+// formatStrftime replaces python codes with what go expects.
+//
+// aka %Y-%m-%d becomes 2006-01-02
+func formatStrftime(in string) string {
+	replacements := map[string]string{
+		"%p": "PM",
 		"%Y": "2006",
 		"%y": "06",
 		"%m": "01",
@@ -619,6 +1474,33 @@ func overrideBuild(tg *configpb.TestGroup, cols []InflatedColumn) {
 	}
 }
 
+// carryAnnotations reattaches annotations from the old grid to columns with a matching build.
+//
+// Columns that already have annotations keep them.
+func carryAnnotations(old *statepb.Grid, cols []InflatedColumn) {
+	if old == nil {
+		return
+	}
+	annotations := map[string]map[string]string{}
+	for _, col := range old.Columns {
+		if len(col.Annotations) == 0 {
+			continue
+		}
+		annotations[col.Build] = col.Annotations
+	}
+	if len(annotations) == 0 {
+		return
+	}
+	for _, col := range cols {
+		if len(col.Column.Annotations) > 0 {
+			continue
+		}
+		if a, ok := annotations[col.Column.Build]; ok {
+			col.Column.Annotations = a
+		}
+	}
+}
+
 const columnIDSeparator = "\ue000"
 
 // GroupColumns merges columns with the same Name and Build.
@@ -715,20 +1597,59 @@ func days(d float64) time.Duration {
 // ConstructGrid will append all the inflatedColumns into the returned Grid.
 //
 // The returned Grid has correctly compressed row values.
-func ConstructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []InflatedColumn, issues map[string][]string) *statepb.Grid {
+// Alerting rows record the number of open bugs from bugs when it is non-nil.
+func ConstructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []InflatedColumn, issues map[string][]string, bugs BugCounter) *statepb.Grid {
 	// Add the columns into a grid message
 	var grid statepb.Grid
 	rows := map[string]*statepb.Row{} // For fast target => row lookup
-	failsOpen := int(group.NumFailuresToAlert)
-	passesClose := int(group.NumPassesToDisableAlert)
-	if failsOpen > 0 && passesClose == 0 {
-		passesClose = 1
+
+	if group.RunningTimeoutMinutes > 0 {
+		timeout := time.Duration(group.RunningTimeoutMinutes) * time.Minute
+		timeoutRunning(cols, gridClock(), timeout, group.FailRunningTimeout)
+	}
+
+	if len(group.MessageNormalizationRules) > 0 {
+		normalizeMessages(log, cols, group.MessageNormalizationRules)
 	}
 
+	if group.LowercaseMetricNames || len(group.MetricAliases) > 0 {
+		canonicalizeMetrics(cols, group.LowercaseMetricNames, group.MetricAliases)
+	}
+
+	if group.MaxMessageLength > 0 || len(group.AllowedIcons) > 0 {
+		limitCells(cols, int(group.MaxMessageLength), group.AllowedIcons)
+	}
+
+	if group.PruneEmptyColumns {
+		cols = pruneEmptyColumns(log, cols)
+	}
+
+	if group.OldestColumnMinFill > 0 {
+		cols = dropPartialOldest(log, cols, float64(group.OldestColumnMinFill))
+	}
+
+	targets := makeTargetLabeler(log, group.TargetMetadata)
+	if group.LivenessMinutes > 0 {
+		window := time.Duration(group.LivenessMinutes) * time.Minute
+		appendColumn(&grid, rows, livenessColumn(cols, gridClock(), window), targets)
+	}
 	for _, col := range cols {
-		appendColumn(&grid, rows, col)
+		appendColumn(&grid, rows, col, targets)
 	}
 
+	if n := int(group.MinColumns); len(cols) < n {
+		for _, col := range placeholderColumns(cols, n-len(cols)) {
+			appendColumn(&grid, rows, col, targets)
+		}
+	}
+
+	if group.MaxRowHistory > 0 {
+		trimGrid(&grid, int(group.MaxRowHistory))
+	}
+
+	if len(group.ExcludeRowRegexes) > 0 {
+		excludeRows(log, &grid, rows, group.ExcludeRowRegexes)
+	}
 	dropEmptyRows(log, &grid, rows)
 
 	for name, row := range rows {
@@ -747,11 +1668,54 @@ func ConstructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []Inf
 		})
 	}
 
-	alertRows(grid.Columns, grid.Rows, failsOpen, passesClose)
-	sort.SliceStable(grid.Rows, func(i, j int) bool {
-		return sortorder.NaturalLess(grid.Rows[i].Name, grid.Rows[j].Name)
-	})
+	if group.ComputeRowFlakiness {
+		commitIdx := commitHeader(group)
+		for _, row := range grid.Rows {
+			row.Flakiness = rowFlakiness(grid.Columns, row, commitIdx)
+		}
+	}
+
+	if group.ComputeDaysSinceGreen {
+		now := gridClock()
+		for _, row := range grid.Rows {
+			row.DaysSinceGreen = daysSinceGreen(grid.Columns, row, now)
+		}
+	}
+
+	if len(group.MetricRegressionRules) > 0 {
+		for _, row := range grid.Rows {
+			row.MetricRegressions = metricRegressions(grid.Columns, row, group.MetricRegressionRules)
+		}
+	}
+
+	if d := group.MetricDownsample; d != nil {
+		spacing := metricSpacing(d, len(grid.Columns))
+		for _, row := range grid.Rows {
+			for _, m := range row.Metrics {
+				downsampleMetric(m, d.RecentColumns, spacing)
+			}
+		}
+	}
+
+	if group.ComputeColumnStats {
+		columnStats(grid.Columns, grid.Rows)
+	}
+
+	if group.ColumnHealth != nil {
+		columnHealth(grid.Columns, grid.Rows, group.ColumnHealth)
+	}
+
+	if group.ColumnGrouping != configpb.TestGroup_COLUMN_GROUPING_NONE {
+		columnGroupKeys(grid.Columns, group)
+	}
+
+	alertGrid(log, group, &grid, bugs)
+	if group.AlertsOnly && group.AlertCooldownMinutes <= 0 {
+		grid.Rows = alertingOnly(grid.Rows) // Otherwise finishAlerts drops rows after the cooldown.
+	}
+	sortRows(grid.Rows, group.RowSort)
 
+	less := metricLess(group.MetricOrder)
 	for _, row := range grid.Rows {
 		del := true
 		for _, up := range row.UserProperty {
@@ -764,15 +1728,458 @@ func ConstructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []Inf
 			row.UserProperty = nil
 		}
 		sort.SliceStable(row.Metric, func(i, j int) bool {
-			return sortorder.NaturalLess(row.Metric[i], row.Metric[j])
+			return less(row.Metric[i], row.Metric[j])
 		})
 		sort.SliceStable(row.Metrics, func(i, j int) bool {
-			return sortorder.NaturalLess(row.Metrics[i].Name, row.Metrics[j].Name)
+			return less(row.Metrics[i].Name, row.Metrics[j].Name)
 		})
 	}
+
+	if group.InternMessages {
+		gcs.InternMessages(&grid)
+	}
 	return &grid
 }
 
+// alertGrid sets the AlertInfo of each row in the grid according to the group configuration.
+//
+// Clears every alert when the group disables them.
+func alertGrid(log logrus.FieldLogger, group *configpb.TestGroup, grid *statepb.Grid, bugs BugCounter) {
+	if group.DisableAlerts {
+		for _, row := range grid.Rows {
+			row.AlertInfo = nil
+		}
+		grid.AlertGroups = nil
+		return
+	}
+	failsOpen, passesClose := resolveAlertThresholds(int(group.NumFailuresToAlert), int(group.NumPassesToDisableAlert))
+	only := alertRowFilter(log, group.AlertRowRegexes)
+	ids := buildIDSelector(group)
+	alertRows(grid.Columns, grid.Rows, failsOpen, passesClose, int(group.MinHistoryColumns), int(group.IgnoreLatestColumns), group.AlertOnAllFailing, group.FlakyAlert, group.RowAlertThresholds, only, ids)
+	if w := group.WeightedAlert; w != nil && w.Threshold > 0 {
+		weightedRows(grid.Columns, grid.Rows, w, only, ids)
+	}
+	if group.DisappearedAfter > 0 {
+		disappearedRows(grid.Columns, grid.Rows, int(group.DisappearedAfter), only, ids)
+	}
+	if until := time.Unix(group.SilenceAlertsUntil, 0); group.SilenceAlertsUntil > 0 && gridClock().Before(until) {
+		silenceAlerts(log, grid.Rows, until)
+	}
+	if linker := makeIssueLinker(log, group.IssueLinkRules); len(linker) > 0 {
+		for _, row := range grid.Rows {
+			if row.AlertInfo == nil {
+				continue
+			}
+			row.AlertInfo.IssueLink = linker.link(row.AlertInfo.FailureMessage)
+		}
+	}
+	if bugs != nil {
+		for _, row := range grid.Rows {
+			if row.AlertInfo == nil {
+				continue
+			}
+			row.AlertInfo.OpenBugs = bugs(row.Id, row.Name)
+		}
+	}
+	grid.AlertGroups = groupAlerts(grid.Rows, int(group.AlertGroupMinRows))
+	templateAlerts(log, group, grid.Rows)
+}
+
+// groupAlerts returns a group for each failure message shared by the alerts of at least minRows rows.
+//
+// Messages match after collapsing whitespace. Returns nil when minRows is not positive.
+func groupAlerts(rows []*statepb.Row, minRows int) []*statepb.AlertGroup {
+	if minRows <= 0 {
+		return nil
+	}
+	members := map[string][]*statepb.Row{}
+	var msgs []string
+	for _, row := range rows {
+		if row.AlertInfo == nil {
+			continue
+		}
+		msg := strings.Join(strings.Fields(row.AlertInfo.FailureMessage), " ")
+		if msg == "" {
+			continue
+		}
+		if _, ok := members[msg]; !ok {
+			msgs = append(msgs, msg)
+		}
+		members[msg] = append(members[msg], row)
+	}
+	var out []*statepb.AlertGroup
+	for _, msg := range msgs {
+		if rows := members[msg]; len(rows) >= minRows {
+			out = append(out, alertGroup(msg, rows))
+		}
+	}
+	return out
+}
+
+// alertGroup summarizes the alerts of the rows sharing the message.
+//
+// The summary copies the alert of the row which failed first,
+// counting the failures of every row.
+func alertGroup(msg string, rows []*statepb.Row) *statepb.AlertGroup {
+	first := rows[0].AlertInfo
+	var failures int32
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Name)
+		failures += row.AlertInfo.FailCount
+		if failedBefore(row.AlertInfo.FailTime, first.FailTime) {
+			first = row.AlertInfo
+		}
+	}
+	sort.Strings(names)
+	alert := proto.Clone(first).(*statepb.AlertInfo)
+	alert.FailCount = failures
+	alert.FailureMessage = msg
+	return &statepb.AlertGroup{
+		Alert: alert,
+		Rows:  names,
+	}
+}
+
+// failedBefore returns true when a is earlier than b, where an unset time is never earlier.
+func failedBefore(a, b *timestamp.Timestamp) bool {
+	switch {
+	case a == nil:
+		return false
+	case b == nil:
+		return true
+	case a.Seconds != b.Seconds:
+		return a.Seconds < b.Seconds
+	}
+	return a.Nanos < b.Nanos
+}
+
+// refreshAlertGroups drops the rows which no longer alert from each group, such as after alertCooldown.
+//
+// Drops groups left with fewer than minRows rows.
+func refreshAlertGroups(grid *statepb.Grid, minRows int) {
+	if len(grid.AlertGroups) == 0 {
+		return
+	}
+	alerting := map[string]*statepb.Row{}
+	for _, row := range grid.Rows {
+		if row.AlertInfo != nil {
+			alerting[row.Name] = row
+		}
+	}
+	kept := grid.AlertGroups[:0]
+	for _, group := range grid.AlertGroups {
+		var rows []*statepb.Row
+		for _, name := range group.Rows {
+			if row, ok := alerting[name]; ok {
+				rows = append(rows, row)
+			}
+		}
+		if len(rows) == 0 || len(rows) < minRows {
+			continue
+		}
+		kept = append(kept, alertGroup(group.Alert.GetFailureMessage(), rows))
+	}
+	grid.AlertGroups = kept
+}
+
+// finishAlerts applies the alert cooldown against the old grid, then drops non-alerting rows from alerts_only groups.
+//
+// Rows are dropped after the cooldown so rows whose alerts it suppresses do not remain.
+func finishAlerts(tg *configpb.TestGroup, old, grid *statepb.Grid) {
+	if tg.AlertCooldownMinutes > 0 {
+		alertCooldown(old, grid, time.Duration(tg.AlertCooldownMinutes)*time.Minute)
+		refreshAlertGroups(grid, int(tg.AlertGroupMinRows))
+	}
+	if tg.AlertsOnly {
+		grid.Rows = alertingOnly(grid.Rows)
+	}
+}
+
+// alertCooldown drops alerts which would reopen within the cooldown of the row's last closed alert.
+//
+// Records when each alert closes as the start of the newest column, carrying it over from the old grid.
+func alertCooldown(old, grid *statepb.Grid, cooldown time.Duration) {
+	if len(grid.Columns) == 0 {
+		return
+	}
+	now := grid.Columns[0].Started
+	wait := float64(cooldown / time.Millisecond)
+	oldRows := map[string]*statepb.Row{}
+	for _, row := range old.GetRows() {
+		oldRows[row.Name] = row
+	}
+	for _, row := range grid.Rows {
+		was := oldRows[row.Name]
+		closed := was.GetLastAlertClosed()
+		switch {
+		case was.GetAlertInfo() != nil && row.AlertInfo == nil:
+			closed = now
+		case was.GetAlertInfo() == nil && row.AlertInfo != nil && closed > 0 && now-closed < wait:
+			row.AlertInfo = nil
+		}
+		row.LastAlertClosed = closed
+	}
+}
+
+// metricLess orders metric names by their position in order, followed by unlisted names in natural order.
+func metricLess(order []string) func(a, b string) bool {
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+	return func(a, b string) bool {
+		ra, okA := rank[a]
+		rb, okB := rank[b]
+		switch {
+		case okA && okB:
+			return ra < rb
+		case okA != okB:
+			return okA
+		}
+		return sortorder.NaturalLess(a, b)
+	}
+}
+
+// sortRows sorts rows in the specified order, using the name to break ties.
+//
+// Failure recency assumes the first column is the most recent.
+func sortRows(rows []*statepb.Row, by configpb.TestGroup_RowSort) {
+	var less func(i, j int) bool
+	switch by {
+	case configpb.TestGroup_ROW_SORT_FAILURE_RATE:
+		rates := make(map[*statepb.Row]float64, len(rows))
+		for _, r := range rows {
+			rates[r] = failureRate(r)
+		}
+		less = func(i, j int) bool {
+			return rates[rows[i]] > rates[rows[j]]
+		}
+	case configpb.TestGroup_ROW_SORT_LAST_FAILURE:
+		last := make(map[*statepb.Row]int, len(rows))
+		for _, r := range rows {
+			last[r] = lastFailure(r)
+		}
+		less = func(i, j int) bool {
+			li, lj := last[rows[i]], last[rows[j]]
+			switch {
+			case li < 0:
+				return false
+			case lj < 0:
+				return true
+			}
+			return li < lj
+		}
+	default:
+		less = func(i, j int) bool { return false }
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if less(i, j) {
+			return true
+		}
+		if less(j, i) {
+			return false
+		}
+		return sortorder.NaturalLess(rows[i].Name, rows[j].Name)
+	})
+}
+
+// failureRate returns the ratio of failing to non-empty results in the row.
+func failureRate(row *statepb.Row) float64 {
+	var total, failures int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		n := int(row.Results[i+1])
+		switch result.Coalesce(statuspb.TestStatus(row.Results[i]), result.IgnoreRunning) {
+		case statuspb.TestStatus_NO_RESULT:
+			continue
+		case statuspb.TestStatus_FAIL:
+			failures += n
+		}
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(failures) / float64(total)
+}
+
+// lastFailure returns the index of the first failing column in the row, or -1 when it never fails.
+func lastFailure(row *statepb.Row) int {
+	var idx int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		if result.Coalesce(statuspb.TestStatus(row.Results[i]), result.IgnoreRunning) == statuspb.TestStatus_FAIL {
+			return idx
+		}
+		idx += int(row.Results[i+1])
+	}
+	return -1
+}
+
+// timeoutRunning replaces RUNNING cells in columns that started more than timeout before now.
+//
+// Replaces these cells with a failure when fail is true, otherwise NO_RESULT.
+func timeoutRunning(cols []InflatedColumn, now time.Time, timeout time.Duration, fail bool) {
+	floor := float64(now.Add(-timeout).UTC().Unix() * 1000)
+	for _, col := range cols {
+		if col.Column.Started >= floor {
+			continue
+		}
+		for name, cell := range col.Cells {
+			if cell.Result != statuspb.TestStatus_RUNNING {
+				continue
+			}
+			if !fail {
+				col.Cells[name] = emptyCell
+				continue
+			}
+			cell.Result = statuspb.TestStatus_FAIL
+			cell.Icon = "T"
+			cell.Message = fmt.Sprintf("Build did not complete within %s", timeout)
+			col.Cells[name] = cell
+		}
+	}
+}
+
+// limitCells truncates messages longer than maxLength and clears icons missing from allowedIcons.
+//
+// Either limit is disabled when unset.
+func limitCells(cols []InflatedColumn, maxLength int, allowedIcons []string) {
+	var allowed map[string]bool
+	if len(allowedIcons) > 0 {
+		allowed = make(map[string]bool, len(allowedIcons))
+		for _, icon := range allowedIcons {
+			allowed[icon] = true
+		}
+	}
+	for _, col := range cols {
+		for name, cell := range col.Cells {
+			cell.Message = truncateMessage(cell.Message, maxLength)
+			if allowed != nil && cell.Icon != "" && !allowed[cell.Icon] {
+				cell.Icon = ""
+			}
+			col.Cells[name] = cell
+		}
+	}
+}
+
+// normalizeMessages applies each rule in order to the message of every cell.
+//
+// Volatile substrings, such as timestamps, otherwise make the same failure
+// look different in each column.
+func normalizeMessages(log logrus.FieldLogger, cols []InflatedColumn, rules []*configpb.TestGroup_MessageNormalizationRule) {
+	var normalize []renameRule
+	for _, r := range rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			log.WithError(err).WithField("regex", r.Pattern).Warning("Ignoring bad message normalization regex")
+			continue
+		}
+		normalize = append(normalize, renameRule{re: re, replacement: r.Replacement})
+	}
+	if len(normalize) == 0 {
+		return
+	}
+	for _, col := range cols {
+		for name, cell := range col.Cells {
+			if cell.Message == "" {
+				continue
+			}
+			for _, r := range normalize {
+				cell.Message = r.re.ReplaceAllString(cell.Message, r.replacement)
+			}
+			col.Cells[name] = cell
+		}
+	}
+}
+
+// canonicalizeMetrics renames the metrics of every cell to their canonical name.
+//
+// Metrics matching an alias use its canonical name, which is then lowercased
+// when lowercase is set. Values which end up with the same name in a cell
+// are averaged.
+func canonicalizeMetrics(cols []InflatedColumn, lowercase bool, aliases []*configpb.TestGroup_MetricAlias) {
+	canonical := make(map[string]string, len(aliases))
+	for _, a := range aliases {
+		canonical[a.Name] = a.Canonical
+	}
+	for _, col := range cols {
+		for name, cell := range col.Cells {
+			if len(cell.Metrics) == 0 {
+				continue
+			}
+			sums := make(map[string]float64, len(cell.Metrics))
+			counts := make(map[string]int, len(cell.Metrics))
+			for metric, value := range cell.Metrics {
+				if c, ok := canonical[metric]; ok {
+					metric = c
+				}
+				if lowercase {
+					metric = strings.ToLower(metric)
+				}
+				sums[metric] += value
+				counts[metric]++
+			}
+			metrics := make(map[string]float64, len(sums))
+			for metric, sum := range sums {
+				metrics[metric] = sum / float64(counts[metric])
+			}
+			cell.Metrics = metrics
+			col.Cells[name] = cell
+		}
+	}
+}
+
+const ellipsis = "..."
+
+// truncateMessage shortens messages longer than max characters, ending them with an ellipsis.
+func truncateMessage(msg string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(msg) <= max {
+		return msg
+	}
+	runes := []rune(msg)
+	if max <= len(ellipsis) {
+		return string(runes[:max])
+	}
+	return string(runes[:max-len(ellipsis)]) + ellipsis
+}
+
+// excludeRows drops rows with a name matching any of the patterns.
+//
+// Columns are unaffected, so remaining rows stay aligned.
+func excludeRows(log logrus.FieldLogger, grid *statepb.Grid, rows map[string]*statepb.Row, patterns []string) {
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.WithError(err).WithField("regex", p).Warning("Ignoring bad exclude row regex")
+			continue
+		}
+		res = append(res, re)
+	}
+	if len(res) == 0 {
+		return
+	}
+
+	kept := make([]*statepb.Row, 0, len(grid.Rows))
+	for _, r := range grid.Rows {
+		var excluded bool
+		for _, re := range res {
+			if re.MatchString(r.Name) {
+				excluded = true
+				break
+			}
+		}
+		if excluded {
+			delete(rows, r.Name)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	grid.Rows = kept
+}
+
 func dropEmptyRows(log logrus.FieldLogger, grid *statepb.Grid, rows map[string]*statepb.Row) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -795,12 +2202,374 @@ func dropEmptyRows(log logrus.FieldLogger, grid *statepb.Grid, rows map[string]*
 		filled = append(filled, r)
 	}
 
-	if dropped == 0 {
-		return
+	if dropped == 0 {
+		return
+	}
+
+	grid.Rows = filled
+	log.WithField("dropped", dropped).Info("Dropped old rows")
+}
+
+// pruneEmptyColumns returns the columns with at least one non-empty cell.
+//
+// Pruning happens before rows are built, so empty columns never break alert streaks.
+func pruneEmptyColumns(log logrus.FieldLogger, cols []InflatedColumn) []InflatedColumn {
+	kept := make([]InflatedColumn, 0, len(cols))
+	for _, col := range cols {
+		for _, c := range col.Cells {
+			if c.Result != statuspb.TestStatus_NO_RESULT {
+				kept = append(kept, col)
+				break
+			}
+		}
+	}
+	if dropped := len(cols) - len(kept); dropped > 0 {
+		log.WithField("dropped", dropped).Info("Pruned empty columns")
+	}
+	return kept
+}
+
+// dropPartialOldest drops the oldest column when less than minFill of the rows have a result in it.
+//
+// Keeps the only column.
+func dropPartialOldest(log logrus.FieldLogger, cols []InflatedColumn, minFill float64) []InflatedColumn {
+	n := len(cols)
+	if n < 2 {
+		return cols
+	}
+	rows := map[string]bool{}
+	for _, col := range cols {
+		for name, c := range col.Cells {
+			if c.Result != statuspb.TestStatus_NO_RESULT {
+				rows[name] = true
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return cols
+	}
+	var filled int
+	for _, c := range cols[n-1].Cells {
+		if c.Result != statuspb.TestStatus_NO_RESULT {
+			filled++
+		}
+	}
+	fill := float64(filled) / float64(len(rows))
+	if fill >= minFill {
+		return cols
+	}
+	log.WithFields(logrus.Fields{
+		"build": cols[n-1].Column.Build,
+		"fill":  fill,
+	}).Info("Dropped partial oldest column")
+	return cols[:n-1]
+}
+
+// alertingOnly returns the rows with an open alert.
+func alertingOnly(rows []*statepb.Row) []*statepb.Row {
+	kept := rows[:0]
+	for _, row := range rows {
+		if row.AlertInfo != nil {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// columnStats sets the aggregate result counts of each column.
+func columnStats(cols []*statepb.Column, rows []*statepb.Row) {
+	for i, stats := range resultCounts(cols, rows) {
+		cols[i].Stats = stats
+	}
+}
+
+const (
+	// defaultBrokenPercent is the percentage of failing results which breaks a column by default.
+	defaultBrokenPercent = 50
+	// defaultFlakyPercent is the percentage of flaky results which makes a column flaky by default.
+	defaultFlakyPercent = 10
+)
+
+// columnHealth sets the overall health of each column.
+func columnHealth(cols []*statepb.Column, rows []*statepb.Row, cfg *configpb.TestGroup_ColumnHealth) {
+	broken, flaky := cfg.GetBrokenPercent(), cfg.GetFlakyPercent()
+	if broken == 0 {
+		broken = defaultBrokenPercent
+	}
+	if flaky == 0 {
+		flaky = defaultFlakyPercent
+	}
+	for i, stats := range resultCounts(cols, rows) {
+		cols[i].Health = health(stats, broken, flaky)
+	}
+}
+
+// health summarizes the result counts of a column.
+//
+// Percentages only include passing, failing and flaky results.
+func health(stats *statepb.Column_Stats, brokenPercent, flakyPercent float64) statepb.Column_Health {
+	total := float64(stats.PassCount + stats.FailCount + stats.FlakyCount)
+	switch {
+	case total == 0:
+		return statepb.Column_HEALTH_UNKNOWN
+	case 100*float64(stats.FailCount)/total >= brokenPercent:
+		return statepb.Column_HEALTH_BROKEN
+	case 100*float64(stats.FlakyCount)/total >= flakyPercent:
+		return statepb.Column_HEALTH_FLAKY
+	case stats.FailCount+stats.FlakyCount == 0:
+		return statepb.Column_HEALTH_PASSING
+	default:
+		return statepb.Column_HEALTH_MOSTLY_PASSING
+	}
+}
+
+// resultCounts returns the aggregate result counts of each column.
+func resultCounts(cols []*statepb.Column, rows []*statepb.Row) []*statepb.Column_Stats {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make([]*statepb.Column_Stats, len(cols))
+	for i := range out {
+		out[i] = &statepb.Column_Stats{}
+	}
+	for _, row := range rows {
+		var idx int
+		for res := range result.Iter(ctx, row.Results) {
+			if idx >= len(cols) {
+				break
+			}
+			stats := out[idx]
+			idx++
+			switch result.Coalesce(res, true) {
+			case statuspb.TestStatus_PASS:
+				stats.PassCount++
+			case statuspb.TestStatus_FAIL:
+				stats.FailCount++
+			case statuspb.TestStatus_FLAKY:
+				stats.FlakyCount++
+			case statuspb.TestStatus_NO_RESULT:
+				stats.NoResultCount++
+			}
+		}
+	}
+	return out
+}
+
+// defaultGroupingPrefixLength is the number of characters of the commit which group columns.
+const defaultGroupingPrefixLength = 7
+
+// commitHeader returns the index of the Commit column header of the group, or -1 without one.
+func commitHeader(tg *configpb.TestGroup) int {
+	for i, h := range tg.ColumnHeader {
+		if h.ConfigurationValue == "Commit" {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnGroupKeys stores the key which groups each column under a header, per the group's column_grouping.
+//
+// Placeholder columns have no key.
+func columnGroupKeys(cols []*statepb.Column, tg *configpb.TestGroup) {
+	commitIdx := commitHeader(tg)
+	n := int(tg.ColumnGroupingPrefixLength)
+	if n <= 0 {
+		n = defaultGroupingPrefixLength
+	}
+	for _, col := range cols {
+		if col.Placeholder {
+			continue
+		}
+		col.GroupKey = columnGroupKey(col, tg.ColumnGrouping, commitIdx, n)
+	}
+}
+
+// columnGroupKey returns the UTC day or ISO week the column started, or a prefix of its commit.
+func columnGroupKey(col *statepb.Column, grouping configpb.TestGroup_ColumnGrouping, commitIdx, n int) string {
+	when := time.Unix(0, int64(col.Started*float64(time.Millisecond))).UTC()
+	switch grouping {
+	case configpb.TestGroup_COLUMN_GROUPING_DAY:
+		return when.Format("2006-01-02")
+	case configpb.TestGroup_COLUMN_GROUPING_WEEK:
+		year, week := when.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case configpb.TestGroup_COLUMN_GROUPING_COMMIT_PREFIX:
+		if commitIdx < 0 || commitIdx >= len(col.Extra) {
+			return ""
+		}
+		commit := col.Extra[commitIdx]
+		if len(commit) > n {
+			commit = commit[:n]
+		}
+		return commit
+	}
+	return ""
+}
+
+// rowFlakiness returns the percentage of flaky results in the row.
+//
+// Only passing, failing and flaky results count towards the total.
+// A result is flaky when it is FLAKY, or when it failed and a more recent
+// column with the same commit (the Extra value at commitIdx) passed.
+// Columns must be sorted from most to least recent.
+func rowFlakiness(cols []*statepb.Column, row *statepb.Row, commitIdx int) float32 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	passedCommits := map[string]bool{}
+	var total, flaky int
+	ch := result.Iter(ctx, row.Results)
+	for _, col := range cols {
+		res := result.Coalesce(<-ch, result.IgnoreRunning)
+		var commit string
+		if commitIdx >= 0 && commitIdx < len(col.Extra) {
+			commit = col.Extra[commitIdx]
+		}
+		switch res {
+		case statuspb.TestStatus_PASS:
+			if commit != "" {
+				passedCommits[commit] = true
+			}
+		case statuspb.TestStatus_FAIL:
+			if commit != "" && passedCommits[commit] {
+				flaky++
+			}
+		case statuspb.TestStatus_FLAKY:
+			flaky++
+		default:
+			continue
+		}
+		total++
+	}
+	if total == 0 {
+		return 0
+	}
+	return 100 * float32(flaky) / float32(total)
+}
+
+// daysSinceGreen returns the days between now and the most recent passing result in the row.
+//
+// Returns zero when the most recent result passed, and -1 when no result passed.
+// Columns must be sorted from most to least recent.
+func daysSinceGreen(cols []*statepb.Column, row *statepb.Row, now time.Time) float64 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, row.Results)
+	latest := true
+	for _, col := range cols {
+		switch result.Coalesce(<-ch, result.IgnoreRunning) {
+		case statuspb.TestStatus_NO_RESULT:
+			continue
+		case statuspb.TestStatus_PASS:
+			if latest {
+				return 0
+			}
+			started := time.Unix(0, int64(col.Started)*int64(time.Millisecond))
+			if days := now.Sub(started).Hours() / 24; days > 0 {
+				return days
+			}
+			return 0
+		}
+		latest = false
+	}
+	return -1
+}
+
+const defaultBaselineColumns = 5
+
+// metricRegressions returns the values of the row's metrics which are worse than their baseline.
+//
+// The baseline of each value is the median of the older values of the metric,
+// skipping columns without the metric. Values with fewer older values than the
+// rule's baseline columns are not compared.
+// Columns must be sorted from most to least recent.
+func metricRegressions(cols []*statepb.Column, row *statepb.Row, rules []*configpb.TestGroup_MetricRegressionRule) []*statepb.MetricRegression {
+	var out []*statepb.MetricRegression
+	for _, rule := range rules {
+		var metric *statepb.Metric
+		for _, m := range row.Metrics {
+			if m.Name == rule.Metric {
+				metric = m
+				break
+			}
+		}
+		if metric == nil {
+			continue
+		}
+		n := int(rule.BaselineColumns)
+		if n <= 0 {
+			n = defaultBaselineColumns
+		}
+		values := metricValues(metric, len(cols))
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			baseline := make([]float64, 0, n)
+			for j := i + 1; j < len(values) && len(baseline) < n; j++ {
+				if values[j] != nil {
+					baseline = append(baseline, *values[j])
+				}
+			}
+			if len(baseline) < n {
+				break // Older values have even less history.
+			}
+			if r := regressed(rule, *value, median(baseline)); r != nil {
+				r.Build = cols[i].Build
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
+
+// metricValues returns the value of the metric in each of the n columns, or nil when missing.
+func metricValues(metric *statepb.Metric, n int) []*float64 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make([]*float64, n)
+	ch := inflateMetric(ctx, metric)
+	for i := range out {
+		v, ok := <-ch
+		if !ok {
+			break
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// median returns the middle value, or the mean of the two middle values.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
 	}
+	return sorted[mid]
+}
 
-	grid.Rows = filled
-	log.WithField("dropped", dropped).Info("Dropped old rows")
+// regressed returns a regression when value is worse than baseline by more than the rule's threshold.
+func regressed(rule *configpb.TestGroup_MetricRegressionRule, value, baseline float64) *statepb.MetricRegression {
+	limit := rule.ThresholdPercent / 100
+	if rule.LowerIsWorse {
+		if value >= baseline*(1-limit) {
+			return nil
+		}
+	} else if value <= baseline*(1+limit) {
+		return nil
+	}
+	msg := fmt.Sprintf("%s regressed from %g to %g", rule.Metric, baseline, value)
+	if baseline != 0 {
+		change := math.Abs(value-baseline) / math.Abs(baseline) * 100
+		msg = fmt.Sprintf("%s regressed %.0f%% from %g to %g", rule.Metric, change, baseline, value)
+	}
+	return &statepb.MetricRegression{
+		Metric:   rule.Metric,
+		Value:    value,
+		Baseline: baseline,
+		Message:  msg,
+	}
 }
 
 // appendMetric adds the value at index to metric.
@@ -876,11 +2645,217 @@ func appendCell(row *statepb.Row, cell Cell, start, count int) {
 		row.Messages = append(row.Messages, cell.Message)
 		row.Icons = append(row.Icons, cell.Icon)
 		row.UserProperty = append(row.UserProperty, cell.UserProperty)
+		// Only add artifact urls once a cell has one, padding earlier cells.
+		if cell.ArtifactURL != "" || len(row.ArtifactUrls) > 0 {
+			for len(row.ArtifactUrls) < len(row.Icons)-1 {
+				row.ArtifactUrls = append(row.ArtifactUrls, "")
+			}
+			row.ArtifactUrls = append(row.ArtifactUrls, cell.ArtifactURL)
+		}
 	}
 
 	row.Issues = append(row.Issues, cell.Issues...)
 }
 
+// trimGrid drops all but the newest max columns from the grid and each of its rows.
+func trimGrid(grid *statepb.Grid, max int) {
+	if len(grid.Columns) <= max {
+		return
+	}
+	grid.Columns = grid.Columns[:max]
+	for _, row := range grid.Rows {
+		trimRow(row, max)
+	}
+}
+
+// trimRow drops all but the first max results of the row.
+//
+// Also drops the cell ids, messages, icons, user properties and metric
+// values of the dropped cells, keeping these slices aligned with the results.
+func trimRow(row *statepb.Row, max int) {
+	var end, total, cells int
+	for end+1 < len(row.Results) && total < max {
+		n := int(row.Results[end+1])
+		if total+n > max {
+			n = max - total
+			row.Results[end+1] = int32(n)
+		}
+		total += n
+		// Only cells with a result have an id, message, icon and user property.
+		if statuspb.TestStatus(row.Results[end]) != statuspb.TestStatus_NO_RESULT {
+			cells += n
+		}
+		end += 2
+	}
+	row.Results = row.Results[:end]
+	row.CellIds = trimStrings(row.CellIds, cells)
+	row.Messages = trimStrings(row.Messages, cells)
+	row.Icons = trimStrings(row.Icons, cells)
+	row.UserProperty = trimStrings(row.UserProperty, cells)
+	row.ArtifactUrls = trimStrings(row.ArtifactUrls, cells)
+	if len(row.MessageIndices) > cells {
+		row.MessageIndices = row.MessageIndices[:cells]
+	}
+
+	if len(row.Metrics) == 0 {
+		return
+	}
+	metrics := row.Metrics[:0]
+	names := map[string]bool{}
+	for _, m := range row.Metrics {
+		trimMetric(m, int32(max))
+		if len(m.Values) == 0 {
+			continue
+		}
+		metrics = append(metrics, m)
+		names[m.Name] = true
+	}
+	row.Metrics = metrics
+	metric := row.Metric[:0]
+	for _, name := range row.Metric {
+		if names[name] {
+			metric = append(metric, name)
+		}
+	}
+	row.Metric = metric
+}
+
+func trimStrings(strs []string, n int) []string {
+	if len(strs) > n {
+		return strs[:n]
+	}
+	return strs
+}
+
+// trimMetric drops the values of the metric at or after column index max.
+func trimMetric(m *statepb.Metric, max int32) {
+	var keep int
+	var values int32
+	for keep+1 < len(m.Indices) {
+		start, n := m.Indices[keep], m.Indices[keep+1]
+		if start >= max {
+			break
+		}
+		if start+n > max {
+			n = max - start
+			m.Indices[keep+1] = n
+		}
+		values += n
+		keep += 2
+	}
+	m.Indices = m.Indices[:keep]
+	if int(values) < len(m.Values) {
+		m.Values = m.Values[:values]
+	}
+}
+
+// metricSpacing returns the minimum number of columns between older values of a downsampled metric.
+func metricSpacing(d *configpb.TestGroup_MetricDownsample, cols int) int32 {
+	spacing := d.EveryNth
+	if span := int32(cols) - d.RecentColumns; d.MaxPoints > 0 && span > d.MaxPoints {
+		if n := (span + d.MaxPoints - 1) / d.MaxPoints; n > spacing {
+			spacing = n
+		}
+	}
+	return spacing
+}
+
+// downsampleMetric drops values older than the recent columns which are within spacing columns of an older kept value.
+//
+// Starts from the oldest value, so downsampling a downsampled metric with the
+// same spacing changes nothing. Ignores malformed metrics.
+func downsampleMetric(m *statepb.Metric, recent, spacing int32) {
+	if spacing <= 1 || validateMetric(m) != nil {
+		return
+	}
+	var idx []int32
+	for i := 0; i+1 < len(m.Indices); i += 2 {
+		for j := int32(0); j < m.Indices[i+1]; j++ {
+			idx = append(idx, m.Indices[i]+j)
+		}
+	}
+	keep := make([]bool, len(idx))
+	last := int32(-1)
+	for i := len(idx) - 1; i >= 0; i-- {
+		switch {
+		case idx[i] < recent:
+			keep[i] = true
+		case last < 0, last-idx[i] >= spacing:
+			keep[i] = true
+			last = idx[i]
+		}
+	}
+	values := m.Values
+	m.Indices, m.Values = nil, nil
+	for i, k := range keep {
+		if k {
+			appendMetric(m, idx[i], values[i])
+		}
+	}
+}
+
+// placeholderColumns returns n empty columns to append after cols.
+//
+// Placeholders start before the oldest column, spaced by the average interval
+// between columns, or else an hour apart.
+func placeholderColumns(cols []InflatedColumn, n int) []InflatedColumn {
+	interval := float64(time.Hour / time.Millisecond)
+	started := float64(gridClock().UnixNano() / int64(time.Millisecond))
+	if nc := len(cols); nc > 0 {
+		newest, oldest := cols[0].Column.Started, cols[nc-1].Column.Started
+		if nc > 1 && newest > oldest {
+			interval = (newest - oldest) / float64(nc-1)
+		}
+		started = oldest - interval
+	}
+	out := make([]InflatedColumn, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, InflatedColumn{
+			Column: &statepb.Column{
+				Started:     started - float64(i)*interval,
+				Placeholder: true,
+			},
+			Cells: map[string]Cell{},
+		})
+	}
+	return out
+}
+
+// livenessColumn returns a placeholder column at the current time to put before cols.
+//
+// The column is empty when the newest build started within the window,
+// and otherwise marks the overall row stale.
+func livenessColumn(cols []InflatedColumn, now time.Time, window time.Duration) InflatedColumn {
+	out := InflatedColumn{
+		Column: &statepb.Column{
+			Name:        "now",
+			Started:     float64(now.UnixNano() / int64(time.Millisecond)),
+			Placeholder: true,
+		},
+		Cells: map[string]Cell{},
+	}
+	var newest float64
+	for _, col := range cols {
+		if !col.Column.Placeholder && col.Column.Started > newest {
+			newest = col.Column.Started
+		}
+	}
+	msg := "No builds"
+	if newest > 0 {
+		started := time.Unix(0, int64(newest)*int64(time.Millisecond))
+		if now.Sub(started) <= window {
+			return out
+		}
+		msg = fmt.Sprintf("No build started within %s, the newest started at %s", window, started.UTC().Format(time.RFC3339))
+	}
+	out.Cells[overallRow] = Cell{
+		Result:  statuspb.TestStatus_UNKNOWN,
+		Icon:    "!",
+		Message: msg,
+	}
+	return out
+}
+
 // appendColumn adds the build column to the grid.
 //
 // This handles details like:
@@ -888,7 +2863,8 @@ func appendCell(row *statepb.Row, cell Cell, start, count int) {
 // * adding auto metadata like duration, commit as well as any user-added metadata
 // * extracting build metadata into the appropriate column header
 // * Ensuring row names are unique and formatted with metadata
-func appendColumn(grid *statepb.Grid, rows map[string]*statepb.Row, inflated InflatedColumn) {
+// * labeling new rows with the owner and component of their target
+func appendColumn(grid *statepb.Grid, rows map[string]*statepb.Row, inflated InflatedColumn, targets targetLabeler) {
 	grid.Columns = append(grid.Columns, inflated.Column)
 	colIdx := len(grid.Columns) - 1
 
@@ -911,6 +2887,7 @@ func appendColumn(grid *statepb.Grid, rows map[string]*statepb.Row, inflated Inf
 				Id:      id,
 				CellIds: []string{}, // TODO(fejta): try and leave this nil
 			}
+			targets.label(row)
 			rows[name] = row
 			grid.Rows = append(grid.Rows, row)
 			if colIdx > 0 {
@@ -925,15 +2902,101 @@ func appendColumn(grid *statepb.Grid, rows map[string]*statepb.Row, inflated Inf
 	}
 }
 
-// alertRows configures the alert for every row that has one.
-func alertRows(cols []*statepb.Column, rows []*statepb.Row, openFailures, closePasses int) {
+// resolveAlertThresholds returns the failures to open and passes to close an alert.
+//
+// Alerting is disabled when failsOpen is zero, in which case both values are returned unchanged.
+// Otherwise an unset passesClose defaults to closing the alert after a single pass.
+func resolveAlertThresholds(failsOpen, passesClose int) (int, int) {
+	if failsOpen > 0 && passesClose == 0 {
+		passesClose = 1
+	}
+	return failsOpen, passesClose
+}
+
+// alertRows sets the AlertInfo of each row.
+//
+// Overrides replace the group thresholds of the named row, where unset
+// override fields fall back to the group value.
+//
+// When only is non-empty, rows that match none of its regexes never alert.
+func alertRows(cols []*statepb.Column, rows []*statepb.Row, openFailures, closePasses, minHistory, ignoreLatest int, allFailing bool, flaky configpb.TestGroup_FlakyAlert, overrides []*configpb.TestGroup_RowAlertThreshold, only []*regexp.Regexp, ids buildIDFunc) {
+	byRow := make(map[string]*configpb.TestGroup_RowAlertThreshold, len(overrides))
+	for _, o := range overrides {
+		byRow[o.RowName] = o
+	}
 	for _, r := range rows {
-		r.AlertInfo = alertRow(cols, r, openFailures, closePasses)
+		if !matchesAny(r.Name, only) {
+			r.AlertInfo = nil
+			continue
+		}
+		opens, closes := openFailures, closePasses
+		if o, ok := byRow[r.Name]; ok {
+			if o.NumFailuresToAlert > 0 {
+				opens = int(o.NumFailuresToAlert)
+			}
+			if o.NumPassesToDisableAlert > 0 {
+				closes = int(o.NumPassesToDisableAlert)
+			}
+			opens, closes = resolveAlertThresholds(opens, closes)
+		}
+		r.AlertInfo = alertRow(cols, r, opens, closes, minHistory, ignoreLatest, allFailing, flaky, ids)
+	}
+}
+
+// silenceAlerts clears the alert of every row, logging the alerts which would have opened.
+func silenceAlerts(log logrus.FieldLogger, rows []*statepb.Row, until time.Time) {
+	var silenced []string
+	for _, row := range rows {
+		if row.AlertInfo == nil {
+			continue
+		}
+		silenced = append(silenced, row.Name)
+		row.AlertInfo = nil
+	}
+	if len(silenced) > 0 {
+		log.WithFields(logrus.Fields{
+			"until": until,
+			"rows":  silenced,
+		}).Info("Silenced alerts")
+	}
+}
+
+// alertRowFilter compiles the patterns selecting which rows may alert, ignoring invalid ones.
+func alertRowFilter(log logrus.FieldLogger, patterns []string) []*regexp.Regexp {
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.WithError(err).WithField("regex", p).Warning("Ignoring bad alert row regex")
+			continue
+		}
+		res = append(res, re)
+	}
+	return res
+}
+
+// matchesAny returns true when name matches one of the regexes, or there are none.
+func matchesAny(name string, res []*regexp.Regexp) bool {
+	if len(res) == 0 {
+		return true
+	}
+	for _, re := range res {
+		if re.MatchString(name) {
+			return true
+		}
 	}
+	return false
 }
 
 // alertRow returns an AlertInfo proto if there have been failuresToOpen consecutive failures more recently than passesToClose.
-func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesToClose int) *statepb.AlertInfo {
+//
+// Rows with fewer than minHistory results never alert.
+// When allFailing is set, rows whose every result failed also alert,
+// even with fewer than failuresToOpen results.
+// The ignoreLatest most recent columns never open or close an alert.
+// Flaky results count according to the flaky policy.
+// Placeholder columns, such as the liveness column, are skipped.
+func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesToClose, minHistory, ignoreLatest int, allFailing bool, flaky configpb.TestGroup_FlakyAlert, ids buildIDFunc) *statepb.AlertInfo {
 	if failuresToOpen == 0 {
 		return nil
 	}
@@ -949,11 +3012,23 @@ func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesTo
 	var latestPass *statepb.Column
 	var failIdx int
 	var latestFailIdx int
+	var firstFailCol, passCol int
+	var ignored int
+	onlyFailures := true
 	// find the first number of consecutive passesToClose (no alert)
 	// or else failuresToOpen (alert).
-	for _, col := range cols {
+	for i, col := range cols {
 		// TODO(fejta): ignore old running
 		rawRes := <-ch
+		if col.Placeholder || ignored < ignoreLatest {
+			if !col.Placeholder {
+				ignored++
+			}
+			if rawRes != statuspb.TestStatus_NO_RESULT {
+				compressedIdx++
+			}
+			continue
+		}
 		res := result.Coalesce(rawRes, result.IgnoreRunning)
 		if res == statuspb.TestStatus_NO_RESULT {
 			if rawRes == statuspb.TestStatus_RUNNING {
@@ -961,10 +3036,21 @@ func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesTo
 			}
 			continue
 		}
+		if res == statuspb.TestStatus_FLAKY {
+			switch flaky {
+			case configpb.TestGroup_FLAKY_ALERT_FAIL:
+				res = statuspb.TestStatus_FAIL
+			case configpb.TestGroup_FLAKY_ALERT_IGNORE:
+				compressedIdx++
+				continue
+			}
+		}
 		if res == statuspb.TestStatus_PASS {
+			onlyFailures = false
 			passes++
 			if failures >= failuresToOpen {
 				latestPass = col // most recent pass before outage
+				passCol = i
 				break
 			}
 			if passes >= passesToClose {
@@ -982,8 +3068,10 @@ func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesTo
 			}
 			failIdx = compressedIdx
 			firstFail = col
+			firstFailCol = i
 		}
 		if res == statuspb.TestStatus_FLAKY {
+			onlyFailures = false
 			passes = 0
 			if failures >= failuresToOpen {
 				break // cannot definitively say which commit is at fault
@@ -992,7 +3080,10 @@ func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesTo
 		}
 		compressedIdx++
 	}
-	if failures < failuresToOpen {
+	if failures < failuresToOpen && !(allFailing && onlyFailures && failures > 0) {
+		return nil
+	}
+	if minHistory > 0 && countResults(row) < minHistory {
 		return nil
 	}
 	var id string
@@ -1002,25 +3093,311 @@ func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesTo
 		latestID = row.CellIds[latestFailIdx]
 	}
 	msg := row.Messages[latestFailIdx]
-	return alertInfo(totalFailures, msg, id, latestID, firstFail, latestFail, latestPass)
+	alert := alertInfo(totalFailures, msg, id, latestID, firstFail, latestFail, latestPass, ids)
+	if latestPass != nil {
+		alert.SuspectBuildIds = suspectBuildIDs(cols[firstFailCol:passCol], ids)
+	}
+	return alert
+}
+
+// suspectBuildIDs returns the distinct build id of each column, preserving order.
+//
+// Consecutive columns often share an id, for example when the group selects
+// the commit as the build id.
+func suspectBuildIDs(cols []*statepb.Column, ids buildIDFunc) []string {
+	var out []string
+	for _, col := range cols {
+		id := ids(col)
+		if n := len(out); n > 0 && out[n-1] == id {
+			continue
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// disappearedRows replaces the alert of each row which stopped reporting results.
+//
+// See disappearedRow.
+func disappearedRows(cols []*statepb.Column, rows []*statepb.Row, after int, only []*regexp.Regexp, ids buildIDFunc) {
+	for _, r := range rows {
+		if !matchesAny(r.Name, only) {
+			continue
+		}
+		if alert := disappearedRow(cols, r, after, ids); alert != nil {
+			r.AlertInfo = alert
+		}
+	}
+}
+
+// disappearedRow returns a disappeared alert if the row has no result in at least the newest after columns.
+//
+// Rows which never reported a result do not alert. The pass fields of
+// the alert describe the last column with a result.
+// Placeholder columns, such as the liveness column, never count as missing.
+func disappearedRow(cols []*statepb.Column, row *statepb.Row, after int, ids buildIDFunc) *statepb.AlertInfo {
+	if after <= 0 {
+		return nil
+	}
+	var missing int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		if statuspb.TestStatus(row.Results[i]) != statuspb.TestStatus_NO_RESULT {
+			break
+		}
+		missing += int(row.Results[i+1])
+	}
+	if missing >= len(cols) {
+		return nil // never reported
+	}
+	var absent int
+	var newest, gone *statepb.Column
+	for _, col := range cols[:missing] {
+		if col.Placeholder {
+			continue
+		}
+		if newest == nil {
+			newest = col
+		}
+		gone = col
+		absent++
+	}
+	if absent < after {
+		return nil // still reporting
+	}
+	last := cols[missing]
+	return &statepb.AlertInfo{
+		AlertType:         statepb.AlertInfo_ALERT_TYPE_DISAPPEARED,
+		FailCount:         int32(absent),
+		FailBuildId:       ids(gone),
+		FailTime:          stamp(gone),
+		LatestFailBuildId: ids(newest),
+		FailureMessage:    fmt.Sprintf("No results in the last %d columns", absent),
+		PassBuildId:       ids(last),
+		PassTime:          stamp(last),
+	}
+}
+
+const defaultWeightedDecay = 0.8
+
+// weightedRows opens a weighted alert on rows without an alert whose recent results are mostly failing.
+func weightedRows(cols []*statepb.Column, rows []*statepb.Row, weighted *configpb.TestGroup_WeightedAlert, only []*regexp.Regexp, ids buildIDFunc) {
+	for _, r := range rows {
+		if r.AlertInfo != nil || !matchesAny(r.Name, only) {
+			continue
+		}
+		r.AlertInfo = weightedRow(cols, r, weighted, ids)
+	}
+}
+
+// weightedRow returns a weighted alert when the decayed fraction of failing results exceeds the threshold.
+//
+// The newest completed result weighs 1, and each older completed result
+// weighs decay times the result after it. Empty and running cells are skipped,
+// as are placeholder columns.
+// The alert spans from the oldest to the newest failure, without a pass.
+func weightedRow(cols []*statepb.Column, row *statepb.Row, weighted *configpb.TestGroup_WeightedAlert, ids buildIDFunc) *statepb.AlertInfo {
+	decay := weighted.Decay
+	if decay <= 0 || decay > 1 {
+		decay = defaultWeightedDecay
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, row.Results)
+	weight := 1.0
+	var failed, total float64
+	var failures int32
+	var firstFail, latestFail *statepb.Column
+	for _, col := range cols {
+		res := <-ch
+		if col.Placeholder {
+			continue
+		}
+		switch result.Coalesce(res, result.IgnoreRunning) {
+		case statuspb.TestStatus_NO_RESULT:
+			continue
+		case statuspb.TestStatus_FAIL:
+			failed += weight
+			failures++
+			firstFail = col
+			if latestFail == nil {
+				latestFail = col
+			}
+		}
+		total += weight
+		weight *= decay
+	}
+	if total == 0 {
+		return nil
+	}
+	score := failed / total
+	if score <= weighted.Threshold {
+		return nil
+	}
+	msg := fmt.Sprintf("Weighted failure score %.2f exceeds %.2f", score, weighted.Threshold)
+	alert := alertInfo(failures, msg, "", "", firstFail, latestFail, nil, ids)
+	alert.AlertType = statepb.AlertInfo_ALERT_TYPE_WEIGHTED
+	return alert
+}
+
+// countResults returns the number of non-empty, completed results in the row.
+func countResults(row *statepb.Row) int {
+	var n int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		res := result.Coalesce(statuspb.TestStatus(row.Results[i]), result.IgnoreRunning)
+		if res == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		n += int(row.Results[i+1])
+	}
+	return n
 }
 
 // alertInfo returns an alert proto with the configured fields
-func alertInfo(failures int32, msg, cellID, latestCellID string, fail, latestFail, pass *statepb.Column) *statepb.AlertInfo {
+func alertInfo(failures int32, msg, cellID, latestCellID string, fail, latestFail, pass *statepb.Column, ids buildIDFunc) *statepb.AlertInfo {
 	return &statepb.AlertInfo{
 		FailCount:         failures,
-		FailBuildId:       buildID(fail),
-		LatestFailBuildId: buildID(latestFail),
+		FailBuildId:       ids(fail),
+		LatestFailBuildId: ids(latestFail),
 		FailTime:          stamp(fail),
 		FailTestId:        cellID,
 		LatestFailTestId:  latestCellID,
 		FailureMessage:    msg,
 		PassTime:          stamp(pass),
-		PassBuildId:       buildID(pass),
+		PassBuildId:       ids(pass),
 		EmailAddresses:    emailAddresses(fail),
 	}
 }
 
+// alertMessage is the data passed to alert message templates.
+type alertMessage struct {
+	Group     string
+	Row       string
+	FailBuild string
+	FailCount int32
+	Message   string
+}
+
+// templateAlerts replaces the failure message of each alert with its rendered template.
+//
+// Row templates override the group template. Invalid templates are ignored,
+// as are alerts which fail to render.
+func templateAlerts(log logrus.FieldLogger, group *configpb.TestGroup, rows []*statepb.Row) {
+	parse := func(tmpl string) *template.Template {
+		if tmpl == "" {
+			return nil
+		}
+		t, err := template.New("alert").Parse(tmpl)
+		if err != nil {
+			log.WithError(err).WithField("template", tmpl).Warning("Ignoring bad alert message template")
+			return nil
+		}
+		return t
+	}
+	groupTmpl := parse(group.AlertMessageTemplate)
+	rowTmpls := map[string]*template.Template{}
+	for _, o := range group.RowAlertThresholds {
+		if t := parse(o.AlertMessageTemplate); t != nil {
+			rowTmpls[o.RowName] = t
+		}
+	}
+	if groupTmpl == nil && len(rowTmpls) == 0 {
+		return
+	}
+	for _, row := range rows {
+		if row.AlertInfo == nil {
+			continue
+		}
+		t, ok := rowTmpls[row.Name]
+		if !ok {
+			t = groupTmpl
+		}
+		if t == nil {
+			continue
+		}
+		var buf strings.Builder
+		err := t.Execute(&buf, alertMessage{
+			Group:     group.Name,
+			Row:       row.Name,
+			FailBuild: row.AlertInfo.FailBuildId,
+			FailCount: row.AlertInfo.FailCount,
+			Message:   row.AlertInfo.FailureMessage,
+		})
+		if err != nil {
+			log.WithError(err).WithField("row", row.Name).Warning("Failed to render alert message")
+			continue
+		}
+		row.AlertInfo.FailureMessage = buf.String()
+	}
+}
+
+type issueLinkRule struct {
+	re       *regexp.Regexp
+	template string
+}
+
+// issueLinker links alert failure messages to issues.
+type issueLinker []issueLinkRule
+
+// makeIssueLinker compiles the configured rules, skipping invalid ones.
+func makeIssueLinker(log logrus.FieldLogger, rules []*configpb.TestGroup_IssueLinkRule) issueLinker {
+	var out issueLinker
+	for i, r := range rules {
+		re, err := regexp.Compile(r.MessageRegex)
+		if err != nil {
+			log.WithError(err).WithField("rule", i).Warning("Ignoring bad issue link rule")
+			continue
+		}
+		out = append(out, issueLinkRule{re: re, template: r.IssueUrlTemplate})
+	}
+	return out
+}
+
+// link returns the expanded template of the first rule to match msg, if any.
+func (il issueLinker) link(msg string) string {
+	for _, r := range il {
+		match := r.re.FindStringSubmatchIndex(msg)
+		if match == nil {
+			continue
+		}
+		return string(r.re.ExpandString(nil, r.template, msg, match))
+	}
+	return ""
+}
+
+type targetMetadataRule struct {
+	re        *regexp.Regexp
+	owner     string
+	component string
+}
+
+// targetLabeler attaches the owner and component of each row's target.
+type targetLabeler []targetMetadataRule
+
+// makeTargetLabeler compiles the configured rules, skipping invalid ones.
+func makeTargetLabeler(log logrus.FieldLogger, rules []*configpb.TestGroup_TargetMetadata) targetLabeler {
+	var out targetLabeler
+	for i, r := range rules {
+		re, err := regexp.Compile(r.TargetRegex)
+		if err != nil {
+			log.WithError(err).WithField("rule", i).Warning("Ignoring bad target metadata rule")
+			continue
+		}
+		out = append(out, targetMetadataRule{re: re, owner: r.Owner, component: r.Component})
+	}
+	return out
+}
+
+// label sets the owner and component of the first rule to match the row's target, if any.
+func (tl targetLabeler) label(row *statepb.Row) {
+	for _, r := range tl {
+		if r.re.MatchString(row.Id) {
+			row.Owner = r.owner
+			row.Component = r.component
+			return
+		}
+	}
+}
+
 func emailAddresses(col *statepb.Column) []string {
 	if col == nil {
 		return []string{}
@@ -1028,6 +3405,41 @@ func emailAddresses(col *statepb.Column) []string {
 	return col.GetEmailAddresses()
 }
 
+// buildIDFunc returns the build id of a column.
+type buildIDFunc func(*statepb.Column) string
+
+// buildIDSelector returns how to extract the build id of each column for the group.
+//
+// Uses buildID unless the group configures a selector, in which case columns
+// without a value for the selected header fall back to the Build field.
+func buildIDSelector(group *configpb.TestGroup) buildIDFunc {
+	sel := group.BuildIdSelector
+	if sel == nil {
+		return buildID
+	}
+	idx := int(sel.HeaderIndex)
+	if key := sel.ConfigurationValue; key != "" {
+		idx = -1
+		for i, h := range group.ColumnHeader {
+			if h.ConfigurationValue == key {
+				idx = i
+				break
+			}
+		}
+	}
+	return func(col *statepb.Column) string {
+		if col == nil {
+			return ""
+		}
+		if idx >= 0 && idx < len(col.Extra) {
+			if val := col.Extra[idx]; val != "" && val != metadata.Missing {
+				return val
+			}
+		}
+		return col.Build
+	}
+}
+
 // buildID extracts the ID from the first extra row or else the Build field.
 func buildID(col *statepb.Column) string {
 	if col == nil {
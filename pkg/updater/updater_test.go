@@ -17,18 +17,34 @@ limitations under the License.
 package updater
 
 import (
+	"bytes"
+	"compress/flate"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/fvbommel/sortorder"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
 	"google.golang.org/protobuf/testing/protocmp"
 	core "k8s.io/api/core/v1"
 
@@ -38,6 +54,7 @@ import (
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
 )
@@ -76,7 +93,7 @@ func TestGCS(t *testing.T) {
 			// either because the context is canceled or things like client are unset)
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel()
-			updater := GCS(0, 0, 0, false, SortStarted)
+			updater := GCS(0, 0, 0, 0, false, SortStarted, nil, false, nil, nil, nil, "", gcs.DefaultCompression, nil)
 			defer func() {
 				if r := recover(); r != nil {
 					if !tc.fail {
@@ -97,16 +114,42 @@ func TestGCS(t *testing.T) {
 	}
 }
 
+// preserveMaxUpdateArea returns a func restoring the current update area.
+//
+// Successful updates grow the update area, see TestBumpMaxUpdateArea.
+func preserveMaxUpdateArea() func() {
+	updateAreaLock.RLock()
+	orig := maxUpdateArea
+	updateAreaLock.RUnlock()
+	return func() {
+		updateAreaLock.Lock()
+		maxUpdateArea = orig
+		updateAreaLock.Unlock()
+	}
+}
+
 func TestUpdate(t *testing.T) {
+	defer preserveMaxUpdateArea()()
 	defaultTimeout := 5 * time.Minute
 	configPath := newPathOrDie("gs://bucket/path/to/config")
+	k8sGroup := func(name string) *configpb.TestGroup {
+		return &configpb.TestGroup{
+			Name:                name,
+			GcsPrefix:           "kubernetes-jenkins/path/to/job",
+			DaysOfResults:       7,
+			UseKubernetesClient: true,
+			NumColumnsRecent:    6,
+		}
+	}
 	cases := []struct {
 		name             string
 		ctx              context.Context
 		config           *configpb.Configuration
 		configErr        error
+		inMemory         bool
 		builds           map[string][]fakeBuild
 		gridPrefix       string
+		replicas         []gcs.Path
 		groupConcurrency int
 		buildConcurrency int
 		skipConfirm      bool
@@ -124,13 +167,7 @@ func TestUpdate(t *testing.T) {
 			name: "basically works",
 			config: &configpb.Configuration{
 				TestGroups: []*configpb.TestGroup{
-					{
-						Name:                "hello",
-						GcsPrefix:           "kubernetes-jenkins/path/to/job",
-						DaysOfResults:       7,
-						UseKubernetesClient: true,
-						NumColumnsRecent:    6,
-					},
+					k8sGroup("hello"),
 					{
 						Name:                "skip-non-k8s",
 						GcsPrefix:           "kubernetes-jenkins/path/to/job",
@@ -160,6 +197,7 @@ func TestUpdate(t *testing.T) {
 					Buf:          mustGrid(&statepb.Grid{}),
 					CacheControl: "no-cache",
 					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hello"), &statepb.Grid{}),
 				},
 				*resolveOrDie(&configPath, "skip-non-k8s"): {
 					Buf:          mustGrid(&statepb.Grid{}),
@@ -170,18 +208,41 @@ func TestUpdate(t *testing.T) {
 			successes: 2,
 		},
 		{
-			name:       "bad grid prefix",
-			gridPrefix: "!@#$%^&*()",
+			name:     "in-memory config",
+			inMemory: true,
 			config: &configpb.Configuration{
 				TestGroups: []*configpb.TestGroup{
+					k8sGroup("hello"),
+				},
+				Dashboards: []*configpb.Dashboard{
 					{
-						Name:                "hello",
-						GcsPrefix:           "kubernetes-jenkins/path/to/job",
-						DaysOfResults:       7,
-						UseKubernetesClient: true,
-						NumColumnsRecent:    6,
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "hello-tab",
+								TestGroupName: "hello",
+							},
+						},
 					},
 				},
+			},
+			expected: fakeUploader{
+				*resolveOrDie(&configPath, "hello"): {
+					Buf:          mustGrid(&statepb.Grid{}),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hello"), &statepb.Grid{}),
+				},
+			},
+			successes: 1,
+		},
+		{
+			name:       "bad grid prefix",
+			gridPrefix: "!@#$%^&*()",
+			config: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					k8sGroup("hello"),
+				},
 				Dashboards: []*configpb.Dashboard{
 					{
 						Name: "dash",
@@ -201,27 +262,9 @@ func TestUpdate(t *testing.T) {
 			name: "update specified",
 			config: &configpb.Configuration{
 				TestGroups: []*configpb.TestGroup{
-					{
-						Name:                "hello",
-						GcsPrefix:           "kubernetes-jenkins/path/to/job",
-						DaysOfResults:       7,
-						UseKubernetesClient: true,
-						NumColumnsRecent:    6,
-					},
-					{
-						Name:                "hiya",
-						GcsPrefix:           "kubernetes-jenkins/path/to/job",
-						DaysOfResults:       7,
-						UseKubernetesClient: true,
-						NumColumnsRecent:    6,
-					},
-					{
-						Name:                "goodbye",
-						GcsPrefix:           "kubernetes-jenkins/path/to/job",
-						DaysOfResults:       7,
-						UseKubernetesClient: true,
-						NumColumnsRecent:    6,
-					},
+					k8sGroup("hello"),
+					k8sGroup("hiya"),
+					k8sGroup("goodbye"),
 				},
 				Dashboards: []*configpb.Dashboard{
 					{
@@ -249,15 +292,63 @@ func TestUpdate(t *testing.T) {
 					Buf:          mustGrid(&statepb.Grid{}),
 					CacheControl: "no-cache",
 					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hello"), &statepb.Grid{}),
 				},
 				*resolveOrDie(&configPath, "hiya"): {
 					Buf:          mustGrid(&statepb.Grid{}),
 					CacheControl: "no-cache",
 					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hiya"), &statepb.Grid{}),
 				},
 			},
 			successes: 2,
 		},
+		{
+			name: "replicate grids",
+			config: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					k8sGroup("hello"),
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "hello-tab",
+								TestGroupName: "hello",
+							},
+						},
+					},
+				},
+			},
+			replicas: []gcs.Path{
+				newPathOrDie("gs://replica-bucket/grid"),
+				newPathOrDie("gs://other-bucket/"),
+			},
+			expected: fakeUploader{
+				*resolveOrDie(&configPath, "hello"): {
+					Buf:          mustGrid(&statepb.Grid{}),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hello"), &statepb.Grid{}),
+				},
+				newPathOrDie("gs://replica-bucket/grid/hello"): {
+					Buf:          mustGrid(&statepb.Grid{}),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hello"), &statepb.Grid{}),
+					Generation:   1,
+				},
+				newPathOrDie("gs://other-bucket/hello"): {
+					Buf:          mustGrid(&statepb.Grid{}),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     mustHashMeta(k8sGroup("hello"), &statepb.Grid{}),
+					Generation:   1,
+				},
+			},
+			successes: 1,
+		},
 		// TODO(fejta): more cases
 	}
 
@@ -290,15 +381,20 @@ func TestUpdate(t *testing.T) {
 				},
 			}
 
-			client.Opener[configPath] = fakeObject{
-				Data: func() string {
-					b, err := config.MarshalBytes(tc.config)
-					if err != nil {
-						t.Fatalf("config.MarshalBytes() errored: %v", err)
-					}
-					return string(b)
-				}(),
-				ReadErr: tc.configErr,
+			var source ConfigSource
+			if tc.inMemory {
+				source = StaticConfig(tc.config)
+			} else {
+				client.Opener[configPath] = fakeObject{
+					Data: func() string {
+						b, err := config.MarshalBytes(tc.config)
+						if err != nil {
+							t.Fatalf("config.MarshalBytes() errored: %v", err)
+						}
+						return string(b)
+					}(),
+					ReadErr: tc.configErr,
+				}
 			}
 
 			for _, group := range tc.config.TestGroups {
@@ -316,7 +412,7 @@ func TestUpdate(t *testing.T) {
 				client.Lister[buildsPath] = fi
 			}
 
-			groupUpdater := GCS(*tc.groupTimeout, *tc.buildTimeout, tc.buildConcurrency, !tc.skipConfirm, SortStarted)
+			groupUpdater := GCS(*tc.groupTimeout, *tc.buildTimeout, tc.buildConcurrency, 0, !tc.skipConfirm, SortStarted, nil, false, nil, nil, nil, "", gcs.DefaultCompression, nil)
 			mets := &Metrics{
 				Successes:    &fakeCounter{},
 				Errors:       &fakeCounter{},
@@ -326,15 +422,22 @@ func TestUpdate(t *testing.T) {
 			}
 			err := Update(
 				ctx,
-				client,
+				syncCopyClient{client, &sync.Mutex{}},
 				mets,
 				configPath,
+				source,
 				tc.gridPrefix,
+				tc.replicas,
 				tc.groupConcurrency,
+				0,
 				tc.groupNames,
 				groupUpdater,
 				!tc.skipConfirm,
 				tc.freq,
+				0,
+				nil,
+				false,
+				nil,
 			)
 			switch {
 			case err != nil:
@@ -363,3092 +466,9448 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+// syncCopyClient serializes copies, which otherwise concurrently write to the fake uploader.
+type syncCopyClient struct {
+	fakeUploadClient
+	lock *sync.Mutex
+}
+
+func (c syncCopyClient) Copy(ctx context.Context, from, to gcs.Path) (*storage.ObjectAttrs, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.fakeUploadClient.Copy(ctx, from, to)
+}
+
+func TestReplicaPaths(t *testing.T) {
+	cases := []struct {
+		name     string
+		replicas []gcs.Path
+		expected []gcs.Path
+	}{
+		{
+			name:     "basically works",
+			expected: []gcs.Path{},
+		},
+		{
+			name: "join the group to each prefix",
+			replicas: []gcs.Path{
+				newPathOrDie("gs://bucket/grid"),
+				newPathOrDie("gs://other/grid/"),
+				newPathOrDie("gs://bucket-only"),
+			},
+			expected: []gcs.Path{
+				newPathOrDie("gs://bucket/grid/hello"),
+				newPathOrDie("gs://other/grid/hello"),
+				newPathOrDie("gs://bucket-only/hello"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := replicaPaths(tc.replicas, "hello")
+			if err != nil {
+				t.Fatalf("replicaPaths() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+				t.Errorf("replicaPaths() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 type fakeInt64 struct {
+	lock   sync.Mutex
 	values []int64
 }
 
 func (fi *fakeInt64) Name() string { return "fake-int64" }
 
 func (fi *fakeInt64) Set(n int64, _ ...string) {
+	fi.lock.Lock()
+	defer fi.lock.Unlock()
 	fi.values = append(fi.values, n)
 }
 
 type fakeCounter struct {
+	lock  sync.Mutex
 	total int64
 }
 
 func (fc *fakeCounter) Name() string { return "fake-counter" }
 
 func (fc *fakeCounter) Add(n int64, _ ...string) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
 	fc.total += n
 }
 
-func TestTestGroupPath(t *testing.T) {
-	path := newPathOrDie("gs://bucket/config")
-	pNewPathOrDie := func(s string) *gcs.Path {
-		p := newPathOrDie(s)
-		return &p
+func TestUpdateLogsRunID(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	hook := logtest.NewGlobal()
+	defer logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.DebugLevel)
+	defer logrus.SetLevel(level)
+
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "hello", GcsPrefix: "bucket/path/to/job"},
+		},
+	}
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fakeLister{},
+			Opener: fakeOpener{},
+		},
+	}
+	mets := &Metrics{
+		Successes:    &fakeCounter{},
+		Errors:       &fakeCounter{},
+		Skips:        &fakeCounter{},
+		DelaySeconds: &fakeInt64{},
+		CycleSeconds: &fakeInt64{},
+	}
+	updateGroup := func(_ context.Context, log logrus.FieldLogger, _ gcs.Client, _ *configpb.TestGroup, _ gcs.Path) error {
+		log.Info("Updating group")
+		return nil
+	}
+	ctx := util.WithRunID(context.Background(), "my-run")
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	if err := Update(ctx, client, mets, configPath, StaticConfig(cfg), "", nil, 1, 0, nil, updateGroup, false, 0, 0, nil, false, nil); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
 	}
+
+	entries := hook.AllEntries()
+	if len(entries) == 0 {
+		t.Fatal("Update() failed to log")
+	}
+	for _, e := range entries {
+		if got, want := e.Data["run"], "my-run"; got != want {
+			t.Errorf("Update() logged %q with run %v, want %q", e.Message, got, want)
+		}
+	}
+}
+
+func TestUpdateRetries(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	defer func(backoff time.Duration) { groupRetryBackoff = backoff }(groupRetryBackoff)
+	groupRetryBackoff = time.Millisecond
+
+	transient := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	permanent := errors.New("invalid config")
 	cases := []struct {
-		name       string
-		groupName  string
-		gridPrefix string
-		expected   *gcs.Path
+		name      string
+		retries   int
+		errs      []error
+		attempts  int
+		successes int64
+		errors    int64
 	}{
 		{
-			name:     "basically works",
-			expected: &path,
-		},
-		{
-			name:      "invalid group name errors",
-			groupName: "---://foo",
+			name:      "basically works",
+			attempts:  1,
+			successes: 1,
 		},
 		{
-			name:      "bucket change errors",
-			groupName: "gs://honey-bucket/config",
+			name:     "fail without retries",
+			errs:     []error{transient},
+			attempts: 1,
+			errors:   1,
 		},
 		{
-			name:      "normal behavior works",
-			groupName: "random-group",
-			expected:  pNewPathOrDie("gs://bucket/random-group"),
+			name:      "retry transient failures",
+			retries:   3,
+			errs:      []error{transient, transient},
+			attempts:  3,
+			successes: 1,
 		},
 		{
-			name:      "target a subfolder works",
-			groupName: "beta/random-group",
-			expected:  pNewPathOrDie("gs://bucket/beta/random-group"),
+			name:     "give up after retries",
+			retries:  1,
+			errs:     []error{transient, transient, transient},
+			attempts: 2,
+			errors:   1,
 		},
 		{
-			name:      "resolve reference fails",
-			groupName: "http://bucket/config",
+			name:     "do not retry permanent failures",
+			retries:  3,
+			errs:     []error{permanent},
+			attempts: 1,
+			errors:   1,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual, err := testGroupPath(path, tc.gridPrefix, tc.groupName)
-			switch {
-			case err != nil:
-				if tc.expected != nil {
-					t.Errorf("testGroupPath(%v, %v) got unexpected error: %v", path, tc.groupName, err)
-				}
-			case tc.expected == nil:
-				t.Errorf("testGroupPath(%v, %v) failed to receive an error", path, tc.groupName)
-			default:
-				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcs.Path{})); diff != "" {
-					t.Errorf("testGroupPath(%v, %v) got unexpected diff (-have, +want):\n%s", path, tc.groupName, diff)
+			cfg := &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "hello", GcsPrefix: "bucket/path/to/job"},
+				},
+			}
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			mets := &Metrics{
+				Successes:    &fakeCounter{},
+				Errors:       &fakeCounter{},
+				Skips:        &fakeCounter{},
+				DelaySeconds: &fakeInt64{},
+				CycleSeconds: &fakeInt64{},
+			}
+			var attempts int
+			updateGroup := func(context.Context, logrus.FieldLogger, gcs.Client, *configpb.TestGroup, gcs.Path) error {
+				attempts++
+				if attempts <= len(tc.errs) {
+					return tc.errs[attempts-1]
 				}
+				return nil
+			}
+			configPath := newPathOrDie("gs://bucket/path/to/config")
+			if err := Update(context.Background(), client, mets, configPath, StaticConfig(cfg), "", nil, 1, tc.retries, nil, updateGroup, false, 0, 0, nil, false, nil); err != nil {
+				t.Fatalf("Update() got unexpected error: %v", err)
+			}
+			if attempts != tc.attempts {
+				t.Errorf("Update() got %d attempts, want %d", attempts, tc.attempts)
+			}
+			if got, want := mets.Successes.(*fakeCounter).total, tc.successes; got != want {
+				t.Errorf("Update() got %d successes, want %d", got, want)
+			}
+			if got, want := mets.Errors.(*fakeCounter).total, tc.errors; got != want {
+				t.Errorf("Update() got %d errors, want %d", got, want)
 			}
 		})
 	}
 }
 
-func jsonStarted(stamp int64) *fakeObject {
-	return &fakeObject{
-		Data: jsonData(metadata.Started{Timestamp: stamp}),
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "permanent",
+			err:  errors.New("invalid config"),
+		},
+		{
+			name:     "server error",
+			err:      &googleapi.Error{Code: http.StatusInternalServerError},
+			expected: true,
+		},
+		{
+			name:     "rate limited",
+			err:      fmt.Errorf("upload: %w", &googleapi.Error{Code: http.StatusTooManyRequests}),
+			expected: true,
+		},
+		{
+			name: "not found",
+			err:  &googleapi.Error{Code: http.StatusNotFound},
+		},
+		{
+			name:     "truncated read",
+			err:      fmt.Errorf("read: %w", io.ErrUnexpectedEOF),
+			expected: true,
+		},
 	}
-}
 
-func jsonFinished(stamp int64, passed bool, meta metadata.Metadata) *fakeObject {
-	return &fakeObject{
-		Data: jsonData(metadata.Finished{
-			Timestamp: &stamp,
-			Passed:    &passed,
-			Metadata:  meta,
-		}),
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := isTransient(tc.err); actual != tc.expected {
+				t.Errorf("isTransient(%v) got %t, want %t", tc.err, actual, tc.expected)
+			}
+		})
 	}
 }
 
-var (
-	podInfoSuccessPodInfo = gcs.PodInfo{
-		Pod: &core.Pod{
-			Status: core.PodStatus{
-				Phase: core.PodSucceeded,
-			},
+func TestUpdateGroupLogLevel(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	hook := logtest.NewGlobal()
+	defer logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.InfoLevel)
+	defer logrus.SetLevel(level)
+
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "noisy", GcsPrefix: "bucket/path/to/job", LogLevel: "debug"},
+			{Name: "quiet", GcsPrefix: "bucket/path/to/job"},
+			{Name: "typo", GcsPrefix: "bucket/path/to/job", LogLevel: "verbose"},
 		},
 	}
-	podInfoSuccess     = jsonPodInfo(podInfoSuccessPodInfo)
-	podInfoPassCell    = cell{Result: statuspb.TestStatus_PASS}
-	podInfoMissingCell = cell{
-		Result:  statuspb.TestStatus_FAIL,
-		Icon:    "!",
-		Message: gcs.MissingPodInfo,
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fakeLister{},
+			Opener: fakeOpener{},
+		},
+	}
+	mets := &Metrics{
+		Successes:    &fakeCounter{},
+		Errors:       &fakeCounter{},
+		Skips:        &fakeCounter{},
+		DelaySeconds: &fakeInt64{},
+		CycleSeconds: &fakeInt64{},
+	}
+	updateGroup := func(_ context.Context, log logrus.FieldLogger, _ gcs.Client, _ *configpb.TestGroup, _ gcs.Path) error {
+		log.Debug("Updating group")
+		return nil
+	}
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	if err := Update(context.Background(), client, mets, configPath, StaticConfig(cfg), "", nil, 1, 0, nil, updateGroup, false, 0, 0, nil, false, nil); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
 	}
-)
-
-func jsonPodInfo(podInfo gcs.PodInfo) *fakeObject {
-	return &fakeObject{Data: jsonData(podInfo)}
-}
 
-func mustGrid(grid *statepb.Grid) []byte {
-	buf, err := gcs.MarshalGrid(grid)
-	if err != nil {
-		panic(err)
+	var actual []string
+	for _, e := range hook.AllEntries() {
+		if e.Message == "Updating group" {
+			actual = append(actual, e.Data["group"].(string))
+		}
+	}
+	if diff := cmp.Diff([]string{"noisy"}, actual); diff != "" {
+		t.Errorf("Update() got unexpected debug lines (-want +got):\n%s", diff)
 	}
-	return buf
 }
 
-/*
-func TestSortGroups(t *testing.T) {
-	now := time.Now()
-	times := []time.Time{
-		now.Add(1 * time.Hour),
-		now.Add(2 * time.Hour),
-		now.Add(3 * time.Hour),
-		now.Add(4 * time.Hour),
+func TestUpdateRunTimeout(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	cfg := &configpb.Configuration{}
+	for i := 0; i < 20; i++ {
+		cfg.TestGroups = append(cfg.TestGroups, &configpb.TestGroup{
+			Name:      fmt.Sprintf("group-%d", i),
+			GcsPrefix: "bucket/path/to/job",
+		})
+	}
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fakeLister{},
+			Opener: fakeOpener{},
+		},
+	}
+	mets := &Metrics{
+		Successes:    &fakeCounter{},
+		Errors:       &fakeCounter{},
+		Skips:        &fakeCounter{},
+		DelaySeconds: &fakeInt64{},
+		CycleSeconds: &fakeInt64{},
+	}
+	var lock sync.Mutex
+	var started, finished int
+	updateGroup := func(ctx context.Context, _ logrus.FieldLogger, _ gcs.Client, _ *configpb.TestGroup, _ gcs.Path) error {
+		lock.Lock()
+		started++
+		lock.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		lock.Lock()
+		finished++
+		lock.Unlock()
+		return nil
+	}
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	if err := Update(context.Background(), client, mets, configPath, StaticConfig(cfg), "", nil, 2, 0, nil, updateGroup, false, 0, 50*time.Millisecond, nil, false, nil); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+
+	if started == 0 || started >= len(cfg.TestGroups) {
+		t.Errorf("Update() started %d of %d groups, want it to stop early", started, len(cfg.TestGroups))
+	}
+	if finished != started {
+		t.Errorf("Update() finished %d of %d started groups, want all in-flight groups to finish", finished, started)
+	}
+	if got, want := mets.Successes.(*fakeCounter).total, int64(finished); got != want {
+		t.Errorf("Update() reported %d successes, want %d", got, want)
+	}
+}
+
+func TestResolveAliases(t *testing.T) {
+	all := []*configpb.TestGroup{
+		{Name: "real", GcsPrefix: "bucket/real"},
+		{Name: "alias", AliasOf: "real", NumFailuresToAlert: 3},
+		{Name: "alias-of-alias", AliasOf: "alias"},
+		{Name: "loop", AliasOf: "cycle"},
+		{Name: "cycle", AliasOf: "loop"},
+		{Name: "missing", AliasOf: "unknown"},
+		{Name: "empty"},
+		{Name: "dead-end", AliasOf: "empty"},
+	}
+	cases := []struct {
+		name     string
+		groups   []string
+		expected []*configpb.TestGroup
+		err      bool
+	}{
+		{
+			name:   "groups with a prefix are unchanged",
+			groups: []string{"real"},
+			expected: []*configpb.TestGroup{
+				{Name: "real", GcsPrefix: "bucket/real"},
+			},
+		},
+		{
+			name:   "read the prefix of the aliased group",
+			groups: []string{"real", "alias"},
+			expected: []*configpb.TestGroup{
+				{Name: "real", GcsPrefix: "bucket/real"},
+				{Name: "alias", AliasOf: "real", GcsPrefix: "bucket/real", NumFailuresToAlert: 3},
+			},
+		},
+		{
+			name:   "follow alias chains",
+			groups: []string{"alias-of-alias"},
+			expected: []*configpb.TestGroup{
+				{Name: "alias-of-alias", AliasOf: "alias", GcsPrefix: "bucket/real"},
+			},
+		},
+		{
+			name:   "groups without an alias are unchanged",
+			groups: []string{"empty"},
+			expected: []*configpb.TestGroup{
+				{Name: "empty"},
+			},
+		},
+		{
+			name:   "reject cycles",
+			groups: []string{"loop"},
+			err:    true,
+		},
+		{
+			name:   "reject unknown groups",
+			groups: []string{"missing"},
+			err:    true,
+		},
+		{
+			name:   "reject aliases without a prefix",
+			groups: []string{"dead-end"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			byName := map[string]*configpb.TestGroup{}
+			for _, tg := range all {
+				byName[tg.Name] = tg
+			}
+			var groups []*configpb.TestGroup
+			for _, name := range tc.groups {
+				groups = append(groups, byName[name])
+			}
+			original := proto.Clone(&configpb.Configuration{TestGroups: all})
+			actual, err := resolveAliases(groups, all)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("resolveAliases() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("resolveAliases() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+					t.Errorf("resolveAliases() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(original, &configpb.Configuration{TestGroups: all}, protocmp.Transform()); diff != "" {
+				t.Errorf("resolveAliases() modified the configuration (-was +now):\n%s", diff)
+			}
+		})
 	}
+}
 
+func TestApplySilences(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour).Unix()
+	muchLater := now.Add(24 * time.Hour).Unix()
+	earlier := now.Add(-time.Hour).Unix()
 	cases := []struct {
 		name     string
 		groups   []*configpb.TestGroup
-		updated  map[string]*time.Time
+		silences []*configpb.AlertSilence
 		expected []*configpb.TestGroup
 	}{
 		{
-			name: "basically works",
+			name: "no silences",
+			groups: []*configpb.TestGroup{
+				{Name: "hello"},
+			},
+			expected: []*configpb.TestGroup{
+				{Name: "hello"},
+			},
 		},
 		{
-			name: "sorts multiple groups by least recently updated",
+			name: "silence matching groups",
 			groups: []*configpb.TestGroup{
-				{Name: "middle"},
-				{Name: "new"},
-				{Name: "old"},
+				{Name: "infra-hello"},
+				{Name: "world"},
+				{Name: "infra-world"},
 			},
-			updated: map[string]*time.Time{
-				"old":    &times[0],
-				"middle": &times[1],
-				"new":    &times[2],
+			silences: []*configpb.AlertSilence{
+				{GroupPattern: "^infra-", Until: later},
 			},
 			expected: []*configpb.TestGroup{
-				{Name: "old"},
-				{Name: "middle"},
-				{Name: "new"},
+				{Name: "infra-hello", SilenceAlertsUntil: later},
+				{Name: "world"},
+				{Name: "infra-world", SilenceAlertsUntil: later},
 			},
 		},
 		{
-			name: "groups with no existing state come first",
+			name: "ignore expired silences",
 			groups: []*configpb.TestGroup{
-				{Name: "new"},
-				{Name: "never"},
-				{Name: "old"},
+				{Name: "hello"},
 			},
-			updated: map[string]*time.Time{
-				"old": &times[0],
-				"new": &times[2],
+			silences: []*configpb.AlertSilence{
+				{GroupPattern: "hello", Until: earlier},
 			},
 			expected: []*configpb.TestGroup{
-				{Name: "never"},
-				{Name: "old"},
-				{Name: "new"},
+				{Name: "hello"},
 			},
 		},
 		{
-			name: "groups with an error state also go first",
+			name: "latest silence wins",
 			groups: []*configpb.TestGroup{
-				{Name: "new"},
-				{Name: "boom"},
-				{Name: "old"},
+				{Name: "hello"},
+				{Name: "world", SilenceAlertsUntil: muchLater},
 			},
-			updated: map[string]*time.Time{
-				"boom": nil,
-				"old":  &times[0],
-				"new":  &times[2],
+			silences: []*configpb.AlertSilence{
+				{GroupPattern: ".*", Until: later},
+				{GroupPattern: "hello", Until: muchLater},
 			},
 			expected: []*configpb.TestGroup{
-				{Name: "boom"},
-				{Name: "old"},
-				{Name: "new"},
+				{Name: "hello", SilenceAlertsUntil: muchLater},
+				{Name: "world", SilenceAlertsUntil: muchLater},
+			},
+		},
+		{
+			name: "ignore bad patterns",
+			groups: []*configpb.TestGroup{
+				{Name: "hello"},
+			},
+			silences: []*configpb.AlertSilence{
+				{GroupPattern: "[", Until: later},
+			},
+			expected: []*configpb.TestGroup{
+				{Name: "hello"},
 			},
 		},
 	}
 
-	const gridPrefix = "grid"
-	configPath, err := gcs.NewPath("gs://k8s-testgrid-canary/config")
-	if err != nil {
-		t.Fatalf("bad path: %v", err)
-	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			ctx, cancel := context.WithCancel(context.Background())
-			client := fakeStater{}
-			for name, updated := range tc.updated {
-				var stat fakeStat
-				if updated == nil {
-					stat.Err = errors.New("error")
-				} else {
-					stat.Attrs.Updated = *updated
-				}
-				path, err := testGroupPath(*configPath, gridPrefix, name)
-				if err != nil {
-					t.Fatalf("bad group path: %v", err)
-				}
-				client[*path] = stat
+			var original []*configpb.TestGroup
+			for _, tg := range tc.groups {
+				original = append(original, proto.Clone(tg).(*configpb.TestGroup))
+			}
+			actual := applySilences(logrus.WithField("name", tc.name), tc.groups, tc.silences, now)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("applySilences() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, tc.groups, protocmp.Transform()); diff != "" {
+				t.Errorf("applySilences() modified the configured groups (-want +got):\n%s", diff)
 			}
-			defer cancel()
-			sortGroups(ctx, logrus.WithField("case", tc.name), client, *configPath, gridPrefix, tc.groups)
 		})
 	}
 }
-*/
 
-func TestTruncateRunning(t *testing.T) {
-	now := float64(time.Now().UTC().Unix() * 1000)
-	ancient := float64(time.Now().Add(-74*time.Hour).UTC().Unix() * 1000)
+func TestReadConfig(t *testing.T) {
+	configPath := newPathOrDie("gs://bucket/path/to/config")
+	makeConfig := func(name string) *configpb.Configuration {
+		return &configpb.Configuration{
+			TestGroups: []*configpb.TestGroup{
+				{
+					Name:             name,
+					GcsPrefix:        "kubernetes-jenkins/path/to/job",
+					DaysOfResults:    7,
+					NumColumnsRecent: 6,
+				},
+			},
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name: "dash",
+					DashboardTab: []*configpb.DashboardTab{
+						{
+							Name:          name + "-tab",
+							TestGroupName: name,
+						},
+					},
+				},
+			},
+		}
+	}
+	good := makeConfig("good")
+	fresh := makeConfig("fresh")
+	marshal := func(cfg *configpb.Configuration) string {
+		buf, err := config.MarshalBytes(cfg)
+		if err != nil {
+			t.Fatalf("config.MarshalBytes() errored: %v", err)
+		}
+		return string(buf)
+	}
 	cases := []struct {
 		name     string
-		cols     []inflatedColumn
-		expected func([]inflatedColumn) []inflatedColumn
+		noCache  bool
+		prior    *configpb.Configuration
+		current  fakeObject
+		expected *configpb.Configuration
+		gen      int64
+		err      bool
 	}{
 		{
 			name: "basically works",
+			current: fakeObject{
+				Data:  marshal(fresh),
+				Attrs: &storage.ReaderObjectAttrs{Generation: 7},
+			},
+			expected: fresh,
+			gen:      7,
 		},
 		{
-			name: "keep everything (no Overall)",
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "this",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "that",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "another",
-						Started: now,
-					},
-				},
+			name:  "prefer fresh config over cache",
+			prior: good,
+			current: fakeObject{
+				Data: marshal(fresh),
 			},
+			expected: fresh,
 		},
 		{
-			name: "keep everything completed",
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "passed",
-						Started: now,
-					},
-					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_PASS}},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "failed",
-						Started: now,
-					},
-					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_FAIL}},
-				},
+			name:  "use cache when open fails",
+			prior: good,
+			current: fakeObject{
+				OpenErr: errors.New("injected open error"),
 			},
+			expected: good,
 		},
 		{
-			name: "drop everything before oldest running",
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "this1",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "this2",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "running1",
-						Started: now,
-					},
-					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "this3",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "running2",
-						Started: now,
-					},
-					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "this4",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "this5",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "this6",
-						Started: now,
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "this7",
-						Started: now,
-					},
-				},
+			name:  "use cache when read fails",
+			prior: good,
+			current: fakeObject{
+				ReadErr: errors.New("injected read error"),
 			},
-			expected: func(cols []inflatedColumn) []inflatedColumn {
-				return cols[5:] // this4 and earlier
+			expected: good,
+		},
+		{
+			name:  "use cache when config is corrupt",
+			prior: good,
+			current: fakeObject{
+				Data: "garbage",
 			},
+			expected: good,
 		},
 		{
-			name: "drop all as all are running",
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "running1",
-						Started: now,
-					},
-					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "running2",
-						Started: now,
-					},
-					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
-				},
-			},
-			expected: func(cols []inflatedColumn) []inflatedColumn {
-				return cols[2:]
+			name: "error without a prior success",
+			current: fakeObject{
+				OpenErr: errors.New("injected open error"),
 			},
+			err: true,
 		},
 		{
-			name: "drop running columns if any process is running",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "running",
-						Started: now,
-					},
-					Cells: map[string]cell{
-						"process1": {Result: statuspb.TestStatus_RUNNING},
-						"process2": {Result: statuspb.TestStatus_RUNNING},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "running-partially",
-						Started: now,
-					},
-					Cells: map[string]cell{
-						"process1": {Result: statuspb.TestStatus_RUNNING},
-						"process2": {Result: statuspb.TestStatus_PASS},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "ok",
-						Started: now,
-					},
-					Cells: map[string]cell{
-						"process1": {Result: statuspb.TestStatus_PASS},
-						"process2": {Result: statuspb.TestStatus_PASS},
-					},
-				},
-			},
-			expected: func(cols []inflatedColumn) []inflatedColumn {
-				return cols[2:]
+			name:    "error without a cache",
+			noCache: true,
+			prior:   good,
+			current: fakeObject{
+				OpenErr: errors.New("injected open error"),
 			},
+			err: true,
 		},
 		{
-			name: "ignore ancient running columns",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "recent-running",
-						Started: now,
-					},
-					Cells: map[string]cell{"drop": {Result: statuspb.TestStatus_RUNNING}},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "recent-done",
-						Started: now - 1,
-					},
-					Cells: map[string]cell{"keep": {Result: statuspb.TestStatus_PASS}},
-				},
-
-				{
-					Column: &statepb.Column{
-						Build:   "running-ancient",
-						Started: ancient,
-					},
-					Cells: map[string]cell{"too-old-to-drop": {Result: statuspb.TestStatus_RUNNING}},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "ok",
-						Started: ancient - 1,
-					},
-					Cells: map[string]cell{"also keep": {Result: statuspb.TestStatus_PASS}},
-				},
-			},
-			expected: func(cols []inflatedColumn) []inflatedColumn {
-				return cols[1:]
+			name:  "do not use cache when config is unchanged",
+			prior: good,
+			current: fakeObject{
+				OpenErr: &googleapi.Error{Code: http.StatusPreconditionFailed},
 			},
+			err: true,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := truncateRunning(tc.cols)
-			expected := tc.cols
-			if tc.expected != nil {
-				expected = tc.expected(expected)
+			dir, err := ioutil.TempDir("", "config-cache")
+			if err != nil {
+				t.Fatalf("ioutil.TempDir() errored: %v", err)
 			}
-			if diff := cmp.Diff(actual, expected, protocmp.Transform()); diff != "" {
-				t.Errorf("truncateRunning() got unexpected diff:\n%s", diff)
+			defer os.RemoveAll(dir)
+			var cachePath string
+			if !tc.noCache {
+				cachePath = filepath.Join(dir, "config.pb")
+			}
+			ctx := context.Background()
+			log := logrus.WithField("name", tc.name)
+			if tc.prior != nil {
+				opener := fakeOpener{configPath: fakeObject{Data: marshal(tc.prior)}}
+				if _, _, err := readConfig(ctx, log, opener, configPath, cachePath); err != nil {
+					t.Fatalf("readConfig() prior read errored: %v", err)
+				}
+			}
+
+			opener := fakeOpener{configPath: tc.current}
+			actual, gen, err := readConfig(ctx, log, opener, configPath, cachePath)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("readConfig() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("readConfig() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+					t.Errorf("readConfig() got unexpected diff (-want +got):\n%s", diff)
+				}
+				if gen != tc.gen {
+					t.Errorf("readConfig() got generation %d, want %d", gen, tc.gen)
+				}
 			}
 		})
 	}
 }
 
-func TestTruncateBuilds(t *testing.T) {
+func TestFileConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	expected := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "hello", GcsPrefix: "bucket/path/to/job", DaysOfResults: 7, NumColumnsRecent: 6},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "hello-tab", TestGroupName: "hello"},
+				},
+			},
+		},
+	}
+	buf, err := config.MarshalBytes(expected)
+	if err != nil {
+		t.Fatalf("config.MarshalBytes() errored: %v", err)
+	}
+	path := filepath.Join(dir, "config.pb")
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	ctx := context.Background()
+	log := logrus.WithField("test", t.Name())
+	actual, gen, err := FileConfig(path).ReadConfig(ctx, log, fakeOpener{})
+	if err != nil {
+		t.Fatalf("ReadConfig() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, actual, protocmp.Transform()); diff != "" {
+		t.Errorf("ReadConfig() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if gen != 0 {
+		t.Errorf("ReadConfig() got generation %d, want 0", gen)
+	}
+
+	if _, _, err := FileConfig(filepath.Join(dir, "missing.pb")).ReadConfig(ctx, log, fakeOpener{}); err == nil {
+		t.Error("ReadConfig() of a missing file failed to return an error")
+	}
+}
+
+func TestOverlayConfig(t *testing.T) {
+	base := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "hello", GcsPrefix: "bucket/base/hello"},
+			{Name: "world", GcsPrefix: "bucket/base/world"},
+		},
+	}
+	overlay := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "world", GcsPrefix: "bucket/prod/world", DaysOfResults: 3},
+			{Name: "new", GcsPrefix: "bucket/prod/new"},
+		},
+	}
+
 	cases := []struct {
-		name   string
-		builds int
-		rows   []int
-		start  int
-		end    int
+		name     string
+		sources  []ConfigSource
+		expected *configpb.Configuration
+		err      bool
 	}{
 		{
-			name: "basically works",
-		},
-		{
-			name:   "usually include everything",
-			builds: 5,
-			rows:   []int{100},
-			start:  0,
-			end:    5,
+			name:     "basically works",
+			expected: &configpb.Configuration{},
 		},
 		{
-			name:   "many rows truncates columns",
-			builds: 10,
-			rows:   []int{maxUpdateArea / 4, maxUpdateArea / 4, maxUpdateArea / 4, maxUpdateArea / 4},
-			start:  6,
-			end:    10,
+			name:    "later sources override earlier ones",
+			sources: []ConfigSource{StaticConfig(base), StaticConfig(overlay)},
+			expected: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "hello", GcsPrefix: "bucket/base/hello"},
+					{Name: "world", GcsPrefix: "bucket/prod/world", DaysOfResults: 3},
+					{Name: "new", GcsPrefix: "bucket/prod/new"},
+				},
+			},
 		},
 		{
-			name:   "many new columns truncates",
-			builds: maxUpdateArea,
-			rows:   []int{1000},
-			start:  maxUpdateArea - (maxUpdateArea / 1000),
-			end:    maxUpdateArea,
+			name:    "error on missing sources",
+			sources: []ConfigSource{StaticConfig(base), FileConfig("/this/does/not/exist.pb")},
+			err:     true,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			var builds []gcs.Build
-			var cols []inflatedColumn
-			var expected []gcs.Build
-
-			for i := 0; i < tc.builds; i++ {
-				p, err := gcs.NewPath(fmt.Sprintf("gs://fake/job/%d", i))
-				if err != nil {
-					t.Fatalf("bad path: %v", err)
-				}
-				b := gcs.Build{Path: *p}
-				builds = append(builds, b)
-				if i >= tc.start && i < tc.end {
-					expected = append(expected, b)
+			ctx := context.Background()
+			log := logrus.WithField("test", t.Name())
+			actual, gen, err := OverlayConfig(tc.sources...).ReadConfig(ctx, log, fakeOpener{})
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ReadConfig() got unexpected error: %v", err)
 				}
-			}
-
-			for _, r := range tc.rows {
-				col := inflatedColumn{
-					Cells: map[string]cell{},
+			case tc.err:
+				t.Error("ReadConfig() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+					t.Errorf("ReadConfig() got unexpected diff (-want +got):\n%s", diff)
 				}
-				for i := 0; i < r; i++ {
-					id := fmt.Sprintf("cell %d", i)
-					c := cell{CellID: id}
-					col.Cells[id] = c
+				if gen != 0 {
+					t.Errorf("ReadConfig() got generation %d, want 0", gen)
 				}
-				cols = append(cols, col)
-			}
-
-			actual := truncateBuilds(logrus.WithField("name", tc.name), builds, cols)
-			diff := cmp.Diff(actual, expected, cmp.AllowUnexported(gcs.Build{}, gcs.Path{}, cell{}, inflatedColumn{}), protocmp.Transform())
-			if diff == "" {
-				return
-			}
-			if have, want := len(actual), len(expected); have != want {
-				t.Errorf("truncateRunning() got %d columns, want %d", have, want)
-			} else {
-				t.Errorf("truncateRunning() got unexpected diff (-have, +want):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestListBuilds(t *testing.T) {
+func TestTestGroupPath(t *testing.T) {
+	path := newPathOrDie("gs://bucket/config")
+	pNewPathOrDie := func(s string) *gcs.Path {
+		p := newPathOrDie(s)
+		return &p
+	}
 	cases := []struct {
-		name     string
-		since    string
-		client   fakeLister
-		paths    []gcs.Path
-		expected []gcs.Build
-		err      bool
+		name       string
+		groupName  string
+		gridPrefix string
+		expected   *gcs.Path
 	}{
 		{
-			name: "basically works",
+			name:     "basically works",
+			expected: &path,
 		},
 		{
-			name: "list stuff correctly",
-			client: fakeLister{
-				newPathOrDie("gs://prefix/job/"): fakeIterator{
-					Objects: []storage.ObjectAttrs{
-						{
-							Prefix: "job/1/",
-						},
-						{
-							Prefix: "job/10/",
-						},
-						{
-							Prefix: "job/2/",
-						},
-					},
-					Idx:    0,
-					Err:    0,
-					Offset: "",
-				},
-			},
-			paths: []gcs.Path{
-				newPathOrDie("gs://prefix/job/"),
-			},
-			expected: []gcs.Build{
-				{
-					Path: newPathOrDie("gs://prefix/job/10/"),
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/2/"),
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/1/"),
-				},
-			},
+			name:      "invalid group name errors",
+			groupName: "---://foo",
 		},
 		{
-			name:  "list offsets correctly",
-			since: "3",
-			client: fakeLister{
-				newPathOrDie("gs://prefix/job/"): fakeIterator{
-					Objects: []storage.ObjectAttrs{
-						{
-							Prefix: "job/1/",
-						},
-						{
-							Prefix: "job/10/",
-						},
-						{
-							Prefix: "job/2/",
-						},
-						{
-							Prefix: "job/3/",
-						},
-						{
-							Prefix: "job/4/",
-						},
-					},
-					Idx:    0,
-					Err:    0,
-					Offset: "",
-				},
-			},
-			paths: []gcs.Path{
-				newPathOrDie("gs://prefix/job/"),
-			},
-			expected: []gcs.Build{
-				{
-					Path: newPathOrDie("gs://prefix/job/10/"),
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/4/"),
-				},
-			},
+			name:      "bucket change errors",
+			groupName: "gs://honey-bucket/config",
 		},
 		{
-			name: "collate stuff correctly",
-			client: fakeLister{
-				newPathOrDie("gs://prefix/job/"): fakeIterator{
-					Objects: []storage.ObjectAttrs{
-						{
-							Prefix: "job/1/",
-						},
-						{
-							Prefix: "job/10/",
-						},
-						{
-							Prefix: "job/3/",
-						},
-					},
-				},
-				newPathOrDie("gs://other-prefix/presubmit-job/"): fakeIterator{
-					Objects: []storage.ObjectAttrs{
-						{
-							Name: "job/2",
-							Metadata: map[string]string{
-								"link": "gs://foo/bar333", // intentionally larger than job 20 and 4
-							},
-						},
-						{
-							Name: "job/20",
-							Metadata: map[string]string{
-								"link": "gs://foo/bar222",
-							},
-						},
-						{
-
-							Name: "job/4",
-							Metadata: map[string]string{
-								"link": "gs://foo/bar111",
-							},
-						},
-					},
-				},
-			},
-			paths: []gcs.Path{
-				newPathOrDie("gs://prefix/job/"),
-				newPathOrDie("gs://other-prefix/presubmit-job/"),
-			},
-			expected: []gcs.Build{
-				{
-					Path: newPathOrDie("gs://foo/bar222/"),
-					// baseName: 20
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/10/"),
-				},
-				{
-					Path: newPathOrDie("gs://foo/bar111/"),
-					// baseName: 4
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/3/"),
-				},
-				{
-					Path: newPathOrDie("gs://foo/bar333/"),
-					// baseName: 2
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/1/"),
-				},
-			},
+			name:      "normal behavior works",
+			groupName: "random-group",
+			expected:  pNewPathOrDie("gs://bucket/random-group"),
 		},
 		{
-			name:  "collated offsets work correctly",
-			since: "5", // drop 4 3 2 1, keep 20, 10
-			client: fakeLister{
-				newPathOrDie("gs://prefix/job/"): fakeIterator{
-					Objects: []storage.ObjectAttrs{
-						{
-							Prefix: "job/1/",
-						},
-						{
-							Prefix: "job/10/",
-						},
-						{
-							Prefix: "job/3/",
-						},
-					},
-				},
-				newPathOrDie("gs://other-prefix/presubmit-job/"): fakeIterator{
-					Objects: []storage.ObjectAttrs{
-						{
-							Name: "job/2",
-							Metadata: map[string]string{
-								"link": "gs://foo/bar333", // intentionally larger than job 20 and 4
-							},
-						},
-						{
-							Name: "job/20",
-							Metadata: map[string]string{
-								"link": "gs://foo/bar222",
-							},
-						},
-						{
-
-							Name: "job/4",
-							Metadata: map[string]string{
-								"link": "gs://foo/bar111",
-							},
-						},
-					},
-				},
-			},
-			paths: []gcs.Path{
-				newPathOrDie("gs://prefix/job/"),
-				newPathOrDie("gs://other-prefix/presubmit-job/"),
-			},
-			expected: []gcs.Build{
-				{
-					Path: newPathOrDie("gs://foo/bar222/"),
-					// baseName: 20
-				},
-				{
-					Path: newPathOrDie("gs://prefix/job/10/"),
-				},
-			},
+			name:      "target a subfolder works",
+			groupName: "beta/random-group",
+			expected:  pNewPathOrDie("gs://bucket/beta/random-group"),
+		},
+		{
+			name:      "resolve reference fails",
+			groupName: "http://bucket/config",
 		},
 	}
 
-	compareBuilds := cmp.Comparer(func(x, y gcs.Build) bool {
-		return x.String() == y.String()
-	})
-	ctx := context.Background()
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual, err := listBuilds(ctx, tc.client, tc.since, tc.paths...)
+			actual, err := testGroupPath(path, tc.gridPrefix, tc.groupName)
 			switch {
 			case err != nil:
-				if !tc.err {
-					t.Errorf("listBuilds() got unexpected error: %v", err)
+				if tc.expected != nil {
+					t.Errorf("testGroupPath(%v, %v) got unexpected error: %v", path, tc.groupName, err)
 				}
-			case tc.err:
-				t.Errorf("listBuilds() failed to return an error")
+			case tc.expected == nil:
+				t.Errorf("testGroupPath(%v, %v) failed to receive an error", path, tc.groupName)
 			default:
-				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcs.Path{}), compareBuilds); diff != "" {
-					t.Errorf("listBuilds() got unexpected diff (-have, +want):\n%s", diff)
+				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+					t.Errorf("testGroupPath(%v, %v) got unexpected diff (-have, +want):\n%s", path, tc.groupName, diff)
 				}
 			}
 		})
 	}
 }
 
-func TestInflateDropAppend(t *testing.T) {
-	now := time.Now().Unix()
-	uploadPath := newPathOrDie("gs://fake/upload/location")
-	defaultTimeout := 5 * time.Minute
-	cases := []struct {
-		name         string
-		ctx          context.Context
-		builds       []fakeBuild
-		group        configpb.TestGroup
-		concurrency  int
-		skipWrite    bool
-		colSorter    ColumnSorter
-		reprocess    time.Duration
-		groupTimeout *time.Duration
-		buildTimeout *time.Duration
-		current      *fake.Object
-		expected     *fakeUpload
-		err          bool
-	}{
-		{
-			name: "basically works",
-			group: configpb.TestGroup{
-				GcsPrefix: "bucket/path/to/build/",
-				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
-					{
-						ConfigurationValue: "Commit",
-					},
-				},
-			},
-			builds: []fakeBuild{
-				{
-					id:      "99",
-					started: jsonStarted(now + 99),
-				},
-				{
-					id:      "80",
-					started: jsonStarted(now + 80),
-					podInfo: podInfoSuccess,
-					finished: jsonFinished(now+81, true, metadata.Metadata{
-						metadata.JobVersion: "build80",
-					}),
-					passed: []string{"good1", "good2", "flaky"},
-				},
-				{
-					id:      "50",
-					started: jsonStarted(now + 50),
-					podInfo: podInfoSuccess,
-					finished: jsonFinished(now+51, false, metadata.Metadata{
-						metadata.JobVersion: "build50",
-					}),
-					passed: []string{"good1", "good2"},
-					failed: []string{"flaky"},
-				},
-				{
-					id:      "10",
-					started: jsonStarted(now + 10),
-					podInfo: podInfoSuccess,
-					finished: jsonFinished(now+11, true, metadata.Metadata{
-						metadata.JobVersion: "build10",
-					}),
-					passed: []string{"good1", "good2", "flaky"},
-				},
+func jsonStarted(stamp int64) *fakeObject {
+	return &fakeObject{
+		Data: jsonData(metadata.Started{Timestamp: stamp}),
+	}
+}
+
+func jsonFinished(stamp int64, passed bool, meta metadata.Metadata) *fakeObject {
+	return &fakeObject{
+		Data: jsonData(metadata.Finished{
+			Timestamp: &stamp,
+			Passed:    &passed,
+			Metadata:  meta,
+		}),
+	}
+}
+
+var (
+	podInfoSuccessPodInfo = gcs.PodInfo{
+		Pod: &core.Pod{
+			Status: core.PodStatus{
+				Phase: core.PodSucceeded,
 			},
-			expected: &fakeUpload{
+		},
+	}
+	podInfoSuccess     = jsonPodInfo(podInfoSuccessPodInfo)
+	podInfoPassCell    = cell{Result: statuspb.TestStatus_PASS}
+	podInfoMissingCell = cell{
+		Result:  statuspb.TestStatus_FAIL,
+		Icon:    "!",
+		Message: gcs.MissingPodInfo,
+	}
+)
+
+func jsonPodInfo(podInfo gcs.PodInfo) *fakeObject {
+	return &fakeObject{Data: jsonData(podInfo)}
+}
+
+func mustGrid(grid *statepb.Grid) []byte {
+	buf, err := gcs.MarshalGrid(grid, gcs.Zlib)
+	if err != nil {
+		panic(err)
+	}
+	return buf
+}
+
+func mustGridLevel(grid *statepb.Grid, level int) []byte {
+	buf, err := gcs.MarshalGridLevel(grid, gcs.Zlib, level)
+	if err != nil {
+		panic(err)
+	}
+	return buf
+}
+
+// mustHashMeta returns the metadata annotating the grid uploaded for the group.
+func mustHashMeta(tg *configpb.TestGroup, grid *statepb.Grid) map[string]string {
+	hash, err := gcs.HashGrid(grid)
+	if err != nil {
+		panic(err)
+	}
+	meta, err := gridMetadata(tg, hash)
+	if err != nil {
+		panic(err)
+	}
+	return meta
+}
+
+/*
+func TestSortGroups(t *testing.T) {
+	now := time.Now()
+	times := []time.Time{
+		now.Add(1 * time.Hour),
+		now.Add(2 * time.Hour),
+		now.Add(3 * time.Hour),
+		now.Add(4 * time.Hour),
+	}
+
+	cases := []struct {
+		name     string
+		groups   []*configpb.TestGroup
+		updated  map[string]*time.Time
+		expected []*configpb.TestGroup
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "sorts multiple groups by least recently updated",
+			groups: []*configpb.TestGroup{
+				{Name: "middle"},
+				{Name: "new"},
+				{Name: "old"},
+			},
+			updated: map[string]*time.Time{
+				"old":    &times[0],
+				"middle": &times[1],
+				"new":    &times[2],
+			},
+			expected: []*configpb.TestGroup{
+				{Name: "old"},
+				{Name: "middle"},
+				{Name: "new"},
+			},
+		},
+		{
+			name: "groups with no existing state come first",
+			groups: []*configpb.TestGroup{
+				{Name: "new"},
+				{Name: "never"},
+				{Name: "old"},
+			},
+			updated: map[string]*time.Time{
+				"old": &times[0],
+				"new": &times[2],
+			},
+			expected: []*configpb.TestGroup{
+				{Name: "never"},
+				{Name: "old"},
+				{Name: "new"},
+			},
+		},
+		{
+			name: "groups with an error state also go first",
+			groups: []*configpb.TestGroup{
+				{Name: "new"},
+				{Name: "boom"},
+				{Name: "old"},
+			},
+			updated: map[string]*time.Time{
+				"boom": nil,
+				"old":  &times[0],
+				"new":  &times[2],
+			},
+			expected: []*configpb.TestGroup{
+				{Name: "boom"},
+				{Name: "old"},
+				{Name: "new"},
+			},
+		},
+	}
+
+	const gridPrefix = "grid"
+	configPath, err := gcs.NewPath("gs://k8s-testgrid-canary/config")
+	if err != nil {
+		t.Fatalf("bad path: %v", err)
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			client := fakeStater{}
+			for name, updated := range tc.updated {
+				var stat fakeStat
+				if updated == nil {
+					stat.Err = errors.New("error")
+				} else {
+					stat.Attrs.Updated = *updated
+				}
+				path, err := testGroupPath(*configPath, gridPrefix, name)
+				if err != nil {
+					t.Fatalf("bad group path: %v", err)
+				}
+				client[*path] = stat
+			}
+			defer cancel()
+			sortGroups(ctx, logrus.WithField("case", tc.name), client, *configPath, gridPrefix, tc.groups)
+		})
+	}
+}
+*/
+
+func TestTruncateRunning(t *testing.T) {
+	now := float64(time.Now().UTC().Unix() * 1000)
+	ancient := float64(time.Now().Add(-74*time.Hour).UTC().Unix() * 1000)
+	cases := []struct {
+		name     string
+		cols     []inflatedColumn
+		expected func([]inflatedColumn) []inflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "keep everything (no Overall)",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "this",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "that",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "another",
+						Started: now,
+					},
+				},
+			},
+		},
+		{
+			name: "keep everything completed",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "passed",
+						Started: now,
+					},
+					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_PASS}},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "failed",
+						Started: now,
+					},
+					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_FAIL}},
+				},
+			},
+		},
+		{
+			name: "drop everything before oldest running",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "this1",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "this2",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "running1",
+						Started: now,
+					},
+					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "this3",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "running2",
+						Started: now,
+					},
+					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "this4",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "this5",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "this6",
+						Started: now,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "this7",
+						Started: now,
+					},
+				},
+			},
+			expected: func(cols []inflatedColumn) []inflatedColumn {
+				return cols[5:] // this4 and earlier
+			},
+		},
+		{
+			name: "drop all as all are running",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "running1",
+						Started: now,
+					},
+					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "running2",
+						Started: now,
+					},
+					Cells: map[string]cell{overallRow: {Result: statuspb.TestStatus_RUNNING}},
+				},
+			},
+			expected: func(cols []inflatedColumn) []inflatedColumn {
+				return cols[2:]
+			},
+		},
+		{
+			name: "drop running columns if any process is running",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "running",
+						Started: now,
+					},
+					Cells: map[string]cell{
+						"process1": {Result: statuspb.TestStatus_RUNNING},
+						"process2": {Result: statuspb.TestStatus_RUNNING},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "running-partially",
+						Started: now,
+					},
+					Cells: map[string]cell{
+						"process1": {Result: statuspb.TestStatus_RUNNING},
+						"process2": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "ok",
+						Started: now,
+					},
+					Cells: map[string]cell{
+						"process1": {Result: statuspb.TestStatus_PASS},
+						"process2": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+			expected: func(cols []inflatedColumn) []inflatedColumn {
+				return cols[2:]
+			},
+		},
+		{
+			name: "ignore ancient running columns",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "recent-running",
+						Started: now,
+					},
+					Cells: map[string]cell{"drop": {Result: statuspb.TestStatus_RUNNING}},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "recent-done",
+						Started: now - 1,
+					},
+					Cells: map[string]cell{"keep": {Result: statuspb.TestStatus_PASS}},
+				},
+
+				{
+					Column: &statepb.Column{
+						Build:   "running-ancient",
+						Started: ancient,
+					},
+					Cells: map[string]cell{"too-old-to-drop": {Result: statuspb.TestStatus_RUNNING}},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "ok",
+						Started: ancient - 1,
+					},
+					Cells: map[string]cell{"also keep": {Result: statuspb.TestStatus_PASS}},
+				},
+			},
+			expected: func(cols []inflatedColumn) []inflatedColumn {
+				return cols[1:]
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := truncateRunning(tc.cols)
+			expected := tc.cols
+			if tc.expected != nil {
+				expected = tc.expected(expected)
+			}
+			if diff := cmp.Diff(actual, expected, protocmp.Transform()); diff != "" {
+				t.Errorf("truncateRunning() got unexpected diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTruncateBuilds(t *testing.T) {
+	cases := []struct {
+		name   string
+		builds int
+		rows   []int
+		limit  configpb.TestGroup_ColumnLimit
+		start  int
+		end    int
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:   "usually include everything",
+			builds: 5,
+			rows:   []int{100},
+			start:  0,
+			end:    5,
+		},
+		{
+			name:   "many rows truncates columns",
+			builds: 10,
+			rows:   []int{maxUpdateArea / 4, maxUpdateArea / 4, maxUpdateArea / 4, maxUpdateArea / 4},
+			start:  6,
+			end:    10,
+		},
+		{
+			name:   "many new columns truncates",
+			builds: maxUpdateArea,
+			rows:   []int{1000},
+			start:  maxUpdateArea - (maxUpdateArea / 1000),
+			end:    maxUpdateArea,
+		},
+		{
+			name:   "keep the newest columns when limited to the newest",
+			builds: 10,
+			rows:   []int{maxUpdateArea / 4, maxUpdateArea / 4, maxUpdateArea / 4, maxUpdateArea / 4},
+			limit:  configpb.TestGroup_COLUMN_LIMIT_NEWEST,
+			start:  0,
+			end:    4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var builds []gcs.Build
+			var cols []inflatedColumn
+			var expected []gcs.Build
+
+			for i := 0; i < tc.builds; i++ {
+				p, err := gcs.NewPath(fmt.Sprintf("gs://fake/job/%d", i))
+				if err != nil {
+					t.Fatalf("bad path: %v", err)
+				}
+				b := gcs.Build{Path: *p}
+				builds = append(builds, b)
+				if i >= tc.start && i < tc.end {
+					expected = append(expected, b)
+				}
+			}
+
+			for _, r := range tc.rows {
+				col := inflatedColumn{
+					Cells: map[string]cell{},
+				}
+				for i := 0; i < r; i++ {
+					id := fmt.Sprintf("cell %d", i)
+					c := cell{CellID: id}
+					col.Cells[id] = c
+				}
+				cols = append(cols, col)
+			}
+
+			actual := truncateBuilds(logrus.WithField("name", tc.name), builds, cols, tc.limit)
+			diff := cmp.Diff(actual, expected, cmp.AllowUnexported(gcs.Build{}, gcs.Path{}, cell{}, inflatedColumn{}), protocmp.Transform())
+			if diff == "" {
+				return
+			}
+			if have, want := len(actual), len(expected); have != want {
+				t.Errorf("truncateRunning() got %d columns, want %d", have, want)
+			} else {
+				t.Errorf("truncateRunning() got unexpected diff (-have, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeBuildLister returns the scripted builds under each path.
+type fakeBuildLister map[gcs.Path][]gcs.Build
+
+func (l fakeBuildLister) ListBuilds(_ context.Context, path gcs.Path, _ *gcs.Path) ([]gcs.Build, error) {
+	builds, ok := l[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown path: %s", path)
+	}
+	return builds, nil
+}
+
+func TestListBuilds(t *testing.T) {
+	cases := []struct {
+		name     string
+		since    string
+		after    string
+		client   fakeLister
+		paths    []gcs.Path
+		expected []gcs.Build
+		err      bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "list stuff correctly",
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Prefix: "job/1/",
+						},
+						{
+							Prefix: "job/10/",
+						},
+						{
+							Prefix: "job/2/",
+						},
+					},
+					Idx:    0,
+					Err:    0,
+					Offset: "",
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://prefix/job/10/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/2/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/1/"),
+				},
+			},
+		},
+		{
+			name:  "list offsets correctly",
+			since: "3",
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Prefix: "job/1/",
+						},
+						{
+							Prefix: "job/10/",
+						},
+						{
+							Prefix: "job/2/",
+						},
+						{
+							Prefix: "job/3/",
+						},
+						{
+							Prefix: "job/4/",
+						},
+					},
+					Idx:    0,
+					Err:    0,
+					Offset: "",
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://prefix/job/10/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/4/"),
+				},
+			},
+		},
+		{
+			name:  "only list builds at or after a build id",
+			after: "3",
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Prefix: "job/1/",
+						},
+						{
+							Prefix: "job/10/",
+						},
+						{
+							Prefix: "job/2/",
+						},
+						{
+							Prefix: "job/3/",
+						},
+						{
+							Prefix: "job/4/",
+						},
+					},
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://prefix/job/10/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/4/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/3/"),
+				},
+			},
+		},
+		{
+			name: "collate stuff correctly",
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Prefix: "job/1/",
+						},
+						{
+							Prefix: "job/10/",
+						},
+						{
+							Prefix: "job/3/",
+						},
+					},
+				},
+				newPathOrDie("gs://other-prefix/presubmit-job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Name: "job/2",
+							Metadata: map[string]string{
+								"link": "gs://foo/bar333", // intentionally larger than job 20 and 4
+							},
+						},
+						{
+							Name: "job/20",
+							Metadata: map[string]string{
+								"link": "gs://foo/bar222",
+							},
+						},
+						{
+
+							Name: "job/4",
+							Metadata: map[string]string{
+								"link": "gs://foo/bar111",
+							},
+						},
+					},
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+				newPathOrDie("gs://other-prefix/presubmit-job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://foo/bar222/"),
+					// baseName: 20
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/10/"),
+				},
+				{
+					Path: newPathOrDie("gs://foo/bar111/"),
+					// baseName: 4
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/3/"),
+				},
+				{
+					Path: newPathOrDie("gs://foo/bar333/"),
+					// baseName: 2
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/1/"),
+				},
+			},
+		},
+		{
+			name:  "collated offsets work correctly",
+			since: "5", // drop 4 3 2 1, keep 20, 10
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Prefix: "job/1/",
+						},
+						{
+							Prefix: "job/10/",
+						},
+						{
+							Prefix: "job/3/",
+						},
+					},
+				},
+				newPathOrDie("gs://other-prefix/presubmit-job/"): fakeIterator{
+					Objects: []storage.ObjectAttrs{
+						{
+							Name: "job/2",
+							Metadata: map[string]string{
+								"link": "gs://foo/bar333", // intentionally larger than job 20 and 4
+							},
+						},
+						{
+							Name: "job/20",
+							Metadata: map[string]string{
+								"link": "gs://foo/bar222",
+							},
+						},
+						{
+
+							Name: "job/4",
+							Metadata: map[string]string{
+								"link": "gs://foo/bar111",
+							},
+						},
+					},
+				},
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+				newPathOrDie("gs://other-prefix/presubmit-job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://foo/bar222/"),
+					// baseName: 20
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/10/"),
+				},
+			},
+		},
+	}
+
+	compareBuilds := cmp.Comparer(func(x, y gcs.Build) bool {
+		return x.String() == y.String()
+	})
+	ctx := context.Background()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := listBuilds(ctx, GCSBuildLister{tc.client}, tc.since, tc.after, tc.paths...)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("listBuilds() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("listBuilds() failed to return an error")
+			default:
+				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcs.Path{}), compareBuilds); diff != "" {
+					t.Errorf("listBuilds() got unexpected diff (-have, +want):\n%s", diff)
+				}
+				if len(tc.paths) != 1 || tc.after != "" || !gcs.LexicalOffset(tc.since) {
+					return
+				}
+				// Streaming yields the same builds.
+				streamed, err := streamBuilds(ctx, GCSBuildLister{tc.client}, tc.since, tc.paths[0], len(actual)+1, time.Time{})
+				if err != nil {
+					t.Fatalf("streamBuilds() got unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(actual, streamed, cmp.AllowUnexported(gcs.Path{}), compareBuilds); diff != "" {
+					t.Errorf("streamBuilds() got unexpected diff (-list, +stream):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestListGroupBuilds(t *testing.T) {
+	client := fakeLister{
+		newPathOrDie("gs://prefix/job/"): fakeIterator{
+			Objects: []storage.ObjectAttrs{
+				{Prefix: "job/1/"},
+				{Prefix: "job/10/"},
+				{Prefix: "job/2/"},
+			},
+		},
+		newPathOrDie("gs://other-prefix/presubmit-job/"): fakeIterator{
+			Objects: []storage.ObjectAttrs{
+				{
+					Name: "job/3",
+					Metadata: map[string]string{
+						"link": "gs://foo/bar333",
+					},
+				},
+			},
+		},
+	}
+	defer func(allow map[string]bool) { AllowMultiplePaths = allow }(AllowMultiplePaths)
+	AllowMultiplePaths = map[string]bool{"multiple": true}
+
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		expected []gcs.Build
+		err      bool
+	}{
+		{
+			name: "basically works",
+			group: &configpb.TestGroup{
+				Name:      "hello",
+				GcsPrefix: "prefix/job",
+			},
+			expected: []gcs.Build{
+				{Path: newPathOrDie("gs://prefix/job/10/")},
+				{Path: newPathOrDie("gs://prefix/job/2/")},
+				{Path: newPathOrDie("gs://prefix/job/1/")},
+			},
+		},
+		{
+			name: "collate multiple paths",
+			group: &configpb.TestGroup{
+				Name:      "multiple",
+				GcsPrefix: "prefix/job,other-prefix/presubmit-job",
+			},
+			expected: []gcs.Build{
+				{Path: newPathOrDie("gs://prefix/job/10/")},
+				{Path: newPathOrDie("gs://foo/bar333/")}, // baseName: 3
+				{Path: newPathOrDie("gs://prefix/job/2/")},
+				{Path: newPathOrDie("gs://prefix/job/1/")},
+			},
+		},
+		{
+			name: "reject multiple paths unless allowed",
+			group: &configpb.TestGroup{
+				Name:      "hello",
+				GcsPrefix: "prefix/job,other-prefix/presubmit-job",
+			},
+			err: true,
+		},
+	}
+
+	compareBuilds := cmp.Comparer(func(x, y gcs.Build) bool {
+		return x.String() == y.String()
+	})
+	ctx := context.Background()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ListGroupBuilds(ctx, client, tc.group)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("ListGroupBuilds() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("ListGroupBuilds() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, actual, cmp.AllowUnexported(gcs.Path{}), compareBuilds); diff != "" {
+				t.Errorf("ListGroupBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+
+			// An update consumes the builds its column reader lists.
+			var consumed []gcs.Build
+			lister := fakeBuildListerFunc(func(ctx context.Context, path gcs.Path, offset *gcs.Path) ([]gcs.Build, error) {
+				builds, err := GCSBuildLister{client}.ListBuilds(ctx, path, offset)
+				consumed = append(consumed, builds...)
+				return builds, err
+			})
+			readCols := gcsColumnReader(fakeUploadClient{Uploader: fakeUploader{}, Client: fakeClient{Lister: client, Opener: fakeOpener{}}}, lister, time.Minute, 1, 0, "")
+			if _, err := readCols(ctx, logrus.WithField("name", tc.name), tc.group, nil, time.Now()); err != nil {
+				t.Fatalf("readCols() got unexpected error: %v", err)
+			}
+			gcs.Sort(consumed)
+			if diff := cmp.Diff(consumed, actual, cmp.AllowUnexported(gcs.Path{}), compareBuilds); diff != "" {
+				t.Errorf("ListGroupBuilds() differs from the builds an update reads (-update +list):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeBuildListerFunc lists builds by calling the function.
+type fakeBuildListerFunc func(ctx context.Context, path gcs.Path, offset *gcs.Path) ([]gcs.Build, error)
+
+func (f fakeBuildListerFunc) ListBuilds(ctx context.Context, path gcs.Path, offset *gcs.Path) ([]gcs.Build, error) {
+	return f(ctx, path, offset)
+}
+
+// fakeBuildStreamer yields the scripted builds oldest first, counting how many it yields.
+type fakeBuildStreamer struct {
+	fakeBuildLister
+	offsets []*gcs.Path
+	yielded int
+	err     error
+}
+
+func (s *fakeBuildStreamer) StreamBuilds(_ context.Context, path gcs.Path, offset *gcs.Path) BuildIterator {
+	s.offsets = append(s.offsets, offset)
+	return fakeBuildIterator{s, s.fakeBuildLister[path]}
+}
+
+type fakeBuildIterator struct {
+	streamer *fakeBuildStreamer
+	builds   []gcs.Build
+}
+
+func (it fakeBuildIterator) Next() (gcs.Build, error) {
+	s := it.streamer
+	if s.err != nil {
+		return gcs.Build{}, s.err
+	}
+	if s.yielded >= len(it.builds) {
+		return gcs.Build{}, iterator.Done
+	}
+	b := it.builds[len(it.builds)-1-s.yielded]
+	s.yielded++
+	return b, nil
+}
+
+func TestStreamBuilds(t *testing.T) {
+	path := newPathOrDie("gs://bucket/job")
+	since := newPathOrDie("gs://bucket/20200601-000000")
+	first := time.Date(2020, 6, 1, 1, 0, 0, 0, time.UTC)
+	var builds []gcs.Build // newest first
+	for i := 9; i >= 0; i-- {
+		id := first.Add(time.Duration(i) * time.Hour).Format("20060102-150405")
+		builds = append(builds, gcs.Build{Path: newPathOrDie("gs://bucket/job/" + id + "/")})
+	}
+	cases := []struct {
+		name     string
+		since    string
+		stop     time.Time
+		max      int
+		err      error
+		yielded  int
+		expected []gcs.Build
+		offset   *gcs.Path
+	}{
+		{
+			name:     "stop after enough builds",
+			max:      3,
+			yielded:  3,
+			expected: builds[7:],
+		},
+		{
+			name:     "stream every build",
+			max:      20,
+			yielded:  10,
+			expected: builds,
+		},
+		{
+			name:     "resolve since",
+			since:    "20200601-000000",
+			max:      1,
+			yielded:  1,
+			expected: builds[9:],
+			offset:   &since,
+		},
+		{
+			name:     "only count builds inside the window",
+			stop:     first.Add(3 * time.Hour),
+			max:      2,
+			yielded:  5,
+			expected: builds[5:7],
+		},
+		{
+			name: "error",
+			max:  3,
+			err:  errors.New("injected"),
+		},
+	}
+
+	compareBuilds := cmp.Comparer(func(x, y gcs.Build) bool {
+		return x.String() == y.String()
+	})
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			streamer := &fakeBuildStreamer{
+				fakeBuildLister: fakeBuildLister{path: builds},
+				err:             tc.err,
+			}
+			actual, err := streamBuilds(context.Background(), streamer, tc.since, path, tc.max, tc.stop)
+			switch {
+			case err != nil:
+				if tc.err == nil {
+					t.Errorf("streamBuilds() got unexpected error: %v", err)
+				}
+				return
+			case tc.err != nil:
+				t.Fatal("streamBuilds() failed to return an error")
+			}
+			if streamer.yielded != tc.yielded {
+				t.Errorf("streamBuilds() pulled %d builds, want %d", streamer.yielded, tc.yielded)
+			}
+			if diff := cmp.Diff(tc.expected, actual, compareBuilds); diff != "" {
+				t.Errorf("streamBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]*gcs.Path{tc.offset}, streamer.offsets, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+				t.Errorf("streamBuilds() got unexpected offsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGCSColumnReaderStreamOldest(t *testing.T) {
+	path := newPathOrDie("gs://bucket/job/")
+	first := time.Date(2020, 6, 1, 1, 0, 0, 0, time.UTC)
+	var builds []gcs.Build // newest first
+	for i := maxCols*2 - 1; i >= 0; i-- {
+		id := first.Add(time.Duration(i) * time.Hour).Format("20060102-150405")
+		builds = append(builds, gcs.Build{Path: newPathOrDie("gs://bucket/job/" + id + "/")})
+	}
+	last := InflatedColumn{
+		Column: &statepb.Column{
+			Build:   "20200601-000000",
+			Hint:    "20200601-000000",
+			Started: float64(first.Add(-time.Hour).Unix() * 1000),
+		},
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		oldCols  []InflatedColumn
+		expected int
+	}{
+		{
+			name: "stop streaming the oldest new builds",
+			group: &configpb.TestGroup{
+				GcsPrefix: "bucket/job",
+			},
+			oldCols:  []InflatedColumn{last},
+			expected: maxCols,
+		},
+		{
+			name: "newest builds need every build",
+			group: &configpb.TestGroup{
+				GcsPrefix:   "bucket/job",
+				ColumnLimit: configpb.TestGroup_COLUMN_LIMIT_NEWEST,
+			},
+			oldCols: []InflatedColumn{last},
+		},
+		{
+			name: "first update needs every build",
+			group: &configpb.TestGroup{
+				GcsPrefix: "bucket/job",
+			},
+		},
+		{
+			name: "sequential build numbers need every build",
+			group: &configpb.TestGroup{
+				GcsPrefix: "bucket/job",
+			},
+			oldCols: []InflatedColumn{
+				{Column: &statepb.Column{Build: "5", Hint: "5", Started: last.Column.Started}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			streamer := &fakeBuildStreamer{
+				fakeBuildLister: fakeBuildLister{path: builds},
+			}
+			client := fakeUploadClient{Uploader: fakeUploader{}, Client: fakeClient{Lister: fakeLister{}, Opener: fakeOpener{}}}
+			readCols := gcsColumnReader(client, streamer, time.Minute, 1, 0, "")
+			// Only the listing matters, so ignore failures to read the missing builds.
+			readCols(context.Background(), logrus.WithField("name", tc.name), tc.group, tc.oldCols, time.Time{})
+			if streamer.yielded != tc.expected {
+				t.Errorf("readCols() streamed %d builds, want %d", streamer.yielded, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGCSColumnReaderTruncateNewest(t *testing.T) {
+	defer preserveMaxUpdateArea()()
+	now := time.Now().Unix()
+	path := newPathOrDie("gs://bucket/job/")
+	client := fakeUploadClient{
+		Uploader: fakeUploader{},
+		Client: fakeClient{
+			Lister: fake.Lister{},
+			Opener: fake.Opener{},
+		},
+	}
+	var fakeBuilds []fakeBuild
+	for i := 5; i > 0; i-- {
+		fakeBuilds = append(fakeBuilds, fakeBuild{
+			id:       fmt.Sprint(i),
+			started:  jsonStarted(now + int64(i)),
+			finished: jsonFinished(now+int64(i)+1, true, nil),
+			podInfo:  podInfoSuccess,
+		})
+	}
+	builds := addBuilds(&client.Client, path, fakeBuilds...)
+	oldCols := []InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "0", Hint: "0", Started: float64(now * 1000)},
+			Cells:  map[string]Cell{"hello": {Result: statuspb.TestStatus_PASS}},
+		},
+	}
+	// The one row of the existing column leaves room for two new columns.
+	updateAreaLock.Lock()
+	maxUpdateArea = 2
+	updateAreaLock.Unlock()
+
+	cases := []struct {
+		name     string
+		lister   BuildLister
+		expected []string
+	}{
+		{
+			name:     "list builds",
+			lister:   fakeBuildLister{path: builds},
+			expected: []string{"5", "4"},
+		},
+		{
+			name: "newest builds are listed rather than streamed",
+			lister: &fakeBuildStreamer{
+				fakeBuildLister: fakeBuildLister{path: builds},
+			},
+			expected: []string{"5", "4"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				GcsPrefix:   "bucket/job",
+				ColumnLimit: configpb.TestGroup_COLUMN_LIMIT_NEWEST,
+			}
+			readCols := gcsColumnReader(client, tc.lister, time.Minute, 1, 0, "")
+			cols, err := readCols(context.Background(), logrus.WithField("name", tc.name), group, oldCols, time.Unix(now-100, 0))
+			if err != nil {
+				t.Fatalf("readCols() got unexpected error: %v", err)
+			}
+			var actual []string
+			for _, col := range cols {
+				actual = append(actual, col.Column.Build)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("readCols() got unexpected builds (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInflateDropAppend(t *testing.T) {
+	now := time.Now().Unix()
+	uploadPath := newPathOrDie("gs://fake/upload/location")
+	defaultTimeout := 5 * time.Minute
+	defaultLevel := gcs.DefaultCompression
+	bestCompression := flate.BestCompression
+	bestSpeed := flate.BestSpeed
+	invalidLevel := flate.BestCompression + 1
+	cases := []struct {
+		name             string
+		ctx              context.Context
+		builds           []fakeBuild
+		group            configpb.TestGroup
+		concurrency      int
+		skipWrite        bool
+		colSorter        ColumnSorter
+		reprocess        time.Duration
+		groupTimeout     *time.Duration
+		buildTimeout     *time.Duration
+		current          *fake.Object
+		afterBuildID     string
+		compressionLevel *int
+		lister           BuildLister
+		expected         *fakeUpload
+		published        []GridEvent
+		verify           bool
+		err              bool
+	}{
+		{
+			name: "basically works",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+					},
+				},
+			},
+			builds: []fakeBuild{
+				{
+					id:      "99",
+					started: jsonStarted(now + 99),
+				},
+				{
+					id:      "80",
+					started: jsonStarted(now + 80),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+81, true, metadata.Metadata{
+						metadata.JobVersion: "build80",
+					}),
+					passed: []string{"good1", "good2", "flaky"},
+				},
+				{
+					id:      "50",
+					started: jsonStarted(now + 50),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+51, false, metadata.Metadata{
+						metadata.JobVersion: "build50",
+					}),
+					passed: []string{"good1", "good2"},
+					failed: []string{"flaky"},
+				},
+				{
+					id:      "10",
+					started: jsonStarted(now + 10),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+11, true, metadata.Metadata{
+						metadata.JobVersion: "build10",
+					}),
+					passed: []string{"good1", "good2", "flaky"},
+				},
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "99",
+							Hint:    "99",
+							Started: float64(now+99) * 1000,
+							Extra:   []string{""},
+						},
+						{
+							Build:   "80",
+							Hint:    "80",
+							Started: float64(now+80) * 1000,
+							Extra:   []string{"build80"},
+						},
+						{
+							Build:   "50",
+							Hint:    "50",
+							Started: float64(now+50) * 1000,
+							Extra:   []string{"build50"},
+						},
+						{
+							Build:   "10",
+							Hint:    "10",
+							Started: float64(now+10) * 1000,
+							Extra:   []string{"build10"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Metrics: setElapsed(nil, 1),
+							},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: podInfoRow,
+								Id:   podInfoRow,
+							},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+							podInfoPassCell,
+							podInfoPassCell,
+							podInfoPassCell,
+						),
+						setupRow(
+							&statepb.Row{
+								Name: "flaky",
+								Id:   "flaky",
+							},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Message: "flaky",
+								Icon:    "F",
+							},
+							cell{Result: statuspb.TestStatus_PASS},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: "good1",
+								Id:   "good1",
+							},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: "good2",
+								Id:   "good2",
+							},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+			published: []GridEvent{
+				{
+					Path:    uploadPath.String(),
+					Columns: 4,
+					Rows:    5,
+				},
+			},
+		},
+		{
+			name: "sort ascending",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+					},
+				},
+			},
+			colSorter: func(tg *configpb.TestGroup, cols []InflatedColumn) {
+				want := configpb.TestGroup{ // same as tc.group
+					GcsPrefix: "bucket/path/to/build/",
+					ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+						{
+							ConfigurationValue: "Commit",
+						},
+					},
+				}
+				if diff := cmp.Diff(&want, tg, protocmp.Transform()); diff != "" {
+					panic(fmt.Sprintf("bad TestGroup:\n%s", diff))
+				}
+				sort.SliceStable(cols, func(i, j int) bool {
+					return cols[i].Column.Started < cols[j].Column.Started
+				})
+			},
+			builds: []fakeBuild{
+				{
+					id:      "99",
+					started: jsonStarted(now + 99),
+				},
+				{
+					id:      "80",
+					started: jsonStarted(now + 80),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+81, true, metadata.Metadata{
+						metadata.JobVersion: "build80",
+					}),
+					passed: []string{"good1", "good2", "flaky"},
+				},
+				{
+					id:      "50",
+					started: jsonStarted(now + 50),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+51, false, metadata.Metadata{
+						metadata.JobVersion: "build50",
+					}),
+					passed: []string{"good1", "good2"},
+					failed: []string{"flaky"},
+				},
+				{
+					id:      "10",
+					started: jsonStarted(now + 10),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+11, true, metadata.Metadata{
+						metadata.JobVersion: "build10",
+					}),
+					passed: []string{"good1", "good2", "flaky"},
+				},
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "10",
+							Hint:    "10",
+							Started: float64(now+10) * 1000,
+							Extra:   []string{"build10"},
+						},
+						{
+							Build:   "50",
+							Hint:    "50",
+							Started: float64(now+50) * 1000,
+							Extra:   []string{"build50"},
+						},
+						{
+							Build:   "80",
+							Hint:    "80",
+							Started: float64(now+80) * 1000,
+							Extra:   []string{"build80"},
+						},
+						{
+							Build:   "99",
+							Hint:    "99",
+							Started: float64(now+99) * 1000,
+							Extra:   []string{""},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: podInfoRow,
+								Id:   podInfoRow,
+							},
+							podInfoPassCell,
+							podInfoPassCell,
+							podInfoPassCell,
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: "flaky",
+								Id:   "flaky",
+							},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Message: "flaky",
+								Icon:    "F",
+							},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: "good1",
+								Id:   "good1",
+							},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: "good2",
+								Id:   "good2",
+							},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_NO_RESULT},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name:      "do not write when requested",
+			skipWrite: true,
+			published: []GridEvent{},
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+					},
+				},
+			},
+			builds: []fakeBuild{
+				{
+					id:      "99",
+					started: jsonStarted(now + 99),
+				},
+				{
+					id:      "80",
+					started: jsonStarted(now + 80),
+					finished: jsonFinished(now+81, true, metadata.Metadata{
+						metadata.JobVersion: "build80",
+					}),
+					passed: []string{"good1", "good2", "flaky"},
+				},
+				{
+					id:      "50",
+					started: jsonStarted(now + 50),
+					finished: jsonFinished(now+51, false, metadata.Metadata{
+						metadata.JobVersion: "build50",
+					}),
+					passed: []string{"good1", "good2"},
+					failed: []string{"flaky"},
+				},
+				{
+					id:      "10",
+					started: jsonStarted(now + 10),
+					finished: jsonFinished(now+11, true, metadata.Metadata{
+						metadata.JobVersion: "build10",
+					}),
+					passed: []string{"good1", "good2", "flaky"},
+				},
+			},
+		},
+		{
+			name:   "verify rejects a mismatched upload", // fake opener does not see uploads
+			verify: true,
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			builds: []fakeBuild{
+				{
+					id:      "80",
+					started: jsonStarted(now + 80),
+					finished: jsonFinished(now+81, true, metadata.Metadata{
+						metadata.JobVersion: "build80",
+					}),
+					passed: []string{"good1"},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "stream upload", // streamed bytes match the buffered grid
+			group: configpb.TestGroup{
+				GcsPrefix:    "bucket/path/to/build/",
+				StreamUpload: true,
+			},
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "current",
+							Started: float64(now) * 1000,
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name: "scripted build lister",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			lister: fakeBuildLister{
+				newPathOrDie("gs://bucket/path/to/build/"): {
+					{Path: newPathOrDie("gs://bucket/path/to/build/listed/")},
+				},
+			},
+			builds: []fakeBuild{
+				{
+					id:      "unlisted",
+					started: jsonStarted(now + 1),
+				},
+				{
+					id:      "listed",
+					started: jsonStarted(now),
+				},
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "listed",
+							Hint:    "listed",
+							Started: float64(now) * 1000,
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name: "build lister error",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			lister: fakeBuildLister{},
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			err: true,
+		},
+		{
+			name: "best compression",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			compressionLevel: &bestCompression,
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			expected: &fakeUpload{
+				Buf: mustGridLevel(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "current",
+							Started: float64(now) * 1000,
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+						),
+					},
+				}, bestCompression),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name: "stream upload at best speed",
+			group: configpb.TestGroup{
+				GcsPrefix:    "bucket/path/to/build/",
+				StreamUpload: true,
+			},
+			compressionLevel: &bestSpeed,
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			expected: &fakeUpload{
+				Buf: mustGridLevel(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "current",
+							Started: float64(now) * 1000,
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+						),
+					},
+				}, bestSpeed),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name: "invalid compression level",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			compressionLevel: &invalidLevel,
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			err: true,
+		},
+		{
+			name: "recent", // keep columns past the reprocess boundary
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+					},
+				},
+			},
+			reprocess: 10 * time.Second,
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			current: &fake.Object{
+				Data: string(mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "should reprocess",
+							Started: float64(now * 1000),
+							Extra:   []string{""},
+						},
+						{
+							Build:   "at boundary",
+							Hint:    "1 should disappear",
+							Started: float64(now-9) * 1000,
+							Extra:   []string{""},
+						},
+						{
+							Build:   "past boundary",
+							Hint:    "boundary+999",
+							Started: float64(now-9)*1000 - 1,
+							Extra:   []string{"keep"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Message: "old data",
+								Icon:    "should reprocess",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Message: "delete me",
+								Icon:    "me too",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FLAKY,
+								Message: "keep me",
+								Icon:    "yes stay",
+							},
+						),
+					},
+				})),
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "current",
+							Started: float64(now) * 1000,
+							Extra:   []string{""},
+						},
+						{
+							Build:   "past boundary",
+							Hint:    "boundary+999",
+							Started: float64(now-9)*1000 - 1,
+							Extra:   []string{"keep"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FLAKY,
+								Message: "keep me",
+								Icon:    "yes stay",
+							},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name:         "only read builds at or after a build id",
+			group:        configpb.TestGroup{GcsPrefix: "bucket/path/to/build/"},
+			reprocess:    10 * time.Second,
+			afterBuildID: "20",
+			builds: []fakeBuild{
+				{
+					id:       "30",
+					started:  jsonStarted(now + 30),
+					finished: jsonFinished(now+31, true, nil),
+					podInfo:  podInfoSuccess,
+				},
+				{
+					id:       "20",
+					started:  jsonStarted(now + 20),
+					finished: jsonFinished(now+21, true, nil),
+					podInfo:  podInfoSuccess,
+				},
+				{
+					id:       "15", // ignore builds before the trigger
+					started:  jsonStarted(now + 15),
+					finished: jsonFinished(now+16, false, nil),
+					podInfo:  podInfoSuccess,
+				},
+			},
+			current: &fake.Object{
+				Data: string(mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "20",
+							Hint:    "20",
+							Started: float64(now+20) * 1000,
+						},
+						{
+							Build:   "10",
+							Hint:    "10",
+							Started: float64(now+10) * 1000,
+						},
+						{
+							Build:   "5",
+							Hint:    "5",
+							Started: float64(now+5) * 1000,
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Message: "old data",
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "keep running",
+								Icon:    "R",
+							},
+						),
+					},
+				})),
+			},
+			expected: &fakeUpload{
 				Buf: mustGrid(&statepb.Grid{
 					Columns: []*statepb.Column{
 						{
-							Build:   "99",
-							Hint:    "99",
-							Started: float64(now+99) * 1000,
+							Build:   "30",
+							Hint:    "30",
+							Started: float64(now+30) * 1000,
+						},
+						{
+							Build:   "20",
+							Hint:    "20",
+							Started: float64(now+20) * 1000,
+						},
+						{
+							Build:   "10",
+							Hint:    "10",
+							Started: float64(now+10) * 1000,
+						},
+						{
+							Build:   "5",
+							Hint:    "5",
+							Started: float64(now+5) * 1000,
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Metrics: setElapsed(nil, 1),
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Message: "old data",
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "keep running",
+								Icon:    "R",
+							},
+						),
+						setupRow(
+							&statepb.Row{
+								Name: podInfoRow,
+								Id:   podInfoRow,
+							},
+							podInfoPassCell,
+							podInfoPassCell,
+							emptyCell,
+							emptyCell,
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			name: "preserve annotations on rebuilt columns",
+			group: configpb.TestGroup{
+				GcsPrefix:                 "bucket/path/to/build/",
+				PreserveColumnAnnotations: true,
+			},
+			reprocess: 10 * time.Second,
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			current: &fake.Object{
+				Data: string(mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:       "current",
+							Hint:        "should reprocess",
+							Started:     float64(now * 1000),
+							Annotations: map[string]string{"known-bad": "bad release"},
+						},
+						{
+							Build:       "past boundary",
+							Hint:        "boundary+999",
+							Started:     float64(now-9)*1000 - 1,
+							Annotations: map[string]string{"note": "keep"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{Result: statuspb.TestStatus_PASS},
+							cell{Result: statuspb.TestStatus_FLAKY},
+						),
+					},
+				})),
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:       "current",
+							Hint:        "current",
+							Started:     float64(now) * 1000,
+							Annotations: map[string]string{"known-bad": "bad release"},
+						},
+						{
+							Build:       "past boundary",
+							Hint:        "boundary+999",
+							Started:     float64(now-9)*1000 - 1,
+							Annotations: map[string]string{"note": "keep"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+							cell{Result: statuspb.TestStatus_FLAKY},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+		{
+			// short reprocessing time depends on our reprocessing running columns outside this timeframe.
+			name: "running", // reprocess everything at least as new as the running column
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+					},
+				},
+			},
+			reprocess: 10 * time.Second,
+			builds: []fakeBuild{
+				{
+					id:      "current",
+					started: jsonStarted(now),
+				},
+			},
+			current: &fake.Object{
+				Data: string(mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "should reprocess",
+							Started: float64(now * 1000),
+							Extra:   []string{""},
+						},
+						{
+							Build:   "at boundary",
+							Hint:    "1 should disappear",
+							Started: float64(now-9) * 1000,
+							Extra:   []string{""},
+						},
+						{
+							Build:   "past boundary",
+							Hint:    "1 done but still reprocess",
+							Started: float64(now-40) * 1000,
+							Extra:   []string{""},
+						},
+						{
+							Build:   "paster boundary",
+							Hint:    "1 running",
+							Started: float64(now-50) * 1000,
+							Extra:   []string{""},
+						},
+						{
+							Build:   "pastest boundary",
+							Hint:    "1 oldest",
+							Started: float64(now-60) * 1000,
+							Extra:   []string{"keep"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_PASS,
+								Message: "old data",
+								Icon:    "should reprocess",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Message: "delete me",
+								Icon:    "me too",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FAIL,
+								Message: "delete me",
+								Icon:    "me too",
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "delete me",
+								Icon:    "me too",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FLAKY,
+								Message: "keep me",
+								Icon:    "yes stay",
+							},
+						),
+					},
+				})),
+			},
+			expected: &fakeUpload{
+				Buf: mustGrid(&statepb.Grid{
+					Columns: []*statepb.Column{
+						{
+							Build:   "current",
+							Hint:    "current",
+							Started: float64(now) * 1000,
 							Extra:   []string{""},
 						},
-						{
-							Build:   "80",
-							Hint:    "80",
-							Started: float64(now+80) * 1000,
-							Extra:   []string{"build80"},
+						{
+							Build:   "pastest boundary",
+							Hint:    "1 oldest",
+							Started: float64(now-60) * 1000,
+							Extra:   []string{"keep"},
+						},
+					},
+					Rows: []*statepb.Row{
+						setupRow(
+							&statepb.Row{
+								Name: overallRow,
+								Id:   overallRow,
+							},
+							cell{
+								Result:  statuspb.TestStatus_RUNNING,
+								Message: "Build still running...",
+								Icon:    "R",
+							},
+							cell{
+								Result:  statuspb.TestStatus_FLAKY,
+								Message: "keep me",
+								Icon:    "yes stay",
+							},
+						),
+					},
+				}),
+				CacheControl: "no-cache",
+				WorldRead:    gcs.DefaultACL,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.ctx == nil {
+				tc.ctx = context.Background()
+			}
+			ctx, cancel := context.WithCancel(tc.ctx)
+			defer cancel()
+
+			if tc.concurrency == 0 {
+				tc.concurrency = 1
+			}
+			if tc.groupTimeout == nil {
+				tc.groupTimeout = &defaultTimeout
+			}
+			if tc.buildTimeout == nil {
+				tc.buildTimeout = &defaultTimeout
+			}
+			if tc.compressionLevel == nil {
+				tc.compressionLevel = &defaultLevel
+			}
+
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+
+			if tc.current != nil {
+				client.Opener[uploadPath] = *tc.current
+			}
+
+			buildsPath := newPathOrDie("gs://" + tc.group.GcsPrefix)
+			fi := client.Lister[buildsPath]
+			for _, build := range addBuilds(&client.Client, buildsPath, tc.builds...) {
+				fi.Objects = append(fi.Objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.Lister[buildsPath] = fi
+
+			colReader := gcsColumnReader(client, tc.lister, *tc.buildTimeout, tc.concurrency, 0, tc.afterBuildID)
+			if tc.colSorter == nil {
+				tc.colSorter = SortStarted
+			}
+			var publisher fakePublisher
+			err := InflateDropAppend(
+				ctx,
+				logrus.WithField("test", tc.name),
+				client,
+				&tc.group,
+				uploadPath,
+				!tc.skipWrite,
+				colReader,
+				tc.colSorter,
+				tc.reprocess,
+				&publisher,
+				tc.verify,
+				nil,
+				nil,
+				nil,
+				tc.afterBuildID,
+				*tc.compressionLevel,
+			)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("updateGroup() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("updateGroup() failed to receive an exception")
+			default:
+				expected := fakeUploader{}
+				if tc.expected != nil {
+					// Uploads are annotated with the hash of the uncompressed grid,
+					// as well as the config and updater which produced it.
+					want, err := gcs.UnmarshalGrid(tc.expected.Buf)
+					if err != nil {
+						t.Fatalf("gcs.UnmarshalGrid(expected) got unexpected error: %v", err)
+					}
+					hash, err := gcs.HashGrid(want)
+					if err != nil {
+						t.Fatalf("gcs.HashGrid(expected) got unexpected error: %v", err)
+					}
+					digest, err := ConfigDigest(&tc.group)
+					if err != nil {
+						t.Fatalf("ConfigDigest() got unexpected error: %v", err)
+					}
+					tc.expected.Metadata = map[string]string{
+						gcs.GridHashKey: hash,
+						ConfigDigestKey: digest,
+						VersionKey:      Version,
+					}
+					expected[uploadPath] = *tc.expected
+				}
+				if tc.published != nil {
+					if diff := cmp.Diff(tc.published, publisher.events, cmpopts.EquateEmpty()); diff != "" {
+						t.Errorf("InflateDropAppend() published unexpected events (-want +got):\n%s", diff)
+					}
+				}
+				actual := client.Uploader
+				diff := cmp.Diff(expected, actual, cmp.AllowUnexported(gcs.Path{}, fakeUpload{}), protocmp.Transform())
+				if diff == "" {
+					return
+				}
+				t.Errorf("updateGroup() got unexpected diff (-want +got):\n%s", diff)
+				fakeDownloader := fakeOpener{
+					uploadPath: {Data: string(actual[uploadPath].Buf)},
+				}
+				actualGrid, _, err := gcs.DownloadGrid(ctx, fakeDownloader, uploadPath)
+				if err != nil {
+					t.Errorf("actual gcs.DownloadGrid() got unexpected error: %v", err)
+				}
+				fakeDownloader[uploadPath] = fakeObject{Data: string(tc.expected.Buf)}
+				expectedGrid, _, err := gcs.DownloadGrid(ctx, fakeDownloader, uploadPath)
+				if err != nil {
+					t.Errorf("expected gcs.DownloadGrid() got unexpected error: %v", err)
+				}
+				diff = cmp.Diff(expectedGrid, actualGrid, protocmp.Transform())
+				if diff == "" {
+					return
+				}
+				t.Errorf("gcs.DownloadGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInflateDropAppendUpdateTime(t *testing.T) {
+	defer func(orig func() time.Time) { gridClock = orig }(gridClock)
+	when := time.Unix(1600000000, 500*int64(time.Millisecond))
+	gridClock = func() time.Time { return when }
+
+	now := time.Now().Unix()
+	uploadPath := newPathOrDie("gs://fake/upload/location")
+	cases := []struct {
+		name     string
+		record   bool
+		expected float64
+	}{
+		{
+			name: "unset by default",
+		},
+		{
+			name:     "record the injected time",
+			record:   true,
+			expected: 1600000000.5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				GcsPrefix:        "bucket/path/to/build/",
+				RecordUpdateTime: tc.record,
+			}
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			fi := client.Lister[buildsPath]
+			for _, build := range addBuilds(&client.Client, buildsPath, fakeBuild{
+				id:       "1",
+				started:  jsonStarted(now),
+				finished: jsonFinished(now+1, true, nil),
+				podInfo:  podInfoSuccess,
+			}) {
+				fi.Objects = append(fi.Objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.Lister[buildsPath] = fi
+
+			colReader := gcsColumnReader(client, nil, time.Minute, 1, 0, "")
+			if err := InflateDropAppend(context.Background(), logrus.WithField("test", tc.name), client, group, uploadPath, true, colReader, SortStarted, 0, nil, false, nil, nil, nil, "", gcs.DefaultCompression); err != nil {
+				t.Fatalf("InflateDropAppend() got unexpected error: %v", err)
+			}
+			grid, err := gcs.UnmarshalGrid(client.Uploader[uploadPath].Buf)
+			if err != nil {
+				t.Fatalf("gcs.UnmarshalGrid() got unexpected error: %v", err)
+			}
+			if len(grid.Columns) != 1 {
+				t.Errorf("InflateDropAppend() got %d columns, want 1", len(grid.Columns))
+			}
+			if got := grid.LastTimeUpdated; got != tc.expected {
+				t.Errorf("InflateDropAppend() got last_time_updated %f, want %f", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInflateDropAppendACL(t *testing.T) {
+	now := time.Now().Unix()
+	uploadPath := newPathOrDie("gs://fake/upload/location")
+	deltaPath := newPathOrDie("gs://fake/upload/location.delta")
+	cases := []struct {
+		name          string
+		worldReadable bool
+		expected      bool
+	}{
+		{
+			name:     "bucket default",
+			expected: gcs.DefaultACL,
+		},
+		{
+			name:          "world readable",
+			worldReadable: true,
+			expected:      gcs.PublicRead,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				GcsPrefix:      "bucket/path/to/build/",
+				WorldReadable:  tc.worldReadable,
+				WriteGridDelta: true,
+			}
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			fi := client.Lister[buildsPath]
+			for _, build := range addBuilds(&client.Client, buildsPath, fakeBuild{
+				id:       "1",
+				started:  jsonStarted(now),
+				finished: jsonFinished(now+1, true, nil),
+				podInfo:  podInfoSuccess,
+			}) {
+				fi.Objects = append(fi.Objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.Lister[buildsPath] = fi
+
+			colReader := gcsColumnReader(client, nil, time.Minute, 1, 0, "")
+			if err := InflateDropAppend(context.Background(), logrus.WithField("test", tc.name), client, group, uploadPath, true, colReader, SortStarted, 0, nil, false, nil, nil, nil, "", gcs.DefaultCompression); err != nil {
+				t.Fatalf("InflateDropAppend() got unexpected error: %v", err)
+			}
+			for _, p := range []gcs.Path{uploadPath, deltaPath} {
+				up, ok := client.Uploader[p]
+				if !ok {
+					t.Fatalf("InflateDropAppend() failed to upload %s", p)
+				}
+				if up.WorldRead != tc.expected {
+					t.Errorf("InflateDropAppend() uploaded %s with world read %t, want %t", p, up.WorldRead, tc.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestInflateDropAppendMinBuilds(t *testing.T) {
+	now := time.Now().Unix()
+	uploadPath := newPathOrDie("gs://fake/upload/location")
+	cases := []struct {
+		name      string
+		builds    int
+		oldCols   int
+		minBuilds int32
+		write     bool
+	}{
+		{
+			name:  "write without a minimum",
+			write: true,
+		},
+		{
+			name:      "skip without new builds despite an existing grid",
+			oldCols:   3,
+			minBuilds: 1,
+		},
+		{
+			name:      "write with enough new builds and an existing grid",
+			builds:    1,
+			oldCols:   3,
+			minBuilds: 1,
+			write:     true,
+		},
+		{
+			name:      "skip with too few builds",
+			builds:    1,
+			minBuilds: 2,
+		},
+		{
+			name:      "skip without builds",
+			minBuilds: 1,
+		},
+		{
+			name:      "write with enough builds",
+			builds:    2,
+			minBuilds: 2,
+			write:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+				MinBuilds: tc.minBuilds,
+			}
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{},
+				},
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			var oldBuf []byte
+			if tc.oldCols > 0 {
+				var cols []inflatedColumn
+				for i := 0; i < tc.oldCols; i++ {
+					started := now - 3600 - int64(i*60)
+					cols = append(cols, inflatedColumn{
+						Column: &statepb.Column{Build: fmt.Sprint(tc.oldCols - i), Started: float64(started * 1000)},
+						Cells:  map[string]cell{"hello": {Result: statuspb.TestStatus_PASS}},
+					})
+				}
+				oldBuf = mustGrid(ConstructGrid(logrus.WithField("test", tc.name), group, cols, nil, nil))
+				client.Opener[uploadPath] = fakeObject{Data: string(oldBuf)}
+				client.Uploader[uploadPath] = fakeUpload{Buf: oldBuf}
+			}
+			var builds []fakeBuild
+			for i := 0; i < tc.builds; i++ {
+				started := now - int64(i*60)
+				builds = append(builds, fakeBuild{
+					id:       fmt.Sprint(100 + tc.builds - i),
+					started:  jsonStarted(started),
+					finished: jsonFinished(started+1, true, nil),
+					podInfo:  podInfoSuccess,
+				})
+			}
+			fi := client.Lister[buildsPath]
+			for _, build := range addBuilds(&client.Client, buildsPath, builds...) {
+				fi.Objects = append(fi.Objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.Lister[buildsPath] = fi
+
+			colReader := gcsColumnReader(client, nil, time.Minute, 1, 0, "")
+			if err := InflateDropAppend(context.Background(), logrus.WithField("test", tc.name), client, group, uploadPath, true, colReader, SortStarted, 0, nil, false, nil, nil, nil, "", gcs.DefaultCompression); err != nil {
+				t.Fatalf("InflateDropAppend() got unexpected error: %v", err)
+			}
+			wrote := !bytes.Equal(client.Uploader[uploadPath].Buf, oldBuf)
+			switch {
+			case wrote && !tc.write:
+				t.Error("InflateDropAppend() unexpectedly wrote the grid")
+			case !wrote && tc.write:
+				t.Error("InflateDropAppend() failed to write the grid")
+			}
+		})
+	}
+}
+
+// interruptingUploader cancels the context between the two phases of a write.
+type interruptingUploader struct {
+	fakeUploader
+	cancel context.CancelFunc
+}
+
+func (iu interruptingUploader) Stage(ctx context.Context, path gcs.Path, r io.Reader, worldRead bool, cacheControl string, meta map[string]string) (gcs.Path, error) {
+	staged, err := iu.fakeUploader.Stage(ctx, path, r, worldRead, cacheControl, meta)
+	iu.cancel()
+	return staged, err
+}
+
+// basicUploader only implements gcs.Uploader.
+type basicUploader struct {
+	fu fakeUploader
+}
+
+func (bu basicUploader) Upload(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cacheControl string) (*storage.ObjectAttrs, error) {
+	return bu.fu.Upload(ctx, path, buf, worldRead, cacheControl)
+}
+
+// metadataUploader only implements gcs.Uploader and gcs.MetadataUploader.
+type metadataUploader struct {
+	basicUploader
+}
+
+func (mu metadataUploader) UploadWithMetadata(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	return mu.fu.UploadWithMetadata(ctx, path, buf, worldRead, cacheControl, meta)
+}
+
+func TestWriteGrid(t *testing.T) {
+	path := newPathOrDie("gs://bucket/grid")
+	staged := path.TempPath()
+	old := []byte("old grid")
+	meta := map[string]string{"hello": "world"}
+	injected := errors.New("injected")
+	cases := []struct {
+		name      string
+		current   fakeUploader
+		worldRead bool
+		interrupt bool
+		basic     bool // client only implements gcs.Uploader
+		metadata  bool // client also implements gcs.MetadataUploader
+		expected  fakeUploader
+		err       bool
+	}{
+		{
+			name:    "basically works",
+			current: fakeUploader{},
+			expected: fakeUploader{
+				path: {
+					Buf:          []byte("new grid"),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     meta,
+				},
+			},
+		},
+		{
+			name:      "world readable",
+			current:   fakeUploader{},
+			worldRead: gcs.PublicRead,
+			expected: fakeUploader{
+				path: {
+					Buf:          []byte("new grid"),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.PublicRead,
+					Metadata:     meta,
+				},
+			},
+		},
+		{
+			name: "replace existing grid",
+			current: fakeUploader{
+				path: {Buf: old},
+			},
+			expected: fakeUploader{
+				path: {
+					Buf:          []byte("new grid"),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     meta,
+				},
+			},
+		},
+		{
+			name: "failed stage keeps existing grid",
+			current: fakeUploader{
+				path:   {Buf: old},
+				staged: {Err: injected},
+			},
+			expected: fakeUploader{
+				path:   {Buf: old},
+				staged: {Err: injected},
+			},
+			err: true,
+		},
+		{
+			name: "failed finalize keeps existing grid and removes staged grid",
+			current: fakeUploader{
+				path: {Buf: old, Err: injected},
+			},
+			expected: fakeUploader{
+				path: {Buf: old, Err: injected},
+			},
+			err: true,
+		},
+		{
+			name: "interrupt between phases keeps existing grid",
+			current: fakeUploader{
+				path: {Buf: old},
+			},
+			interrupt: true,
+			expected: fakeUploader{
+				path: {Buf: old},
+			},
+			err: true,
+		},
+		{
+			name: "upload directly without staging",
+			current: fakeUploader{
+				path: {Buf: old},
+			},
+			basic: true,
+			expected: fakeUploader{
+				path: {
+					Buf:          []byte("new grid"),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+				},
+			},
+		},
+		{
+			name: "upload metadata directly without staging",
+			current: fakeUploader{
+				path: {Buf: old},
+			},
+			metadata: true,
+			expected: fakeUploader{
+				path: {
+					Buf:          []byte("new grid"),
+					CacheControl: "no-cache",
+					WorldRead:    gcs.DefaultACL,
+					Metadata:     meta,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var client gcs.Uploader = tc.current
+			switch {
+			case tc.interrupt:
+				client = interruptingUploader{tc.current, cancel}
+			case tc.basic:
+				client = basicUploader{tc.current}
+			case tc.metadata:
+				client = metadataUploader{basicUploader{tc.current}}
+			}
+			err := writeGrid(ctx, client, path, strings.NewReader("new grid"), tc.worldRead, meta)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("writeGrid() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("writeGrid() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, tc.current, cmp.AllowUnexported(gcs.Path{}), cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("writeGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGridMetadata(t *testing.T) {
+	orig := Version
+	defer func() { Version = orig }()
+	Version = "v1.2.3"
+
+	group := &configpb.TestGroup{
+		Name:               "group",
+		GcsPrefix:          "bucket/path/to/job",
+		NumFailuresToAlert: 3,
+	}
+	digest, err := ConfigDigest(group)
+	if err != nil {
+		t.Fatalf("ConfigDigest() got unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		same  bool
+	}{
+		{
+			name:  "same config",
+			group: proto.Clone(group).(*configpb.TestGroup),
+			same:  true,
+		},
+		{
+			name: "changed config",
+			group: &configpb.TestGroup{
+				Name:               "group",
+				GcsPrefix:          "bucket/path/to/job",
+				NumFailuresToAlert: 4,
+			},
+		},
+		{
+			name:  "empty config",
+			group: &configpb.TestGroup{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := gridMetadata(tc.group, "hash")
+			if err != nil {
+				t.Fatalf("gridMetadata() got unexpected error: %v", err)
+			}
+			if got, want := meta[gcs.GridHashKey], "hash"; got != want {
+				t.Errorf("gridMetadata() got hash %q, want %q", got, want)
+			}
+			if got, want := meta[VersionKey], "v1.2.3"; got != want {
+				t.Errorf("gridMetadata() got version %q, want %q", got, want)
+			}
+			if got := meta[ConfigDigestKey]; (got == digest) != tc.same {
+				t.Errorf("gridMetadata() got digest %q, original %q, want same=%t", got, digest, tc.same)
+			}
+		})
+	}
+}
+
+func TestConfigDrift(t *testing.T) {
+	gridPath := newPathOrDie("gs://bucket/grid/hello")
+	group := &configpb.TestGroup{
+		Name:               "hello",
+		GcsPrefix:          "bucket/path/to/job",
+		NumFailuresToAlert: 3,
+	}
+	digest, err := ConfigDigest(group)
+	if err != nil {
+		t.Fatalf("ConfigDigest() got unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		stater   fakeStater
+		expected bool
+		err      bool
+	}{
+		{
+			name: "missing grid",
+		},
+		{
+			name: "grid without a digest",
+			stater: fakeStater{
+				gridPath: {Attrs: storage.ObjectAttrs{Metadata: map[string]string{VersionKey: "old"}}},
+			},
+		},
+		{
+			name: "matching digest",
+			stater: fakeStater{
+				gridPath: {Attrs: storage.ObjectAttrs{Metadata: map[string]string{ConfigDigestKey: digest}}},
+			},
+		},
+		{
+			name: "mismatched digest",
+			stater: fakeStater{
+				gridPath: {Attrs: storage.ObjectAttrs{Metadata: map[string]string{ConfigDigestKey: "something else"}}},
+			},
+			expected: true,
+		},
+		{
+			name: "stat error",
+			stater: fakeStater{
+				gridPath: {Err: errors.New("injected")},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := configDrift(context.Background(), tc.stater, gridPath, group)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("configDrift() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("configDrift() failed to return an error")
+			case actual != tc.expected:
+				t.Errorf("configDrift() got %t, want %t", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInflateDropAppendConfigDrift(t *testing.T) {
+	now := time.Now().Unix()
+	uploadPath := newPathOrDie("gs://fake/upload/location")
+	old := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "1", Started: float64(now-60) * 1000},
+		},
+	}
+	cases := []struct {
+		name     string
+		digest   string
+		rebuild  bool
+		expected []string
+	}{
+		{
+			name:     "keep columns with matching digests",
+			rebuild:  true,
+			expected: []string{"2", "1"},
+		},
+		{
+			name:     "keep columns without rebuilding",
+			digest:   "something else",
+			expected: []string{"2", "1"},
+		},
+		{
+			name:     "rebuild with mismatched digests",
+			digest:   "something else",
+			rebuild:  true,
+			expected: []string{"2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				GcsPrefix:             "bucket/path/to/build/",
+				RebuildOnConfigChange: tc.rebuild,
+			}
+			digest := tc.digest
+			if digest == "" {
+				var err error
+				if digest, err = ConfigDigest(group); err != nil {
+					t.Fatalf("ConfigDigest() got unexpected error: %v", err)
+				}
+			}
+			client := fakeUploadClient{
+				Uploader: fakeUploader{},
+				Client: fakeClient{
+					Lister: fakeLister{},
+					Opener: fakeOpener{
+						uploadPath: {Data: string(mustGrid(old))},
+					},
+				},
+				Stater: fakeStater{
+					uploadPath: {Attrs: storage.ObjectAttrs{Metadata: map[string]string{ConfigDigestKey: digest}}},
+				},
+			}
+			buildsPath := newPathOrDie("gs://" + group.GcsPrefix)
+			fi := client.Lister[buildsPath]
+			for _, build := range addBuilds(&client.Client, buildsPath, fakeBuild{
+				id:       "2",
+				started:  jsonStarted(now),
+				finished: jsonFinished(now+1, true, nil),
+				podInfo:  podInfoSuccess,
+			}) {
+				fi.Objects = append(fi.Objects, storage.ObjectAttrs{
+					Prefix: build.Path.Object(),
+				})
+			}
+			client.Lister[buildsPath] = fi
+
+			colReader := gcsColumnReader(client, nil, time.Minute, 1, 0, "")
+			if err := InflateDropAppend(context.Background(), logrus.WithField("test", tc.name), client, group, uploadPath, true, colReader, SortStarted, 0, nil, false, nil, nil, nil, "", gcs.DefaultCompression); err != nil {
+				t.Fatalf("InflateDropAppend() got unexpected error: %v", err)
+			}
+			up, ok := client.Uploader[uploadPath]
+			if !ok {
+				t.Fatal("InflateDropAppend() failed to write the grid")
+			}
+			grid, err := gcs.UnmarshalGrid(up.Buf)
+			if err != nil {
+				t.Fatalf("gcs.UnmarshalGrid() got unexpected error: %v", err)
+			}
+			var actual []string
+			for _, col := range grid.Columns {
+				actual = append(actual, col.Build)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("InflateDropAppend() got unexpected columns (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCarryAnnotations(t *testing.T) {
+	cases := []struct {
+		name     string
+		old      *statepb.Grid
+		cols     []InflatedColumn
+		expected []InflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "missing old grid",
+			cols: []InflatedColumn{
+				{Column: &statepb.Column{Build: "1"}},
+			},
+			expected: []InflatedColumn{
+				{Column: &statepb.Column{Build: "1"}},
+			},
+		},
+		{
+			name: "reattach matching builds",
+			old: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{
+						Build:       "3",
+						Annotations: map[string]string{"known-bad": "release 3"},
+					},
+					{
+						Build: "2",
+					},
+					{
+						Build:       "1",
+						Annotations: map[string]string{"hello": "world"},
+					},
+				},
+			},
+			cols: []InflatedColumn{
+				{Column: &statepb.Column{Build: "4"}},
+				{Column: &statepb.Column{Build: "3"}},
+				{Column: &statepb.Column{Build: "2"}},
+			},
+			expected: []InflatedColumn{
+				{Column: &statepb.Column{Build: "4"}},
+				{
+					Column: &statepb.Column{
+						Build:       "3",
+						Annotations: map[string]string{"known-bad": "release 3"},
+					},
+				},
+				{Column: &statepb.Column{Build: "2"}},
+			},
+		},
+		{
+			name: "keep current annotations",
+			old: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{
+						Build:       "1",
+						Annotations: map[string]string{"old": "value"},
+					},
+				},
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:       "1",
+						Annotations: map[string]string{"new": "value"},
+					},
+				},
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:       "1",
+						Annotations: map[string]string{"new": "value"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			carryAnnotations(tc.old, tc.cols)
+			if diff := cmp.Diff(tc.expected, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("carryAnnotations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatStrftime(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "basically works",
+			want: "basically works",
+		},
+		{
+			name: "Mon Jan 2 15:04:05",
+			want: "Mon Jan 2 15:04:05",
+		},
+		{
+			name: "python am/pm: %p",
+			want: "python am/pm: PM",
+		},
+		{
+			name: "python year: %Y",
+			want: "python year: 2006",
+		},
+		{
+			name: "python short year: %y",
+			want: "python short year: 06",
+		},
+		{
+			name: "python month: %m",
+			want: "python month: 01",
+		},
+		{
+			name: "python date: %d",
+			want: "python date: 02",
+		},
+		{
+			name: "python 24hr: %H",
+			want: "python 24hr: 15",
+		},
+		{
+			name: "python minutes: %M",
+			want: "python minutes: 04",
+		},
+		{
+			name: "python seconds: %S",
+			want: "python seconds: 05",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := formatStrftime(tc.name); got != tc.want {
+				t.Errorf("formatStrftime(%q) got %q want %q", tc.name, got, tc.want)
+			}
+		})
+	}
+
+}
+
+func TestOverrideBuild(t *testing.T) {
+	cases := []struct {
+		name string
+		tg   *configpb.TestGroup
+		cols []InflatedColumn
+		want []InflatedColumn
+	}{
+		{
+			name: "basically works",
+			tg:   &configpb.TestGroup{},
+		},
+		{
+			name: "empty override does not override",
+			tg:   &configpb.TestGroup{},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "world",
+						Started: 6,
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "world",
+						Started: 6,
+					},
+				},
+			},
+		},
+		{
+			name: "override with python style",
+			tg: &configpb.TestGroup{
+				BuildOverrideStrftime: "%y-%m-%d (%Y) %H:%M:%S %p",
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "drop",
+						Hint:    "of fruit",
+						Name:    "keep",
+						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me too"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "21-04-22 (2021) 13:14:15 PM",
+						Hint:    "of fruit",
+						Name:    "keep",
+						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me too"},
+					},
+				},
+			},
+		},
+		{
+			name: "override with golang format",
+			tg: &configpb.TestGroup{
+				BuildOverrideStrftime: "hello 2006 PM",
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "drop",
+						Hint:    "of fruit",
+						Name:    "keep",
+						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me too"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello 2021 PM",
+						Hint:    "of fruit",
+						Name:    "keep",
+						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me too"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			overrideBuild(tc.tg, tc.cols)
+			if diff := cmp.Diff(tc.want, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("overrideBuild() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupColumns(t *testing.T) {
+	cases := []struct {
+		name string
+		tg   *configpb.TestGroup
+		cols []InflatedColumn
+		want []InflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "single column groups do not change",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello",
+						Name:    "world",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "another",
+						Name:    "column",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello",
+						Name:    "world",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "another",
+						Name:    "column",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+		},
+		{
+			name: "group columns with the same build and name",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "lemming",
+						Hint:    "99",
+						Started: 7,
+						Extra: []string{
+							"first",
+							"",
+							"same",
+							"different",
+						},
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "lemming",
+						Hint:    "100",
+						Started: 9,
+						Extra: []string{
+							"",
+							"second",
+							"same",
+							"changed",
+						},
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "lemming",
+						Started: 7,
+						Hint:    "100",
+						Extra: []string{
+							"first",
+							"second",
+							"same",
+							"*",
+						},
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+						"also": {ID: "remains"},
+					},
+				},
+			},
+		},
+		{
+			name: "do not group different builds",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "this",
+						Name:    "same",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "that",
+						Name:    "same",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "this",
+						Name:    "same",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "that",
+						Name:    "same",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+		},
+		{
+			name: "do not group different names",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "different",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "changed",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "different",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "changed",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"also": {ID: "remains"},
+					},
+				},
+			},
+		},
+		{
+			name: "split merged rows with the same name",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"first": {ID: "first"},
+						"same":  {ID: "first-different"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"same":   {ID: "second-changed"},
+						"second": {ID: "second"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"first":    {ID: "first"},
+						"same":     {ID: "first-different"},
+						"same [1]": {ID: "second-changed"},
+						"second":   {ID: "second"},
+					},
+				},
+			},
+		},
+		{
+			name: "ignore_old_results only takes newest",
+			tg: &configpb.TestGroup{
+				IgnoreOldResults: true,
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 9,
+					},
+					Cells: map[string]Cell{
+						"first": {ID: "first"},
+						"same":  {ID: "first-different"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"same":   {ID: "second-changed"},
+						"second": {ID: "second"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"first":  {ID: "first"},
+						"same":   {ID: "first-different"},
+						"second": {ID: "second"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tg := tc.tg
+			if tg == nil {
+				tg = &configpb.TestGroup{}
+			}
+			got := groupColumns(tg, tc.cols)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("groupColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructGrid(t *testing.T) {
+	cases := []struct {
+		name     string
+		group    configpb.TestGroup
+		cols     []inflatedColumn
+		issues   map[string][]string
+		bugs     BugCounter
+		expected statepb.Grid
+		// issueLinks expected on the alert of each named row.
+		issueLinks map[string]string
+		// openBugs expected on the alert of each named row.
+		openBugs map[string]int32
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "multiple columns",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "15"},
+					Cells: map[string]cell{
+						"green": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"red": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+						"only-15": {
+							Result: statuspb.TestStatus_FLAKY,
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "10"},
+					Cells: map[string]cell{
+						"full": {
+							Result:  statuspb.TestStatus_PASS,
+							CellID:  "cell",
+							Icon:    "icon",
+							Message: "message",
+							Metrics: map[string]float64{
+								"elapsed": 1,
+								"keys":    2,
+							},
+							UserProperty: "food",
+						},
+						"green": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"red": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+						"only-10": {
+							Result: statuspb.TestStatus_FLAKY,
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "15"},
+					{Build: "10"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name:         "full",
+							Id:           "full",
+							UserProperty: []string{},
+						},
+						emptyCell,
+						cell{
+							Result:  statuspb.TestStatus_PASS,
+							CellID:  "cell",
+							Icon:    "icon",
+							Message: "message",
+							Metrics: map[string]float64{
+								"elapsed": 1,
+								"keys":    2,
+							},
+							UserProperty: "food",
+						},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "green",
+							Id:   "green",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "only-10",
+							Id:   "only-10",
+						},
+						emptyCell,
+						cell{Result: statuspb.TestStatus_FLAKY},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "only-15",
+							Id:   "only-15",
+						},
+						cell{Result: statuspb.TestStatus_FLAKY},
+						emptyCell,
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "red",
+							Id:   "red",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+		},
+		{
+			name: "open alert",
+			group: configpb.TestGroup{
+				NumFailuresToAlert: 2,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "4"},
+					Cells: map[string]cell{
+						"just-flaky": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+						"broken": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "3"},
+					Cells: map[string]cell{
+						"just-flaky": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"broken": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4"},
+					{Build: "3"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "broken",
+							Id:   "broken",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "just-flaky",
+							Id:   "just-flaky",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+		},
+		{
+			name: "alert on disappeared rows",
+			group: configpb.TestGroup{
+				DisappearedAfter: 2,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "3"},
+					Cells: map[string]cell{
+						"here": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"here": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"here": {Result: statuspb.TestStatus_PASS},
+						"gone": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3"},
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "gone",
+							Id:   "gone",
+						},
+						emptyCell,
+						emptyCell,
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "here",
+							Id:   "here",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+		},
+		{
+			name: "link alerts to issues",
+			group: configpb.TestGroup{
+				NumFailuresToAlert: 1,
+				IssueLinkRules: []*configpb.TestGroup_IssueLinkRule{
+					{
+						MessageRegex:     `timeout in (\w+)`,
+						IssueUrlTemplate: "https://issues/timeout-$1",
+					},
+					{
+						MessageRegex:     `timeout`,
+						IssueUrlTemplate: "https://issues/generic",
+					},
+				},
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"linked": {
+							Result:  statuspb.TestStatus_FAIL,
+							Message: "timeout in setup",
+						},
+						"unlinked": {
+							Result:  statuspb.TestStatus_FAIL,
+							Message: "assertion failed",
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "linked",
+							Id:   "linked",
+						},
+						cell{Result: statuspb.TestStatus_FAIL, Message: "timeout in setup"},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "unlinked",
+							Id:   "unlinked",
+						},
+						cell{Result: statuspb.TestStatus_FAIL, Message: "assertion failed"},
+					),
+				},
+			},
+			issueLinks: map[string]string{
+				"linked": "https://issues/timeout-setup",
+			},
+		},
+		{
+			name: "active silence suppresses alerts",
+			group: configpb.TestGroup{
+				NumFailuresToAlert: 1,
+				SilenceAlertsUntil: time.Now().Add(time.Hour).Unix(),
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"broken": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "broken",
+							Id:   "broken",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+		},
+		{
+			name: "expired silence still alerts",
+			group: configpb.TestGroup{
+				NumFailuresToAlert: 1,
+				SilenceAlertsUntil: time.Now().Add(-time.Hour).Unix(),
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"broken": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "broken",
+							Id:   "broken",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+		},
+		{
+			name: "prune empty columns",
+			group: configpb.TestGroup{
+				NumFailuresToAlert: 2,
+				PruneEmptyColumns:  true,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "4"},
+					Cells: map[string]cell{
+						"broken": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "3"},
+					Cells: map[string]cell{
+						"broken": {Result: statuspb.TestStatus_NO_RESULT},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"broken": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4"},
+					{Build: "2"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "broken",
+							Id:   "broken",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+		},
+		{
+			name: "drop a sparse oldest column",
+			group: configpb.TestGroup{
+				OldestColumnMinFill: 0.5,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_PASS},
+						"world": {Result: statuspb.TestStatus_PASS},
+						"there": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "hello",
+							Id:   "hello",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "there",
+							Id:   "there",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "world",
+							Id:   "world",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+		},
+		{
+			name: "normalize messages",
+			group: configpb.TestGroup{
+				MessageNormalizationRules: []*configpb.TestGroup_MessageNormalizationRule{
+					{Pattern: `0x[0-9a-f]+`, Replacement: "0x?"},
+					{Pattern: `\d{4}-\d{2}-\d{2}T[\d:.]+Z`, Replacement: "<time>"},
+				},
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"panics": {
+							Result:  statuspb.TestStatus_FAIL,
+							Message: "2020-06-02T10:11:12.345Z: nil pointer at 0xc000a1b2c3",
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"panics": {
+							Result:  statuspb.TestStatus_FAIL,
+							Message: "2020-06-01T09:08:07.654Z: nil pointer at 0xc0001f2e3d",
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "panics",
+							Id:   "panics",
+						},
+						cell{Result: statuspb.TestStatus_FAIL, Message: "<time>: nil pointer at 0x?"},
+						cell{Result: statuspb.TestStatus_FAIL, Message: "<time>: nil pointer at 0x?"},
+					),
+				},
+			},
+		},
+		{
+			name: "merge metrics which differ in case",
+			group: configpb.TestGroup{
+				LowercaseMetricNames: true,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"hello": {
+							Result:  statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{"Latency": 3},
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"hello": {
+							Result:  statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{"latency": 1},
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "hello",
+							Id:   "hello",
+						},
+						cell{Result: statuspb.TestStatus_PASS, Metrics: map[string]float64{"latency": 3}},
+						cell{Result: statuspb.TestStatus_PASS, Metrics: map[string]float64{"latency": 1}},
+					),
+				},
+			},
+		},
+		{
+			name: "limit messages and icons",
+			group: configpb.TestGroup{
+				MaxMessageLength: 10,
+				AllowedIcons:     []string{"F"},
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"huge": {
+							Result:  statuspb.TestStatus_FAIL,
+							Message: "a very long failure message with a stack trace",
+							Icon:    "F",
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"huge": {
+							Result:  statuspb.TestStatus_FAIL,
+							Message: "short",
+							Icon:    "<img src=x>",
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "huge",
+							Id:   "huge",
+						},
+						cell{Result: statuspb.TestStatus_FAIL, Message: "a very ...", Icon: "F"},
+						cell{Result: statuspb.TestStatus_FAIL, Message: "short"},
+					),
+				},
+			},
+		},
+		{
+			name: "intern messages",
+			group: configpb.TestGroup{
+				InternMessages: true,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_FAIL, Message: "timeout", Icon: "F"},
+						"world": {Result: statuspb.TestStatus_FAIL, Message: "timeout", Icon: "F"},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"hello": {Result: statuspb.TestStatus_PASS},
+						"world": {Result: statuspb.TestStatus_FAIL, Message: "boom", Icon: "F"},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name: "hello",
+						Id:   "hello",
+						Results: []int32{
+							int32(statuspb.TestStatus_FAIL), 1,
+							int32(statuspb.TestStatus_PASS), 1,
+						},
+						CellIds:        []string{"", ""},
+						Icons:          []string{"F", ""},
+						MessageIndices: []int32{0, 1},
+					},
+					{
+						Name: "world",
+						Id:   "world",
+						Results: []int32{
+							int32(statuspb.TestStatus_FAIL), 2,
+						},
+						CellIds:        []string{"", ""},
+						Icons:          []string{"F", "F"},
+						MessageIndices: []int32{0, 2},
+					},
+				},
+				MessageTable: []string{"timeout", "", "boom"},
+			},
+		},
+		{
+			name: "count open bugs of alerting rows",
+			group: configpb.TestGroup{
+				NumFailuresToAlert: 1,
+			},
+			bugs: func(id, name string) int32 {
+				return map[string]int32{
+					"tracked": 3,
+					"passing": 5,
+				}[id]
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"tracked":   {Result: statuspb.TestStatus_FAIL},
+						"untracked": {Result: statuspb.TestStatus_FAIL},
+						"passing":   {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "passing",
+							Id:   "passing",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "tracked",
+							Id:   "tracked",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "untracked",
+							Id:   "untracked",
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+			openBugs: map[string]int32{
+				"tracked": 3,
+			},
+		},
+		{
+			name: "close alert",
+			group: configpb.TestGroup{
+				NumPassesToDisableAlert: 2,
+				NumFailuresToAlert:      1,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "4"},
+					Cells: map[string]cell{
+						"still-broken": {
+							Result: statuspb.TestStatus_PASS,
+						},
+						"fixed": {
+							Result: statuspb.TestStatus_PASS,
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "3"},
+					Cells: map[string]cell{
+						"still-broken": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+						"fixed": {
+							Result: statuspb.TestStatus_PASS,
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"still-broken": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+						"fixed": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"still-broken": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+						"fixed": {
+							Result: statuspb.TestStatus_FAIL,
+						},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "4"},
+					{Build: "3"},
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "fixed",
+							Id:   "fixed",
 						},
-						{
-							Build:   "50",
-							Hint:    "50",
-							Started: float64(now+50) * 1000,
-							Extra:   []string{"build50"},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "still-broken",
+							Id:   "still-broken",
 						},
-						{
-							Build:   "10",
-							Hint:    "10",
-							Started: float64(now+10) * 1000,
-							Extra:   []string{"build10"},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+		},
+		{
+			name: "exclude rows",
+			group: configpb.TestGroup{
+				ExcludeRowRegexes: []string{"^setup", "teardown$", "("},
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "15"},
+					Cells: map[string]cell{
+						"setup":        {Result: statuspb.TestStatus_PASS},
+						"the-teardown": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "10"},
+					Cells: map[string]cell{
+						"setup-cluster": {Result: statuspb.TestStatus_PASS},
+						"keep":          {Result: statuspb.TestStatus_FAIL},
+						"teardown-keep": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "5"},
+					Cells: map[string]cell{
+						"keep": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "15"},
+					{Build: "10"},
+					{Build: "5"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name: "keep",
+							Id:   "keep",
+						},
+						emptyCell,
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "teardown-keep",
+							Id:   "teardown-keep",
 						},
+						emptyCell,
+						cell{Result: statuspb.TestStatus_PASS},
+						emptyCell,
+					),
+				},
+			},
+		},
+		{
+			name: "row flakiness",
+			group: configpb.TestGroup{
+				ComputeRowFlakiness: true,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"flaky": {Result: statuspb.TestStatus_FLAKY},
+						"good":  {Result: statuspb.TestStatus_PASS},
 					},
-					Rows: []*statepb.Row{
-						setupRow(
-							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
-							},
-							cell{
-								Result:  statuspb.TestStatus_RUNNING,
-								Message: "Build still running...",
-								Icon:    "R",
-							},
-							cell{
-								Result:  statuspb.TestStatus_PASS,
-								Metrics: setElapsed(nil, 1),
-							},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Metrics: setElapsed(nil, 1),
-							},
-							cell{
-								Result:  statuspb.TestStatus_PASS,
-								Metrics: setElapsed(nil, 1),
-							},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: podInfoRow,
-								Id:   podInfoRow,
-							},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-							podInfoPassCell,
-							podInfoPassCell,
-							podInfoPassCell,
-						),
-						setupRow(
-							&statepb.Row{
-								Name: "flaky",
-								Id:   "flaky",
-							},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Message: "flaky",
-								Icon:    "F",
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"flaky": {Result: statuspb.TestStatus_PASS},
+						"good":  {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name:      "flaky",
+							Id:        "flaky",
+							Flakiness: 50,
+						},
+						cell{Result: statuspb.TestStatus_FLAKY},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "good",
+							Id:   "good",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+		},
+		{
+			name: "days since green",
+			group: configpb.TestGroup{
+				ComputeDaysSinceGreen: true,
+			},
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"bad":  {Result: statuspb.TestStatus_FAIL},
+						"good": {Result: statuspb.TestStatus_PASS},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"bad":  {Result: statuspb.TestStatus_FAIL},
+						"good": {Result: statuspb.TestStatus_FAIL},
+					},
+				},
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "2"},
+					{Build: "1"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name:           "bad",
+							Id:             "bad",
+							DaysSinceGreen: -1,
+						},
+						cell{Result: statuspb.TestStatus_FAIL},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "good",
+							Id:   "good",
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_FAIL},
+					),
+				},
+			},
+		},
+		{
+			name: "issues",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "15"},
+					Cells: map[string]cell{
+						"row": {
+							Result: statuspb.TestStatus_PASS,
+							Issues: []string{
+								"from-cell-15",
+								"should-deduplicate-from-both",
+								"should-deduplicate-from-row",
+								"should-deduplicate-from-cell",
+								"should-deduplicate-from-cell",
 							},
-							cell{Result: statuspb.TestStatus_PASS},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: "good1",
-								Id:   "good1",
+						},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "10"},
+					Cells: map[string]cell{
+						"row": {
+							Result: statuspb.TestStatus_PASS,
+							Issues: []string{
+								"from-cell-10",
+								"should-deduplicate-from-row",
 							},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: "good2",
-								Id:   "good2",
+						},
+						"other": {
+							Result: statuspb.TestStatus_PASS,
+							Issues: []string{"fun"},
+						},
+						"sort": {
+							Result: statuspb.TestStatus_PASS,
+							Issues: []string{
+								"3-is-second",
+								"100-is-last",
+								"2-is-first",
 							},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-						),
+						},
 					},
-				}),
-				CacheControl: "no-cache",
-				WorldRead:    gcs.DefaultACL,
+				},
 			},
-		},
-		{
-			name: "sort ascending",
-			group: configpb.TestGroup{
-				GcsPrefix: "bucket/path/to/build/",
-				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
-					{
-						ConfigurationValue: "Commit",
-					},
+			issues: map[string][]string{
+				"row": {
+					"from-argument",
+					"should-deduplicate-from-arg",
+					"should-deduplicate-from-arg",
+					"should-deduplicate-from-both",
 				},
 			},
-			colSorter: func(tg *configpb.TestGroup, cols []InflatedColumn) {
-				want := configpb.TestGroup{ // same as tc.group
-					GcsPrefix: "bucket/path/to/build/",
-					ColumnHeader: []*configpb.TestGroup_ColumnHeader{
-						{
-							ConfigurationValue: "Commit",
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "15"},
+					{Build: "10"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name:   "other",
+							Id:     "other",
+							Issues: []string{"fun"},
 						},
-					},
+						cell{Result: statuspb.TestStatus_NO_RESULT},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "row",
+							Id:   "row",
+							Issues: []string{
+								"should-deduplicate-from-row",
+								"should-deduplicate-from-cell",
+								"should-deduplicate-from-both",
+								"should-deduplicate-from-arg",
+								"from-cell-15",
+								"from-cell-10",
+								"from-argument",
+							},
+						},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name: "sort",
+							Id:   "sort",
+							Issues: []string{
+								"100-is-last",
+								"3-is-second",
+								"2-is-first",
+							},
+						},
+						cell{Result: statuspb.TestStatus_NO_RESULT},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := ConstructGrid(logrus.WithField("name", tc.name), &tc.group, tc.cols, tc.issues, tc.bugs)
+			failuresOpen, passesClose := resolveAlertThresholds(int(tc.group.NumFailuresToAlert), int(tc.group.NumPassesToDisableAlert))
+			only := alertRowFilter(logrus.WithField("name", tc.name), tc.group.AlertRowRegexes)
+			if tc.group.SilenceAlertsUntil <= time.Now().Unix() {
+				ids := buildIDSelector(&tc.group)
+				alertRows(tc.expected.Columns, tc.expected.Rows, failuresOpen, passesClose, int(tc.group.MinHistoryColumns), int(tc.group.IgnoreLatestColumns), tc.group.AlertOnAllFailing, tc.group.FlakyAlert, tc.group.RowAlertThresholds, only, ids)
+				disappearedRows(tc.expected.Columns, tc.expected.Rows, int(tc.group.DisappearedAfter), only, ids)
+			}
+			for _, row := range tc.expected.Rows {
+				if link, ok := tc.issueLinks[row.Name]; ok {
+					row.AlertInfo.IssueLink = link
 				}
-				if diff := cmp.Diff(&want, tg, protocmp.Transform()); diff != "" {
-					panic(fmt.Sprintf("bad TestGroup:\n%s", diff))
+				if n, ok := tc.openBugs[row.Name]; ok {
+					row.AlertInfo.OpenBugs = n
 				}
-				sort.SliceStable(cols, func(i, j int) bool {
-					return cols[i].Column.Started < cols[j].Column.Started
+				sort.SliceStable(row.Metric, func(i, j int) bool {
+					return sortorder.NaturalLess(row.Metric[i], row.Metric[j])
+				})
+				sort.SliceStable(row.Metrics, func(i, j int) bool {
+					return sortorder.NaturalLess(row.Metrics[i].Name, row.Metrics[j].Name)
 				})
+			}
+			if diff := cmp.Diff(&tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructGridMinColumns(t *testing.T) {
+	const hour = float64(time.Hour / time.Millisecond)
+	fail := cell{Result: statuspb.TestStatus_FAIL, Message: "boom"}
+	cases := []struct {
+		name     string
+		cols     []inflatedColumn
+		min      int32
+		alert    int32
+		expected []*statepb.Column
+		results  []int32
+		alerts   bool
+	}{
+		{
+			name: "pad using the interval between columns",
+			cols: []inflatedColumn{
+				{Column: &statepb.Column{Build: "2", Started: 100 * hour}, Cells: map[string]cell{"red": fail}},
+				{Column: &statepb.Column{Build: "1", Started: 98 * hour}, Cells: map[string]cell{"red": fail}},
+			},
+			min:   5,
+			alert: 3,
+			expected: []*statepb.Column{
+				{Build: "2", Started: 100 * hour},
+				{Build: "1", Started: 98 * hour},
+				{Started: 96 * hour, Placeholder: true},
+				{Started: 94 * hour, Placeholder: true},
+				{Started: 92 * hour, Placeholder: true},
+			},
+			results: []int32{int32(statuspb.TestStatus_FAIL), 2, int32(statuspb.TestStatus_NO_RESULT), 3},
+		},
+		{
+			name: "pad a single column an hour apart",
+			cols: []inflatedColumn{
+				{Column: &statepb.Column{Build: "1", Started: 100 * hour}, Cells: map[string]cell{"red": fail}},
 			},
-			builds: []fakeBuild{
-				{
-					id:      "99",
-					started: jsonStarted(now + 99),
-				},
-				{
-					id:      "80",
-					started: jsonStarted(now + 80),
-					podInfo: podInfoSuccess,
-					finished: jsonFinished(now+81, true, metadata.Metadata{
-						metadata.JobVersion: "build80",
-					}),
-					passed: []string{"good1", "good2", "flaky"},
-				},
-				{
-					id:      "50",
-					started: jsonStarted(now + 50),
-					podInfo: podInfoSuccess,
-					finished: jsonFinished(now+51, false, metadata.Metadata{
-						metadata.JobVersion: "build50",
-					}),
-					passed: []string{"good1", "good2"},
-					failed: []string{"flaky"},
-				},
-				{
-					id:      "10",
-					started: jsonStarted(now + 10),
-					podInfo: podInfoSuccess,
-					finished: jsonFinished(now+11, true, metadata.Metadata{
-						metadata.JobVersion: "build10",
-					}),
-					passed: []string{"good1", "good2", "flaky"},
-				},
+			min:   3,
+			alert: 1,
+			expected: []*statepb.Column{
+				{Build: "1", Started: 100 * hour},
+				{Started: 99 * hour, Placeholder: true},
+				{Started: 98 * hour, Placeholder: true},
 			},
-			expected: &fakeUpload{
-				Buf: mustGrid(&statepb.Grid{
-					Columns: []*statepb.Column{
-						{
-							Build:   "10",
-							Hint:    "10",
-							Started: float64(now+10) * 1000,
-							Extra:   []string{"build10"},
-						},
-						{
-							Build:   "50",
-							Hint:    "50",
-							Started: float64(now+50) * 1000,
-							Extra:   []string{"build50"},
-						},
-						{
-							Build:   "80",
-							Hint:    "80",
-							Started: float64(now+80) * 1000,
-							Extra:   []string{"build80"},
-						},
-						{
-							Build:   "99",
-							Hint:    "99",
-							Started: float64(now+99) * 1000,
-							Extra:   []string{""},
-						},
-					},
-					Rows: []*statepb.Row{
-						setupRow(
-							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
-							},
-							cell{
-								Result:  statuspb.TestStatus_PASS,
-								Metrics: setElapsed(nil, 1),
-							},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Metrics: setElapsed(nil, 1),
-							},
-							cell{
-								Result:  statuspb.TestStatus_PASS,
-								Metrics: setElapsed(nil, 1),
-							},
-							cell{
-								Result:  statuspb.TestStatus_RUNNING,
-								Message: "Build still running...",
-								Icon:    "R",
-							},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: podInfoRow,
-								Id:   podInfoRow,
-							},
-							podInfoPassCell,
-							podInfoPassCell,
-							podInfoPassCell,
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: "flaky",
-								Id:   "flaky",
-							},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Message: "flaky",
-								Icon:    "F",
-							},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: "good1",
-								Id:   "good1",
-							},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-						),
-						setupRow(
-							&statepb.Row{
-								Name: "good2",
-								Id:   "good2",
-							},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_PASS},
-							cell{Result: statuspb.TestStatus_NO_RESULT},
-						),
-					},
-				}),
-				CacheControl: "no-cache",
-				WorldRead:    gcs.DefaultACL,
+			results: []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_NO_RESULT), 2},
+			alerts:  true,
+		},
+		{
+			name: "wide enough grids are unchanged",
+			cols: []inflatedColumn{
+				{Column: &statepb.Column{Build: "2", Started: 100 * hour}, Cells: map[string]cell{"red": fail}},
+				{Column: &statepb.Column{Build: "1", Started: 98 * hour}, Cells: map[string]cell{"red": fail}},
+			},
+			min:   2,
+			alert: 2,
+			expected: []*statepb.Column{
+				{Build: "2", Started: 100 * hour},
+				{Build: "1", Started: 98 * hour},
+			},
+			results: []int32{int32(statuspb.TestStatus_FAIL), 2},
+			alerts:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				MinColumns:         tc.min,
+				NumFailuresToAlert: tc.alert,
+			}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), group, tc.cols, nil, nil)
+			if diff := cmp.Diff(tc.expected, grid.Columns, protocmp.Transform()); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected columns (-want +got):\n%s", diff)
+			}
+			if len(grid.Rows) != 1 {
+				t.Fatalf("ConstructGrid() got %d rows, want 1", len(grid.Rows))
+			}
+			row := grid.Rows[0]
+			if diff := cmp.Diff(tc.results, row.Results); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected results (-want +got):\n%s", diff)
+			}
+			if alerted := row.AlertInfo != nil; alerted != tc.alerts {
+				t.Errorf("ConstructGrid() alerted %t, want %t", alerted, tc.alerts)
+			} else if alerted && row.AlertInfo.FailCount != int32(len(tc.cols)) {
+				t.Errorf("ConstructGrid() alert counted %d failures, want %d", row.AlertInfo.FailCount, len(tc.cols))
+			}
+			if err := VerifyGrid(grid); err != nil {
+				t.Errorf("VerifyGrid() got unexpected error: %v", err)
+			}
+
+			// Placeholders are dropped from the next update.
+			cols, _ := InflateGrid(grid, time.Time{}, time.Now().Add(1000*time.Hour))
+			if got, want := len(cols), len(tc.cols); got != want {
+				t.Errorf("InflateGrid() got %d columns, want %d without placeholders", got, want)
+			}
+		})
+	}
+}
+
+func TestPlaceholderColumnsWithoutColumns(t *testing.T) {
+	const hour = float64(time.Hour / time.Millisecond)
+	defer func(orig func() time.Time) { gridClock = orig }(gridClock)
+	now := time.Unix(0, int64(100*hour)*int64(time.Millisecond))
+	gridClock = func() time.Time { return now }
+
+	var got []*statepb.Column
+	for _, col := range placeholderColumns(nil, 2) {
+		got = append(got, col.Column)
+	}
+	expected := []*statepb.Column{
+		{Started: 100 * hour, Placeholder: true},
+		{Started: 99 * hour, Placeholder: true},
+	}
+	if diff := cmp.Diff(expected, got, protocmp.Transform()); diff != "" {
+		t.Errorf("placeholderColumns() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestConstructGridLiveness(t *testing.T) {
+	const hour = float64(time.Hour / time.Millisecond)
+	defer func(orig func() time.Time) { gridClock = orig }(gridClock)
+	now := time.Unix(0, int64(100*hour)*int64(time.Millisecond))
+	gridClock = func() time.Time { return now }
+	pass := cell{Result: statuspb.TestStatus_PASS}
+	cases := []struct {
+		name     string
+		cols     []inflatedColumn
+		liveness int32
+		expected []*statepb.Column
+		overall  []int32
+		message  string
+	}{
+		{
+			name: "disabled by default",
+			cols: []inflatedColumn{
+				{Column: &statepb.Column{Build: "1", Started: 90 * hour}, Cells: map[string]cell{overallRow: pass}},
 			},
+			expected: []*statepb.Column{
+				{Build: "1", Started: 90 * hour},
+			},
+			overall: []int32{int32(statuspb.TestStatus_PASS), 1},
 		},
 		{
-			name:      "do not write when requested",
-			skipWrite: true,
-			group: configpb.TestGroup{
-				GcsPrefix: "bucket/path/to/build/",
-				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
-					{
-						ConfigurationValue: "Commit",
-					},
-				},
+			name: "empty when the newest build is recent",
+			cols: []inflatedColumn{
+				{Column: &statepb.Column{Build: "2", Started: 99 * hour}, Cells: map[string]cell{overallRow: pass}},
+				{Column: &statepb.Column{Build: "1", Started: 90 * hour}, Cells: map[string]cell{overallRow: pass}},
 			},
-			builds: []fakeBuild{
-				{
-					id:      "99",
-					started: jsonStarted(now + 99),
-				},
-				{
-					id:      "80",
-					started: jsonStarted(now + 80),
-					finished: jsonFinished(now+81, true, metadata.Metadata{
-						metadata.JobVersion: "build80",
-					}),
-					passed: []string{"good1", "good2", "flaky"},
-				},
-				{
-					id:      "50",
-					started: jsonStarted(now + 50),
-					finished: jsonFinished(now+51, false, metadata.Metadata{
-						metadata.JobVersion: "build50",
-					}),
-					passed: []string{"good1", "good2"},
-					failed: []string{"flaky"},
-				},
-				{
-					id:      "10",
-					started: jsonStarted(now + 10),
-					finished: jsonFinished(now+11, true, metadata.Metadata{
-						metadata.JobVersion: "build10",
-					}),
-					passed: []string{"good1", "good2", "flaky"},
-				},
+			liveness: 120,
+			expected: []*statepb.Column{
+				{Name: "now", Started: 100 * hour, Placeholder: true},
+				{Build: "2", Started: 99 * hour},
+				{Build: "1", Started: 90 * hour},
 			},
+			overall: []int32{int32(statuspb.TestStatus_NO_RESULT), 1, int32(statuspb.TestStatus_PASS), 2},
 		},
 		{
-			name: "recent", // keep columns past the reprocess boundary
-			group: configpb.TestGroup{
-				GcsPrefix: "bucket/path/to/build/",
-				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
-					{
-						ConfigurationValue: "Commit",
-					},
+			name: "mark stale when the newest build is old",
+			cols: []inflatedColumn{
+				{Column: &statepb.Column{Build: "2", Started: 97 * hour}, Cells: map[string]cell{overallRow: pass}},
+				{Column: &statepb.Column{Build: "1", Started: 90 * hour}, Cells: map[string]cell{overallRow: pass}},
+			},
+			liveness: 120,
+			expected: []*statepb.Column{
+				{Name: "now", Started: 100 * hour, Placeholder: true},
+				{Build: "2", Started: 97 * hour},
+				{Build: "1", Started: 90 * hour},
+			},
+			overall: []int32{int32(statuspb.TestStatus_UNKNOWN), 1, int32(statuspb.TestStatus_PASS), 2},
+			message: "No build started within 2h0m0s, the newest started at 1970-01-05T01:00:00Z",
+		},
+		{
+			name:     "mark stale without builds",
+			liveness: 120,
+			expected: []*statepb.Column{
+				{Name: "now", Started: 100 * hour, Placeholder: true},
+			},
+			overall: []int32{int32(statuspb.TestStatus_UNKNOWN), 1},
+			message: "No builds",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				LivenessMinutes: tc.liveness,
+			}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), group, tc.cols, nil, nil)
+			if diff := cmp.Diff(tc.expected, grid.Columns, protocmp.Transform()); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected columns (-want +got):\n%s", diff)
+			}
+			if len(grid.Rows) != 1 {
+				t.Fatalf("ConstructGrid() got %d rows, want 1", len(grid.Rows))
+			}
+			row := grid.Rows[0]
+			if diff := cmp.Diff(tc.overall, row.Results); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected results (-want +got):\n%s", diff)
+			}
+			var message string
+			if len(row.Messages) > 0 {
+				message = row.Messages[0]
+			}
+			if tc.message != message {
+				t.Errorf("ConstructGrid() got message %q, want %q", message, tc.message)
+			}
+			if err := VerifyGrid(grid); err != nil {
+				t.Errorf("VerifyGrid() got unexpected error: %v", err)
+			}
+
+			// The liveness column is dropped from the next update.
+			cols, _ := InflateGrid(grid, time.Time{}, now.Add(1000*time.Hour))
+			if got, want := len(cols), len(tc.cols); got != want {
+				t.Errorf("InflateGrid() got %d columns, want %d without the liveness column", got, want)
+			}
+		})
+	}
+}
+
+func TestConstructGridLivenessAlerts(t *testing.T) {
+	const hour = float64(time.Hour / time.Millisecond)
+	defer func(orig func() time.Time) { gridClock = orig }(gridClock)
+	now := time.Unix(0, int64(100*hour)*int64(time.Millisecond))
+	gridClock = func() time.Time { return now }
+	col := func(build string, started float64, cells map[string]cell) inflatedColumn {
+		return inflatedColumn{Column: &statepb.Column{Build: build, Started: started}, Cells: cells}
+	}
+	pass := cell{Result: statuspb.TestStatus_PASS}
+	fail := cell{Result: statuspb.TestStatus_FAIL}
+	type alert struct {
+		Type      statepb.AlertInfo_AlertType
+		FailCount int32
+		FailBuild string
+		Latest    string
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		cols     []inflatedColumn
+		expected map[string]alert
+	}{
+		{
+			name: "liveness column does not count as disappeared",
+			group: &configpb.TestGroup{
+				LivenessMinutes:  120,
+				DisappearedAfter: 1,
+			},
+			cols: []inflatedColumn{
+				col("2", 99*hour, map[string]cell{"foo": pass, "bar": pass}),
+				col("1", 98*hour, map[string]cell{"foo": pass, "bar": pass}),
+			},
+		},
+		{
+			name: "rows missing from builds still disappear",
+			group: &configpb.TestGroup{
+				LivenessMinutes:  120,
+				DisappearedAfter: 1,
+			},
+			cols: []inflatedColumn{
+				col("3", 99*hour, map[string]cell{"foo": pass}),
+				col("2", 98.5*hour, map[string]cell{"foo": pass}),
+				col("1", 98*hour, map[string]cell{"foo": pass, "bar": pass}),
+			},
+			expected: map[string]alert{
+				"bar": {
+					Type:      statepb.AlertInfo_ALERT_TYPE_DISAPPEARED,
+					FailCount: 2,
+					FailBuild: "2",
+					Latest:    "3",
 				},
 			},
-			reprocess: 10 * time.Second,
-			builds: []fakeBuild{
-				{
-					id:      "current",
-					started: jsonStarted(now),
+		},
+		{
+			name: "liveness column does not use up ignore_latest_columns",
+			group: &configpb.TestGroup{
+				LivenessMinutes:     120,
+				IgnoreLatestColumns: 1,
+				NumFailuresToAlert:  2,
+			},
+			cols: []inflatedColumn{
+				col("4", 99*hour, map[string]cell{"foo": pass}),
+				col("3", 98.5*hour, map[string]cell{"foo": fail}),
+				col("2", 98*hour, map[string]cell{"foo": fail}),
+				col("1", 97.5*hour, map[string]cell{"foo": pass}),
+			},
+			expected: map[string]alert{
+				"foo": {
+					Type:      statepb.AlertInfo_ALERT_TYPE_FAILING,
+					FailCount: 2,
+					FailBuild: "2",
+					Latest:    "3",
 				},
 			},
-			current: &fake.Object{
-				Data: string(mustGrid(&statepb.Grid{
-					Columns: []*statepb.Column{
-						{
-							Build:   "current",
-							Hint:    "should reprocess",
-							Started: float64(now * 1000),
-							Extra:   []string{""},
-						},
-						{
-							Build:   "at boundary",
-							Hint:    "1 should disappear",
-							Started: float64(now-9) * 1000,
-							Extra:   []string{""},
-						},
-						{
-							Build:   "past boundary",
-							Hint:    "boundary+999",
-							Started: float64(now-9)*1000 - 1,
-							Extra:   []string{"keep"},
-						},
-					},
-					Rows: []*statepb.Row{
-						setupRow(
-							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
-							},
-							cell{
-								Result:  statuspb.TestStatus_PASS,
-								Message: "old data",
-								Icon:    "should reprocess",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Message: "delete me",
-								Icon:    "me too",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FLAKY,
-								Message: "keep me",
-								Icon:    "yes stay",
-							},
-						),
-					},
-				})),
+		},
+		{
+			name: "ignore_latest_columns still ignores the newest build",
+			group: &configpb.TestGroup{
+				LivenessMinutes:     120,
+				IgnoreLatestColumns: 1,
+				NumFailuresToAlert:  2,
 			},
-			expected: &fakeUpload{
-				Buf: mustGrid(&statepb.Grid{
-					Columns: []*statepb.Column{
-						{
-							Build:   "current",
-							Hint:    "current",
-							Started: float64(now) * 1000,
-							Extra:   []string{""},
-						},
-						{
-							Build:   "past boundary",
-							Hint:    "boundary+999",
-							Started: float64(now-9)*1000 - 1,
-							Extra:   []string{"keep"},
-						},
+			cols: []inflatedColumn{
+				col("3", 99*hour, map[string]cell{"foo": fail}),
+				col("2", 98.5*hour, map[string]cell{"foo": fail}),
+				col("1", 98*hour, map[string]cell{"foo": pass}),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := ConstructGrid(logrus.WithField("name", tc.name), tc.group, tc.cols, nil, nil)
+			if !grid.Columns[0].Placeholder {
+				t.Fatalf("ConstructGrid() got newest column %v, want the liveness column", grid.Columns[0])
+			}
+			got := map[string]alert{}
+			for _, row := range grid.Rows {
+				if a := row.AlertInfo; a != nil {
+					got[row.Name] = alert{
+						Type:      a.AlertType,
+						FailCount: a.FailCount,
+						FailBuild: a.FailBuildId,
+						Latest:    a.LatestFailBuildId,
+					}
+				}
+			}
+			if tc.expected == nil {
+				tc.expected = map[string]alert{}
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected alerts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDropPartialOldest(t *testing.T) {
+	col := func(build string, results ...statuspb.TestStatus) InflatedColumn {
+		cells := map[string]cell{}
+		for i, r := range results {
+			cells[fmt.Sprintf("row-%d", i)] = cell{Result: r}
+		}
+		return InflatedColumn{Column: &statepb.Column{Build: build}, Cells: cells}
+	}
+	const (
+		pass  = statuspb.TestStatus_PASS
+		fail  = statuspb.TestStatus_FAIL
+		empty = statuspb.TestStatus_NO_RESULT
+	)
+	cases := []struct {
+		name     string
+		cols     []InflatedColumn
+		minFill  float64
+		expected []string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "keep the only column",
+			cols:     []InflatedColumn{col("1", pass)},
+			minFill:  1,
+			expected: []string{"1"},
+		},
+		{
+			name: "keep a full oldest column",
+			cols: []InflatedColumn{
+				col("2", pass, pass, pass, pass),
+				col("1", pass, fail, pass, fail),
+			},
+			minFill:  1,
+			expected: []string{"2", "1"},
+		},
+		{
+			name: "drop a sparse oldest column",
+			cols: []InflatedColumn{
+				col("3", pass, pass, pass, pass),
+				col("2", pass, pass, pass),
+				col("1", fail),
+			},
+			minFill:  0.5,
+			expected: []string{"3", "2"},
+		},
+		{
+			name: "keep an oldest column at the ratio",
+			cols: []InflatedColumn{
+				col("2", pass, pass, pass, pass),
+				col("1", fail, fail),
+			},
+			minFill:  0.5,
+			expected: []string{"2", "1"},
+		},
+		{
+			name: "empty cells do not count",
+			cols: []InflatedColumn{
+				col("2", pass, pass, pass, pass),
+				col("1", fail, empty, empty, empty),
+			},
+			minFill:  0.5,
+			expected: []string{"2"},
+		},
+		{
+			name: "only drop the oldest column",
+			cols: []InflatedColumn{
+				col("3", pass, pass, pass, pass),
+				col("2", pass),
+				col("1", pass),
+			},
+			minFill:  0.5,
+			expected: []string{"3", "2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for _, col := range dropPartialOldest(logrus.WithField("name", tc.name), tc.cols, tc.minFill) {
+				actual = append(actual, col.Column.Build)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("dropPartialOldest() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnStats(t *testing.T) {
+	cases := []struct {
+		name     string
+		cols     []inflatedColumn
+		expected []*statepb.Column_Stats
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "count results",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "3"},
+					Cells: map[string]cell{
+						"pass":  {Result: statuspb.TestStatus_PASS},
+						"fail":  {Result: statuspb.TestStatus_FAIL},
+						"flaky": {Result: statuspb.TestStatus_FLAKY},
+						"new":   {Result: statuspb.TestStatus_BUILD_FAIL},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "2"},
+					Cells: map[string]cell{
+						"pass":  {Result: statuspb.TestStatus_PASS_WITH_SKIPS},
+						"fail":  {Result: statuspb.TestStatus_RUNNING},
+						"flaky": {Result: statuspb.TestStatus_PASS},
 					},
-					Rows: []*statepb.Row{
-						setupRow(
-							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
-							},
-							cell{
-								Result:  statuspb.TestStatus_RUNNING,
-								Message: "Build still running...",
-								Icon:    "R",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FLAKY,
-								Message: "keep me",
-								Icon:    "yes stay",
-							},
-						),
+				},
+				{
+					Column: &statepb.Column{Build: "1"},
+					Cells: map[string]cell{
+						"pass": {Result: statuspb.TestStatus_PASS},
 					},
-				}),
-				CacheControl: "no-cache",
-				WorldRead:    gcs.DefaultACL,
+				},
+			},
+			expected: []*statepb.Column_Stats{
+				{
+					PassCount:  1,
+					FailCount:  2,
+					FlakyCount: 1,
+				},
+				{
+					PassCount:     2,
+					NoResultCount: 2, // running and new
+				},
+				{
+					PassCount:     1,
+					NoResultCount: 3, // rows that do not exist yet
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{ComputeColumnStats: true}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, tc.cols, nil, nil)
+			var actual []*statepb.Column_Stats
+			for _, col := range grid.Columns {
+				actual = append(actual, col.Stats)
+			}
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected column stats (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnHealth(t *testing.T) {
+	col := func(build string, results ...statuspb.TestStatus) inflatedColumn {
+		cells := map[string]cell{}
+		for i, r := range results {
+			cells[fmt.Sprint("row", i)] = cell{Result: r}
+		}
+		return inflatedColumn{
+			Column: &statepb.Column{Build: build},
+			Cells:  cells,
+		}
+	}
+	const (
+		pass    = statuspb.TestStatus_PASS
+		fail    = statuspb.TestStatus_FAIL
+		flaky   = statuspb.TestStatus_FLAKY
+		running = statuspb.TestStatus_RUNNING
+	)
+	cols := []inflatedColumn{
+		col("empty", running),
+		col("green", pass, pass, pass, pass),
+		col("mostly green", pass, pass, pass, pass, pass, pass, pass, pass, pass, pass, pass, fail),
+		col("flaky", pass, pass, pass, flaky),
+		col("broken", pass, fail, fail, flaky),
+	}
+	cases := []struct {
+		name     string
+		health   *configpb.TestGroup_ColumnHealth
+		expected []statepb.Column_Health
+	}{
+		{
+			name: "disabled by default",
+			expected: []statepb.Column_Health{
+				statepb.Column_HEALTH_UNKNOWN,
+				statepb.Column_HEALTH_UNKNOWN,
+				statepb.Column_HEALTH_UNKNOWN,
+				statepb.Column_HEALTH_UNKNOWN,
+				statepb.Column_HEALTH_UNKNOWN,
 			},
 		},
 		{
-			// short reprocessing time depends on our reprocessing running columns outside this timeframe.
-			name: "running", // reprocess everything at least as new as the running column
-			group: configpb.TestGroup{
-				GcsPrefix: "bucket/path/to/build/",
+			name:   "default thresholds",
+			health: &configpb.TestGroup_ColumnHealth{},
+			expected: []statepb.Column_Health{
+				statepb.Column_HEALTH_UNKNOWN,
+				statepb.Column_HEALTH_PASSING,
+				statepb.Column_HEALTH_MOSTLY_PASSING,
+				statepb.Column_HEALTH_FLAKY,
+				statepb.Column_HEALTH_BROKEN,
+			},
+		},
+		{
+			name: "custom thresholds",
+			health: &configpb.TestGroup_ColumnHealth{
+				BrokenPercent: 5,
+				FlakyPercent:  50,
+			},
+			expected: []statepb.Column_Health{
+				statepb.Column_HEALTH_UNKNOWN,
+				statepb.Column_HEALTH_PASSING,
+				statepb.Column_HEALTH_BROKEN,
+				statepb.Column_HEALTH_MOSTLY_PASSING,
+				statepb.Column_HEALTH_BROKEN,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{ColumnHealth: tc.health}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, cols, nil, nil)
+			var actual []statepb.Column_Health
+			for _, col := range grid.Columns {
+				actual = append(actual, col.Health)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected column health (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnGroupKeys(t *testing.T) {
+	const midnight = 1600041600 // 2020-09-14, a Monday
+	col := func(started int64, commit string) inflatedColumn {
+		return inflatedColumn{
+			Column: &statepb.Column{
+				Build:   fmt.Sprint(started),
+				Started: float64(started * 1000),
+				Extra:   []string{commit},
+			},
+			Cells: map[string]cell{
+				"hello": {Result: statuspb.TestStatus_PASS},
+			},
+		}
+	}
+	cols := []inflatedColumn{
+		col(midnight+1, "deadbeef1234"),
+		col(midnight, "deadbeef5678"),
+		col(midnight-1, "cafe"),
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		expected []string
+	}{
+		{
+			name:     "disabled by default",
+			group:    &configpb.TestGroup{},
+			expected: []string{"", "", ""},
+		},
+		{
+			name: "day",
+			group: &configpb.TestGroup{
+				ColumnGrouping: configpb.TestGroup_COLUMN_GROUPING_DAY,
+			},
+			expected: []string{"2020-09-14", "2020-09-14", "2020-09-13"},
+		},
+		{
+			name: "week",
+			group: &configpb.TestGroup{
+				ColumnGrouping: configpb.TestGroup_COLUMN_GROUPING_WEEK,
+			},
+			expected: []string{"2020-W38", "2020-W38", "2020-W37"},
+		},
+		{
+			name: "commit prefix",
+			group: &configpb.TestGroup{
+				ColumnGrouping: configpb.TestGroup_COLUMN_GROUPING_COMMIT_PREFIX,
 				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
-					{
-						ConfigurationValue: "Commit",
-					},
+					{ConfigurationValue: "Commit"},
 				},
 			},
-			reprocess: 10 * time.Second,
-			builds: []fakeBuild{
-				{
-					id:      "current",
-					started: jsonStarted(now),
+			expected: []string{"deadbee", "deadbee", "cafe"},
+		},
+		{
+			name: "custom commit prefix",
+			group: &configpb.TestGroup{
+				ColumnGrouping:             configpb.TestGroup_COLUMN_GROUPING_COMMIT_PREFIX,
+				ColumnGroupingPrefixLength: 9,
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{ConfigurationValue: "Commit"},
 				},
 			},
-			current: &fake.Object{
-				Data: string(mustGrid(&statepb.Grid{
-					Columns: []*statepb.Column{
-						{
-							Build:   "current",
-							Hint:    "should reprocess",
-							Started: float64(now * 1000),
-							Extra:   []string{""},
-						},
-						{
-							Build:   "at boundary",
-							Hint:    "1 should disappear",
-							Started: float64(now-9) * 1000,
-							Extra:   []string{""},
-						},
-						{
-							Build:   "past boundary",
-							Hint:    "1 done but still reprocess",
-							Started: float64(now-40) * 1000,
-							Extra:   []string{""},
-						},
-						{
-							Build:   "paster boundary",
-							Hint:    "1 running",
-							Started: float64(now-50) * 1000,
-							Extra:   []string{""},
-						},
-						{
-							Build:   "pastest boundary",
-							Hint:    "1 oldest",
-							Started: float64(now-60) * 1000,
-							Extra:   []string{"keep"},
-						},
-					},
-					Rows: []*statepb.Row{
-						setupRow(
-							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
-							},
-							cell{
-								Result:  statuspb.TestStatus_PASS,
-								Message: "old data",
-								Icon:    "should reprocess",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Message: "delete me",
-								Icon:    "me too",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FAIL,
-								Message: "delete me",
-								Icon:    "me too",
-							},
-							cell{
-								Result:  statuspb.TestStatus_RUNNING,
-								Message: "delete me",
-								Icon:    "me too",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FLAKY,
-								Message: "keep me",
-								Icon:    "yes stay",
-							},
-						),
-					},
-				})),
+			expected: []string{"deadbeef1", "deadbeef5", "cafe"},
+		},
+		{
+			name: "commit prefix without a commit header",
+			group: &configpb.TestGroup{
+				ColumnGrouping: configpb.TestGroup_COLUMN_GROUPING_COMMIT_PREFIX,
 			},
-			expected: &fakeUpload{
-				Buf: mustGrid(&statepb.Grid{
-					Columns: []*statepb.Column{
-						{
-							Build:   "current",
-							Hint:    "current",
-							Started: float64(now) * 1000,
-							Extra:   []string{""},
-						},
-						{
-							Build:   "pastest boundary",
-							Hint:    "1 oldest",
-							Started: float64(now-60) * 1000,
-							Extra:   []string{"keep"},
-						},
-					},
-					Rows: []*statepb.Row{
-						setupRow(
-							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
-							},
-							cell{
-								Result:  statuspb.TestStatus_RUNNING,
-								Message: "Build still running...",
-								Icon:    "R",
-							},
-							cell{
-								Result:  statuspb.TestStatus_FLAKY,
-								Message: "keep me",
-								Icon:    "yes stay",
-							},
-						),
-					},
-				}),
-				CacheControl: "no-cache",
-				WorldRead:    gcs.DefaultACL,
+			expected: []string{"", "", ""},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := ConstructGrid(logrus.WithField("name", tc.name), tc.group, cols, nil, nil)
+			var actual []string
+			for _, col := range grid.Columns {
+				actual = append(actual, col.GroupKey)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected group keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDisableAlerts(t *testing.T) {
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "2", Started: 2000},
+			Cells: map[string]cell{
+				"broken": {Result: statuspb.TestStatus_FAIL, Message: "boom"},
+				"good":   {Result: statuspb.TestStatus_PASS},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "1", Started: 1000},
+			Cells: map[string]cell{
+				"broken": {Result: statuspb.TestStatus_FAIL, Message: "bang"},
+				"good":   {Result: statuspb.TestStatus_PASS},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		disable  bool
+		expected []string
+	}{
+		{
+			name:     "alert by default",
+			expected: []string{"broken"},
+		},
+		{
+			name:    "disable alerts",
+			disable: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{
+				NumFailuresToAlert: 2,
+				DisableAlerts:      tc.disable,
+			}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, cols, nil, nil)
+			var actual []string
+			for _, row := range grid.Rows {
+				if row.AlertInfo != nil {
+					actual = append(actual, row.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected alerting rows (-want +got):\n%s", diff)
+			}
+
+			// Recomputing must also clear alerts of an existing grid.
+			grid.Rows[0].AlertInfo = &statepb.AlertInfo{FailCount: 1}
+			alertGrid(logrus.WithField("name", tc.name), &group, grid, nil)
+			actual = nil
+			for _, row := range grid.Rows {
+				if row.AlertInfo != nil {
+					actual = append(actual, row.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("alertGrid() got unexpected alerting rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSilenceAlerts(t *testing.T) {
+	defer func(orig func() time.Time) { gridClock = orig }(gridClock)
+	until := time.Unix(1600000000, 0)
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "1", Started: 1000},
+			Cells: map[string]cell{
+				"broken": {Result: statuspb.TestStatus_FAIL, Message: "boom"},
 			},
 		},
 	}
+	cases := []struct {
+		name     string
+		now      time.Time
+		expected []string
+	}{
+		{
+			name: "silence before the deadline",
+			now:  until.Add(-time.Second),
+		},
+		{
+			name:     "alert at the deadline",
+			now:      until,
+			expected: []string{"broken"},
+		},
+		{
+			name:     "alert after the deadline",
+			now:      until.Add(time.Second),
+			expected: []string{"broken"},
+		},
+	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if tc.ctx == nil {
-				tc.ctx = context.Background()
+			gridClock = func() time.Time { return tc.now }
+			group := configpb.TestGroup{
+				NumFailuresToAlert: 1,
+				SilenceAlertsUntil: until.Unix(),
 			}
-			ctx, cancel := context.WithCancel(tc.ctx)
-			defer cancel()
-
-			if tc.concurrency == 0 {
-				tc.concurrency = 1
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, cols, nil, nil)
+			var actual []string
+			for _, row := range grid.Rows {
+				if row.AlertInfo != nil {
+					actual = append(actual, row.Name)
+				}
 			}
-			if tc.groupTimeout == nil {
-				tc.groupTimeout = &defaultTimeout
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected alerting rows (-want +got):\n%s", diff)
 			}
-			if tc.buildTimeout == nil {
-				tc.buildTimeout = &defaultTimeout
+		})
+	}
+}
+
+// BenchmarkAlertGrid compares alerting on a large grid with and without alerts disabled.
+func BenchmarkAlertGrid(b *testing.B) {
+	const nCols, nRows = 200, 500
+	cols := make([]inflatedColumn, 0, nCols)
+	for c := 0; c < nCols; c++ {
+		cells := make(map[string]cell, nRows)
+		for r := 0; r < nRows; r++ {
+			result := statuspb.TestStatus_FAIL // Long failing runs are the costliest to alert.
+			if (c+r)%50 == 0 {
+				result = statuspb.TestStatus_PASS
+			}
+			cells[fmt.Sprint("row", r)] = cell{Result: result, Message: "hello"}
+		}
+		cols = append(cols, inflatedColumn{
+			Column: &statepb.Column{Build: fmt.Sprint(nCols - c), Started: float64(nCols - c)},
+			Cells:  cells,
+		})
+	}
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+	grid := ConstructGrid(log, &configpb.TestGroup{DisableAlerts: true}, cols, nil, nil)
+	for _, disable := range []bool{false, true} {
+		b.Run(fmt.Sprintf("disable alerts %t", disable), func(b *testing.B) {
+			group := configpb.TestGroup{DisableAlerts: disable}
+			for i := 0; i < b.N; i++ {
+				alertGrid(log, &group, grid, nil)
 			}
+		})
+	}
+}
 
-			client := fakeUploadClient{
-				Uploader: fakeUploader{},
-				Client: fakeClient{
-					Lister: fakeLister{},
-					Opener: fakeOpener{},
+func TestMetricOrder(t *testing.T) {
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "1"},
+			Cells: map[string]cell{
+				"hello": {
+					Result: statuspb.TestStatus_PASS,
+					Metrics: map[string]float64{
+						"throughput": 1,
+						"latency":    2,
+						"memory10":   3,
+						"memory9":    4,
+						"cpu":        5,
+					},
 				},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		order    []string
+		expected []string
+	}{
+		{
+			name:     "natural order by default",
+			expected: []string{"cpu", "latency", "memory9", "memory10", "throughput"},
+		},
+		{
+			name:     "listed metrics first",
+			order:    []string{"throughput", "latency"},
+			expected: []string{"throughput", "latency", "cpu", "memory9", "memory10"},
+		},
+		{
+			name:     "ignore missing and repeated metrics",
+			order:    []string{"missing", "memory10", "latency", "memory10"},
+			expected: []string{"memory10", "latency", "cpu", "memory9", "throughput"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{MetricOrder: tc.order}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, cols, nil, nil)
+			row := grid.Rows[0]
+			if diff := cmp.Diff(tc.expected, row.Metric); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected metric names (-want +got):\n%s", diff)
+			}
+			var names []string
+			for _, m := range row.Metrics {
+				names = append(names, m.Name)
 			}
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected metrics (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
 
-			if tc.current != nil {
-				client.Opener[uploadPath] = *tc.current
+func TestTargetMetadata(t *testing.T) {
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "1"},
+			Cells: map[string]cell{
+				"foo":       {Result: statuspb.TestStatus_PASS, ID: "//pkg/foo:go_default_test"},
+				"foo [gpu]": {Result: statuspb.TestStatus_PASS, ID: "//pkg/foo:go_default_test"},
+				"bar":       {Result: statuspb.TestStatus_FAIL, ID: "//pkg/bar:go_default_test"},
+				"unmapped":  {Result: statuspb.TestStatus_PASS},
+			},
+		},
+	}
+	type labels struct {
+		Owner     string
+		Component string
+	}
+	cases := []struct {
+		name     string
+		rules    []*configpb.TestGroup_TargetMetadata
+		expected map[string]labels
+	}{
+		{
+			name: "no labels by default",
+			expected: map[string]labels{
+				"foo":       {},
+				"foo [gpu]": {},
+				"bar":       {},
+				"unmapped":  {},
+			},
+		},
+		{
+			name: "first matching rule wins",
+			rules: []*configpb.TestGroup_TargetMetadata{
+				{TargetRegex: "[", Owner: "bad regex"},
+				{TargetRegex: "^//pkg/foo:", Owner: "foo-team", Component: "Foo"},
+				{TargetRegex: "^//pkg/", Owner: "pkg-team"},
+				{TargetRegex: "^//pkg/bar:", Owner: "never"},
+			},
+			expected: map[string]labels{
+				"foo":       {Owner: "foo-team", Component: "Foo"},
+				"foo [gpu]": {Owner: "foo-team", Component: "Foo"},
+				"bar":       {Owner: "pkg-team"},
+				"unmapped":  {},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{TargetMetadata: tc.rules}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, cols, nil, nil)
+			actual := map[string]labels{}
+			for _, row := range grid.Rows {
+				actual[row.Name] = labels{Owner: row.Owner, Component: row.Component}
 			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected labels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
 
-			buildsPath := newPathOrDie("gs://" + tc.group.GcsPrefix)
-			fi := client.Lister[buildsPath]
-			for _, build := range addBuilds(&client.Client, buildsPath, tc.builds...) {
-				fi.Objects = append(fi.Objects, storage.ObjectAttrs{
-					Prefix: build.Path.Object(),
-				})
+func TestAlertCooldown(t *testing.T) {
+	const (
+		closed = 1000 * 1000 // milliseconds
+		hour   = float64(time.Hour / time.Millisecond)
+	)
+	alert := &statepb.AlertInfo{FailCount: 3}
+	cases := []struct {
+		name     string
+		old      *statepb.Row
+		row      *statepb.Row
+		started  float64
+		expected *statepb.Row
+	}{
+		{
+			name:     "open alerts without a closed alert",
+			row:      &statepb.Row{Name: "hello", AlertInfo: alert},
+			started:  closed,
+			expected: &statepb.Row{Name: "hello", AlertInfo: alert},
+		},
+		{
+			name:     "record when an alert closes",
+			old:      &statepb.Row{Name: "hello", AlertInfo: alert},
+			row:      &statepb.Row{Name: "hello"},
+			started:  closed,
+			expected: &statepb.Row{Name: "hello", LastAlertClosed: closed},
+		},
+		{
+			name:     "keep open alerts",
+			old:      &statepb.Row{Name: "hello", AlertInfo: alert, LastAlertClosed: closed},
+			row:      &statepb.Row{Name: "hello", AlertInfo: alert},
+			started:  closed + hour/2,
+			expected: &statepb.Row{Name: "hello", AlertInfo: alert, LastAlertClosed: closed},
+		},
+		{
+			name:     "suppress reopening within the cooldown",
+			old:      &statepb.Row{Name: "hello", LastAlertClosed: closed},
+			row:      &statepb.Row{Name: "hello", AlertInfo: alert},
+			started:  closed + hour/2,
+			expected: &statepb.Row{Name: "hello", LastAlertClosed: closed},
+		},
+		{
+			name:     "reopen after the cooldown",
+			old:      &statepb.Row{Name: "hello", LastAlertClosed: closed},
+			row:      &statepb.Row{Name: "hello", AlertInfo: alert},
+			started:  closed + hour,
+			expected: &statepb.Row{Name: "hello", AlertInfo: alert, LastAlertClosed: closed},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var old *statepb.Grid
+			if tc.old != nil {
+				old = &statepb.Grid{Rows: []*statepb.Row{tc.old}}
 			}
-			client.Lister[buildsPath] = fi
+			grid := &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "2", Started: tc.started}, {Build: "1", Started: 1}},
+				Rows:    []*statepb.Row{tc.row},
+			}
+			alertCooldown(old, grid, time.Hour)
+			if diff := cmp.Diff(tc.expected, grid.Rows[0], protocmp.Transform()); diff != "" {
+				t.Errorf("alertCooldown() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
 
-			colReader := gcsColumnReader(client, *tc.buildTimeout, tc.concurrency)
-			if tc.colSorter == nil {
-				tc.colSorter = SortStarted
+func TestAlertsOnly(t *testing.T) {
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "2", Started: 2000},
+			Cells: map[string]cell{
+				"broken": {Result: statuspb.TestStatus_FAIL, Message: "boom"},
+				"fixed":  {Result: statuspb.TestStatus_PASS},
+				"good":   {Result: statuspb.TestStatus_PASS},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "1", Started: 1000},
+			Cells: map[string]cell{
+				"broken": {Result: statuspb.TestStatus_FAIL, Message: "bang"},
+				"fixed":  {Result: statuspb.TestStatus_FAIL},
+				"good":   {Result: statuspb.TestStatus_PASS},
+			},
+		},
+	}
+	cases := []struct {
+		name       string
+		alertsOnly bool
+		expected   []string
+	}{
+		{
+			name:     "keep every row by default",
+			expected: []string{"broken", "fixed", "good"},
+		},
+		{
+			name:       "only keep alerting rows",
+			alertsOnly: true,
+			expected:   []string{"broken"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := configpb.TestGroup{
+				NumFailuresToAlert: 2,
+				AlertsOnly:         tc.alertsOnly,
 			}
-			err := InflateDropAppend(
-				ctx,
-				logrus.WithField("test", tc.name),
-				client,
-				&tc.group,
-				uploadPath,
-				!tc.skipWrite,
-				colReader,
-				tc.colSorter,
-				tc.reprocess,
-			)
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("updateGroup() got unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("updateGroup() failed to receive an exception")
-			default:
-				expected := fakeUploader{}
-				if tc.expected != nil {
-					expected[uploadPath] = *tc.expected
-				}
-				actual := client.Uploader
-				diff := cmp.Diff(expected, actual, cmp.AllowUnexported(gcs.Path{}, fakeUpload{}), protocmp.Transform())
-				if diff == "" {
-					return
-				}
-				t.Errorf("updateGroup() got unexpected diff (-want +got):\n%s", diff)
-				fakeDownloader := fakeOpener{
-					uploadPath: {Data: string(actual[uploadPath].Buf)},
-				}
-				actualGrid, _, err := gcs.DownloadGrid(ctx, fakeDownloader, uploadPath)
-				if err != nil {
-					t.Errorf("actual gcs.DownloadGrid() got unexpected error: %v", err)
-				}
-				fakeDownloader[uploadPath] = fakeObject{Data: string(tc.expected.Buf)}
-				expectedGrid, _, err := gcs.DownloadGrid(ctx, fakeDownloader, uploadPath)
-				if err != nil {
-					t.Errorf("expected gcs.DownloadGrid() got unexpected error: %v", err)
-				}
-				diff = cmp.Diff(expectedGrid, actualGrid, protocmp.Transform())
-				if diff == "" {
-					return
+			grid := ConstructGrid(logrus.WithField("name", tc.name), &group, cols, nil, nil)
+			var actual []string
+			for _, row := range grid.Rows {
+				actual = append(actual, row.Name)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("ConstructGrid() got unexpected rows (-want +got):\n%s", diff)
+			}
+			if got, want := len(grid.Columns), len(cols); got != want {
+				t.Errorf("ConstructGrid() got %d columns, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestFinishAlerts(t *testing.T) {
+	fail := cell{Result: statuspb.TestStatus_FAIL, Message: "boom"}
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "2", Started: 2000},
+			Cells: map[string]cell{
+				"broken":  fail,
+				"cooling": fail,
+				"fixed":   {Result: statuspb.TestStatus_PASS},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "1", Started: 1000},
+			Cells: map[string]cell{
+				"broken":  fail,
+				"cooling": fail,
+				"fixed":   fail,
+			},
+		},
+	}
+	old := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "broken", AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+			{Name: "cooling", LastAlertClosed: 1500},
+			{Name: "fixed", AlertInfo: &statepb.AlertInfo{FailCount: 2}},
+		},
+	}
+	cases := []struct {
+		name       string
+		alertsOnly bool
+		cooldown   int32
+		expected   []string
+		alerting   []string
+	}{
+		{
+			name:     "keep every row by default",
+			expected: []string{"broken", "cooling", "fixed"},
+			alerting: []string{"broken", "cooling"},
+		},
+		{
+			name:       "only keep alerting rows",
+			alertsOnly: true,
+			expected:   []string{"broken", "cooling"},
+			alerting:   []string{"broken", "cooling"},
+		},
+		{
+			name:     "suppress alerts within the cooldown",
+			cooldown: 60,
+			expected: []string{"broken", "cooling", "fixed"},
+			alerting: []string{"broken"},
+		},
+		{
+			name:       "drop rows after the cooldown suppresses their alerts",
+			alertsOnly: true,
+			cooldown:   60,
+			expected:   []string{"broken"},
+			alerting:   []string{"broken"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &configpb.TestGroup{
+				NumFailuresToAlert:   2,
+				AlertsOnly:           tc.alertsOnly,
+				AlertCooldownMinutes: tc.cooldown,
+			}
+			grid := ConstructGrid(logrus.WithField("name", tc.name), group, cols, nil, nil)
+			finishAlerts(group, old, grid)
+			var actual, alerting []string
+			for _, row := range grid.Rows {
+				actual = append(actual, row.Name)
+				if row.AlertInfo != nil {
+					alerting = append(alerting, row.Name)
 				}
-				t.Errorf("gcs.DownloadGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("finishAlerts() got unexpected rows (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.alerting, alerting); diff != "" {
+				t.Errorf("finishAlerts() got unexpected alerts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	rle := func(results ...statuspb.TestStatus) []int32 {
+		row := &statepb.Row{}
+		for i, r := range results {
+			appendCell(row, cell{Result: r}, i, 1)
+		}
+		return row.Results
+	}
+	const (
+		pass    = statuspb.TestStatus_PASS
+		fail    = statuspb.TestStatus_FAIL
+		flaky   = statuspb.TestStatus_FLAKY
+		running = statuspb.TestStatus_RUNNING
+		none    = statuspb.TestStatus_NO_RESULT
+	)
+	newRows := func() []*statepb.Row {
+		return []*statepb.Row{
+			{Name: "green", Results: rle(pass, pass, pass, pass)},
+			{Name: "recent-fail", Results: rle(running, fail, pass, pass, pass)},
+			{Name: "old-fail-10", Results: rle(pass, none, pass, fail, fail)},
+			{Name: "old-fail-9", Results: rle(pass, pass, flaky, fail, fail)},
+			{Name: "red", Results: rle(fail, fail, fail, pass)},
+			{Name: "empty", Results: rle(none, none, none, none)},
+		}
+	}
+	cases := []struct {
+		name     string
+		by       configpb.TestGroup_RowSort
+		expected []string
+	}{
+		{
+			name:     "name by default",
+			expected: []string{"empty", "green", "old-fail-9", "old-fail-10", "recent-fail", "red"},
+		},
+		{
+			name: "failure rate",
+			by:   configpb.TestGroup_ROW_SORT_FAILURE_RATE,
+			expected: []string{
+				"red",         // 3/4
+				"old-fail-10", // 2/4
+				"old-fail-9",  // 2/5
+				"recent-fail", // 1/4
+				"empty",       // 0
+				"green",       // 0
+			},
+		},
+		{
+			name: "last failure",
+			by:   configpb.TestGroup_ROW_SORT_LAST_FAILURE,
+			expected: []string{
+				"red",         // 0
+				"recent-fail", // 1
+				"old-fail-9",  // 3
+				"old-fail-10", // 3
+				"empty",       // never
+				"green",       // never
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := newRows()
+			sortRows(rows, tc.by)
+			var actual []string
+			for _, r := range rows {
+				actual = append(actual, r.Name)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("sortRows() got unexpected order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeMessages(t *testing.T) {
+	rule := func(pattern, replacement string) *configpb.TestGroup_MessageNormalizationRule {
+		return &configpb.TestGroup_MessageNormalizationRule{Pattern: pattern, Replacement: replacement}
+	}
+	cases := []struct {
+		name     string
+		rules    []*configpb.TestGroup_MessageNormalizationRule
+		messages []string
+		expected []string
+	}{
+		{
+			name:     "basically works",
+			messages: []string{"hello", ""},
+			expected: []string{"hello", ""},
+		},
+		{
+			name:     "replace volatile substrings",
+			rules:    []*configpb.TestGroup_MessageNormalizationRule{rule(`took \d+(\.\d+)?s`, "took ?s")},
+			messages: []string{"timeout: took 12.5s", "timeout: took 3s", "unrelated"},
+			expected: []string{"timeout: took ?s", "timeout: took ?s", "unrelated"},
+		},
+		{
+			name: "apply rules in order",
+			rules: []*configpb.TestGroup_MessageNormalizationRule{
+				rule(`pod-[a-z0-9]{5}`, "pod-X"),
+				rule(`pod-X`, "<pod>"),
+			},
+			messages: []string{"pod-ab12c crashed", "pod-zz99z crashed"},
+			expected: []string{"<pod> crashed", "<pod> crashed"},
+		},
+		{
+			name:     "replacements reference submatches",
+			rules:    []*configpb.TestGroup_MessageNormalizationRule{rule(`(?P<file>\w+\.go):\d+`, "${file}:N")},
+			messages: []string{"failed at main.go:123 and util.go:45"},
+			expected: []string{"failed at main.go:N and util.go:N"},
+		},
+		{
+			name: "ignore bad rules",
+			rules: []*configpb.TestGroup_MessageNormalizationRule{
+				rule(`[`, "bad"),
+				rule(`id=\d+`, "id=?"),
+			},
+			messages: []string{"id=12345 failed"},
+			expected: []string{"id=? failed"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cols []inflatedColumn
+			for i, msg := range tc.messages {
+				cols = append(cols, inflatedColumn{
+					Column: &statepb.Column{Build: fmt.Sprint(i)},
+					Cells: map[string]cell{
+						"row": {Result: statuspb.TestStatus_FAIL, Message: msg},
+					},
+				})
+			}
+			normalizeMessages(logrus.WithField("name", tc.name), cols, tc.rules)
+			var actual []string
+			for _, col := range cols {
+				actual = append(actual, col.Cells["row"].Message)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("normalizeMessages() got unexpected diff (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestFormatStrftime(t *testing.T) {
+func TestCanonicalizeMetrics(t *testing.T) {
+	alias := func(name, canonical string) *configpb.TestGroup_MetricAlias {
+		return &configpb.TestGroup_MetricAlias{Name: name, Canonical: canonical}
+	}
 	cases := []struct {
-		name string
-		want string
+		name      string
+		lowercase bool
+		aliases   []*configpb.TestGroup_MetricAlias
+		metrics   []map[string]float64
+		expected  []map[string]float64
 	}{
 		{
-			name: "basically works",
-			want: "basically works",
+			name:     "basically works",
+			metrics:  []map[string]float64{{"Latency": 1}, nil},
+			expected: []map[string]float64{{"Latency": 1}, nil},
 		},
 		{
-			name: "Mon Jan 2 15:04:05",
-			want: "Mon Jan 2 15:04:05",
+			name:      "lowercase names",
+			lowercase: true,
+			metrics:   []map[string]float64{{"Latency": 1}, {"LATENCY": 2, "Memory": 3}},
+			expected:  []map[string]float64{{"latency": 1}, {"latency": 2, "memory": 3}},
 		},
 		{
-			name: "python am/pm: %p",
-			want: "python am/pm: PM",
+			name:      "average values with the same name",
+			lowercase: true,
+			metrics:   []map[string]float64{{"Latency": 1, "latency": 3}},
+			expected:  []map[string]float64{{"latency": 2}},
 		},
 		{
-			name: "python year: %Y",
-			want: "python year: 2006",
+			name:     "rename aliases",
+			aliases:  []*configpb.TestGroup_MetricAlias{alias("p50", "latency-p50"), alias("median", "latency-p50")},
+			metrics:  []map[string]float64{{"p50": 1}, {"median": 2, "p99": 5}},
+			expected: []map[string]float64{{"latency-p50": 1}, {"latency-p50": 2, "p99": 5}},
 		},
 		{
-			name: "python short year: %y",
-			want: "python short year: 06",
+			name:      "lowercase aliases",
+			lowercase: true,
+			aliases:   []*configpb.TestGroup_MetricAlias{alias("P50", "Latency")},
+			metrics:   []map[string]float64{{"P50": 1}, {"Latency": 3}},
+			expected:  []map[string]float64{{"latency": 1}, {"latency": 3}},
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cols []inflatedColumn
+			for i, metrics := range tc.metrics {
+				cols = append(cols, inflatedColumn{
+					Column: &statepb.Column{Build: fmt.Sprint(i)},
+					Cells: map[string]cell{
+						"row": {Result: statuspb.TestStatus_PASS, Metrics: metrics},
+					},
+				})
+			}
+			canonicalizeMetrics(cols, tc.lowercase, tc.aliases)
+			var actual []map[string]float64
+			for _, col := range cols {
+				actual = append(actual, col.Cells["row"].Metrics)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("canonicalizeMetrics() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		msg      string
+		max      int
+		expected string
+	}{
 		{
-			name: "python month: %m",
-			want: "python month: 01",
+			name:     "unlimited",
+			msg:      "hello world",
+			expected: "hello world",
 		},
 		{
-			name: "python date: %d",
-			want: "python date: 02",
+			name:     "short messages are unchanged",
+			msg:      "hello",
+			max:      5,
+			expected: "hello",
 		},
 		{
-			name: "python 24hr: %H",
-			want: "python 24hr: 15",
+			name:     "oversized message ends with ellipsis",
+			msg:      strings.Repeat("x", 1000),
+			max:      8,
+			expected: "xxxxx...",
 		},
 		{
-			name: "python minutes: %M",
-			want: "python minutes: 04",
+			name:     "count characters rather than bytes",
+			msg:      "héllo wörld",
+			max:      8,
+			expected: "héllo...",
 		},
 		{
-			name: "python seconds: %S",
-			want: "python seconds: 05",
+			name:     "tiny limits omit the ellipsis",
+			msg:      "hello",
+			max:      2,
+			expected: "he",
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := formatStrftime(tc.name); got != tc.want {
-				t.Errorf("formatStrftime(%q) got %q want %q", tc.name, got, tc.want)
+			if actual := truncateMessage(tc.msg, tc.max); actual != tc.expected {
+				t.Errorf("truncateMessage(%q, %d) got %q, want %q", tc.msg, tc.max, actual, tc.expected)
 			}
 		})
 	}
-
 }
 
-func TestOverrideBuild(t *testing.T) {
+func TestTimeoutRunning(t *testing.T) {
+	now := time.Now()
+	timeout := time.Hour
+	stale := float64(now.Add(-2*time.Hour).Unix() * 1000)
+	fresh := float64(now.Add(-time.Minute).Unix() * 1000)
 	cases := []struct {
-		name string
-		tg   *configpb.TestGroup
-		cols []InflatedColumn
-		want []InflatedColumn
+		name     string
+		cols     []inflatedColumn
+		fail     bool
+		expected []inflatedColumn
 	}{
 		{
 			name: "basically works",
-			tg:   &configpb.TestGroup{},
 		},
 		{
-			name: "empty override does not override",
-			tg:   &configpb.TestGroup{},
-			cols: []InflatedColumn{
+			name: "keep fresh running cells",
+			cols: []inflatedColumn{
 				{
-					Column: &statepb.Column{
-						Build:   "hello",
-						Started: 7,
+					Column: &statepb.Column{Build: "fresh", Started: fresh},
+					Cells: map[string]cell{
+						"running": {Result: statuspb.TestStatus_RUNNING, Message: "still going"},
 					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
+				},
+			},
+			fail: true,
+			expected: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "fresh", Started: fresh},
+					Cells: map[string]cell{
+						"running": {Result: statuspb.TestStatus_RUNNING, Message: "still going"},
 					},
 				},
+			},
+		},
+		{
+			name: "clear stale running cells",
+			cols: []inflatedColumn{
 				{
-					Column: &statepb.Column{
-						Build:   "world",
-						Started: 6,
+					Column: &statepb.Column{Build: "fresh", Started: fresh},
+					Cells: map[string]cell{
+						"running": {Result: statuspb.TestStatus_RUNNING},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "stale", Started: stale},
+					Cells: map[string]cell{
+						"running": {Result: statuspb.TestStatus_RUNNING, Message: "stuck", CellID: "hello"},
+						"pass":    {Result: statuspb.TestStatus_PASS},
 					},
 				},
 			},
-			want: []InflatedColumn{
+			expected: []inflatedColumn{
 				{
-					Column: &statepb.Column{
-						Build:   "hello",
-						Started: 7,
+					Column: &statepb.Column{Build: "fresh", Started: fresh},
+					Cells: map[string]cell{
+						"running": {Result: statuspb.TestStatus_RUNNING},
 					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
+				},
+				{
+					Column: &statepb.Column{Build: "stale", Started: stale},
+					Cells: map[string]cell{
+						"running": emptyCell,
+						"pass":    {Result: statuspb.TestStatus_PASS},
+					},
+				},
+			},
+		},
+		{
+			name: "fail stale running cells",
+			cols: []inflatedColumn{
+				{
+					Column: &statepb.Column{Build: "stale", Started: stale},
+					Cells: map[string]cell{
+						"running": {Result: statuspb.TestStatus_RUNNING, Message: "stuck", CellID: "hello"},
+						"fail":    {Result: statuspb.TestStatus_FAIL},
 					},
 				},
+			},
+			fail: true,
+			expected: []inflatedColumn{
 				{
-					Column: &statepb.Column{
-						Build:   "world",
-						Started: 6,
+					Column: &statepb.Column{Build: "stale", Started: stale},
+					Cells: map[string]cell{
+						"running": {
+							Result:  statuspb.TestStatus_FAIL,
+							Icon:    "T",
+							Message: "Build did not complete within 1h0m0s",
+							CellID:  "hello",
+						},
+						"fail": {Result: statuspb.TestStatus_FAIL},
 					},
 				},
 			},
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeoutRunning(tc.cols, now, timeout, tc.fail)
+			if diff := cmp.Diff(tc.expected, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("timeoutRunning() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRowFlakiness(t *testing.T) {
+	cases := []struct {
+		name      string
+		commits   []string
+		results   []statuspb.TestStatus
+		commitIdx int
+		expected  float32
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name:     "all passing",
+			results:  []statuspb.TestStatus{statuspb.TestStatus_PASS, statuspb.TestStatus_PASS},
+			expected: 0,
+		},
+		{
+			name: "flaky results",
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FLAKY,
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_FLAKY,
+			},
+			expected: 50,
+		},
+		{
+			name: "ignore empty and running results",
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_RUNNING,
+				statuspb.TestStatus_FLAKY,
+				statuspb.TestStatus_NO_RESULT,
+				statuspb.TestStatus_PASS,
+			},
+			expected: 50,
+		},
+		{
+			name:      "failures at different commits are not flaky",
+			commits:   []string{"c", "b", "a"},
+			commitIdx: 0,
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_FAIL,
+			},
+			expected: 0,
+		},
+		{
+			name:      "failure then pass at the same commit is flaky",
+			commits:   []string{"b", "a", "a", "a"},
+			commitIdx: 0,
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_FAIL,
+			},
+			expected: 50,
+		},
+		{
+			name:      "pass then failure at the same commit is not flaky",
+			commits:   []string{"a", "a"},
+			commitIdx: 0,
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_PASS,
+			},
+			expected: 0,
+		},
+		{
+			name:      "ignore commits without a commit header",
+			commits:   []string{"a", "a"},
+			commitIdx: -1,
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_FAIL,
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cols []*statepb.Column
+			var row statepb.Row
+			for i, res := range tc.results {
+				col := statepb.Column{Build: fmt.Sprintf("%d", len(tc.results)-i)}
+				if tc.commits != nil {
+					col.Extra = []string{tc.commits[i]}
+				}
+				cols = append(cols, &col)
+				appendCell(&row, cell{Result: res}, i, 1)
+			}
+			if actual := rowFlakiness(cols, &row, tc.commitIdx); actual != tc.expected {
+				t.Errorf("rowFlakiness() got %f, want %f", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMetricRegressions(t *testing.T) {
+	pass := statuspb.TestStatus_PASS
+	// row returns a row with a duration for each non-negative value.
+	row := func(values ...float64) *statepb.Row {
+		var cells []cell
+		for _, v := range values {
+			c := cell{Result: pass}
+			if v >= 0 {
+				c.Metrics = map[string]float64{"duration": v}
+			}
+			cells = append(cells, c)
+		}
+		return setupRow(&statepb.Row{Name: "hello", Id: "hello"}, cells...)
+	}
+	columns := func(n int) []*statepb.Column {
+		var out []*statepb.Column
+		for i := n; i > 0; i-- {
+			out = append(out, &statepb.Column{Build: fmt.Sprint(i)})
+		}
+		return out
+	}
+	rule := func(baseline int32, threshold float64) *configpb.TestGroup_MetricRegressionRule {
+		return &configpb.TestGroup_MetricRegressionRule{
+			Metric:           "duration",
+			BaselineColumns:  baseline,
+			ThresholdPercent: threshold,
+		}
+	}
+
+	cases := []struct {
+		name     string
+		row      *statepb.Row
+		cols     int
+		rules    []*configpb.TestGroup_MetricRegressionRule
+		expected []*statepb.MetricRegression
+	}{
+		{
+			name: "basically works",
+			row:  row(10, 10, 10, 10),
+			cols: 4,
+		},
+		{
+			name:  "clear regression",
+			row:   row(30, 10, 11, 9),
+			cols:  4,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(3, 20)},
+			expected: []*statepb.MetricRegression{
+				{
+					Metric:   "duration",
+					Build:    "4",
+					Value:    30,
+					Baseline: 10,
+					Message:  "duration regressed 200% from 10 to 30",
+				},
+			},
+		},
+		{
+			name:  "within noise",
+			row:   row(11.5, 10, 11, 9),
+			cols:  4,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(3, 20)},
+		},
+		{
+			name:  "improvements are not regressions",
+			row:   row(1, 10, 11, 9),
+			cols:  4,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(3, 20)},
+		},
+		{
+			name: "lower values are worse",
+			row:  row(1, 10, 11, 9),
+			cols: 4,
+			rules: []*configpb.TestGroup_MetricRegressionRule{
+				{
+					Metric:           "duration",
+					BaselineColumns:  3,
+					ThresholdPercent: 20,
+					LowerIsWorse:     true,
+				},
+			},
+			expected: []*statepb.MetricRegression{
+				{
+					Metric:   "duration",
+					Build:    "4",
+					Value:    1,
+					Baseline: 10,
+					Message:  "duration regressed 90% from 10 to 1",
+				},
+			},
+		},
+		{
+			name:  "skip columns without the metric",
+			row:   row(-1, 20, -1, 10, -1, -1, 12, 8),
+			cols:  8,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(3, 50)},
+			expected: []*statepb.MetricRegression{
+				{
+					Metric:   "duration",
+					Build:    "7",
+					Value:    20,
+					Baseline: 10,
+					Message:  "duration regressed 100% from 10 to 20",
+				},
+			},
+		},
+		{
+			name:  "require a full baseline",
+			row:   row(30, 10, 10),
+			cols:  3,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(3, 20)},
+		},
+		{
+			name:  "default baseline columns",
+			row:   row(30, 10, 10, 10, 10, 30),
+			cols:  6,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(0, 20)},
+			expected: []*statepb.MetricRegression{
+				{
+					Metric:   "duration",
+					Build:    "6",
+					Value:    30,
+					Baseline: 10,
+					Message:  "duration regressed 200% from 10 to 30",
+				},
+			},
+		},
+		{
+			name:  "flag every regressed value",
+			row:   row(40, 30, 10, 10, 10),
+			cols:  5,
+			rules: []*configpb.TestGroup_MetricRegressionRule{rule(3, 20)},
+			expected: []*statepb.MetricRegression{
+				{
+					Metric:   "duration",
+					Build:    "5",
+					Value:    40,
+					Baseline: 10,
+					Message:  "duration regressed 300% from 10 to 40",
+				},
+				{
+					Metric:   "duration",
+					Build:    "4",
+					Value:    30,
+					Baseline: 10,
+					Message:  "duration regressed 200% from 10 to 30",
+				},
+			},
+		},
+		{
+			name: "ignore missing metrics",
+			row:  row(30, 10, 10, 10),
+			cols: 4,
+			rules: []*configpb.TestGroup_MetricRegressionRule{
+				{Metric: "memory", BaselineColumns: 3},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := metricRegressions(columns(tc.cols), tc.row, tc.rules)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("metricRegressions() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDaysSinceGreen(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	day := 24 * time.Hour
+	cases := []struct {
+		name     string
+		daysAgo  []float64
+		results  []statuspb.TestStatus
+		expected float64
+	}{
+		{
+			name:     "empty row is never green",
+			expected: -1,
+		},
+		{
+			name:     "currently green",
+			daysAgo:  []float64{3, 4},
+			results:  []statuspb.TestStatus{statuspb.TestStatus_PASS, statuspb.TestStatus_FAIL},
+			expected: 0,
+		},
+		{
+			name:    "never green",
+			daysAgo: []float64{1, 2, 3},
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_FLAKY,
+				statuspb.TestStatus_FAIL,
+			},
+			expected: -1,
+		},
+		{
+			name:    "last green column",
+			daysAgo: []float64{1, 2, 3.5, 4},
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_FLAKY,
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_PASS,
+			},
+			expected: 3.5,
+		},
+		{
+			name:    "ignore empty and running results",
+			daysAgo: []float64{1, 2, 3, 5},
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_RUNNING,
+				statuspb.TestStatus_NO_RESULT,
+				statuspb.TestStatus_PASS,
+				statuspb.TestStatus_FAIL,
+			},
+			expected: 0,
+		},
+		{
+			name:    "passing variants are green",
+			daysAgo: []float64{1, 2},
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_PASS_WITH_SKIPS,
+			},
+			expected: 2,
+		},
+		{
+			name:    "future passes are green now",
+			daysAgo: []float64{1, -1},
+			results: []statuspb.TestStatus{
+				statuspb.TestStatus_FAIL,
+				statuspb.TestStatus_PASS,
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cols []*statepb.Column
+			var row statepb.Row
+			for i, res := range tc.results {
+				started := now.Add(-time.Duration(tc.daysAgo[i] * float64(day)))
+				cols = append(cols, &statepb.Column{
+					Build:   fmt.Sprintf("%d", len(tc.results)-i),
+					Started: float64(started.UnixNano() / int64(time.Millisecond)),
+				})
+				appendCell(&row, cell{Result: res}, i, 1)
+			}
+			if actual := daysSinceGreen(cols, &row, now); actual != tc.expected {
+				t.Errorf("daysSinceGreen() got %f, want %f", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAppendMetric(t *testing.T) {
+	cases := []struct {
+		name     string
+		metric   statepb.Metric
+		idx      int32
+		value    float64
+		expected statepb.Metric
+	}{
+		{
+			name: "basically works",
+			expected: statepb.Metric{
+				Indices: []int32{0, 1},
+				Values:  []float64{0},
+			},
+		},
 		{
-			name: "override with python style",
-			tg: &configpb.TestGroup{
-				BuildOverrideStrftime: "%y-%m-%d (%Y) %H:%M:%S %p",
+			name:  "start metric at random column",
+			idx:   7,
+			value: 11,
+			expected: statepb.Metric{
+				Indices: []int32{7, 1},
+				Values:  []float64{11},
 			},
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "drop",
-						Hint:    "of fruit",
-						Name:    "keep",
-						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me too"},
-					},
-				},
+		},
+		{
+			name: "continue existing series",
+			metric: statepb.Metric{
+				Indices: []int32{6, 2},
+				Values:  []float64{6.1, 6.2},
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "21-04-22 (2021) 13:14:15 PM",
-						Hint:    "of fruit",
-						Name:    "keep",
-						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me too"},
-					},
-				},
+			idx:   8,
+			value: 88,
+			expected: statepb.Metric{
+				Indices: []int32{6, 3},
+				Values:  []float64{6.1, 6.2, 88},
 			},
 		},
 		{
-			name: "override with golang format",
-			tg: &configpb.TestGroup{
-				BuildOverrideStrftime: "hello 2006 PM",
-			},
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "drop",
-						Hint:    "of fruit",
-						Name:    "keep",
-						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me too"},
-					},
-				},
+			name: "start new series",
+			metric: statepb.Metric{
+				Indices: []int32{3, 2},
+				Values:  []float64{6.1, 6.2},
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "hello 2021 PM",
-						Hint:    "of fruit",
-						Name:    "keep",
-						Started: float64(time.Date(2021, 04, 22, 13, 14, 15, 0, time.Local).Unix() * 1000),
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me too"},
-					},
-				},
+			idx:   8,
+			value: 88,
+			expected: statepb.Metric{
+				Indices: []int32{3, 2, 8, 1},
+				Values:  []float64{6.1, 6.2, 88},
 			},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			overrideBuild(tc.tg, tc.cols)
-			if diff := cmp.Diff(tc.want, tc.cols, protocmp.Transform()); diff != "" {
-				t.Errorf("overrideBuild() got unexpected diff (-want +got):\n%s", diff)
+			appendMetric(&tc.metric, tc.idx, tc.value)
+			if diff := cmp.Diff(tc.metric, tc.expected, protocmp.Transform()); diff != "" {
+				t.Errorf("appendMetric() got unexpected diff (-got +want):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestGroupColumns(t *testing.T) {
+func TestAppendCell(t *testing.T) {
 	cases := []struct {
-		name string
-		tg   *configpb.TestGroup
-		cols []InflatedColumn
-		want []InflatedColumn
+		name  string
+		row   statepb.Row
+		cell  cell
+		start int
+		count int
+
+		expected statepb.Row
 	}{
 		{
 			name: "basically works",
+			expected: statepb.Row{
+				Results: []int32{0, 0},
+			},
 		},
 		{
-			name: "single column groups do not change",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "hello",
-						Name:    "world",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "another",
-						Name:    "column",
-						Started: 9,
-					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
-					},
-				},
+			name: "first result",
+			cell: cell{
+				Result: statuspb.TestStatus_PASS,
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "hello",
-						Name:    "world",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "another",
-						Name:    "column",
-						Started: 9,
-					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
-					},
-				},
+			count: 1,
+			expected: statepb.Row{
+				Results:      []int32{int32(statuspb.TestStatus_PASS), 1},
+				CellIds:      []string{""},
+				Messages:     []string{""},
+				Icons:        []string{""},
+				UserProperty: []string{""},
 			},
 		},
 		{
-			name: "group columns with the same build and name",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "lemming",
-						Hint:    "99",
-						Started: 7,
-						Extra: []string{
-							"first",
-							"",
-							"same",
-							"different",
-						},
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "lemming",
-						Hint:    "100",
-						Started: 9,
-						Extra: []string{
-							"",
-							"second",
-							"same",
-							"changed",
-						},
-					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
-					},
+			name: "all fields filled",
+			cell: cell{
+				Result:  statuspb.TestStatus_PASS,
+				CellID:  "cell-id",
+				Message: "hi",
+				Icon:    "there",
+				Metrics: map[string]float64{
+					"pi":     3.14,
+					"golden": 1.618,
 				},
+				UserProperty: "hello",
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "lemming",
-						Started: 7,
-						Hint:    "100",
-						Extra: []string{
-							"first",
-							"second",
-							"same",
-							"*",
-						},
+			count: 1,
+			expected: statepb.Row{
+				Results:  []int32{int32(statuspb.TestStatus_PASS), 1},
+				CellIds:  []string{"cell-id"},
+				Messages: []string{"hi"},
+				Icons:    []string{"there"},
+				Metric: []string{
+					"golden",
+					"pi",
+				},
+				Metrics: []*statepb.Metric{
+					{
+						Name:    "pi",
+						Indices: []int32{0, 1},
+						Values:  []float64{3.14},
 					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-						"also": {ID: "remains"},
+					{
+						Name:    "golden",
+						Indices: []int32{0, 1},
+						Values:  []float64{1.618},
 					},
 				},
+				UserProperty: []string{"hello"},
+			},
+		},
+		{
+			name: "append same result",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 3,
+				},
+				CellIds:      []string{"", "", ""},
+				Messages:     []string{"", "", ""},
+				Icons:        []string{"", "", ""},
+				UserProperty: []string{"", "", ""},
+			},
+			cell: cell{
+				Result:       statuspb.TestStatus_FLAKY,
+				Message:      "echo",
+				CellID:       "again and",
+				Icon:         "keeps going",
+				UserProperty: "more more",
+			},
+			count: 2,
+			expected: statepb.Row{
+				Results:      []int32{int32(statuspb.TestStatus_FLAKY), 5},
+				CellIds:      []string{"", "", "", "again and", "again and"},
+				Messages:     []string{"", "", "", "echo", "echo"},
+				Icons:        []string{"", "", "", "keeps going", "keeps going"},
+				UserProperty: []string{"", "", "", "more more", "more more"},
 			},
 		},
 		{
-			name: "do not group different builds",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "this",
-						Name:    "same",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
+			name: "append different result",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 3,
 				},
-				{
-					Column: &statepb.Column{
-						Build:   "that",
-						Name:    "same",
-						Started: 9,
-					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
-					},
+				CellIds:      []string{"", "", ""},
+				Messages:     []string{"", "", ""},
+				Icons:        []string{"", "", ""},
+				UserProperty: []string{"", "", ""},
+			},
+			cell: cell{
+				Result: statuspb.TestStatus_PASS,
+			},
+			count: 2,
+			expected: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 3,
+					int32(statuspb.TestStatus_PASS), 2,
 				},
+				CellIds:      []string{"", "", "", "", ""},
+				Messages:     []string{"", "", "", "", ""},
+				Icons:        []string{"", "", "", "", ""},
+				UserProperty: []string{"", "", "", "", ""},
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "this",
-						Name:    "same",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
+		},
+		{
+			name: "append no Result (results, no cellIDs, messages or icons)",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 3,
 				},
-				{
-					Column: &statepb.Column{
-						Build:   "that",
-						Name:    "same",
-						Started: 9,
-					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
-					},
+				CellIds:      []string{"", "", ""},
+				Messages:     []string{"", "", ""},
+				Icons:        []string{"", "", ""},
+				UserProperty: []string{"", "", ""},
+			},
+			cell: cell{
+				Result: statuspb.TestStatus_NO_RESULT,
+			},
+			count: 2,
+			expected: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 3,
+					int32(statuspb.TestStatus_NO_RESULT), 2,
 				},
+				CellIds:      []string{"", "", ""},
+				Messages:     []string{"", "", ""},
+				Icons:        []string{"", "", ""},
+				UserProperty: []string{"", "", ""},
 			},
 		},
 		{
-			name: "do not group different names",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "different",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
+			name: "add metric to series",
+			row: statepb.Row{
+				Results:      []int32{int32(statuspb.TestStatus_PASS), 5},
+				CellIds:      []string{"", "", "", "", "c"},
+				Messages:     []string{"", "", "", "", "m"},
+				Icons:        []string{"", "", "", "", "i"},
+				UserProperty: []string{"", "", "", "", "up"},
+				Metric: []string{
+					"continued-series",
+					"new-series",
 				},
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "changed",
-						Started: 9,
+				Metrics: []*statepb.Metric{
+					{
+						Name:    "continued-series",
+						Indices: []int32{0, 5},
+						Values:  []float64{0, 1, 2, 3, 4},
 					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
+					{
+						Name:    "new-series",
+						Indices: []int32{2, 2},
+						Values:  []float64{2, 3},
 					},
 				},
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "different",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"keep": {ID: "me"},
-					},
+			cell: cell{
+				Result: statuspb.TestStatus_PASS,
+				Metrics: map[string]float64{
+					"continued-series":  5.1,
+					"new-series":        5.2,
+					"additional-metric": 5.3,
 				},
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "changed",
-						Started: 9,
+			},
+			start: 5,
+			count: 1,
+			expected: statepb.Row{
+				Results:      []int32{int32(statuspb.TestStatus_PASS), 6},
+				CellIds:      []string{"", "", "", "", "c", ""},
+				Messages:     []string{"", "", "", "", "m", ""},
+				Icons:        []string{"", "", "", "", "i", ""},
+				UserProperty: []string{"", "", "", "", "up", ""},
+				Metric: []string{
+					"continued-series",
+					"new-series",
+					"additional-metric",
+				},
+				Metrics: []*statepb.Metric{
+					{
+						Name:    "continued-series",
+						Indices: []int32{0, 6},
+						Values:  []float64{0, 1, 2, 3, 4, 5.1},
 					},
-					Cells: map[string]Cell{
-						"also": {ID: "remains"},
+					{
+						Name:    "new-series",
+						Indices: []int32{2, 2, 5, 1},
+						Values:  []float64{2, 3, 5.2},
+					},
+					{
+						Name:    "additional-metric",
+						Indices: []int32{5, 1},
+						Values:  []float64{5.3},
 					},
 				},
 			},
 		},
 		{
-			name: "split merged rows with the same name",
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "same",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"first": {ID: "first"},
-						"same":  {ID: "first-different"},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "same",
-						Started: 9,
-					},
-					Cells: map[string]Cell{
-						"same":   {ID: "second-changed"},
-						"second": {ID: "second"},
-					},
-				},
+			name:  "add a bunch of initial blank columns (eg a deleted row)",
+			cell:  emptyCell,
+			count: 7,
+			expected: statepb.Row{
+				Results: []int32{int32(statuspb.TestStatus_NO_RESULT), 7},
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "same",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"first":    {ID: "first"},
-						"same":     {ID: "first-different"},
-						"same [1]": {ID: "second-changed"},
-						"second":   {ID: "second"},
-					},
+		},
+		{
+			name: "artifact url",
+			cell: cell{
+				Result:      statuspb.TestStatus_FAIL,
+				ArtifactURL: "https://artifacts/123",
+			},
+			count: 1,
+			expected: statepb.Row{
+				Results:      []int32{int32(statuspb.TestStatus_FAIL), 1},
+				CellIds:      []string{""},
+				Messages:     []string{""},
+				Icons:        []string{""},
+				UserProperty: []string{""},
+				ArtifactUrls: []string{"https://artifacts/123"},
+			},
+		},
+		{
+			name: "pad artifact urls of earlier cells",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+				},
+				CellIds:      []string{"", ""},
+				Messages:     []string{"", ""},
+				Icons:        []string{"", ""},
+				UserProperty: []string{"", ""},
+			},
+			cell: cell{
+				Result:      statuspb.TestStatus_FAIL,
+				ArtifactURL: "https://artifacts/124",
+			},
+			start: 2,
+			count: 2,
+			expected: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+					int32(statuspb.TestStatus_FAIL), 2,
 				},
+				CellIds:      []string{"", "", "", ""},
+				Messages:     []string{"", "", "", ""},
+				Icons:        []string{"", "", "", ""},
+				UserProperty: []string{"", "", "", ""},
+				ArtifactUrls: []string{"", "", "https://artifacts/124", "https://artifacts/124"},
 			},
 		},
 		{
-			name: "ignore_old_results only takes newest",
-			tg: &configpb.TestGroup{
-				IgnoreOldResults: true,
-			},
-			cols: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "same",
-						Started: 9,
-					},
-					Cells: map[string]Cell{
-						"first": {ID: "first"},
-						"same":  {ID: "first-different"},
-					},
-				},
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "same",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"same":   {ID: "second-changed"},
-						"second": {ID: "second"},
-					},
-				},
+			name:  "issues",
+			count: 395,
+			cell: Cell{
+				Issues: []string{"problematic", "state"},
 			},
-			want: []InflatedColumn{
-				{
-					Column: &statepb.Column{
-						Build:   "same",
-						Name:    "same",
-						Started: 7,
-					},
-					Cells: map[string]Cell{
-						"first":  {ID: "first"},
-						"same":   {ID: "first-different"},
-						"second": {ID: "second"},
-					},
-				},
+			expected: statepb.Row{
+				Results: []int32{int32(statuspb.TestStatus_NO_RESULT), 395},
+				Issues:  []string{"problematic", "state"},
 			},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			tg := tc.tg
-			if tg == nil {
-				tg = &configpb.TestGroup{}
-			}
-			got := groupColumns(tg, tc.cols)
-			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
-				t.Errorf("groupColumns() got unexpected diff (-want +got):\n%s", diff)
+			appendCell(&tc.row, tc.cell, tc.start, tc.count)
+			sort.SliceStable(tc.row.Metric, func(i, j int) bool {
+				return tc.row.Metric[i] < tc.row.Metric[j]
+			})
+			sort.SliceStable(tc.row.Metrics, func(i, j int) bool {
+				return tc.row.Metrics[i].Name < tc.row.Metrics[j].Name
+			})
+			sort.SliceStable(tc.expected.Metric, func(i, j int) bool {
+				return tc.expected.Metric[i] < tc.expected.Metric[j]
+			})
+			sort.SliceStable(tc.expected.Metrics, func(i, j int) bool {
+				return tc.expected.Metrics[i].Name < tc.expected.Metrics[j].Name
+			})
+			if diff := cmp.Diff(tc.row, tc.expected, protocmp.Transform()); diff != "" {
+				t.Errorf("appendCell() got unexpected diff (-got +want):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestConstructGrid(t *testing.T) {
+func TestCheckColumnOrder(t *testing.T) {
+	col := func(build string, started float64) inflatedColumn {
+		return inflatedColumn{Column: &statepb.Column{Build: build, Started: started}}
+	}
 	cases := []struct {
 		name     string
-		group    configpb.TestGroup
+		sort     bool
 		cols     []inflatedColumn
-		issues   map[string][]string
+		expected []string
+		skewed   []string
+	}{
+		{
+			name:     "in order",
+			cols:     []inflatedColumn{col("3", 300), col("2", 200), col("1", 100)},
+			expected: []string{"3", "2", "1"},
+		},
+		{
+			name:     "warn about skewed builds",
+			cols:     []inflatedColumn{col("4", 400), col("3", 100), col("2", 200), col("1", 50)},
+			expected: []string{"4", "3", "2", "1"},
+			skewed:   []string{"2"},
+		},
+		{
+			name:     "sort skewed builds",
+			sort:     true,
+			cols:     []inflatedColumn{col("4", 400), col("3", 100), col("2", 200), col("1", 50), col("0", 500)},
+			expected: []string{"0", "4", "2", "3", "1"},
+			skewed:   []string{"2", "0"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			log, hook := logtest.NewNullLogger()
+			tg := &configpb.TestGroup{SortSkewedColumns: tc.sort}
+			checkColumnOrder(log, tg, tc.cols)
+
+			var actual []string
+			for _, c := range tc.cols {
+				actual = append(actual, c.Column.Build)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("checkColumnOrder() got unexpected order (-want +got):\n%s", diff)
+			}
+
+			var skewed []string
+			for _, e := range hook.AllEntries() {
+				if e.Level == logrus.WarnLevel {
+					skewed = append(skewed, e.Data["builds"].([]string)...)
+				}
+			}
+			if diff := cmp.Diff(tc.skewed, skewed); diff != "" {
+				t.Errorf("checkColumnOrder() warned about unexpected builds (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// setupRow appends cells to the row.
+//
+// Auto-drops UserProperty if row.UserProperty == nil (set to empty to preserve).
+func setupRow(row *statepb.Row, cells ...cell) *statepb.Row {
+	dropUserPropety := row.UserProperty == nil
+	for idx, c := range cells {
+		appendCell(row, c, idx, 1)
+	}
+	if dropUserPropety {
+		row.UserProperty = nil
+	}
+
+	return row
+}
+
+func TestAppendColumn(t *testing.T) {
+	cases := []struct {
+		name     string
+		grid     statepb.Grid
+		col      inflatedColumn
 		expected statepb.Grid
 	}{
 		{
-			name: "basically works",
+			name: "append first column",
+			col:  inflatedColumn{Column: &statepb.Column{Build: "10"}},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "10"},
+				},
+			},
 		},
 		{
-			name: "multiple columns",
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{Build: "15"},
-					Cells: map[string]cell{
-						"green": {
-							Result: statuspb.TestStatus_PASS,
-						},
-						"red": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-						"only-15": {
-							Result: statuspb.TestStatus_FLAKY,
+			name: "append additional column",
+			grid: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "10"},
+					{Build: "11"},
+				},
+			},
+			col: inflatedColumn{Column: &statepb.Column{Build: "20"}},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "10"},
+					{Build: "11"},
+					{Build: "20"},
+				},
+			},
+		},
+		{
+			name: "add rows to first column",
+			col: inflatedColumn{
+				Column: &statepb.Column{Build: "10"},
+				Cells: map[string]cell{
+					"hello": {
+						Result: statuspb.TestStatus_PASS,
+						CellID: "yes",
+						Metrics: map[string]float64{
+							"answer": 42,
 						},
 					},
+					"world": {
+						Result:       statuspb.TestStatus_FAIL,
+						Message:      "boom",
+						Icon:         "X",
+						UserProperty: "prop",
+					},
 				},
-				{
-					Column: &statepb.Column{Build: "10"},
-					Cells: map[string]cell{
-						"full": {
+			},
+			expected: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "10"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name:         "hello",
+							Id:           "hello",
+							UserProperty: []string{},
+						},
+						cell{
 							Result:  statuspb.TestStatus_PASS,
-							CellID:  "cell",
-							Icon:    "icon",
-							Message: "message",
-							Metrics: map[string]float64{
-								"elapsed": 1,
-								"keys":    2,
-							},
-							UserProperty: "food",
+							CellID:  "yes",
+							Metrics: map[string]float64{"answer": 42},
+						}),
+					setupRow(
+						&statepb.Row{
+							Name:         "world",
+							Id:           "world",
+							UserProperty: []string{},
 						},
-						"green": {
-							Result: statuspb.TestStatus_PASS,
+						cell{
+							Result:       statuspb.TestStatus_FAIL,
+							Message:      "boom",
+							Icon:         "X",
+							UserProperty: "prop",
 						},
-						"red": {
-							Result: statuspb.TestStatus_FAIL,
+					),
+				},
+			},
+		},
+		{
+			name: "add empty cells",
+			grid: statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "10"},
+					{Build: "11"},
+					{Build: "12"},
+				},
+				Rows: []*statepb.Row{
+					setupRow(
+						&statepb.Row{
+							Name:         "deleted",
+							UserProperty: []string{},
 						},
-						"only-10": {
-							Result: statuspb.TestStatus_FLAKY,
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+					setupRow(
+						&statepb.Row{
+							Name:         "always",
+							UserProperty: []string{},
 						},
-					},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+					),
+				},
+			},
+			col: inflatedColumn{
+				Column: &statepb.Column{Build: "20"},
+				Cells: map[string]cell{
+					"always": {Result: statuspb.TestStatus_PASS},
+					"new":    {Result: statuspb.TestStatus_PASS},
 				},
 			},
 			expected: statepb.Grid{
 				Columns: []*statepb.Column{
-					{Build: "15"},
 					{Build: "10"},
+					{Build: "11"},
+					{Build: "12"},
+					{Build: "20"},
 				},
 				Rows: []*statepb.Row{
 					setupRow(
 						&statepb.Row{
-							Name:         "full",
-							Id:           "full",
+							Name:         "deleted",
 							UserProperty: []string{},
 						},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
 						emptyCell,
-						cell{
-							Result:  statuspb.TestStatus_PASS,
-							CellID:  "cell",
-							Icon:    "icon",
-							Message: "message",
-							Metrics: map[string]float64{
-								"elapsed": 1,
-								"keys":    2,
-							},
-							UserProperty: "food",
-						},
 					),
 					setupRow(
 						&statepb.Row{
-							Name: "green",
-							Id:   "green",
+							Name:         "always",
+							UserProperty: []string{},
 						},
 						cell{Result: statuspb.TestStatus_PASS},
 						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
+						cell{Result: statuspb.TestStatus_PASS},
 					),
 					setupRow(
 						&statepb.Row{
-							Name: "only-10",
-							Id:   "only-10",
+							Name:         "new",
+							Id:           "new",
+							UserProperty: []string{},
 						},
 						emptyCell,
-						cell{Result: statuspb.TestStatus_FLAKY},
-					),
-					setupRow(
-						&statepb.Row{
-							Name: "only-15",
-							Id:   "only-15",
-						},
-						cell{Result: statuspb.TestStatus_FLAKY},
 						emptyCell,
-					),
-					setupRow(
-						&statepb.Row{
-							Name: "red",
-							Id:   "red",
-						},
-						cell{Result: statuspb.TestStatus_FAIL},
-						cell{Result: statuspb.TestStatus_FAIL},
+						emptyCell,
+						cell{Result: statuspb.TestStatus_PASS},
 					),
 				},
 			},
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := map[string]*statepb.Row{}
+			for _, r := range tc.grid.Rows {
+				rows[r.Name] = r
+			}
+			appendColumn(&tc.grid, rows, tc.col, nil)
+			sort.SliceStable(tc.grid.Rows, func(i, j int) bool {
+				return tc.grid.Rows[i].Name < tc.grid.Rows[j].Name
+			})
+			sort.SliceStable(tc.expected.Rows, func(i, j int) bool {
+				return tc.expected.Rows[i].Name < tc.expected.Rows[j].Name
+			})
+			if diff := cmp.Diff(&tc.expected, &tc.grid, protocmp.Transform()); diff != "" {
+				t.Errorf("appendColumn() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricSpacing(t *testing.T) {
+	cases := []struct {
+		name     string
+		d        *configpb.TestGroup_MetricDownsample
+		cols     int
+		expected int32
+	}{
+		{
+			name: "basically works",
+			d:    &configpb.TestGroup_MetricDownsample{},
+			cols: 100,
+		},
+		{
+			name:     "every nth",
+			d:        &configpb.TestGroup_MetricDownsample{EveryNth: 3},
+			cols:     100,
+			expected: 3,
+		},
+		{
+			name:     "fit older columns within max points",
+			d:        &configpb.TestGroup_MetricDownsample{RecentColumns: 10, MaxPoints: 20},
+			cols:     100,
+			expected: 5,
+		},
+		{
+			name:     "round up to fit",
+			d:        &configpb.TestGroup_MetricDownsample{MaxPoints: 30},
+			cols:     100,
+			expected: 4,
+		},
+		{
+			name: "already fits",
+			d:    &configpb.TestGroup_MetricDownsample{RecentColumns: 10, MaxPoints: 20},
+			cols: 25,
+		},
+		{
+			name:     "every nth spaces further apart than max points",
+			d:        &configpb.TestGroup_MetricDownsample{EveryNth: 10, MaxPoints: 50},
+			cols:     100,
+			expected: 10,
+		},
+		{
+			name:     "max points spaces further apart than every nth",
+			d:        &configpb.TestGroup_MetricDownsample{EveryNth: 2, MaxPoints: 10},
+			cols:     100,
+			expected: 10,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := metricSpacing(tc.d, tc.cols); actual != tc.expected {
+				t.Errorf("metricSpacing() got %d, want %d", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestDownsampleMetric(t *testing.T) {
+	dense := func(n int) *statepb.Metric {
+		var m statepb.Metric
+		for i := 0; i < n; i++ {
+			appendMetric(&m, int32(i), float64(i))
+		}
+		return &m
+	}
+	cases := []struct {
+		name     string
+		metric   *statepb.Metric
+		recent   int32
+		spacing  int32
+		expected *statepb.Metric
+	}{
+		{
+			name:     "basically works",
+			metric:   &statepb.Metric{},
+			spacing:  2,
+			expected: &statepb.Metric{},
+		},
+		{
+			name:     "keep everything without spacing",
+			metric:   dense(5),
+			spacing:  1,
+			expected: dense(5),
+		},
+		{
+			name:    "keep every nth from the oldest",
+			metric:  dense(10),
+			spacing: 3,
+			expected: &statepb.Metric{
+				Indices: []int32{0, 1, 3, 1, 6, 1, 9, 1},
+				Values:  []float64{0, 3, 6, 9},
+			},
+		},
 		{
-			name: "open alert",
-			group: configpb.TestGroup{
-				NumFailuresToAlert: 2,
+			name:    "keep recent columns at full resolution",
+			metric:  dense(10),
+			recent:  4,
+			spacing: 3,
+			expected: &statepb.Metric{
+				Indices: []int32{0, 4, 6, 1, 9, 1},
+				Values:  []float64{0, 1, 2, 3, 6, 9},
 			},
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{Build: "4"},
-					Cells: map[string]cell{
-						"just-flaky": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-						"broken": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-					},
+		},
+		{
+			name: "respect gaps in sparse metrics",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 2, 5, 2, 9, 1},
+				Values:  []float64{0, 1, 5, 6, 9},
+			},
+			spacing: 3,
+			expected: &statepb.Metric{
+				Indices: []int32{1, 1, 6, 1, 9, 1},
+				Values:  []float64{1, 6, 9},
+			},
+		},
+		{
+			name: "ignore malformed metrics",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 3},
+				Values:  []float64{1},
+			},
+			spacing: 2,
+			expected: &statepb.Metric{
+				Indices: []int32{0, 3},
+				Values:  []float64{1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid := validateMetric(tc.metric) == nil
+			downsampleMetric(tc.metric, tc.recent, tc.spacing)
+			if diff := cmp.Diff(tc.expected, tc.metric, protocmp.Transform()); diff != "" {
+				t.Fatalf("downsampleMetric() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if valid {
+				if err := verifyMetric(tc.metric, 10); err != nil {
+					t.Errorf("downsampleMetric() produced an invalid metric: %v", err)
+				}
+			}
+
+			// Downsampling again changes nothing.
+			again := proto.Clone(tc.metric).(*statepb.Metric)
+			downsampleMetric(again, tc.recent, tc.spacing)
+			if diff := cmp.Diff(tc.metric, again, protocmp.Transform()); diff != "" {
+				t.Errorf("downsampleMetric() changed a downsampled metric (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTrimGrid(t *testing.T) {
+	cols := []inflatedColumn{
+		{
+			Column: &statepb.Column{Build: "4"},
+			Cells: map[string]cell{
+				"hello": {Result: statuspb.TestStatus_PASS, CellID: "h4", Message: "yay", Icon: "P", Metrics: map[string]float64{"elapsed": 4}},
+				"world": {Result: statuspb.TestStatus_FAIL, CellID: "w4", Message: "boom", Icon: "F", UserProperty: "w"},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "3"},
+			Cells: map[string]cell{
+				"hello": {Result: statuspb.TestStatus_PASS, CellID: "h3", Metrics: map[string]float64{"elapsed": 3}},
+				"world": {Result: statuspb.TestStatus_FAIL, CellID: "w3", Message: "ugh"},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "2"},
+			Cells: map[string]cell{
+				"hello": {Result: statuspb.TestStatus_FAIL, CellID: "h2", Message: "oops", Metrics: map[string]float64{"coverage": 0.5}},
+				"old":   {Result: statuspb.TestStatus_PASS, CellID: "o2", Metrics: map[string]float64{"elapsed": 2}},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "1"},
+			Cells: map[string]cell{
+				"hello": {Result: statuspb.TestStatus_PASS, CellID: "h1", Metrics: map[string]float64{"elapsed": 1}},
+				"world": {Result: statuspb.TestStatus_PASS, CellID: "w1", Icon: "P"},
+				"old":   {Result: statuspb.TestStatus_FAIL, CellID: "o1", Message: "first"},
+			},
+		},
+	}
+
+	build := func(cols []inflatedColumn) *statepb.Grid {
+		var grid statepb.Grid
+		rows := map[string]*statepb.Row{}
+		for _, col := range cols {
+			appendColumn(&grid, rows, col, nil)
+		}
+		return &grid
+	}
+
+	for max := 1; max <= len(cols)+1; max++ {
+		t.Run(fmt.Sprintf("max %d", max), func(t *testing.T) {
+			log := logrus.WithField("max", max)
+			end := max
+			if end > len(cols) {
+				end = len(cols)
+			}
+			expected := build(cols[:end])
+			dropEmptyRows(log, expected, map[string]*statepb.Row{})
+
+			actual := build(cols)
+			trimGrid(actual, max)
+			if err := VerifyGrid(actual); err != nil {
+				t.Fatalf("trimGrid() left misaligned rows: %v", err)
+			}
+			dropEmptyRows(log, actual, map[string]*statepb.Row{})
+
+			for _, g := range []*statepb.Grid{expected, actual} {
+				sort.SliceStable(g.Rows, func(i, j int) bool {
+					return g.Rows[i].Name < g.Rows[j].Name
+				})
+			}
+			if diff := cmp.Diff(expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("trimGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDynamicEmails(t *testing.T) {
+	columnWithEmails := statepb.Column{Build: "columnWithEmail", Started: 100 - float64(0), EmailAddresses: []string{"email1@", "email2@"}}
+	anotherColumnWithEmails := statepb.Column{Build: "anotherColumnWithEmails", Started: 100 - float64(1), EmailAddresses: []string{"email3@", "email2@"}}
+	cases := []struct {
+		name     string
+		row      *statepb.Row
+		columns  []*statepb.Column
+		expected *statepb.AlertInfo
+	}{
+		{
+			name: "first column with dynamic emails",
+			row: &statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
 				},
-				{
-					Column: &statepb.Column{Build: "3"},
-					Cells: map[string]cell{
-						"just-flaky": {
-							Result: statuspb.TestStatus_PASS,
-						},
-						"broken": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-					},
+				Messages: []string{""},
+				CellIds:  []string{""},
+			},
+			columns:  []*statepb.Column{&columnWithEmails},
+			expected: alertInfo(1, "", "", "", &columnWithEmails, &columnWithEmails, nil, buildID),
+		},
+		{
+			name: "two column with dynamic emails, we get only the first one",
+			row: &statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+				Messages: []string{"", ""},
+				CellIds:  []string{"", ""},
+			},
+			columns:  []*statepb.Column{&anotherColumnWithEmails, &columnWithEmails},
+			expected: alertInfo(2, "", "", "", &columnWithEmails, &anotherColumnWithEmails, nil, buildID),
+		},
+		{
+			name: "first column don't have results, second column emails on the alert",
+			row: &statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+				},
+				Messages: []string{"", ""},
+				CellIds:  []string{"", ""},
+			},
+			columns:  []*statepb.Column{&columnWithEmails, &anotherColumnWithEmails},
+			expected: alertInfo(1, "", "", "", &anotherColumnWithEmails, &anotherColumnWithEmails, nil, buildID),
+		},
+	}
+	for _, tc := range cases {
+		actual := alertRow(tc.columns, tc.row, 1, 1, 0, 0, false, configpb.TestGroup_FLAKY_ALERT_RESET, buildID)
+		if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+			t.Errorf("alertRow() not as expected (-want, +got): %s", diff)
+		}
+	}
+}
+
+func TestSuspectBuildIDs(t *testing.T) {
+	cases := []struct {
+		name     string
+		cols     []*statepb.Column
+		expected []string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "preserve column order",
+			cols: []*statepb.Column{
+				{Build: "3"},
+				{Build: "2"},
+				{Build: "1"},
+			},
+			expected: []string{"3", "2", "1"},
+		},
+		{
+			name: "merge columns with the same id",
+			cols: []*statepb.Column{
+				{Build: "4", Extra: []string{"commit-b"}},
+				{Build: "3", Extra: []string{"commit-b"}},
+				{Build: "2", Extra: []string{"commit-a"}},
+				{Build: "1", Extra: []string{"commit-a"}},
+			},
+			expected: []string{"commit-b", "commit-a"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := suspectBuildIDs(tc.cols, buildID)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("suspectBuildIDs() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// withSuspects sets the suspect build ids of the alert.
+func withSuspects(alert *statepb.AlertInfo, ids ...string) *statepb.AlertInfo {
+	alert.SuspectBuildIds = ids
+	return alert
+}
+
+func TestAlertRow(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	cases := []struct {
+		name         string
+		row          statepb.Row
+		failOpen     int
+		passClose    int
+		minHistory   int
+		ignoreLatest int
+		allFailing   bool
+		flaky        configpb.TestGroup_FlakyAlert
+		expected     *statepb.AlertInfo
+	}{
+		{
+			name: "never alert by default",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 6,
 				},
 			},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "4"},
-					{Build: "3"},
-				},
-				Rows: []*statepb.Row{
-					setupRow(
-						&statepb.Row{
-							Name: "broken",
-							Id:   "broken",
-						},
-						cell{Result: statuspb.TestStatus_FAIL},
-						cell{Result: statuspb.TestStatus_FAIL},
-					),
-					setupRow(
-						&statepb.Row{
-							Name: "just-flaky",
-							Id:   "just-flaky",
-						},
-						cell{Result: statuspb.TestStatus_FAIL},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
+		},
+		{
+			name: "passes do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 6,
 				},
 			},
+			failOpen:  1,
+			passClose: 3,
 		},
 		{
-			name: "close alert",
-			group: configpb.TestGroup{
-				NumPassesToDisableAlert: 2,
-				NumFailuresToAlert:      1,
-			},
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{Build: "4"},
-					Cells: map[string]cell{
-						"still-broken": {
-							Result: statuspb.TestStatus_PASS,
-						},
-						"fixed": {
-							Result: statuspb.TestStatus_PASS,
-						},
-					},
-				},
-				{
-					Column: &statepb.Column{Build: "3"},
-					Cells: map[string]cell{
-						"still-broken": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-						"fixed": {
-							Result: statuspb.TestStatus_PASS,
-						},
-					},
-				},
-				{
-					Column: &statepb.Column{Build: "2"},
-					Cells: map[string]cell{
-						"still-broken": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-						"fixed": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-					},
-				},
-				{
-					Column: &statepb.Column{Build: "1"},
-					Cells: map[string]cell{
-						"still-broken": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-						"fixed": {
-							Result: statuspb.TestStatus_FAIL,
-						},
-					},
+			name: "flakes do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 6,
 				},
 			},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "4"},
-					{Build: "3"},
-					{Build: "2"},
-					{Build: "1"},
-				},
-				Rows: []*statepb.Row{
-					setupRow(
-						&statepb.Row{
-							Name: "fixed",
-							Id:   "fixed",
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_FAIL},
-						cell{Result: statuspb.TestStatus_FAIL},
-					),
-					setupRow(
-						&statepb.Row{
-							Name: "still-broken",
-							Id:   "still-broken",
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_FAIL},
-						cell{Result: statuspb.TestStatus_FAIL},
-						cell{Result: statuspb.TestStatus_FAIL},
-					),
+			failOpen: 1,
+		},
+		{
+			name: "flakes alert when they count as failures",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 2,
+					int32(statuspb.TestStatus_PASS), 1,
 				},
+				Messages: []string{"flaky", "flaky again", "pass"},
+				CellIds:  []string{"a", "b", "c"},
 			},
+			failOpen: 2,
+			flaky:    configpb.TestGroup_FLAKY_ALERT_FAIL,
+			expected: withSuspects(alertInfo(2, "flaky", "b", "a", columns[1], columns[0], columns[2], buildID), "b"),
 		},
 		{
-			name: "issues",
-			cols: []inflatedColumn{
-				{
-					Column: &statepb.Column{Build: "15"},
-					Cells: map[string]cell{
-						"row": {
-							Result: statuspb.TestStatus_PASS,
-							Issues: []string{
-								"from-cell-15",
-								"should-deduplicate-from-both",
-								"should-deduplicate-from-row",
-								"should-deduplicate-from-cell",
-								"should-deduplicate-from-cell",
-							},
-						},
-					},
-				},
-				{
-					Column: &statepb.Column{Build: "10"},
-					Cells: map[string]cell{
-						"row": {
-							Result: statuspb.TestStatus_PASS,
-							Issues: []string{
-								"from-cell-10",
-								"should-deduplicate-from-row",
-							},
-						},
-						"other": {
-							Result: statuspb.TestStatus_PASS,
-							Issues: []string{"fun"},
-						},
-						"sort": {
-							Result: statuspb.TestStatus_PASS,
-							Issues: []string{
-								"3-is-second",
-								"100-is-last",
-								"2-is-first",
-							},
-						},
-					},
+			name: "flakes break failure streaks by default",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
 				},
+				Messages: []string{"fail", "flaky", "fail again", "pass"},
+				CellIds:  []string{"a", "b", "c", "d"},
 			},
-			issues: map[string][]string{
-				"row": {
-					"from-argument",
-					"should-deduplicate-from-arg",
-					"should-deduplicate-from-arg",
-					"should-deduplicate-from-both",
+			failOpen:  2,
+			passClose: 1,
+		},
+		{
+			name: "ignored flakes do not break failure streaks",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
 				},
+				Messages: []string{"fail", "flaky", "fail again", "pass"},
+				CellIds:  []string{"a", "b", "c", "d"},
 			},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "15"},
-					{Build: "10"},
+			failOpen:  2,
+			passClose: 1,
+			flaky:     configpb.TestGroup_FLAKY_ALERT_IGNORE,
+			expected:  withSuspects(alertInfo(2, "fail", "c", "a", columns[2], columns[0], columns[3], buildID), "c"),
+		},
+		{
+			name: "intermittent failures do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
 				},
-				Rows: []*statepb.Row{
-					setupRow(
-						&statepb.Row{
-							Name:   "other",
-							Id:     "other",
-							Issues: []string{"fun"},
-						},
-						cell{Result: statuspb.TestStatus_NO_RESULT},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
-					setupRow(
-						&statepb.Row{
-							Name: "row",
-							Id:   "row",
-							Issues: []string{
-								"should-deduplicate-from-row",
-								"should-deduplicate-from-cell",
-								"should-deduplicate-from-both",
-								"should-deduplicate-from-arg",
-								"from-cell-15",
-								"from-cell-10",
-								"from-argument",
-							},
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
-					setupRow(
-						&statepb.Row{
-							Name: "sort",
-							Id:   "sort",
-							Issues: []string{
-								"100-is-last",
-								"3-is-second",
-								"2-is-first",
-							},
-						},
-						cell{Result: statuspb.TestStatus_NO_RESULT},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
+			},
+			failOpen: 3,
+		},
+		{
+			name: "new failures alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 3,
 				},
+				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
+				CellIds:  []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
 			},
+			failOpen: 3,
+			expected: withSuspects(alertInfo(3, "no", "very wrong", "no", columns[2], columns[0], columns[3], buildID), "c"),
 		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			actual := ConstructGrid(logrus.WithField("name", tc.name), &tc.group, tc.cols, tc.issues)
-			failuresOpen := int(tc.group.NumFailuresToAlert)
-			passesClose := int(tc.group.NumPassesToDisableAlert)
-			if failuresOpen > 0 && passesClose == 0 {
-				passesClose = 1
-			}
-			alertRows(tc.expected.Columns, tc.expected.Rows, failuresOpen, passesClose)
-			for _, row := range tc.expected.Rows {
-				sort.SliceStable(row.Metric, func(i, j int) bool {
-					return sortorder.NaturalLess(row.Metric[i], row.Metric[j])
-				})
-				sort.SliceStable(row.Metrics, func(i, j int) bool {
-					return sortorder.NaturalLess(row.Metrics[i].Name, row.Metrics[j].Name)
-				})
-			}
-			if diff := cmp.Diff(&tc.expected, actual, protocmp.Transform()); diff != "" {
-				t.Errorf("ConstructGrid() got unexpected diff (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestAppendMetric(t *testing.T) {
-	cases := []struct {
-		name     string
-		metric   statepb.Metric
-		idx      int32
-		value    float64
-		expected statepb.Metric
-	}{
 		{
-			name: "basically works",
-			expected: statepb.Metric{
-				Indices: []int32{0, 1},
-				Values:  []float64{0},
+			name: "rows without cell IDs can alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 3,
+				},
+				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
 			},
+			failOpen: 3,
+			expected: withSuspects(alertInfo(3, "no", "", "", columns[2], columns[0], columns[3], buildID), "c"),
 		},
 		{
-			name:  "start metric at random column",
-			idx:   7,
-			value: 11,
-			expected: statepb.Metric{
-				Indices: []int32{7, 1},
-				Values:  []float64{11},
+			name: "open alerts with enough history",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+				},
+				Messages: []string{"hello", "no", "yay"},
+				CellIds:  []string{"yes", "no", "yep"},
 			},
+			failOpen:   2,
+			minHistory: 3,
+			expected:   withSuspects(alertInfo(2, "hello", "no", "yes", columns[1], columns[0], columns[3], buildID), "b", "c"),
 		},
 		{
-			name: "continue existing series",
-			metric: statepb.Metric{
-				Indices: []int32{6, 2},
-				Values:  []float64{6.1, 6.2},
+			name: "do not alert without enough history",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_RUNNING), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_NO_RESULT), 3,
+				},
+				Messages: []string{"running", "hello", "no"},
+				CellIds:  []string{"running", "yes", "no"},
 			},
-			idx:   8,
-			value: 88,
-			expected: statepb.Metric{
-				Indices: []int32{6, 3},
-				Values:  []float64{6.1, 6.2, 88},
+			failOpen:   2,
+			minHistory: 3,
+		},
+		{
+			name: "too few passes do not close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
+				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
 			},
+			failOpen:  1,
+			passClose: 3,
+			expected:  alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil, buildID),
 		},
 		{
-			name: "start new series",
-			metric: statepb.Metric{
-				Indices: []int32{3, 2},
-				Values:  []float64{6.1, 6.2},
+			name: "flakes do not close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 2,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
+				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
 			},
-			idx:   8,
-			value: 88,
-			expected: statepb.Metric{
-				Indices: []int32{3, 2, 8, 1},
-				Values:  []float64{6.1, 6.2, 88},
+			failOpen: 1,
+			expected: alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil, buildID),
+		},
+		{
+			name: "count failures after flaky passes",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+				Messages: []string{"nope", "no", "buu", "wrong", "this one", "hi"},
+				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job", "hi"},
 			},
+			failOpen:  2,
+			passClose: 2,
+			expected:  alertInfo(4, "this one", "hi", "good job", columns[5], columns[4], nil, buildID),
 		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			appendMetric(&tc.metric, tc.idx, tc.value)
-			if diff := cmp.Diff(tc.metric, tc.expected, protocmp.Transform()); diff != "" {
-				t.Errorf("appendMetric() got unexpected diff (-got +want):\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestAppendCell(t *testing.T) {
-	cases := []struct {
-		name  string
-		row   statepb.Row
-		cell  cell
-		start int
-		count int
-
-		expected statepb.Row
-	}{
 		{
-			name: "basically works",
-			expected: statepb.Row{
-				Results: []int32{0, 0},
+			name: "close alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
+				},
 			},
+			failOpen: 1,
 		},
 		{
-			name: "first result",
-			cell: cell{
-				Result: statuspb.TestStatus_PASS,
+			name: "track through empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"yay" /*no result */, "no", "buu", "wrong", "nono"},
+				CellIds:  []string{"yay-cell" /*no result */, "no", "buzz", "wrong2", "nada"},
 			},
-			count: 1,
-			expected: statepb.Row{
-				Results:      []int32{int32(statuspb.TestStatus_PASS), 1},
-				CellIds:      []string{""},
-				Messages:     []string{""},
-				Icons:        []string{""},
-				UserProperty: []string{""},
+			failOpen:  5,
+			passClose: 2,
+			expected:  alertInfo(5, "yay", "nada", "yay-cell", columns[5], columns[0], nil, buildID),
+		},
+		{
+			name: "track passes through empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 3,
+				},
 			},
+			failOpen:  1,
+			passClose: 2,
 		},
 		{
-			name: "all fields filled",
-			cell: cell{
-				Result:  statuspb.TestStatus_PASS,
-				CellID:  "cell-id",
-				Message: "hi",
-				Icon:    "there",
-				Metrics: map[string]float64{
-					"pi":     3.14,
-					"golden": 1.618,
+			name: "running cells advance compressed index",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_RUNNING), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
 				},
-				UserProperty: "hello",
+				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
+				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
 			},
-			count: 1,
-			expected: statepb.Row{
-				Results:  []int32{int32(statuspb.TestStatus_PASS), 1},
-				CellIds:  []string{"cell-id"},
-				Messages: []string{"hi"},
-				Icons:    []string{"there"},
-				Metric: []string{
-					"golden",
-					"pi",
+			failOpen: 1,
+			expected: alertInfo(5, "fail1-expected", "no5", "yep", columns[5], columns[1], nil, buildID),
+		},
+		{
+			name: "suspect range of an outage",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_RUNNING), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 2,
 				},
-				Metrics: []*statepb.Metric{
-					{
-						Name:    "pi",
-						Indices: []int32{0, 1},
-						Values:  []float64{3.14},
-					},
-					{
-						Name:    "golden",
-						Indices: []int32{0, 1},
-						Values:  []float64{1.618},
-					},
+				Messages: []string{"newest", "running", "oldest", "pass", "pass"},
+				CellIds:  []string{"a1", "b1", "c1", "e1", "f1"},
+			},
+			failOpen:  2,
+			passClose: 1,
+			expected:  withSuspects(alertInfo(2, "newest", "c1", "a1", columns[2], columns[0], columns[4], buildID), "c", "d"),
+		},
+		{
+			name: "ignoring the latest failure prevents an alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 4,
 				},
-				UserProperty: []string{"hello"},
+				Messages: []string{"latest", "older", "", "", "", ""},
+				CellIds:  []string{"a1", "b1", "c1", "d1", "e1", "f1"},
+			},
+			failOpen:     2,
+			passClose:    1,
+			ignoreLatest: 1,
+		},
+		{
+			name: "ignoring the latest pass permits an alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 3,
+				},
+				Messages: []string{"partial", "newer", "older", "", "", ""},
+				CellIds:  []string{"a1", "b1", "c1", "d1", "e1", "f1"},
+			},
+			failOpen:     2,
+			passClose:    1,
+			ignoreLatest: 1,
+			expected:     withSuspects(alertInfo(2, "newer", "c1", "b1", columns[2], columns[1], columns[3], buildID), "c"),
+		},
+		{
+			name: "ignoring latest columns skips running results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_RUNNING), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 2,
+				},
+				Messages: []string{"running", "newer", "older", "", ""},
+				CellIds:  []string{"a1", "c1", "d1", "e1", "f1"},
 			},
+			failOpen:     2,
+			passClose:    1,
+			ignoreLatest: 2,
+			expected:     withSuspects(alertInfo(2, "newer", "d1", "c1", columns[3], columns[2], columns[4], buildID), "d"),
 		},
 		{
-			name: "append same result",
+			name: "too few failures do not alert",
 			row: statepb.Row{
 				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 3,
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_NO_RESULT), 3,
 				},
-				CellIds:      []string{"", "", ""},
-				Messages:     []string{"", "", ""},
-				Icons:        []string{"", "", ""},
-				UserProperty: []string{"", "", ""},
-			},
-			cell: cell{
-				Result:       statuspb.TestStatus_FLAKY,
-				Message:      "echo",
-				CellID:       "again and",
-				Icon:         "keeps going",
-				UserProperty: "more more",
-			},
-			count: 2,
-			expected: statepb.Row{
-				Results:      []int32{int32(statuspb.TestStatus_FLAKY), 5},
-				CellIds:      []string{"", "", "", "again and", "again and"},
-				Messages:     []string{"", "", "", "echo", "echo"},
-				Icons:        []string{"", "", "", "keeps going", "keeps going"},
-				UserProperty: []string{"", "", "", "more more", "more more"},
+				Messages: []string{"newest", "middle", "oldest"},
+				CellIds:  []string{"c1", "c2", "c3"},
 			},
+			failOpen: 5,
 		},
 		{
-			name: "append different result",
+			name: "alert when all results fail",
 			row: statepb.Row{
 				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 3,
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_NO_RESULT), 3,
 				},
-				CellIds:      []string{"", "", ""},
-				Messages:     []string{"", "", ""},
-				Icons:        []string{"", "", ""},
-				UserProperty: []string{"", "", ""},
-			},
-			cell: cell{
-				Result: statuspb.TestStatus_PASS,
+				Messages: []string{"newest", "middle", "oldest"},
+				CellIds:  []string{"c1", "c2", "c3"},
 			},
-			count: 2,
-			expected: statepb.Row{
+			failOpen:   5,
+			allFailing: true,
+			expected:   alertInfo(3, "newest", "c3", "c1", columns[2], columns[0], nil, buildID),
+		},
+		{
+			name: "passes prevent alerting when all results fail",
+			row: statepb.Row{
 				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 3,
-					int32(statuspb.TestStatus_PASS), 2,
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 3,
 				},
-				CellIds:      []string{"", "", "", "", ""},
-				Messages:     []string{"", "", "", "", ""},
-				Icons:        []string{"", "", "", "", ""},
-				UserProperty: []string{"", "", "", "", ""},
+				Messages: []string{"newest", "middle", "pass"},
+				CellIds:  []string{"c1", "c2", "c3"},
 			},
+			failOpen:   5,
+			passClose:  2,
+			allFailing: true,
 		},
 		{
-			name: "append no Result (results, no cellIDs, messages or icons)",
+			name: "flakes prevent alerting when all results fail",
 			row: statepb.Row{
 				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 3,
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 3,
 				},
-				CellIds:      []string{"", "", ""},
-				Messages:     []string{"", "", ""},
-				Icons:        []string{"", "", ""},
-				UserProperty: []string{"", "", ""},
-			},
-			cell: cell{
-				Result: statuspb.TestStatus_NO_RESULT,
+				Messages: []string{"newest", "middle", "flaky"},
+				CellIds:  []string{"c1", "c2", "c3"},
 			},
-			count: 2,
-			expected: statepb.Row{
+			failOpen:   5,
+			allFailing: true,
+		},
+		{
+			name: "empty rows do not alert when all results fail",
+			row: statepb.Row{
 				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 3,
-					int32(statuspb.TestStatus_NO_RESULT), 2,
+					int32(statuspb.TestStatus_NO_RESULT), 6,
 				},
-				CellIds:      []string{"", "", ""},
-				Messages:     []string{"", "", ""},
-				Icons:        []string{"", "", ""},
-				UserProperty: []string{"", "", ""},
 			},
+			failOpen:   5,
+			allFailing: true,
 		},
 		{
-			name: "add metric to series",
+			name: "minimum history applies when all results fail",
 			row: statepb.Row{
-				Results:      []int32{int32(statuspb.TestStatus_PASS), 5},
-				CellIds:      []string{"", "", "", "", "c"},
-				Messages:     []string{"", "", "", "", "m"},
-				Icons:        []string{"", "", "", "", "i"},
-				UserProperty: []string{"", "", "", "", "up"},
-				Metric: []string{
-					"continued-series",
-					"new-series",
-				},
-				Metrics: []*statepb.Metric{
-					{
-						Name:    "continued-series",
-						Indices: []int32{0, 5},
-						Values:  []float64{0, 1, 2, 3, 4},
-					},
-					{
-						Name:    "new-series",
-						Indices: []int32{2, 2},
-						Values:  []float64{2, 3},
-					},
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_NO_RESULT), 3,
 				},
+				Messages: []string{"newest", "middle", "oldest"},
+				CellIds:  []string{"c1", "c2", "c3"},
 			},
-			cell: cell{
-				Result: statuspb.TestStatus_PASS,
-				Metrics: map[string]float64{
-					"continued-series":  5.1,
-					"new-series":        5.2,
-					"additional-metric": 5.3,
-				},
+			failOpen:   5,
+			minHistory: 4,
+			allFailing: true,
+		},
+	}
+
+	for _, tc := range cases {
+		actual := alertRow(columns, &tc.row, tc.failOpen, tc.passClose, tc.minHistory, tc.ignoreLatest, tc.allFailing, tc.flaky, buildID)
+		if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+			t.Errorf("alertRow() not as expected (-want, +got): %s", diff)
+		}
+	}
+}
+
+func TestDisappearedRow(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	disappeared := func(missing int) *statepb.AlertInfo {
+		return &statepb.AlertInfo{
+			AlertType:         statepb.AlertInfo_ALERT_TYPE_DISAPPEARED,
+			FailCount:         int32(missing),
+			FailBuildId:       columns[missing-1].Build,
+			FailTime:          stamp(columns[missing-1]),
+			LatestFailBuildId: "a",
+			FailureMessage:    fmt.Sprintf("No results in the last %d columns", missing),
+			PassBuildId:       columns[missing].Build,
+			PassTime:          stamp(columns[missing]),
+		}
+	}
+	cases := []struct {
+		name     string
+		results  []int32
+		after    int
+		expected *statepb.AlertInfo
+	}{
+		{
+			name: "disabled by default",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 3,
+				int32(statuspb.TestStatus_PASS), 3,
 			},
-			start: 5,
-			count: 1,
-			expected: statepb.Row{
-				Results:      []int32{int32(statuspb.TestStatus_PASS), 6},
-				CellIds:      []string{"", "", "", "", "c", ""},
-				Messages:     []string{"", "", "", "", "m", ""},
-				Icons:        []string{"", "", "", "", "i", ""},
-				UserProperty: []string{"", "", "", "", "up", ""},
-				Metric: []string{
-					"continued-series",
-					"new-series",
-					"additional-metric",
-				},
-				Metrics: []*statepb.Metric{
-					{
-						Name:    "continued-series",
-						Indices: []int32{0, 6},
-						Values:  []float64{0, 1, 2, 3, 4, 5.1},
-					},
-					{
-						Name:    "new-series",
-						Indices: []int32{2, 2, 5, 1},
-						Values:  []float64{2, 3, 5.2},
-					},
-					{
-						Name:    "additional-metric",
-						Indices: []int32{5, 1},
-						Values:  []float64{5.3},
-					},
-				},
+		},
+		{
+			name: "reporting rows do not alert",
+			results: []int32{
+				int32(statuspb.TestStatus_PASS), 6,
 			},
+			after: 2,
 		},
 		{
-			name:  "add a bunch of initial blank columns (eg a deleted row)",
-			cell:  emptyCell,
-			count: 7,
-			expected: statepb.Row{
-				Results: []int32{int32(statuspb.TestStatus_NO_RESULT), 7},
+			name: "briefly missing rows do not alert",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 1,
+				int32(statuspb.TestStatus_PASS), 5,
 			},
+			after: 2,
 		},
 		{
-			name:  "issues",
-			count: 395,
-			cell: Cell{
-				Issues: []string{"problematic", "state"},
+			name: "running rows are still reporting",
+			results: []int32{
+				int32(statuspb.TestStatus_RUNNING), 1,
+				int32(statuspb.TestStatus_NO_RESULT), 3,
+				int32(statuspb.TestStatus_PASS), 2,
 			},
-			expected: statepb.Row{
-				Results: []int32{int32(statuspb.TestStatus_NO_RESULT), 395},
-				Issues:  []string{"problematic", "state"},
+			after: 2,
+		},
+		{
+			name: "rows which never reported do not alert",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 6,
+			},
+			after: 2,
+		},
+		{
+			name: "alert after reporting then disappearing",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 2,
+				int32(statuspb.TestStatus_PASS), 4,
 			},
+			after:    2,
+			expected: disappeared(2),
+		},
+		{
+			name: "failing rows can disappear",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 3,
+				int32(statuspb.TestStatus_FAIL), 2,
+				int32(statuspb.TestStatus_PASS), 1,
+			},
+			after:    2,
+			expected: disappeared(3),
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			appendCell(&tc.row, tc.cell, tc.start, tc.count)
-			sort.SliceStable(tc.row.Metric, func(i, j int) bool {
-				return tc.row.Metric[i] < tc.row.Metric[j]
-			})
-			sort.SliceStable(tc.row.Metrics, func(i, j int) bool {
-				return tc.row.Metrics[i].Name < tc.row.Metrics[j].Name
-			})
-			sort.SliceStable(tc.expected.Metric, func(i, j int) bool {
-				return tc.expected.Metric[i] < tc.expected.Metric[j]
-			})
-			sort.SliceStable(tc.expected.Metrics, func(i, j int) bool {
-				return tc.expected.Metrics[i].Name < tc.expected.Metrics[j].Name
-			})
-			if diff := cmp.Diff(tc.row, tc.expected, protocmp.Transform()); diff != "" {
-				t.Errorf("appendCell() got unexpected diff (-got +want):\n%s", diff)
+			row := statepb.Row{Results: tc.results}
+			actual := disappearedRow(columns, &row, tc.after, buildID)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("disappearedRow() got unexpected diff (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-// setupRow appends cells to the row.
-//
-// Auto-drops UserProperty if row.UserProperty == nil (set to empty to preserve).
-func setupRow(row *statepb.Row, cells ...cell) *statepb.Row {
-	dropUserPropety := row.UserProperty == nil
-	for idx, c := range cells {
-		appendCell(row, c, idx, 1)
+func TestWeightedRow(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
 	}
-	if dropUserPropety {
-		row.UserProperty = nil
+	const (
+		pass = int32(statuspb.TestStatus_PASS)
+		fail = int32(statuspb.TestStatus_FAIL)
+	)
+	weighted := func(failures int32, first, latest int, msg string) *statepb.AlertInfo {
+		return &statepb.AlertInfo{
+			AlertType:         statepb.AlertInfo_ALERT_TYPE_WEIGHTED,
+			FailCount:         failures,
+			FailBuildId:       columns[first].Build,
+			FailTime:          stamp(columns[first]),
+			LatestFailBuildId: columns[latest].Build,
+			FailureMessage:    msg,
+		}
+	}
+	cases := []struct {
+		name      string
+		results   []int32
+		decay     float64
+		threshold float64
+		expected  *statepb.AlertInfo
+	}{
+		{
+			name: "empty rows do not alert",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 6,
+			},
+			decay:     0.5,
+			threshold: 0.5,
+		},
+		{
+			name: "intermittent recent failures cross the threshold",
+			results: []int32{
+				fail, 1,
+				pass, 1,
+				fail, 2,
+				pass, 2,
+			},
+			decay:     0.5,
+			threshold: 0.5,
+			expected:  weighted(3, 3, 0, "Weighted failure score 0.70 exceeds 0.50"),
+		},
+		{
+			name: "intermittent failures within the threshold",
+			results: []int32{
+				pass, 1,
+				fail, 1,
+				pass, 1,
+				fail, 1,
+				pass, 2,
+			},
+			decay:     0.5,
+			threshold: 0.5,
+		},
+		{
+			name: "old failures decay below the threshold",
+			results: []int32{
+				pass, 2,
+				fail, 4,
+			},
+			decay:     0.5,
+			threshold: 0.5,
+		},
+		{
+			name: "no decay weighs results equally",
+			results: []int32{
+				pass, 2,
+				fail, 4,
+			},
+			decay:     1,
+			threshold: 0.5,
+			expected:  weighted(4, 5, 2, "Weighted failure score 0.67 exceeds 0.50"),
+		},
+		{
+			name: "skip empty and running results",
+			results: []int32{
+				int32(statuspb.TestStatus_NO_RESULT), 1,
+				int32(statuspb.TestStatus_RUNNING), 1,
+				fail, 1,
+				pass, 3,
+			},
+			decay:     0.5,
+			threshold: 0.5,
+			expected:  weighted(1, 2, 2, "Weighted failure score 0.53 exceeds 0.50"),
+		},
+		{
+			name: "decay defaults to 0.8",
+			results: []int32{
+				fail, 1,
+				pass, 5,
+			},
+			threshold: 0.25,
+			expected:  weighted(1, 0, 0, "Weighted failure score 0.27 exceeds 0.25"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := statepb.Row{Results: tc.results}
+			cfg := &configpb.TestGroup_WeightedAlert{Decay: tc.decay, Threshold: tc.threshold}
+			actual := weightedRow(columns, &row, cfg, buildID)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("weightedRow() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWeightedRows(t *testing.T) {
+	cols := []*statepb.Column{{Build: "b"}, {Build: "a"}}
+	existing := &statepb.AlertInfo{FailCount: 2}
+	rows := []*statepb.Row{
+		{Name: "alerting", Results: []int32{int32(statuspb.TestStatus_FAIL), 2}, AlertInfo: existing},
+		{Name: "flaky", Results: []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_PASS), 1}},
+		{Name: "ignored", Results: []int32{int32(statuspb.TestStatus_FAIL), 2}},
+	}
+	only := []*regexp.Regexp{regexp.MustCompile("alerting|flaky")}
+	weightedRows(cols, rows, &configpb.TestGroup_WeightedAlert{Threshold: 0.5}, only, buildID)
+	if rows[0].AlertInfo != existing {
+		t.Errorf("weightedRows() replaced an existing alert with %v", rows[0].AlertInfo)
+	}
+	if got := rows[1].AlertInfo.GetAlertType(); got != statepb.AlertInfo_ALERT_TYPE_WEIGHTED {
+		t.Errorf("weightedRows() opened a %v alert, want a weighted one", got)
+	}
+	if rows[2].AlertInfo != nil {
+		t.Errorf("weightedRows() opened an alert on an unmatched row: %v", rows[2].AlertInfo)
+	}
+}
+
+func TestResolveAlertThresholds(t *testing.T) {
+	cases := []struct {
+		name        string
+		failsOpen   int
+		passesClose int
+		wantOpen    int
+		wantClose   int
+	}{
+		{
+			name: "both zero disables alerts",
+		},
+		{
+			name:        "passes alone do not enable alerts",
+			passesClose: 3,
+			wantClose:   3,
+		},
+		{
+			name:      "close after one pass by default",
+			failsOpen: 2,
+			wantOpen:  2,
+			wantClose: 1,
+		},
+		{
+			name:        "keep explicit passes",
+			failsOpen:   2,
+			passesClose: 4,
+			wantOpen:    2,
+			wantClose:   4,
+		},
+		{
+			name:        "negative passes are not defaulted",
+			failsOpen:   1,
+			passesClose: -1,
+			wantOpen:    1,
+			wantClose:   -1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotOpen, gotClose := resolveAlertThresholds(tc.failsOpen, tc.passesClose)
+			if gotOpen != tc.wantOpen || gotClose != tc.wantClose {
+				t.Errorf("resolveAlertThresholds(%d, %d) got (%d, %d), want (%d, %d)", tc.failsOpen, tc.passesClose, gotOpen, gotClose, tc.wantOpen, tc.wantClose)
+			}
+		})
 	}
-
-	return row
 }
 
-func TestAppendColumn(t *testing.T) {
-	cases := []struct {
-		name     string
-		grid     statepb.Grid
-		col      inflatedColumn
-		expected statepb.Grid
-	}{
-		{
-			name: "append first column",
-			col:  inflatedColumn{Column: &statepb.Column{Build: "10"}},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "10"},
+func TestAlertRows(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	messages := []string{"a", "b", "c", "d", "e", "f"}
+	newRows := func() []*statepb.Row {
+		return []*statepb.Row{
+			{
+				Name: "failing",
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 4,
 				},
+				Messages: messages,
 			},
-		},
-		{
-			name: "append additional column",
-			grid: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "10"},
-					{Build: "11"},
+			{
+				Name: "noisy",
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 4,
 				},
+				Messages: messages,
 			},
-			col: inflatedColumn{Column: &statepb.Column{Build: "20"}},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "10"},
-					{Build: "11"},
-					{Build: "20"},
+			{
+				Name: "recovering",
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
 				},
+				Messages: messages,
 			},
+		}
+	}
+	cases := []struct {
+		name      string
+		failOpen  int
+		passClose int
+		overrides []*configpb.TestGroup_RowAlertThreshold
+		only      []string
+		expected  []string
+	}{
+		{
+			name:      "basically works",
+			failOpen:  2,
+			passClose: 1,
+			expected:  []string{"failing", "noisy"},
 		},
 		{
-			name: "add rows to first column",
-			col: inflatedColumn{
-				Column: &statepb.Column{Build: "10"},
-				Cells: map[string]cell{
-					"hello": {
-						Result: statuspb.TestStatus_PASS,
-						CellID: "yes",
-						Metrics: map[string]float64{
-							"answer": 42,
-						},
-					},
-					"world": {
-						Result:       statuspb.TestStatus_FAIL,
-						Message:      "boom",
-						Icon:         "X",
-						UserProperty: "prop",
-					},
+			name:      "stricter row threshold does not alert",
+			failOpen:  2,
+			passClose: 1,
+			overrides: []*configpb.TestGroup_RowAlertThreshold{
+				{
+					RowName:            "noisy",
+					NumFailuresToAlert: 3,
 				},
 			},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "10"},
-				},
-				Rows: []*statepb.Row{
-					setupRow(
-						&statepb.Row{
-							Name:         "hello",
-							Id:           "hello",
-							UserProperty: []string{},
-						},
-						cell{
-							Result:  statuspb.TestStatus_PASS,
-							CellID:  "yes",
-							Metrics: map[string]float64{"answer": 42},
-						}),
-					setupRow(
-						&statepb.Row{
-							Name:         "world",
-							Id:           "world",
-							UserProperty: []string{},
-						},
-						cell{
-							Result:       statuspb.TestStatus_FAIL,
-							Message:      "boom",
-							Icon:         "X",
-							UserProperty: "prop",
-						},
-					),
+			expected: []string{"failing"},
+		},
+		{
+			name:      "row needs more passes to close",
+			failOpen:  2,
+			passClose: 1,
+			overrides: []*configpb.TestGroup_RowAlertThreshold{
+				{
+					RowName:                 "recovering",
+					NumPassesToDisableAlert: 2,
 				},
 			},
+			expected: []string{"failing", "noisy", "recovering"},
 		},
 		{
-			name: "add empty cells",
-			grid: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "10"},
-					{Build: "11"},
-					{Build: "12"},
-				},
-				Rows: []*statepb.Row{
-					setupRow(
-						&statepb.Row{
-							Name:         "deleted",
-							UserProperty: []string{},
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
-					setupRow(
-						&statepb.Row{
-							Name:         "always",
-							UserProperty: []string{},
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
+			name: "row alerts when group does not",
+			overrides: []*configpb.TestGroup_RowAlertThreshold{
+				{
+					RowName:            "noisy",
+					NumFailuresToAlert: 2,
 				},
 			},
-			col: inflatedColumn{
-				Column: &statepb.Column{Build: "20"},
-				Cells: map[string]cell{
-					"always": {Result: statuspb.TestStatus_PASS},
-					"new":    {Result: statuspb.TestStatus_PASS},
+			expected: []string{"noisy"},
+		},
+		{
+			name:      "ignore overrides for missing rows",
+			failOpen:  2,
+			passClose: 1,
+			overrides: []*configpb.TestGroup_RowAlertThreshold{
+				{
+					RowName:            "missing",
+					NumFailuresToAlert: 1,
 				},
 			},
-			expected: statepb.Grid{
-				Columns: []*statepb.Column{
-					{Build: "10"},
-					{Build: "11"},
-					{Build: "12"},
-					{Build: "20"},
-				},
-				Rows: []*statepb.Row{
-					setupRow(
-						&statepb.Row{
-							Name:         "deleted",
-							UserProperty: []string{},
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						emptyCell,
-					),
-					setupRow(
-						&statepb.Row{
-							Name:         "always",
-							UserProperty: []string{},
-						},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-						cell{Result: statuspb.TestStatus_PASS},
-					),
-					setupRow(
-						&statepb.Row{
-							Name:         "new",
-							Id:           "new",
-							UserProperty: []string{},
-						},
-						emptyCell,
-						emptyCell,
-						emptyCell,
-						cell{Result: statuspb.TestStatus_PASS},
-					),
+			expected: []string{"failing", "noisy"},
+		},
+		{
+			name:      "only alert on matching rows",
+			failOpen:  2,
+			passClose: 1,
+			only:      []string{"^fail"},
+			expected:  []string{"failing"},
+		},
+		{
+			name:      "only alert on rows matching any pattern",
+			failOpen:  2,
+			passClose: 1,
+			overrides: []*configpb.TestGroup_RowAlertThreshold{
+				{
+					RowName:                 "recovering",
+					NumPassesToDisableAlert: 2,
 				},
 			},
+			only:     []string{"noisy", "recover"},
+			expected: []string{"noisy", "recovering"},
+		},
+		{
+			name:      "ignore bad patterns",
+			failOpen:  2,
+			passClose: 1,
+			only:      []string{"(", "noisy"},
+			expected:  []string{"noisy"},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			rows := map[string]*statepb.Row{}
-			for _, r := range tc.grid.Rows {
-				rows[r.Name] = r
+			rows := newRows()
+			only := alertRowFilter(logrus.WithField("name", tc.name), tc.only)
+			alertRows(columns, rows, tc.failOpen, tc.passClose, 0, 0, false, configpb.TestGroup_FLAKY_ALERT_RESET, tc.overrides, only, buildID)
+			var actual []string
+			for _, row := range rows {
+				if row.AlertInfo != nil {
+					actual = append(actual, row.Name)
+				}
 			}
-			appendColumn(&tc.grid, rows, tc.col)
-			sort.SliceStable(tc.grid.Rows, func(i, j int) bool {
-				return tc.grid.Rows[i].Name < tc.grid.Rows[j].Name
-			})
-			sort.SliceStable(tc.expected.Rows, func(i, j int) bool {
-				return tc.expected.Rows[i].Name < tc.expected.Rows[j].Name
-			})
-			if diff := cmp.Diff(&tc.expected, &tc.grid, protocmp.Transform()); diff != "" {
-				t.Errorf("appendColumn() got unexpected diff (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("alertRows() got unexpected alerting rows (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestDynamicEmails(t *testing.T) {
-	columnWithEmails := statepb.Column{Build: "columnWithEmail", Started: 100 - float64(0), EmailAddresses: []string{"email1@", "email2@"}}
-	anotherColumnWithEmails := statepb.Column{Build: "anotherColumnWithEmails", Started: 100 - float64(1), EmailAddresses: []string{"email3@", "email2@"}}
+func TestIssueLinker(t *testing.T) {
 	cases := []struct {
 		name     string
-		row      *statepb.Row
-		columns  []*statepb.Column
-		expected *statepb.AlertInfo
+		rules    []*configpb.TestGroup_IssueLinkRule
+		msg      string
+		expected string
 	}{
 		{
-			name: "first column with dynamic emails",
-			row: &statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 1,
-				},
-				Messages: []string{""},
-				CellIds:  []string{""},
+			name: "basically works",
+			msg:  "hello",
+		},
+		{
+			name: "no match",
+			rules: []*configpb.TestGroup_IssueLinkRule{
+				{MessageRegex: "world", IssueUrlTemplate: "https://issue/world"},
 			},
-			columns:  []*statepb.Column{&columnWithEmails},
-			expected: alertInfo(1, "", "", "", &columnWithEmails, &columnWithEmails, nil),
+			msg: "hello",
 		},
 		{
-			name: "two column with dynamic emails, we get only the first one",
-			row: &statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 2,
-				},
-				Messages: []string{"", ""},
-				CellIds:  []string{"", ""},
+			name: "match",
+			rules: []*configpb.TestGroup_IssueLinkRule{
+				{MessageRegex: "hello", IssueUrlTemplate: "https://issue/hello"},
 			},
-			columns:  []*statepb.Column{&anotherColumnWithEmails, &columnWithEmails},
-			expected: alertInfo(2, "", "", "", &columnWithEmails, &anotherColumnWithEmails, nil),
+			msg:      "hello world",
+			expected: "https://issue/hello",
 		},
 		{
-			name: "first column don't have results, second column emails on the alert",
-			row: &statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_FAIL), 1,
-				},
-				Messages: []string{"", ""},
-				CellIds:  []string{"", ""},
+			name: "first match wins",
+			rules: []*configpb.TestGroup_IssueLinkRule{
+				{MessageRegex: "nope", IssueUrlTemplate: "https://issue/nope"},
+				{MessageRegex: "world", IssueUrlTemplate: "https://issue/world"},
+				{MessageRegex: "hello", IssueUrlTemplate: "https://issue/hello"},
+			},
+			msg:      "hello world",
+			expected: "https://issue/world",
+		},
+		{
+			name: "expand submatches",
+			rules: []*configpb.TestGroup_IssueLinkRule{
+				{MessageRegex: `(?P<kind>\w+) bug #(\d+)`, IssueUrlTemplate: "https://${kind}/issues/$2"},
 			},
-			columns:  []*statepb.Column{&columnWithEmails, &anotherColumnWithEmails},
-			expected: alertInfo(1, "", "", "", &anotherColumnWithEmails, &anotherColumnWithEmails, nil),
+			msg:      "known bug #123 happened",
+			expected: "https://known/issues/123",
+		},
+		{
+			name: "skip invalid rules",
+			rules: []*configpb.TestGroup_IssueLinkRule{
+				{MessageRegex: "(", IssueUrlTemplate: "https://issue/bad"},
+				{MessageRegex: "", IssueUrlTemplate: "https://issue/anything"},
+			},
+			msg:      "hello",
+			expected: "https://issue/anything",
 		},
 	}
+
 	for _, tc := range cases {
-		actual := alertRow(tc.columns, tc.row, 1, 1)
-		if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
-			t.Errorf("alertRow() not as expected (-want, +got): %s", diff)
-		}
+		t.Run(tc.name, func(t *testing.T) {
+			linker := makeIssueLinker(logrus.WithField("name", tc.name), tc.rules)
+			if actual := linker.link(tc.msg); actual != tc.expected {
+				t.Errorf("link(%q) got %q, want %q", tc.msg, actual, tc.expected)
+			}
+		})
 	}
 }
 
-func TestAlertRow(t *testing.T) {
-	var columns []*statepb.Column
-	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
-		columns = append(columns, &statepb.Column{
-			Build:   id,
-			Started: 100 - float64(i),
-		})
+func TestTemplateAlerts(t *testing.T) {
+	alert := func(msg string) *statepb.AlertInfo {
+		return &statepb.AlertInfo{
+			FailBuildId:    "123",
+			FailCount:      4,
+			FailureMessage: msg,
+		}
 	}
 	cases := []struct {
-		name      string
-		row       statepb.Row
-		failOpen  int
-		passClose int
-		expected  *statepb.AlertInfo
+		name     string
+		group    *configpb.TestGroup
+		rows     []*statepb.Row
+		expected []*statepb.Row
 	}{
 		{
-			name: "never alert by default",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 6,
-				},
+			name:  "basically works",
+			group: &configpb.TestGroup{Name: "group"},
+			rows: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+			},
+			expected: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
 			},
 		},
 		{
-			name: "passes do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 6,
-				},
+			name: "render group template",
+			group: &configpb.TestGroup{
+				Name:                 "group",
+				AlertMessageTemplate: "{{.Group}}/{{.Row}} failed {{.FailCount}} times since {{.FailBuild}}: {{.Message}}",
+			},
+			rows: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+				{Name: "world"},
+			},
+			expected: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("group/hello failed 4 times since 123: boom")},
+				{Name: "world"},
 			},
-			failOpen:  1,
-			passClose: 3,
 		},
 		{
-			name: "flakes do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 6,
+			name: "row templates override the group",
+			group: &configpb.TestGroup{
+				Name:                 "group",
+				AlertMessageTemplate: "{{.Row}} failed",
+				RowAlertThresholds: []*configpb.TestGroup_RowAlertThreshold{
+					{RowName: "world", AlertMessageTemplate: "page {{.Group}}: {{.Row}}"},
 				},
 			},
-			failOpen: 1,
+			rows: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+				{Name: "world", AlertInfo: alert("boom")},
+			},
+			expected: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("hello failed")},
+				{Name: "world", AlertInfo: alert("page group: world")},
+			},
 		},
 		{
-			name: "intermittent failures do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 2,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 2,
+			name: "row template without a group template",
+			group: &configpb.TestGroup{
+				Name: "group",
+				RowAlertThresholds: []*configpb.TestGroup_RowAlertThreshold{
+					{RowName: "world", AlertMessageTemplate: "{{.Row}} broke"},
 				},
 			},
-			failOpen: 3,
+			rows: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+				{Name: "world", AlertInfo: alert("boom")},
+			},
+			expected: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+				{Name: "world", AlertInfo: alert("world broke")},
+			},
 		},
 		{
-			name: "new failures alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 3,
-					int32(statuspb.TestStatus_PASS), 3,
+			name: "ignore bad templates",
+			group: &configpb.TestGroup{
+				Name:                 "group",
+				AlertMessageTemplate: "{{.Row",
+				RowAlertThresholds: []*configpb.TestGroup_RowAlertThreshold{
+					{RowName: "world", AlertMessageTemplate: "{{.Missing}}"},
 				},
-				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
-				CellIds:  []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
 			},
-			failOpen: 3,
-			expected: alertInfo(3, "no", "very wrong", "no", columns[2], columns[0], columns[3]),
+			rows: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+				{Name: "world", AlertInfo: alert("boom")},
+			},
+			expected: []*statepb.Row{
+				{Name: "hello", AlertInfo: alert("boom")},
+				{Name: "world", AlertInfo: alert("boom")},
+			},
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateAlerts(logrus.WithField("name", tc.name), tc.group, tc.rows)
+			if diff := cmp.Diff(tc.expected, tc.rows, protocmp.Transform()); diff != "" {
+				t.Errorf("templateAlerts() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAlertMixedResults(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	pass := Cell{Result: statuspb.TestStatus_PASS}
+	fail := Cell{Result: statuspb.TestStatus_FAIL, Message: "boom"}
+	cases := []struct {
+		name   string
+		policy configpb.TestGroup_RetryPolicy
+		flaky  configpb.TestGroup_FlakyAlert
+		alert  bool
+	}{
 		{
-			name: "rows without cell IDs can alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 3,
-					int32(statuspb.TestStatus_PASS), 3,
-				},
-				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
-			},
-			failOpen: 3,
-			expected: alertInfo(3, "no", "", "", columns[2], columns[0], columns[3]),
+			name: "mixed runs are flaky, which do not alert",
 		},
 		{
-			name: "too few passes do not close",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 2,
-					int32(statuspb.TestStatus_FAIL), 4,
-				},
-				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
-			},
-			failOpen:  1,
-			passClose: 3,
-			expected:  alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil),
+			name:  "mixed runs alert when flakes count as failures",
+			flaky: configpb.TestGroup_FLAKY_ALERT_FAIL,
+			alert: true,
 		},
 		{
-			name: "flakes do not close",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 2,
-					int32(statuspb.TestStatus_FAIL), 4,
-				},
-				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
-			},
-			failOpen: 1,
-			expected: alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil),
+			name:  "ignored flakes do not alert",
+			flaky: configpb.TestGroup_FLAKY_ALERT_IGNORE,
 		},
 		{
-			name: "count failures after flaky passes",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_FLAKY), 1,
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 2,
-				},
-				Messages: []string{"nope", "no", "buu", "wrong", "this one", "hi"},
-				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job", "hi"},
-			},
-			failOpen:  2,
-			passClose: 2,
-			expected:  alertInfo(4, "this one", "hi", "good job", columns[5], columns[4], nil),
+			name:   "mixed runs fail under the worst result",
+			policy: configpb.TestGroup_RETRY_POLICY_WORST_RESULT,
+			alert:  true,
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cells []cell
+			for range columns {
+				c := coalesceCells(tc.policy, pass, fail)
+				if tc.policy == configpb.TestGroup_RETRY_POLICY_FLAKY_IF_MIXED && c.Result != statuspb.TestStatus_FLAKY {
+					t.Fatalf("coalesceCells() got %s, want %s", c.Result, statuspb.TestStatus_FLAKY)
+				}
+				cells = append(cells, c)
+			}
+			row := setupRow(&statepb.Row{Name: "hello", Id: "hello"}, cells...)
+			actual := alertRow(columns, row, 3, 1, 0, 0, false, tc.flaky, buildID)
+			if got := actual != nil; got != tc.alert {
+				t.Errorf("alertRow() got alert %t, want %t: %v", got, tc.alert, actual)
+			}
+		})
+	}
+}
+
+func TestGroupAlerts(t *testing.T) {
+	alert := func(msg string, failures int32, failed int64) *statepb.AlertInfo {
+		return &statepb.AlertInfo{
+			FailCount:      failures,
+			FailBuildId:    fmt.Sprint(failed),
+			FailTime:       &timestamp.Timestamp{Seconds: failed},
+			FailureMessage: msg,
+		}
+	}
+	cases := []struct {
+		name     string
+		rows     []*statepb.Row
+		minRows  int
+		expected []*statepb.AlertGroup
+	}{
 		{
-			name: "close alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 5,
-				},
+			name: "basically works",
+			rows: []*statepb.Row{
+				{Name: "a", AlertInfo: alert("boom", 1, 10)},
+				{Name: "b", AlertInfo: alert("boom", 1, 10)},
 			},
-			failOpen: 1,
 		},
 		{
-			name: "track through empty results",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_FAIL), 4,
+			name: "group rows failing with the same message",
+			rows: []*statepb.Row{
+				{Name: "c", AlertInfo: alert("infra outage", 2, 20)},
+				{Name: "a", AlertInfo: alert("infra  outage\n", 3, 10)},
+				{Name: "other", AlertInfo: alert("something else", 1, 5)},
+				{Name: "passing"},
+				{Name: "b", AlertInfo: alert(" infra outage", 4, 30)},
+			},
+			minRows: 2,
+			expected: []*statepb.AlertGroup{
+				{
+					Alert: alert("infra outage", 9, 10),
+					Rows:  []string{"a", "b", "c"},
 				},
-				Messages: []string{"yay" /*no result */, "no", "buu", "wrong", "nono"},
-				CellIds:  []string{"yay-cell" /*no result */, "no", "buzz", "wrong2", "nada"},
 			},
-			failOpen:  5,
-			passClose: 2,
-			expected:  alertInfo(5, "yay", "nada", "yay-cell", columns[5], columns[0], nil),
 		},
 		{
-			name: "track passes through empty results",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 3,
+			name: "require enough rows",
+			rows: []*statepb.Row{
+				{Name: "a", AlertInfo: alert("boom", 1, 10)},
+				{Name: "b", AlertInfo: alert("boom", 1, 10)},
+				{Name: "c", AlertInfo: alert("bang", 1, 10)},
+				{Name: "d", AlertInfo: alert("bang", 1, 10)},
+				{Name: "e", AlertInfo: alert("bang", 1, 10)},
+			},
+			minRows: 3,
+			expected: []*statepb.AlertGroup{
+				{
+					Alert: alert("bang", 3, 10),
+					Rows:  []string{"c", "d", "e"},
 				},
 			},
-			failOpen:  1,
-			passClose: 2,
 		},
 		{
-			name: "running cells advance compressed index",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_RUNNING), 1,
-					int32(statuspb.TestStatus_FAIL), 5,
-				},
-				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
-				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
+			name: "ignore empty messages",
+			rows: []*statepb.Row{
+				{Name: "a", AlertInfo: alert("", 1, 10)},
+				{Name: "b", AlertInfo: alert(" ", 1, 10)},
 			},
-			failOpen: 1,
-			expected: alertInfo(5, "fail1-expected", "no5", "yep", columns[5], columns[1], nil),
+			minRows: 2,
 		},
 	}
 
 	for _, tc := range cases {
-		actual := alertRow(columns, &tc.row, tc.failOpen, tc.passClose)
-		if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
-			t.Errorf("alertRow() not as expected (-want, +got): %s", diff)
-		}
+		t.Run(tc.name, func(t *testing.T) {
+			actual := groupAlerts(tc.rows, tc.minRows)
+			if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+				t.Errorf("groupAlerts() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRefreshAlertGroups(t *testing.T) {
+	alert := func(failures int32) *statepb.AlertInfo {
+		return &statepb.AlertInfo{FailCount: failures, FailureMessage: "boom"}
+	}
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "a", AlertInfo: alert(1)},
+			{Name: "b", AlertInfo: alert(2)},
+			{Name: "c", AlertInfo: alert(4)},
+			{Name: "d", AlertInfo: &statepb.AlertInfo{FailCount: 8, FailureMessage: "bang"}},
+			{Name: "e", AlertInfo: &statepb.AlertInfo{FailCount: 16, FailureMessage: "bang"}},
+		},
+	}
+	grid.AlertGroups = groupAlerts(grid.Rows, 2)
+	grid.Rows[1].AlertInfo = nil // cooled down
+	grid.Rows[3].AlertInfo = nil
+
+	refreshAlertGroups(grid, 2)
+	expected := []*statepb.AlertGroup{
+		{
+			Alert: alert(5),
+			Rows:  []string{"a", "c"},
+		},
+	}
+	if diff := cmp.Diff(expected, grid.AlertGroups, protocmp.Transform()); diff != "" {
+		t.Errorf("refreshAlertGroups() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestAlertGridGroupsBeforeTemplates(t *testing.T) {
+	group := &configpb.TestGroup{
+		Name:                 "group",
+		NumFailuresToAlert:   1,
+		AlertGroupMinRows:    2,
+		AlertMessageTemplate: "{{.Row}}: {{.Message}}",
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1", Started: 1000}},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "hello", Id: "hello"}, cell{Result: statuspb.TestStatus_FAIL, Message: "boom"}),
+			setupRow(&statepb.Row{Name: "world", Id: "world"}, cell{Result: statuspb.TestStatus_FAIL, Message: "boom"}),
+		},
+	}
+	alertGrid(logrus.WithField("test", t.Name()), group, grid, nil)
+	if n := len(grid.AlertGroups); n != 1 {
+		t.Fatalf("alertGrid() got %d alert groups, want 1: %v", n, grid.AlertGroups)
+	}
+	actual := grid.AlertGroups[0]
+	if got, want := actual.Alert.FailureMessage, "boom"; got != want {
+		t.Errorf("alertGrid() grouped message %q, want %q", got, want)
+	}
+	if diff := cmp.Diff([]string{"hello", "world"}, actual.Rows); diff != "" {
+		t.Errorf("alertGrid() got unexpected rows (-want +got):\n%s", diff)
+	}
+	if got, want := grid.Rows[0].AlertInfo.GetFailureMessage(), "hello: boom"; got != want {
+		t.Errorf("alertGrid() rendered row message %q, want %q", got, want)
+	}
+
+	group.DisableAlerts = true
+	alertGrid(logrus.WithField("test", t.Name()), group, grid, nil)
+	if len(grid.AlertGroups) > 0 {
+		t.Errorf("alertGrid() failed to clear disabled alert groups: %v", grid.AlertGroups)
 	}
 }
 
@@ -3490,6 +9949,123 @@ func TestBuildID(t *testing.T) {
 	}
 }
 
+func TestBuildIDSelector(t *testing.T) {
+	headers := []*configpb.TestGroup_ColumnHeader{
+		{ConfigurationValue: "Commit"},
+		{ConfigurationValue: "build-id"},
+	}
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		col      *statepb.Column
+		expected string
+	}{
+		{
+			name:     "nil column",
+			group:    &configpb.TestGroup{},
+			expected: "",
+		},
+		{
+			name:  "default to the first extra",
+			group: &configpb.TestGroup{ColumnHeader: headers},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{"commit", "id"},
+			},
+			expected: "commit",
+		},
+		{
+			name: "select extra by index",
+			group: &configpb.TestGroup{
+				ColumnHeader: headers,
+				BuildIdSelector: &configpb.TestGroup_BuildIdSelector{
+					HeaderIndex: 1,
+				},
+			},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{"commit", "id"},
+			},
+			expected: "id",
+		},
+		{
+			name: "select extra by metadata key",
+			group: &configpb.TestGroup{
+				ColumnHeader: headers,
+				BuildIdSelector: &configpb.TestGroup_BuildIdSelector{
+					HeaderIndex:        0,
+					ConfigurationValue: "build-id",
+				},
+			},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{"commit", "id"},
+			},
+			expected: "id",
+		},
+		{
+			name: "fall back to build for an unknown metadata key",
+			group: &configpb.TestGroup{
+				ColumnHeader: headers,
+				BuildIdSelector: &configpb.TestGroup_BuildIdSelector{
+					ConfigurationValue: "unknown",
+				},
+			},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{"commit", "id"},
+			},
+			expected: "build",
+		},
+		{
+			name: "fall back to build for missing metadata",
+			group: &configpb.TestGroup{
+				ColumnHeader: headers,
+				BuildIdSelector: &configpb.TestGroup_BuildIdSelector{
+					ConfigurationValue: "build-id",
+				},
+			},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{"commit", "missing"},
+			},
+			expected: "build",
+		},
+		{
+			name: "fall back to build for an out of range index",
+			group: &configpb.TestGroup{
+				BuildIdSelector: &configpb.TestGroup_BuildIdSelector{
+					HeaderIndex: 3,
+				},
+			},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{"commit", "id"},
+			},
+			expected: "build",
+		},
+		{
+			name: "fall back to build for an empty value",
+			group: &configpb.TestGroup{
+				BuildIdSelector: &configpb.TestGroup_BuildIdSelector{},
+			},
+			col: &statepb.Column{
+				Build: "build",
+				Extra: []string{""},
+			},
+			expected: "build",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := buildIDSelector(tc.group)(tc.col); actual != tc.expected {
+				t.Errorf("buildIDSelector() got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestStamp(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+var (
+	errResults   = errors.New("results do not match columns")
+	errCellIDs   = errors.New("cell ids do not match results")
+	errMessages  = errors.New("messages do not match results")
+	errIcons     = errors.New("icons do not match results")
+	errProps     = errors.New("user properties do not match results")
+	errArtifacts = errors.New("artifact urls do not match results")
+	errMetric    = errors.New("malformed metric")
+	errMismatch  = errors.New("downloaded grid does not match uploaded grid")
+)
+
+// VerifyGrid returns an error if the grid is not self-consistent.
+//
+// Specifically each row must:
+// * have run-length-encoded results spanning every column.
+// * have a message (or interned message index), icon and (when present) cell id, user property and artifact url for each non-empty result.
+// * have metrics with well-formed, in-bounds sparse indices matching its values.
+func VerifyGrid(grid *statepb.Grid) error {
+	cols := len(grid.Columns)
+	for _, row := range grid.Rows {
+		if err := verifyRow(row, cols); err != nil {
+			return fmt.Errorf("row %q: %w", row.Name, err)
+		}
+		for _, idx := range row.MessageIndices {
+			if idx < 0 || int(idx) >= len(grid.MessageTable) {
+				return fmt.Errorf("row %q: %w: index %d beyond %d interned messages", row.Name, errMessages, idx, len(grid.MessageTable))
+			}
+		}
+	}
+	return nil
+}
+
+func verifyRow(row *statepb.Row, cols int) error {
+	if len(row.Results)%2 != 0 {
+		return fmt.Errorf("%w: odd length %d", errResults, len(row.Results))
+	}
+	var total, filled int
+	for i := 0; i < len(row.Results); i += 2 {
+		n := int(row.Results[i+1])
+		if n <= 0 {
+			return fmt.Errorf("%w: non-positive count %d at %d", errResults, n, i+1)
+		}
+		total += n
+		if row.Results[i] != int32(emptyCell.Result) {
+			filled += n
+		}
+	}
+	if total != cols {
+		return fmt.Errorf("%w: %d results for %d columns", errResults, total, cols)
+	}
+	if n := len(row.CellIds); n > 0 && n != filled {
+		return fmt.Errorf("%w: %d ids for %d results", errCellIDs, n, filled)
+	}
+	n := len(row.Messages)
+	if len(row.MessageIndices) > 0 {
+		n = len(row.MessageIndices)
+	}
+	if n != filled {
+		return fmt.Errorf("%w: %d messages for %d results", errMessages, n, filled)
+	}
+	if n := len(row.Icons); n != filled {
+		return fmt.Errorf("%w: %d icons for %d results", errIcons, n, filled)
+	}
+	if n := len(row.UserProperty); n > 0 && n != filled {
+		return fmt.Errorf("%w: %d properties for %d results", errProps, n, filled)
+	}
+	if n := len(row.ArtifactUrls); n > 0 && n != filled {
+		return fmt.Errorf("%w: %d artifact urls for %d results", errArtifacts, n, filled)
+	}
+	for _, m := range row.Metrics {
+		if err := verifyMetric(m, cols); err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+// verifyMetric ensures the metric is well-formed and its indices are in bounds.
+func verifyMetric(m *statepb.Metric, cols int) error {
+	if err := validateMetric(m); err != nil {
+		return err
+	}
+	if n := len(m.Indices); n > 0 {
+		if end := m.Indices[n-2] + m.Indices[n-1]; int(end) > cols {
+			return fmt.Errorf("%w: index %d beyond %d columns", errMetric, end-1, cols)
+		}
+	}
+	return nil
+}
+
+// validateMetric ensures the [start, length, ...] indices are ordered, non-overlapping and match the values.
+func validateMetric(m *statepb.Metric) error {
+	if len(m.Indices)%2 != 0 {
+		return fmt.Errorf("%w: odd index length %d", errMetric, len(m.Indices))
+	}
+	var end, values int32
+	for i := 0; i < len(m.Indices); i += 2 {
+		start, n := m.Indices[i], m.Indices[i+1]
+		if n <= 0 {
+			return fmt.Errorf("%w: non-positive length %d at %d", errMetric, n, i+1)
+		}
+		if start < end {
+			return fmt.Errorf("%w: index %d overlaps previous range ending at %d", errMetric, start, end)
+		}
+		end = start + n
+		values += n
+	}
+	if int(values) != len(m.Values) {
+		return fmt.Errorf("%w: %d values for %d indices", errMetric, len(m.Values), values)
+	}
+	return nil
+}
+
+// repairMetric keeps the well-formed prefix of the metric, dropping any trailing indices or values.
+//
+// A range with fewer values than its length is shortened to the remaining values.
+// Returns true if the metric changed.
+func repairMetric(m *statepb.Metric) bool {
+	var end, values int32
+	var keep int
+	var changed bool
+	for keep+1 < len(m.Indices) {
+		start, n := m.Indices[keep], m.Indices[keep+1]
+		if n <= 0 || start < end {
+			break
+		}
+		if remain := int32(len(m.Values)) - values; n > remain {
+			if remain <= 0 {
+				break
+			}
+			n = remain
+			m.Indices[keep+1] = n
+			changed = true
+		}
+		end = start + n
+		values += n
+		keep += 2
+	}
+	if keep != len(m.Indices) || int(values) != len(m.Values) {
+		changed = true
+	}
+	m.Indices = m.Indices[:keep]
+	m.Values = m.Values[:values]
+	return changed
+}
+
+// verifyUpload downloads the grid at path, ensuring it is readable, consistent and matches what we wrote.
+func verifyUpload(ctx context.Context, opener gcs.Opener, path gcs.Path, wrote *statepb.Grid) error {
+	grid, _, err := gcs.DownloadGrid(ctx, opener, path)
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+	if err := VerifyGrid(grid); err != nil {
+		return err
+	}
+	if len(grid.Columns) != len(wrote.Columns) || len(grid.Rows) != len(wrote.Rows) {
+		return fmt.Errorf("%w: got %d columns and %d rows, want %d and %d", errMismatch, len(grid.Columns), len(grid.Rows), len(wrote.Columns), len(wrote.Rows))
+	}
+	return nil
+}
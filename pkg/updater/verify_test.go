@@ -0,0 +1,358 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestVerifyGrid(t *testing.T) {
+	columns := []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}}
+	pass := cell{Result: statuspb.TestStatus_PASS, CellID: "id", Metrics: map[string]float64{"m": 1}}
+	fail := cell{Result: statuspb.TestStatus_FAIL, Message: "boom", Icon: "F"}
+	good := func() *statepb.Row {
+		return setupRow(&statepb.Row{Name: "good", Id: "good"}, pass, emptyCell, fail)
+	}
+	cases := []struct {
+		name     string
+		interned bool
+		row      func(*statepb.Row)
+		expected error
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "odd results",
+			row: func(r *statepb.Row) {
+				r.Results = r.Results[:len(r.Results)-1]
+			},
+			expected: errResults,
+		},
+		{
+			name: "too few results",
+			row: func(r *statepb.Row) {
+				r.Results[len(r.Results)-1]--
+			},
+			expected: errResults,
+		},
+		{
+			name: "too many results",
+			row: func(r *statepb.Row) {
+				r.Results[1]++
+			},
+			expected: errResults,
+		},
+		{
+			name: "missing cell id",
+			row: func(r *statepb.Row) {
+				r.CellIds = r.CellIds[1:]
+			},
+			expected: errCellIDs,
+		},
+		{
+			name: "cell ids are optional",
+			row: func(r *statepb.Row) {
+				r.CellIds = nil
+			},
+		},
+		{
+			name: "extra message",
+			row: func(r *statepb.Row) {
+				r.Messages = append(r.Messages, "extra")
+			},
+			expected: errMessages,
+		},
+		{
+			name: "missing icon",
+			row: func(r *statepb.Row) {
+				r.Icons = nil
+			},
+			expected: errIcons,
+		},
+		{
+			name: "extra user property",
+			row: func(r *statepb.Row) {
+				r.UserProperty = append(r.UserProperty, "extra")
+			},
+			expected: errProps,
+		},
+		{
+			name: "odd metric indices",
+			row: func(r *statepb.Row) {
+				r.Metrics[0].Indices = []int32{0}
+			},
+			expected: errMetric,
+		},
+		{
+			name: "overlapping metric indices",
+			row: func(r *statepb.Row) {
+				r.Metrics[0].Indices = []int32{0, 2, 1, 1}
+				r.Metrics[0].Values = []float64{1, 2, 3}
+			},
+			expected: errMetric,
+		},
+		{
+			name: "metric indices beyond columns",
+			row: func(r *statepb.Row) {
+				r.Metrics[0].Indices = []int32{2, 2}
+				r.Metrics[0].Values = []float64{1, 2}
+			},
+			expected: errMetric,
+		},
+		{
+			name: "too many metric values",
+			row: func(r *statepb.Row) {
+				r.Metrics[0].Values = append(r.Metrics[0].Values, 2)
+			},
+			expected: errMetric,
+		},
+		{
+			name:     "interned messages",
+			interned: true,
+		},
+		{
+			name:     "missing interned message",
+			interned: true,
+			row: func(r *statepb.Row) {
+				r.MessageIndices = r.MessageIndices[1:]
+			},
+			expected: errMessages,
+		},
+		{
+			name:     "interned message beyond table",
+			interned: true,
+			row: func(r *statepb.Row) {
+				r.MessageIndices[0] = 100
+			},
+			expected: errMessages,
+		},
+		{
+			name: "too few metric values",
+			row: func(r *statepb.Row) {
+				r.Metrics[0].Values = nil
+			},
+			expected: errMetric,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := good()
+			grid := &statepb.Grid{
+				Columns: columns,
+				Rows:    []*statepb.Row{good(), row},
+			}
+			if tc.interned {
+				gcs.InternMessages(grid)
+			}
+			if tc.row != nil {
+				tc.row(row)
+			}
+			err := VerifyGrid(grid)
+			switch {
+			case tc.expected == nil:
+				if err != nil {
+					t.Errorf("VerifyGrid() got unexpected error: %v", err)
+				}
+			case !errors.Is(err, tc.expected):
+				t.Errorf("VerifyGrid() got error %v, want %v", err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRepairMetric(t *testing.T) {
+	cases := []struct {
+		name     string
+		metric   *statepb.Metric
+		expected *statepb.Metric
+		changed  bool
+	}{
+		{
+			name:     "empty metric is fine",
+			metric:   &statepb.Metric{},
+			expected: &statepb.Metric{},
+		},
+		{
+			name: "valid metric is unchanged",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 2, 5, 1},
+				Values:  []float64{1, 2, 3},
+			},
+			expected: &statepb.Metric{
+				Indices: []int32{0, 2, 5, 1},
+				Values:  []float64{1, 2, 3},
+			},
+		},
+		{
+			name: "drop odd index",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 2, 5},
+				Values:  []float64{1, 2},
+			},
+			expected: &statepb.Metric{
+				Indices: []int32{0, 2},
+				Values:  []float64{1, 2},
+			},
+			changed: true,
+		},
+		{
+			name: "drop overlapping indices",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 2, 1, 1, 4, 1},
+				Values:  []float64{1, 2, 3, 4},
+			},
+			expected: &statepb.Metric{
+				Indices: []int32{0, 2},
+				Values:  []float64{1, 2},
+			},
+			changed: true,
+		},
+		{
+			name: "drop non-positive length",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 1, 3, 0},
+				Values:  []float64{1},
+			},
+			expected: &statepb.Metric{
+				Indices: []int32{0, 1},
+				Values:  []float64{1},
+			},
+			changed: true,
+		},
+		{
+			name: "drop extra values",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 2},
+				Values:  []float64{1, 2, 3, 4},
+			},
+			expected: &statepb.Metric{
+				Indices: []int32{0, 2},
+				Values:  []float64{1, 2},
+			},
+			changed: true,
+		},
+		{
+			name: "shorten ranges missing values",
+			metric: &statepb.Metric{
+				Indices: []int32{0, 2, 4, 3, 9, 1},
+				Values:  []float64{1, 2, 3},
+			},
+			expected: &statepb.Metric{
+				Indices: []int32{0, 2, 4, 1},
+				Values:  []float64{1, 2, 3},
+			},
+			changed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := repairMetric(tc.metric)
+			if changed != tc.changed {
+				t.Errorf("repairMetric() got changed %t, want %t", changed, tc.changed)
+			}
+			if diff := cmp.Diff(tc.expected, tc.metric, protocmp.Transform()); diff != "" {
+				t.Errorf("repairMetric() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if err := validateMetric(tc.metric); err != nil {
+				t.Errorf("validateMetric() after repair got unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyUpload(t *testing.T) {
+	path := newPathOrDie("gs://bucket/grid")
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows: []*statepb.Row{
+			setupRow(&statepb.Row{Name: "hello", Id: "hello"}, cell{Result: statuspb.TestStatus_PASS}),
+		},
+	}
+	cases := []struct {
+		name     string
+		object   *fakeObject
+		err      bool
+		expected error
+	}{
+		{
+			name:   "basically works",
+			object: &fakeObject{Data: string(mustGrid(grid))},
+		},
+		{
+			name: "missing object",
+			err:  true,
+		},
+		{
+			name: "inconsistent object",
+			object: &fakeObject{
+				Data: string(mustGrid(&statepb.Grid{
+					Columns: grid.Columns,
+					Rows: []*statepb.Row{
+						{Name: "hello", Results: []int32{int32(statuspb.TestStatus_PASS), 2}},
+					},
+				})),
+			},
+			err:      true,
+			expected: errResults,
+		},
+		{
+			name: "wrong size object",
+			object: &fakeObject{
+				Data: string(mustGrid(&statepb.Grid{Columns: grid.Columns})),
+			},
+			err:      true,
+			expected: errMismatch,
+		},
+		{
+			name:   "corrupt object",
+			object: &fakeObject{Data: "garbage"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := fakeOpener{}
+			if tc.object != nil {
+				opener[path] = *tc.object
+			}
+			err := verifyUpload(context.Background(), opener, path, grid)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("verifyUpload() got unexpected error: %v", err)
+				} else if tc.expected != nil && !errors.Is(err, tc.expected) {
+					t.Errorf("verifyUpload() got error %v, want %v", err, tc.expected)
+				}
+			case tc.err:
+				t.Error("verifyUpload() failed to return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// DefaultWebhookTemplate renders each alert notification as a JSON object.
+//
+// Templates receive an AlertNotification. The json function quotes a value.
+const DefaultWebhookTemplate = `{"group":{{json .Group}},"row":{{json .Row}},"transition":{{json .Transition}},"fail_build":{{json .FailBuild}},"message":{{json .Message}}}`
+
+// Webhook POSTs a templated JSON payload to a URL for each alert notification.
+type Webhook struct {
+	url     string
+	tmpl    *template.Template
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// NewWebhook returns a notifier which POSTs the payload template to url.
+//
+// Uses DefaultWebhookTemplate when tmpl is empty.
+// Each POST times out after timeout, retrying failed ones up to retries times.
+func NewWebhook(url, tmpl string, timeout time.Duration, retries int) (*Webhook, error) {
+	if tmpl == "" {
+		tmpl = DefaultWebhookTemplate
+	}
+	t, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			buf, err := json.Marshal(v)
+			return string(buf), err
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return &Webhook{
+		url:     url,
+		tmpl:    t,
+		client:  &http.Client{Timeout: timeout},
+		retries: retries,
+		backoff: time.Second,
+	}, nil
+}
+
+// Notify POSTs a payload for each notification.
+func (w *Webhook) Notify(ctx context.Context, notes []AlertNotification) error {
+	var errs *multierror.Error
+	for _, n := range notes {
+		var buf bytes.Buffer
+		if err := w.tmpl.Execute(&buf, n); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%s: render: %w", n.Row, err))
+			continue
+		}
+		if err := w.post(ctx, buf.Bytes()); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%s: %w", n.Row, err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// post sends the payload, retrying server errors with exponential backoff.
+func (w *Webhook) post(ctx context.Context, payload []byte) error {
+	var err error
+	for attempt := 0; attempt <= w.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w (after %v)", ctx.Err(), err)
+			case <-time.After(w.backoff << (attempt - 1)):
+			}
+		}
+		var retry bool
+		if retry, err = w.postOnce(ctx, payload); err == nil || !retry {
+			return err
+		}
+	}
+	return fmt.Errorf("%d attempts: %w", w.retries+1, err)
+}
+
+// postOnce sends the payload, returning whether to retry any error.
+func (w *Webhook) postOnce(ctx context.Context, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	switch code := resp.StatusCode; {
+	case code >= 200 && code < 300:
+		return false, nil
+	case code == http.StatusTooManyRequests || code >= 500:
+		return true, fmt.Errorf("post: %s", resp.Status)
+	default:
+		return false, fmt.Errorf("post: %s", resp.Status)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhook(t *testing.T) {
+	notes := []AlertNotification{
+		{
+			Group:      "hello",
+			Row:        "broken",
+			Transition: AlertNew,
+			FailBuild:  "12",
+			Message:    `expected "yes"`,
+		},
+		{
+			Group:      "hello",
+			Row:        "fixed",
+			Transition: AlertResolved,
+			FailBuild:  "10",
+		},
+	}
+	cases := []struct {
+		name     string
+		tmpl     string
+		retries  int
+		statuses []int // returned in order, then 200
+		expected []string
+		err      bool
+	}{
+		{
+			name: "basically works",
+			expected: []string{
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"fixed","transition":"resolved","fail_build":"10","message":""}`,
+			},
+		},
+		{
+			name: "custom template",
+			tmpl: `{"text":{{json (printf "%s/%s is %s" .Group .Row .Transition)}}}`,
+			expected: []string{
+				`{"text":"hello/broken is new"}`,
+				`{"text":"hello/fixed is resolved"}`,
+			},
+		},
+		{
+			name:     "retry server errors",
+			retries:  2,
+			statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests},
+			expected: []string{
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"fixed","transition":"resolved","fail_build":"10","message":""}`,
+			},
+		},
+		{
+			name:     "give up after retries",
+			retries:  1,
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway},
+			expected: []string{
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"fixed","transition":"resolved","fail_build":"10","message":""}`,
+			},
+			err: true,
+		},
+		{
+			name:     "do not retry client errors",
+			retries:  2,
+			statuses: []int{http.StatusBadRequest},
+			expected: []string{
+				`{"group":"hello","row":"broken","transition":"new","fail_build":"12","message":"expected \"yes\""}`,
+				`{"group":"hello","row":"fixed","transition":"resolved","fail_build":"10","message":""}`,
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var actual []string
+			statuses := tc.statuses
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+				if r.Method != http.MethodPost {
+					t.Errorf("Notify() sent a %s, want POST", r.Method)
+				}
+				if got, want := r.Header.Get("Content-Type"), "application/json"; got != want {
+					t.Errorf("Notify() sent Content-Type %q, want %q", got, want)
+				}
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("Failed to read body: %v", err)
+				}
+				actual = append(actual, string(body))
+				if len(statuses) > 0 {
+					w.WriteHeader(statuses[0])
+					statuses = statuses[1:]
+				}
+			}))
+			defer server.Close()
+
+			webhook, err := NewWebhook(server.URL, tc.tmpl, time.Minute, tc.retries)
+			if err != nil {
+				t.Fatalf("NewWebhook() got unexpected error: %v", err)
+			}
+			webhook.backoff = time.Millisecond
+
+			err = webhook.Notify(context.Background(), notes)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("Notify() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("Notify() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("Notify() sent unexpected payloads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	webhook, err := NewWebhook(server.URL, "", 10*time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("NewWebhook() got unexpected error: %v", err)
+	}
+	if err := webhook.Notify(context.Background(), []AlertNotification{{Row: "slow"}}); err == nil {
+		t.Error("Notify() to a slow server failed to return an error")
+	}
+}
+
+func TestNewWebhookBadTemplate(t *testing.T) {
+	if _, err := NewWebhook("https://example.com", "{{.Row", time.Second, 0); err == nil {
+		t.Error("NewWebhook() with a bad template failed to return an error")
+	}
+}
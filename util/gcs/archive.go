@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// ReadArchive downloads the archive and returns a Downloader for the result files inside it.
+//
+// Files are presented as objects under dir, so a build whose results are stored in a
+// single archive can be read as if each file were uploaded individually.
+// Only metadata (.json) and junit files are extracted, and they are held in memory.
+//
+// The format is determined by the archive extension: .tar, .tar.gz, .tgz or .zip.
+func ReadArchive(ctx context.Context, opener Opener, archive, dir Path) (Downloader, error) {
+	r, _, err := opener.Open(ctx, archive)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+
+	ac := archiveClient{files: map[string][]byte{}}
+	keep := func(name string) (string, bool) {
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		obj, err := dir.ResolveReference(&url.URL{Path: name})
+		if err != nil {
+			return "", false
+		}
+		o := obj.Object()
+		return o, strings.HasSuffix(o, ".json") || re.MatchString(o)
+	}
+
+	switch name := archive.Object(); {
+	case strings.HasSuffix(name, ".zip"):
+		buf, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+		if err != nil {
+			return nil, fmt.Errorf("zip: %w", err)
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			obj, ok := keep(f.Name)
+			if !ok {
+				continue
+			}
+			fr, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("open %s: %w", f.Name, err)
+			}
+			buf, err := ioutil.ReadAll(fr)
+			fr.Close()
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", f.Name, err)
+			}
+			ac.files[obj] = buf
+		}
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer gr.Close()
+		if err := ac.readTar(gr, keep); err != nil {
+			return nil, err
+		}
+	case strings.HasSuffix(name, ".tar"):
+		if err := ac.readTar(r, keep); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown archive format: %s", name)
+	}
+
+	ac.bucket = dir.Bucket()
+	for name := range ac.files {
+		ac.names = append(ac.names, name)
+	}
+	sort.Strings(ac.names)
+	return ac, nil
+}
+
+// archiveClient serves the files extracted from an archive.
+type archiveClient struct {
+	bucket string
+	files  map[string][]byte // object name: contents
+	names  []string          // sorted object names
+}
+
+func (ac archiveClient) readTar(r io.Reader, keep func(string) (string, bool)) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("tar: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		obj, ok := keep(hdr.Name)
+		if !ok {
+			continue
+		}
+		buf, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		ac.files[obj] = buf
+	}
+}
+
+// Open returns a reader for the extracted file.
+func (ac archiveClient) Open(ctx context.Context, p Path) (io.ReadCloser, *storage.ReaderObjectAttrs, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	buf, ok := ac.files[p.Object()]
+	if p.Bucket() != ac.bucket || !ok {
+		return nil, nil, fmt.Errorf("%s: %w", p, storage.ErrObjectNotExist)
+	}
+	attrs := storage.ReaderObjectAttrs{Size: int64(len(buf))}
+	return ioutil.NopCloser(bytes.NewReader(buf)), &attrs, nil
+}
+
+// Objects lists the extracted files under the prefix.
+func (ac archiveClient) Objects(_ context.Context, prefix Path, delimiter, start string) Iterator {
+	var objs []storage.ObjectAttrs
+	if prefix.Bucket() == ac.bucket {
+		p := prefix.Object()
+		seen := map[string]bool{}
+		for _, name := range ac.names {
+			if !strings.HasPrefix(name, p) || name < start {
+				continue
+			}
+			if delimiter != "" {
+				if idx := strings.Index(name[len(p):], delimiter); idx >= 0 {
+					dir := name[:len(p)+idx+len(delimiter)]
+					if !seen[dir] {
+						seen[dir] = true
+						objs = append(objs, storage.ObjectAttrs{Bucket: ac.bucket, Prefix: dir})
+					}
+					continue
+				}
+			}
+			objs = append(objs, storage.ObjectAttrs{
+				Bucket: ac.bucket,
+				Name:   name,
+				Size:   int64(len(ac.files[name])),
+			})
+		}
+	}
+	return &archiveIterator{objs: objs}
+}
+
+type archiveIterator struct {
+	objs []storage.ObjectAttrs
+}
+
+func (ai *archiveIterator) Next() (*storage.ObjectAttrs, error) {
+	if len(ai.objs) == 0 {
+		return nil, iterator.Done
+	}
+	o := ai.objs[0]
+	ai.objs = ai.objs[1:]
+	return &o, nil
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+)
+
+func makeTar(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(data)); err != nil {
+			t.Fatalf("tar write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func makeZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		if _, err := w.Write([]byte(data)); err != nil {
+			t.Fatalf("zip write: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func gzipBytes(t *testing.T, buf []byte) []byte {
+	var out bytes.Buffer
+	zw := gzip.NewWriter(&out)
+	if _, err := zw.Write(buf); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestReadArchive(t *testing.T) {
+	files := map[string]string{
+		"started.json":                 `{"timestamp": 1}`,
+		"finished.json":                `{"timestamp": 2, "passed": true}`,
+		"./artifacts/junit_01.xml":     "<testsuites/>",
+		"artifacts/build-log.txt":      "ignored",
+		"artifacts/nested/junit_2.xml": "<testsuite/>",
+	}
+	cases := []struct {
+		name    string
+		archive string
+		data    []byte
+		err     bool
+	}{
+		{
+			name:    "tar",
+			archive: "results.tar",
+			data:    makeTar(t, files),
+		},
+		{
+			name:    "tar.gz",
+			archive: "results.tar.gz",
+			data:    gzipBytes(t, makeTar(t, files)),
+		},
+		{
+			name:    "tgz",
+			archive: "results.tgz",
+			data:    gzipBytes(t, makeTar(t, files)),
+		},
+		{
+			name:    "zip",
+			archive: "results.zip",
+			data:    makeZip(t, files),
+		},
+		{
+			name:    "unknown format",
+			archive: "results.rar",
+			data:    makeTar(t, files),
+			err:     true,
+		},
+		{
+			name:    "corrupt archive",
+			archive: "results.tar.gz",
+			data:    []byte("not gzip"),
+			err:     true,
+		},
+		{
+			name:    "missing archive",
+			archive: "results.tar",
+			err:     true,
+		},
+	}
+
+	dir := newPathOrDie("gs://bucket/logs/job/123/")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			archive := newPathOrDie("gs://bucket/logs/job/123/" + tc.archive)
+			opener := fakeOpener{}
+			if tc.data != nil {
+				opener[archive] = fakeObject{data: string(tc.data)}
+			}
+			client, err := ReadArchive(ctx, opener, archive, dir)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("ReadArchive() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("ReadArchive() failed to return an error")
+			}
+
+			var names []string
+			it := client.Objects(ctx, dir, "", "")
+			for {
+				attrs, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() got unexpected error: %v", err)
+				}
+				names = append(names, attrs.Name)
+			}
+			expectedNames := []string{
+				"logs/job/123/artifacts/junit_01.xml",
+				"logs/job/123/artifacts/nested/junit_2.xml",
+				"logs/job/123/finished.json",
+				"logs/job/123/started.json",
+			}
+			if diff := cmp.Diff(expectedNames, names); diff != "" {
+				t.Errorf("Objects() got unexpected diff (-want +got):\n%s", diff)
+			}
+
+			var prefixes []string
+			it = client.Objects(ctx, dir, "/", "")
+			for {
+				attrs, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() got unexpected error: %v", err)
+				}
+				prefixes = append(prefixes, attrs.Prefix+attrs.Name)
+			}
+			expectedPrefixes := []string{
+				"logs/job/123/artifacts/",
+				"logs/job/123/finished.json",
+				"logs/job/123/started.json",
+			}
+			if diff := cmp.Diff(expectedPrefixes, prefixes); diff != "" {
+				t.Errorf("Objects(delimiter) got unexpected diff (-want +got):\n%s", diff)
+			}
+
+			r, _, err := client.Open(ctx, newPathOrDie("gs://bucket/logs/job/123/finished.json"))
+			if err != nil {
+				t.Fatalf("Open() got unexpected error: %v", err)
+			}
+			buf, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() got unexpected error: %v", err)
+			}
+			if got, want := string(buf), files["finished.json"]; got != want {
+				t.Errorf("Open() got %q, want %q", got, want)
+			}
+
+			_, _, err = client.Open(ctx, newPathOrDie("gs://bucket/logs/job/123/artifacts/build-log.txt"))
+			if err == nil || !errors.Is(err, storage.ErrObjectNotExist) {
+				t.Errorf("Open(non-result) got %v, want not exist", err)
+			}
+		})
+	}
+}
@@ -28,6 +28,27 @@ type Uploader interface {
 	Upload(context.Context, Path, []byte, bool, string) (*storage.ObjectAttrs, error)
 }
 
+// A MetadataUploader uploads content along with custom object metadata.
+type MetadataUploader interface {
+	UploadWithMetadata(context.Context, Path, []byte, bool, string, map[string]string) (*storage.ObjectAttrs, error)
+}
+
+// A StreamUploader uploads the contents of a reader, avoiding the need to buffer them.
+type StreamUploader interface {
+	UploadFrom(context.Context, Path, io.Reader, bool, string, map[string]string) (*storage.ObjectAttrs, error)
+}
+
+// A StagingUploader writes content in two phases, so readers never observe a partial object.
+type StagingUploader interface {
+	// Stage writes the content to the temporary object for path, removing it on failure.
+	Stage(ctx context.Context, path Path, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (Path, error)
+	// Finalize replaces path with the staged object and then removes the staged object.
+	//
+	// The existing object at path is unchanged when finalizing fails.
+	// The object at path is world readable when worldReadable is set.
+	Finalize(ctx context.Context, staged, path Path, worldReadable bool) (*storage.ObjectAttrs, error)
+}
+
 // Downloader can list files and open them for reading.
 type Downloader interface {
 	Lister
@@ -96,7 +117,15 @@ func (gc gcsClient) If(read, write *storage.Conditions) ConditionalClient {
 	}
 }
 
-func (gc gcsClient) clientFromPath(path Path) ConditionalClient {
+// uploadClient is a ConditionalClient which also supports every optional upload interface.
+type uploadClient interface {
+	ConditionalClient
+	MetadataUploader
+	StreamUploader
+	StagingUploader
+}
+
+func (gc gcsClient) clientFromPath(path Path) uploadClient {
 	if path.URL().Scheme == "gs" {
 		return gc.gcs
 	}
@@ -127,6 +156,30 @@ func (gc gcsClient) Upload(ctx context.Context, path Path, buf []byte, worldRead
 	return client.Upload(ctx, path, buf, worldReadable, cacheControl)
 }
 
+// UploadWithMetadata writes content and custom metadata to the given path.
+func (gc gcsClient) UploadWithMetadata(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	client := gc.clientFromPath(path)
+	return client.UploadWithMetadata(ctx, path, buf, worldReadable, cacheControl, meta)
+}
+
+// UploadFrom writes the content of the reader to the given path.
+func (gc gcsClient) UploadFrom(ctx context.Context, path Path, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	client := gc.clientFromPath(path)
+	return client.UploadFrom(ctx, path, r, worldReadable, cacheControl, meta)
+}
+
+// Stage writes the content of the reader to the temporary object for path.
+func (gc gcsClient) Stage(ctx context.Context, path Path, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (Path, error) {
+	client := gc.clientFromPath(path)
+	return client.Stage(ctx, path, r, worldReadable, cacheControl, meta)
+}
+
+// Finalize replaces path with the staged object.
+func (gc gcsClient) Finalize(ctx context.Context, staged, path Path, worldReadable bool) (*storage.ObjectAttrs, error) {
+	client := gc.clientFromPath(path)
+	return client.Finalize(ctx, staged, path, worldReadable)
+}
+
 // Stat returns object attributes for a given path.
 func (gc gcsClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
 	client := gc.clientFromPath(path)
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"cloud.google.com/go/storage"
@@ -86,12 +87,17 @@ func (cc ConditionalClient) Copy(ctx context.Context, from, to gcs.Path) (*stora
 
 // Upload writes content to the given path.
 func (cc ConditionalClient) Upload(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cache string) (*storage.ObjectAttrs, error) {
+	return cc.UploadWithMetadata(ctx, path, buf, worldRead, cache, nil)
+}
+
+// UploadWithMetadata writes content and metadata to the given path.
+func (cc ConditionalClient) UploadWithMetadata(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cache string, meta map[string]string) (*storage.ObjectAttrs, error) {
 	if err := cc.check(ctx, nil, &path); err != nil {
 		return nil, err
 	}
 
 	gen := cc.Uploader[path].Generation + 1
-	_, err := cc.UploadClient.Upload(ctx, path, buf, worldRead, cache)
+	_, err := cc.UploadClient.UploadWithMetadata(ctx, path, buf, worldRead, cache, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +108,32 @@ func (cc ConditionalClient) Upload(ctx context.Context, path gcs.Path, buf []byt
 	return u.Attrs(path), nil
 }
 
+// UploadFrom writes the content of the reader to the given path.
+func (cc ConditionalClient) UploadFrom(ctx context.Context, path gcs.Path, r io.Reader, worldRead bool, cache string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	return cc.UploadWithMetadata(ctx, path, buf, worldRead, cache, meta)
+}
+
+// Finalize replaces path with the staged object if path matches the write conditions.
+func (cc ConditionalClient) Finalize(ctx context.Context, staged, path gcs.Path, worldRead bool) (*storage.ObjectAttrs, error) {
+	if err := cc.check(ctx, nil, &path); err != nil {
+		delete(cc.Uploader, staged)
+		return nil, err
+	}
+
+	gen := cc.Uploader[path].Generation + 1
+	if _, err := cc.UploadClient.Finalize(ctx, staged, path, worldRead); err != nil {
+		return nil, err
+	}
+	u := cc.Uploader[path]
+	u.Generation = gen
+	cc.Uploader[path] = u
+	return u.Attrs(path), nil
+}
+
 // If returns a fake conditional client.
 func (cc ConditionalClient) If(read, write *storage.Conditions) gcs.ConditionalClient {
 	return ConditionalClient{
@@ -171,6 +203,11 @@ func (fu Uploader) Copy(ctx context.Context, from, to gcs.Path) (*storage.Object
 
 // Upload writes content to the given path.
 func (fu Uploader) Upload(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cacheControl string) (*storage.ObjectAttrs, error) {
+	return fu.UploadWithMetadata(ctx, path, buf, worldRead, cacheControl, nil)
+}
+
+// UploadWithMetadata writes content and metadata to the given path.
+func (fu Uploader) UploadWithMetadata(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("injected interrupt: %w", err)
 	}
@@ -182,7 +219,49 @@ func (fu Uploader) Upload(ctx context.Context, path gcs.Path, buf []byte, worldR
 		Buf:          buf,
 		CacheControl: cacheControl,
 		WorldRead:    worldRead,
+		Metadata:     meta,
+	}
+	fu[path] = u
+	return u.Attrs(path), nil
+}
+
+// UploadFrom writes the content of the reader to the given path.
+func (fu Uploader) UploadFrom(ctx context.Context, path gcs.Path, r io.Reader, worldRead bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	return fu.UploadWithMetadata(ctx, path, buf, worldRead, cacheControl, meta)
+}
+
+// Stage writes the content of the reader to the temporary object for path.
+func (fu Uploader) Stage(ctx context.Context, path gcs.Path, r io.Reader, worldRead bool, cacheControl string, meta map[string]string) (gcs.Path, error) {
+	staged := path.TempPath()
+	if _, err := fu.UploadFrom(ctx, staged, r, worldRead, cacheControl, meta); err != nil {
+		if fu[staged].Err == nil {
+			delete(fu, staged)
+		}
+		return staged, err
+	}
+	return staged, nil
+}
+
+// Finalize moves the staged object to path, unless path has an injected error.
+//
+// Like a GCS copy, the object only keeps worldRead rather than the ACL of the staged object.
+func (fu Uploader) Finalize(ctx context.Context, staged, path gcs.Path, worldRead bool) (*storage.ObjectAttrs, error) {
+	u, present := fu[staged]
+	delete(fu, staged)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("injected interrupt: %w", err)
+	}
+	if !present {
+		return nil, storage.ErrObjectNotExist
+	}
+	if err := fu[path].Err; err != nil {
+		return nil, fmt.Errorf("injected finalize error: %w", err)
 	}
+	u.WorldRead = worldRead
 	fu[path] = u
 	return u.Attrs(path), nil
 }
@@ -192,6 +271,7 @@ type Upload struct {
 	Buf          []byte
 	CacheControl string
 	WorldRead    bool
+	Metadata     map[string]string
 	Err          error
 	Generation   int64
 }
@@ -203,6 +283,7 @@ func (u Upload) Attrs(path gcs.Path) *storage.ObjectAttrs {
 		Name:         path.Object(),
 		CacheControl: u.CacheControl,
 		Generation:   u.Generation,
+		Metadata:     u.Metadata,
 	}
 }
 
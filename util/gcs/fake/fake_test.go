@@ -30,6 +30,7 @@ func TestInterfaces(t *testing.T) {
 		_ gcs.Iterator          = &Iterator{}
 		_ gcs.Opener            = &Opener{}
 		_ gcs.Copier            = &Uploader{}
+		_ gcs.StagingUploader   = &Uploader{}
 		_ gcs.Client            = &UploadClient{}
 		_ gcs.ConditionalClient = &UploadClient{}
 	)
@@ -22,12 +22,17 @@ package gcs
 
 import (
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
 	"compress/zlib"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash/crc32"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/url"
@@ -38,6 +43,7 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/api/option"
+	protov2 "google.golang.org/protobuf/proto"
 )
 
 // ClientWithCreds returns a storage client, optionally authenticated with the specified .json creds
@@ -149,6 +155,13 @@ func (g Path) Object() string {
 	return g.url.Path[1:]
 }
 
+// TempPath returns the temporary object used to stage writes to gs://bucket/obj
+func (g Path) TempPath() Path {
+	u := g.url
+	u.Path += ".tmp"
+	return Path{url: u}
+}
+
 func calcCRC(buf []byte) uint32 {
 	return crc32.Checksum(buf, crc32.MakeTable(crc32.Castagnoli))
 }
@@ -167,6 +180,10 @@ func Upload(ctx context.Context, client *storage.Client, path Path, buf []byte,
 
 // UploadHandle writes bytes to the specified ObjectHandle
 func UploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte, worldReadable bool, cacheControl string) (*storage.ObjectAttrs, error) {
+	return uploadHandle(ctx, handle, buf, worldReadable, cacheControl, nil)
+}
+
+func uploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte, worldReadable bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
 	crc := calcCRC(buf)
 	w := handle.NewWriter(ctx)
 	defer w.Close()
@@ -176,6 +193,7 @@ func UploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte,
 	if cacheControl != "" {
 		w.ObjectAttrs.CacheControl = cacheControl
 	}
+	w.ObjectAttrs.Metadata = meta
 	w.SendCRC32C = true
 	// Send our CRC32 to ensure google received the same data we sent.
 	// See checksum example at:
@@ -195,7 +213,31 @@ func UploadHandle(ctx context.Context, handle *storage.ObjectHandle, buf []byte,
 	return w.Attrs(), nil
 }
 
+// UploadHandleFrom copies the reader to the specified ObjectHandle.
+//
+// Unlike UploadHandle, the content is streamed and so no CRC is sent.
+func UploadHandleFrom(ctx context.Context, handle *storage.ObjectHandle, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	w := handle.NewWriter(ctx)
+	defer w.Close()
+	if worldReadable {
+		w.ACL = []storage.ACLRule{{Entity: storage.AllUsers, Role: storage.RoleReader}}
+	}
+	if cacheControl != "" {
+		w.ObjectAttrs.CacheControl = cacheControl
+	}
+	w.ObjectAttrs.Metadata = meta
+	if _, err := io.Copy(w, r); err != nil {
+		return nil, fmt.Errorf("write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("close: %w", err)
+	}
+	return w.Attrs(), nil
+}
+
 // DownloadGrid downloads and decompresses a grid from the specified path.
+//
+// Detects the codec used to compress the grid, see UnmarshalGrid.
 func DownloadGrid(ctx context.Context, opener Opener, path Path) (*statepb.Grid, *storage.ReaderObjectAttrs, error) {
 	var g statepb.Grid
 	r, attrs, err := opener.Open(ctx, path)
@@ -206,31 +248,174 @@ func DownloadGrid(ctx context.Context, opener Opener, path Path) (*statepb.Grid,
 		return nil, nil, fmt.Errorf("open: %w", err)
 	}
 	defer r.Close()
-	zr, err := zlib.NewReader(r)
+	buf, err := ioutil.ReadAll(r)
 	if err != nil {
-		return nil, nil, fmt.Errorf("open zlib: %w", err)
+		return nil, nil, fmt.Errorf("read: %w", err)
 	}
-	pbuf, err := ioutil.ReadAll(zr)
-	if err != nil {
-		return nil, nil, fmt.Errorf("decompress: %w", err)
+	grid, err := UnmarshalGrid(buf)
+	return grid, attrs, err
+}
+
+// Codec determines how to compress a serialized grid.
+type Codec int
+
+const (
+	// Zlib compresses grids with compress/zlib, the default.
+	Zlib Codec = iota
+	// Gzip compresses grids with compress/gzip.
+	Gzip
+	// Uncompressed leaves the serialized grid as is.
+	Uncompressed
+)
+
+func (c Codec) String() string {
+	switch c {
+	case Zlib:
+		return "zlib"
+	case Gzip:
+		return "gzip"
+	case Uncompressed:
+		return "none"
 	}
-	err = proto.Unmarshal(pbuf, &g)
-	return &g, attrs, err
+	return fmt.Sprintf("Codec(%d)", int(c))
 }
 
-// MarshalGrid serializes a state proto into zlib-compressed bytes.
-func MarshalGrid(grid *statepb.Grid) ([]byte, error) {
-	buf, err := proto.Marshal(grid)
+// DefaultCompression compresses grids using the default level of the codec.
+const DefaultCompression = flate.DefaultCompression
+
+// ValidateCompressionLevel returns an error unless level is a compress/flate level.
+//
+// Valid levels range from flate.HuffmanOnly through flate.BestCompression,
+// trading more CPU for smaller grids, and include DefaultCompression.
+func ValidateCompressionLevel(level int) error {
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		return fmt.Errorf("invalid compression level %d, want %d through %d", level, flate.HuffmanOnly, flate.BestCompression)
+	}
+	return nil
+}
+
+// MarshalGrid serializes a state proto into bytes compressed with the codec.
+func MarshalGrid(grid *statepb.Grid, codec Codec) ([]byte, error) {
+	return MarshalGridLevel(grid, codec, DefaultCompression)
+}
+
+// MarshalGridLevel serializes a state proto into bytes compressed with the codec at the level.
+//
+// See ValidateCompressionLevel.
+func MarshalGridLevel(grid *statepb.Grid, codec Codec, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteGridLevel(&buf, grid, codec, level); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteGrid serializes a state proto, writing it to w compressed with the codec.
+//
+// The output is identical to MarshalGrid.
+func WriteGrid(w io.Writer, grid *statepb.Grid, codec Codec) error {
+	return WriteGridLevel(w, grid, codec, DefaultCompression)
+}
+
+// WriteGridLevel serializes a state proto, writing it to w compressed with the codec at the level.
+//
+// The output is identical to MarshalGridLevel.
+func WriteGridLevel(w io.Writer, grid *statepb.Grid, codec Codec, level int) error {
+	if err := ValidateCompressionLevel(level); err != nil {
+		return err
+	}
+	buf, err := marshalState(grid)
 	if err != nil {
-		return nil, fmt.Errorf("marshal: %w", err)
+		return fmt.Errorf("marshal: %w", err)
+	}
+	var zw io.WriteCloser
+	switch codec {
+	case Zlib:
+		zw, err = zlib.NewWriterLevel(w, level)
+	case Gzip:
+		zw, err = gzip.NewWriterLevel(w, level)
+	case Uncompressed:
+		if _, err := w.Write(buf); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown codec: %s", codec)
+	}
+	if err != nil {
+		return fmt.Errorf("create %s writer: %w", codec, err)
 	}
-	var zbuf bytes.Buffer
-	zw := zlib.NewWriter(&zbuf)
 	if _, err = zw.Write(buf); err != nil {
-		return nil, fmt.Errorf("compress: %w", err)
+		return fmt.Errorf("compress: %w", err)
 	}
 	if err = zw.Close(); err != nil {
-		return nil, fmt.Errorf("close: %w", err)
+		return fmt.Errorf("close: %w", err)
+	}
+	return nil
+}
+
+// marshalState deterministically serializes the grid, so equal grids produce equal bytes.
+func marshalState(grid *statepb.Grid) ([]byte, error) {
+	return protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(grid))
+}
+
+// GridHashKey is the object metadata key holding the HashGrid value of an uploaded grid.
+const GridHashKey = "testgrid-sha256"
+
+// HashGrid returns the hex-encoded SHA-256 of the uncompressed, serialized grid.
+//
+// Clients can validate a cached grid by comparing this to the GridHashKey metadata.
+func HashGrid(grid *statepb.Grid) (string, error) {
+	buf, err := marshalState(grid)
+	if err != nil {
+		return "", fmt.Errorf("marshal: %w", err)
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DetectCodec returns the codec used to compress the serialized grid.
+//
+// Assumes uncompressed when the buffer starts with neither a gzip nor zlib header.
+func DetectCodec(buf []byte) Codec {
+	if len(buf) < 2 {
+		return Uncompressed
+	}
+	switch {
+	case buf[0] == 0x1f && buf[1] == 0x8b:
+		return Gzip
+	case buf[0]&0x0f == 8 && (uint16(buf[0])<<8|uint16(buf[1]))%31 == 0:
+		// RFC 1950: deflate compression method with a valid header checksum.
+		return Zlib
+	}
+	return Uncompressed
+}
+
+// UnmarshalGrid decompresses and deserializes a grid written with any codec.
+//
+// Expands any interned messages, see ExpandMessages.
+func UnmarshalGrid(buf []byte) (*statepb.Grid, error) {
+	var r io.ReadCloser
+	var err error
+	switch DetectCodec(buf) {
+	case Zlib:
+		r, err = zlib.NewReader(bytes.NewReader(buf))
+	case Gzip:
+		r, err = gzip.NewReader(bytes.NewReader(buf))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open decompressor: %w", err)
+	}
+	if r != nil {
+		defer r.Close()
+		if buf, err = ioutil.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("decompress: %w", err)
+		}
+	}
+	var g statepb.Grid
+	if err := proto.Unmarshal(buf, &g); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
-	return zbuf.Bytes(), nil
+	ExpandMessages(&g)
+	return &g, nil
 }
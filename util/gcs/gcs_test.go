@@ -17,6 +17,10 @@ limitations under the License.
 package gcs
 
 import (
+	"bytes"
+	"compress/flate"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/url"
 	"reflect"
 	"testing"
@@ -160,8 +164,8 @@ func TestMarshalGrid(t *testing.T) {
 		},
 	}
 
-	b1, e1 := MarshalGrid(&g1)
-	b2, e2 := MarshalGrid(&g2)
+	b1, e1 := MarshalGrid(&g1, Zlib)
+	b2, e2 := MarshalGrid(&g2, Zlib)
 	uncompressed, e1a := proto.Marshal(&g1)
 
 	switch {
@@ -177,3 +181,209 @@ func TestMarshalGrid(t *testing.T) {
 		t.Errorf("should be compressed but is not: %v", b1)
 	}
 }
+
+func TestMarshalGridCodecs(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "first", Started: 1},
+			{Build: "second", Started: 2},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:     "hello",
+				Id:       "hello",
+				Results:  []int32{1, 2},
+				Messages: []string{"hi", "there"},
+				Icons:    []string{"", ""},
+			},
+		},
+	}
+	cases := []struct {
+		name  string
+		codec Codec
+		err   bool
+	}{
+		{
+			name:  "zlib",
+			codec: Zlib,
+		},
+		{
+			name:  "gzip",
+			codec: Gzip,
+		},
+		{
+			name:  "uncompressed",
+			codec: Uncompressed,
+		},
+		{
+			name:  "unknown",
+			codec: Codec(99),
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf, err := MarshalGrid(grid, tc.codec)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("MarshalGrid() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("MarshalGrid() failed to return an error")
+			}
+			var streamed bytes.Buffer
+			if err := WriteGrid(&streamed, grid, tc.codec); err != nil {
+				t.Fatalf("WriteGrid() got unexpected error: %v", err)
+			}
+			if !bytes.Equal(buf, streamed.Bytes()) {
+				t.Errorf("WriteGrid() wrote %q, want MarshalGrid() output %q", streamed.Bytes(), buf)
+			}
+			if got := DetectCodec(buf); got != tc.codec {
+				t.Errorf("DetectCodec() got %s, want %s", got, tc.codec)
+			}
+			actual, err := UnmarshalGrid(buf)
+			if err != nil {
+				t.Fatalf("UnmarshalGrid() got unexpected error: %v", err)
+			}
+			if !proto.Equal(grid, actual) {
+				t.Errorf("UnmarshalGrid() got %v, want %v", actual, grid)
+			}
+		})
+	}
+}
+
+func TestMarshalGridLevel(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "first", Started: 1},
+			{Build: "second", Started: 2},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:     "hello",
+				Id:       "hello",
+				Results:  []int32{1, 2},
+				Messages: []string{"hi", "there"},
+				Icons:    []string{"", ""},
+			},
+		},
+	}
+	cases := []struct {
+		name  string
+		level int
+		err   bool
+	}{
+		{
+			name:  "default",
+			level: DefaultCompression,
+		},
+		{
+			name:  "huffman only",
+			level: flate.HuffmanOnly,
+		},
+		{
+			name:  "no compression",
+			level: flate.NoCompression,
+		},
+		{
+			name:  "best speed",
+			level: flate.BestSpeed,
+		},
+		{
+			name:  "best compression",
+			level: flate.BestCompression,
+		},
+		{
+			name:  "too low",
+			level: flate.HuffmanOnly - 1,
+			err:   true,
+		},
+		{
+			name:  "too high",
+			level: flate.BestCompression + 1,
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		for _, codec := range []Codec{Zlib, Gzip} {
+			t.Run(tc.name+" "+codec.String(), func(t *testing.T) {
+				if err := ValidateCompressionLevel(tc.level); (err != nil) != tc.err {
+					t.Errorf("ValidateCompressionLevel(%d) got error %v, want error %t", tc.level, err, tc.err)
+				}
+				buf, err := MarshalGridLevel(grid, codec, tc.level)
+				switch {
+				case err != nil:
+					if !tc.err {
+						t.Fatalf("MarshalGridLevel() got unexpected error: %v", err)
+					}
+					return
+				case tc.err:
+					t.Fatal("MarshalGridLevel() failed to return an error")
+				}
+				var streamed bytes.Buffer
+				if err := WriteGridLevel(&streamed, grid, codec, tc.level); err != nil {
+					t.Fatalf("WriteGridLevel() got unexpected error: %v", err)
+				}
+				if !bytes.Equal(buf, streamed.Bytes()) {
+					t.Errorf("WriteGridLevel() wrote %q, want MarshalGridLevel() output %q", streamed.Bytes(), buf)
+				}
+				if got := DetectCodec(buf); got != codec {
+					t.Errorf("DetectCodec() got %s, want %s", got, codec)
+				}
+				actual, err := UnmarshalGrid(buf)
+				if err != nil {
+					t.Fatalf("UnmarshalGrid() got unexpected error: %v", err)
+				}
+				if !proto.Equal(grid, actual) {
+					t.Errorf("UnmarshalGrid() got %v, want %v", actual, grid)
+				}
+			})
+		}
+	}
+}
+
+func TestHashGrid(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{
+				Build:       "first",
+				Started:     1,
+				Annotations: map[string]string{"a": "1", "b": "2", "c": "3"},
+			},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:    "hello",
+				Id:      "hello",
+				Results: []int32{1, 1},
+			},
+		},
+	}
+
+	buf, err := MarshalGrid(grid, Uncompressed)
+	if err != nil {
+		t.Fatalf("MarshalGrid() got unexpected error: %v", err)
+	}
+	sum := sha256.Sum256(buf)
+	want := hex.EncodeToString(sum[:])
+
+	for i := 0; i < 10; i++ { // map ordering must not change the hash
+		got, err := HashGrid(grid)
+		if err != nil {
+			t.Fatalf("HashGrid() got unexpected error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("HashGrid() got %s, want %s", got, want)
+		}
+	}
+
+	other := proto.Clone(grid).(*statepb.Grid)
+	other.Columns[0].Build = "second"
+	if got, _ := HashGrid(other); got == want {
+		t.Errorf("HashGrid() of a different grid got the same hash %s", got)
+	}
+}
@@ -111,6 +111,42 @@ func (lc localClient) Upload(ctx context.Context, path Path, buf []byte, _ bool,
 	return lc.Stat(ctx, path)
 }
 
+func (lc localClient) UploadWithMetadata(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, _ map[string]string) (*storage.ObjectAttrs, error) {
+	return lc.Upload(ctx, path, buf, worldReadable, cacheControl)
+}
+
+func (lc localClient) UploadFrom(ctx context.Context, path Path, r io.Reader, _ bool, _ string, _ map[string]string) (*storage.ObjectAttrs, error) {
+	f, err := os.Create(cleanFilepath(path))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return lc.Stat(ctx, path)
+}
+
+func (lc localClient) Stage(ctx context.Context, path Path, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (Path, error) {
+	staged := path.TempPath()
+	if _, err := lc.UploadFrom(ctx, staged, r, worldReadable, cacheControl, meta); err != nil {
+		os.Remove(cleanFilepath(staged))
+		return staged, err
+	}
+	return staged, nil
+}
+
+func (lc localClient) Finalize(ctx context.Context, staged, path Path, _ bool) (*storage.ObjectAttrs, error) {
+	if err := os.Rename(cleanFilepath(staged), cleanFilepath(path)); err != nil {
+		os.Remove(cleanFilepath(staged))
+		return nil, err
+	}
+	return lc.Stat(ctx, path)
+}
+
 func (lc localClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
 	info, err := os.Stat(cleanFilepath(path))
 	if err != nil {
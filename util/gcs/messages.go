@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// InternMessages stores each unique message once in the grid's message table.
+//
+// Rows then reference their messages by index, see ExpandMessages.
+func InternMessages(grid *statepb.Grid) {
+	if len(grid.MessageTable) > 0 {
+		return // already interned
+	}
+	table := map[string]int32{}
+	for _, row := range grid.Rows {
+		if len(row.Messages) == 0 {
+			continue
+		}
+		row.MessageIndices = make([]int32, 0, len(row.Messages))
+		for _, msg := range row.Messages {
+			idx, ok := table[msg]
+			if !ok {
+				idx = int32(len(grid.MessageTable))
+				table[msg] = idx
+				grid.MessageTable = append(grid.MessageTable, msg)
+			}
+			row.MessageIndices = append(row.MessageIndices, idx)
+		}
+		row.Messages = nil
+	}
+}
+
+// ExpandMessages restores the messages of each row in a grid with interned messages.
+//
+// Out of range indices become empty messages.
+func ExpandMessages(grid *statepb.Grid) {
+	if len(grid.MessageTable) == 0 {
+		return
+	}
+	for _, row := range grid.Rows {
+		if len(row.MessageIndices) == 0 {
+			continue
+		}
+		row.Messages = make([]string, 0, len(row.MessageIndices))
+		for _, idx := range row.MessageIndices {
+			var msg string
+			if idx >= 0 && int(idx) < len(grid.MessageTable) {
+				msg = grid.MessageTable[idx]
+			}
+			row.Messages = append(row.Messages, msg)
+		}
+		row.MessageIndices = nil
+	}
+	grid.MessageTable = nil
+}
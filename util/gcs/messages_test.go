@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestInternMessages(t *testing.T) {
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		expected *statepb.Grid
+	}{
+		{
+			name:     "empty grid",
+			grid:     &statepb.Grid{},
+			expected: &statepb.Grid{},
+		},
+		{
+			name: "intern repeated messages",
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "hello", Messages: []string{"timeout", "", "timeout", "boom"}},
+					{Name: "empty"},
+					{Name: "world", Messages: []string{"boom", "timeout", "oh no"}},
+				},
+			},
+			expected: &statepb.Grid{
+				MessageTable: []string{"timeout", "", "boom", "oh no"},
+				Rows: []*statepb.Row{
+					{Name: "hello", MessageIndices: []int32{0, 1, 0, 2}},
+					{Name: "empty"},
+					{Name: "world", MessageIndices: []int32{2, 0, 3}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := proto.Clone(tc.grid).(*statepb.Grid)
+			InternMessages(tc.grid)
+			if diff := cmp.Diff(tc.expected, tc.grid, protocmp.Transform()); diff != "" {
+				t.Fatalf("InternMessages() got unexpected diff (-want +got):\n%s", diff)
+			}
+			seen := map[string]bool{}
+			for _, msg := range tc.grid.MessageTable {
+				if seen[msg] {
+					t.Errorf("InternMessages() duplicated %q in the table", msg)
+				}
+				seen[msg] = true
+			}
+
+			InternMessages(tc.grid)
+			if diff := cmp.Diff(tc.expected, tc.grid, protocmp.Transform()); diff != "" {
+				t.Errorf("InternMessages() twice got unexpected diff (-want +got):\n%s", diff)
+			}
+
+			ExpandMessages(tc.grid)
+			if diff := cmp.Diff(original, tc.grid, protocmp.Transform()); diff != "" {
+				t.Errorf("ExpandMessages() failed to reconstruct messages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExpandMessages(t *testing.T) {
+	cases := []struct {
+		name     string
+		grid     *statepb.Grid
+		expected *statepb.Grid
+	}{
+		{
+			name: "messages are unchanged without a table",
+			grid: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "hello", Messages: []string{"hi"}},
+				},
+			},
+			expected: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "hello", Messages: []string{"hi"}},
+				},
+			},
+		},
+		{
+			name: "out of range indices are empty",
+			grid: &statepb.Grid{
+				MessageTable: []string{"hi"},
+				Rows: []*statepb.Row{
+					{Name: "hello", MessageIndices: []int32{0, 1, -1, 0}},
+				},
+			},
+			expected: &statepb.Grid{
+				Rows: []*statepb.Row{
+					{Name: "hello", Messages: []string{"hi", "", "", "hi"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ExpandMessages(tc.grid)
+			if diff := cmp.Diff(tc.expected, tc.grid, protocmp.Transform()); diff != "" {
+				t.Errorf("ExpandMessages() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
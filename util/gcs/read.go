@@ -17,10 +17,14 @@ limitations under the License.
 package gcs
 
 import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"path"
 	"regexp"
@@ -148,8 +152,15 @@ type Build struct {
 	Path              Path
 	baseName          string
 	suitesConcurrency int // override the max number of concurrent suite downloads
+	// Parser converts junit artifacts into suites, defaulting to junit.ParseStream.
+	Parser SuitesParser
 }
 
+// SuitesParser converts the contents of a result artifact into junit suites.
+//
+// Each result becomes a target, with its properties holding metrics and metadata.
+type SuitesParser func(io.Reader) (*junit.Suites, error)
+
 func (build Build) object() string {
 	o := build.Path.Object()
 	if strings.HasSuffix(o, "/") {
@@ -163,6 +174,16 @@ func (build Build) Build() string {
 	return path.Base(build.object())
 }
 
+// sortName returns the name which orders the build, defaulting to its id.
+//
+// Listing builds from a link object names them after the link.
+func (build Build) sortName() string {
+	if build.baseName != "" {
+		return build.baseName
+	}
+	return build.Build()
+}
+
 // Job is the name of the job for this build
 func (build Build) Job() string {
 	return path.Base(path.Dir(build.object()))
@@ -216,46 +237,13 @@ func ListBuilds(parent context.Context, lister Lister, gcsPath Path, after *Path
 		if err != nil {
 			return nil, fmt.Errorf("list objects: %w", err)
 		}
-
-		// if this is a link under directory/, resolve the build value
-		// This is used for PR type jobs which we store in a PR specific prefix.
-		// The directory prefix contains a link header to the result
-		// under the PR specific prefix.
-		if link := readLink(objAttrs); link != "" {
-			// links created by bootstrap.py have a space
-			link = strings.TrimSpace(link)
-			u, err := url.Parse(link)
-			if err != nil {
-				return nil, fmt.Errorf("parse %s link: %v", objAttrs.Name, err)
-			}
-			if !strings.HasSuffix(u.Path, "/") {
-				u.Path += "/"
-			}
-			var linkPath Path
-			if err := linkPath.SetURL(u); err != nil {
-				return nil, fmt.Errorf("bad %s link path %s: %w", objAttrs.Name, u, err)
-			}
-			all = append(all, Build{
-				Path:     linkPath,
-				baseName: path.Base(objAttrs.Name),
-			})
-			continue
-		}
-
-		if objAttrs.Prefix == "" {
-			continue // not a symlink to a directory
-		}
-
-		loc := "gs://" + gcsPath.Bucket() + "/" + objAttrs.Prefix
-		gcsPath, err := NewPath(loc)
+		b, err := listedBuild(gcsPath, objAttrs)
 		if err != nil {
-			return nil, fmt.Errorf("bad path %q: %w", loc, err)
+			return nil, err
+		}
+		if b != nil {
+			all = append(all, *b)
 		}
-
-		all = append(all, Build{
-			Path:     *gcsPath,
-			baseName: path.Base(objAttrs.Prefix),
-		})
 	}
 
 	Sort(all)
@@ -272,6 +260,138 @@ func ListBuilds(parent context.Context, lister Lister, gcsPath Path, after *Path
 	return all, nil
 }
 
+// listedBuild returns the build of a listed object, or nil when the object is not a build.
+func listedBuild(gcsPath Path, objAttrs *storage.ObjectAttrs) (*Build, error) {
+	// if this is a link under directory/, resolve the build value
+	// This is used for PR type jobs which we store in a PR specific prefix.
+	// The directory prefix contains a link header to the result
+	// under the PR specific prefix.
+	if link := readLink(objAttrs); link != "" {
+		// links created by bootstrap.py have a space
+		link = strings.TrimSpace(link)
+		u, err := url.Parse(link)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s link: %v", objAttrs.Name, err)
+		}
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		var linkPath Path
+		if err := linkPath.SetURL(u); err != nil {
+			return nil, fmt.Errorf("bad %s link path %s: %w", objAttrs.Name, u, err)
+		}
+		return &Build{
+			Path:     linkPath,
+			baseName: path.Base(objAttrs.Name),
+		}, nil
+	}
+
+	if objAttrs.Prefix == "" {
+		return nil, nil // not a symlink to a directory
+	}
+
+	loc := "gs://" + gcsPath.Bucket() + "/" + objAttrs.Prefix
+	buildPath, err := NewPath(loc)
+	if err != nil {
+		return nil, fmt.Errorf("bad path %q: %w", loc, err)
+	}
+	return &Build{
+		Path:     *buildPath,
+		baseName: path.Base(objAttrs.Prefix),
+	}, nil
+}
+
+// LexicalOffset returns true when builds listed after the build id sort lexically in the order they started.
+//
+// Sequential build numbers do not, see hackOffset.
+func LexicalOffset(id string) bool {
+	offset := id
+	hackOffset(&offset)
+	return offset == id
+}
+
+// A BuildStream lists the builds under a path one page at a time, in ascending lexical order.
+//
+// Each page is a separate Objects call starting after the previous page,
+// so callers which stop early do not list the remaining builds.
+type BuildStream struct {
+	ctx      context.Context
+	lister   Lister
+	path     Path
+	after    string
+	cursor   string
+	pageSize int
+	page     []Build
+	done     bool
+}
+
+// StreamBuilds returns a stream of the builds under path after the specified build, listing pageSize objects at a time.
+//
+// Unlike ListBuilds, the offset is not adjusted for sequential build numbers,
+// which callers should list instead, see LexicalOffset.
+func StreamBuilds(ctx context.Context, lister Lister, gcsPath Path, after *Path, pageSize int) *BuildStream {
+	s := BuildStream{
+		ctx:      ctx,
+		lister:   lister,
+		path:     gcsPath,
+		pageSize: pageSize,
+	}
+	if after != nil {
+		s.cursor = after.Object()
+		s.after = path.Base(s.cursor)
+	}
+	return &s
+}
+
+// Next returns the next build, or iterator.Done after the last one.
+func (s *BuildStream) Next() (Build, error) {
+	for len(s.page) == 0 {
+		if s.done {
+			return Build{}, iterator.Done
+		}
+		if err := s.list(); err != nil {
+			return Build{}, err
+		}
+	}
+	b := s.page[0]
+	s.page = s.page[1:]
+	return b, nil
+}
+
+// list the next page of builds, advancing the cursor past the last listed object.
+func (s *BuildStream) list() error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	it := s.lister.Objects(ctx, s.path, "/", s.cursor)
+	var n int
+	for n < s.pageSize {
+		objAttrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			s.done = true
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("list objects: %w", err)
+		}
+		n++
+		if objAttrs.Prefix != "" {
+			// Skip everything under the prefix: / sorts right before 0.
+			s.cursor = strings.TrimSuffix(objAttrs.Prefix, "/") + "0"
+		} else {
+			s.cursor = objAttrs.Name + "\x00"
+		}
+		b, err := listedBuild(s.path, objAttrs)
+		if err != nil {
+			return err
+		}
+		if b == nil || (s.after != "" && !sortorder.NaturalLess(s.after, b.baseName)) {
+			continue
+		}
+		s.page = append(s.page, *b)
+	}
+	return nil
+}
+
 // junit_CONTEXT_TIMESTAMP_THREAD.xml
 var re = regexp.MustCompile(`.+/junit((_[^_]+)?(_\d+-\d+)?(_\d+)?|.+)?\.xml$`)
 
@@ -407,13 +527,38 @@ type SuitesMeta struct {
 	Path     string
 }
 
-func readSuites(ctx context.Context, opener Opener, p Path) (*junit.Suites, error) {
+// decompress returns a reader which transparently decompresses gzip or zlib content.
+//
+// Returns plain content as is, see DetectCodec.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch DetectCodec(head) {
+	case Gzip:
+		return gzip.NewReader(br)
+	case Zlib:
+		return zlib.NewReader(br)
+	}
+	return br, nil
+}
+
+func readSuites(ctx context.Context, opener Opener, p Path, parse SuitesParser) (*junit.Suites, error) {
 	r, _, err := opener.Open(ctx, p)
 	if err != nil {
 		return nil, fmt.Errorf("open: %w", err)
 	}
 	defer r.Close()
-	suitesMeta, err := junit.ParseStream(r)
+	content, err := decompress(r)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	if parse == nil {
+		parse = junit.ParseStream
+	}
+	suitesMeta, err := parse(content)
 	if err != nil {
 		return nil, fmt.Errorf("parse: %w", err)
 	}
@@ -485,7 +630,7 @@ func (build Build) Suites(parent context.Context, opener Opener, artifacts <-cha
 				Metadata: meta,
 				Path:     path.String(),
 			}
-			s, err := readSuites(ctx, opener, *path)
+			s, err := readSuites(ctx, opener, *path, build.Parser)
 			if err != nil {
 				select {
 				case <-ctx.Done():
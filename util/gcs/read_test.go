@@ -18,6 +18,8 @@ package gcs
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/xml"
 	"errors"
@@ -27,6 +29,7 @@ import (
 	"net/url"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 
@@ -553,6 +556,107 @@ func TestListBuilds(t *testing.T) {
 	}
 }
 
+type countingLister struct {
+	fakeLister
+	calls int
+}
+
+func (cl *countingLister) Objects(ctx context.Context, path Path, delimiter, offset string) Iterator {
+	cl.calls++
+	return cl.fakeLister.Objects(ctx, path, delimiter, offset)
+}
+
+func TestStreamBuilds(t *testing.T) {
+	path := newPathOrDie("gs://bucket/path/to/build/")
+	var objects []storage.ObjectAttrs
+	var builds []Build
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("build-%d", i)
+		objects = append(objects, subdir(resolveOrDie(path, name+"/").Object()))
+		builds = append(builds, Build{
+			Path:     resolveOrDie(path, name+"/"),
+			baseName: name,
+		})
+	}
+	objects = append(objects, link(path, "build-6.txt", "gs://another-bucket/build-6"))
+	builds = append(builds, Build{
+		Path:     newPathOrDie("gs://another-bucket/build-6/"),
+		baseName: "build-6.txt",
+	})
+
+	cases := []struct {
+		name     string
+		offset   *Path
+		pulls    int
+		err      int
+		expected []Build
+		calls    int
+		fail     bool
+	}{
+		{
+			name:     "stream every build",
+			pulls:    10,
+			expected: builds,
+			calls:    4,
+		},
+		{
+			name:     "stop listing after enough builds",
+			pulls:    3,
+			expected: builds[:3],
+			calls:    2,
+		},
+		{
+			name:     "start after the offset",
+			offset:   pResolveOrDie(path, "build-2"),
+			pulls:    2,
+			expected: builds[2:4],
+			calls:    2,
+		},
+		{
+			name:  "error",
+			pulls: 10,
+			err:   3,
+			calls: 2,
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lister := &countingLister{
+				fakeLister: fakeLister{path: fakeIterator{objects: objects, err: tc.err}},
+			}
+			stream := StreamBuilds(context.Background(), lister, path, tc.offset, 2)
+			var actual []Build
+			var err error
+			for len(actual) < tc.pulls {
+				var b Build
+				if b, err = stream.Next(); err != nil {
+					break
+				}
+				actual = append(actual, b)
+			}
+			switch {
+			case errors.Is(err, iterator.Done):
+			case err != nil:
+				if !tc.fail {
+					t.Errorf("Next() got unexpected error: %v", err)
+				}
+			case tc.fail:
+				t.Error("Next() failed to return an error")
+			}
+			if !tc.fail {
+				if diff := cmp.Diff(tc.expected, actual, cmp.AllowUnexported(Build{}, Path{})); diff != "" {
+					t.Errorf("Next() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			if lister.calls != tc.calls {
+				t.Errorf("StreamBuilds() listed %d pages, want %d", lister.calls, tc.calls)
+			}
+		})
+	}
+}
+
 func TestReadLink(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -1073,12 +1177,48 @@ func newPathOrDie(s string) Path {
 	return *p
 }
 
+// compress returns the data compressed with the codec.
+func compress(codec Codec, data string) string {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch codec {
+	case Gzip:
+		w = gzip.NewWriter(&buf)
+	case Zlib:
+		w = zlib.NewWriter(&buf)
+	default:
+		return data
+	}
+	if _, err := w.Write([]byte(data)); err != nil {
+		panic(err)
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
 func TestReadSuites(t *testing.T) {
 	path := newPathOrDie("gs://bucket/object")
+	const junitFoo = `<testsuites><testsuite><testcase name="foo"/></testsuite></testsuites>`
+	suitesFoo := &junit.Suites{
+		XMLName: xml.Name{Local: "testsuites"},
+		Suites: []junit.Suite{
+			{
+				XMLName: xml.Name{Local: "testsuite"},
+				Results: []junit.Result{
+					{
+						Name: "foo",
+					},
+				},
+			},
+		},
+	}
 	cases := []struct {
 		name     string
 		ctx      context.Context
 		opener   fakeOpener
+		parser   SuitesParser
 		expected *junit.Suites
 		checkErr error
 	}{
@@ -1103,6 +1243,79 @@ func TestReadSuites(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "custom parser",
+			opener: fakeOpener{
+				path: {data: "foo\nbar"},
+			},
+			parser: func(r io.Reader) (*junit.Suites, error) {
+				buf, err := ioutil.ReadAll(r)
+				if err != nil {
+					return nil, err
+				}
+				var suite junit.Suite
+				for _, name := range strings.Split(string(buf), "\n") {
+					suite.Results = append(suite.Results, junit.Result{Name: name})
+				}
+				return &junit.Suites{Suites: []junit.Suite{suite}}, nil
+			},
+			expected: &junit.Suites{
+				Suites: []junit.Suite{
+					{
+						Results: []junit.Result{
+							{Name: "foo"},
+							{Name: "bar"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "gzip compressed",
+			opener: fakeOpener{
+				path: {data: compress(Gzip, junitFoo)},
+			},
+			expected: suitesFoo,
+		},
+		{
+			name: "zlib compressed",
+			opener: fakeOpener{
+				path: {data: compress(Zlib, junitFoo)},
+			},
+			expected: suitesFoo,
+		},
+		{
+			name: "custom parser reads decompressed content",
+			opener: fakeOpener{
+				path: {data: compress(Gzip, "foo")},
+			},
+			parser: func(r io.Reader) (*junit.Suites, error) {
+				buf, err := ioutil.ReadAll(r)
+				if err != nil {
+					return nil, err
+				}
+				return &junit.Suites{Suites: []junit.Suite{{Name: string(buf)}}}, nil
+			},
+			expected: &junit.Suites{
+				Suites: []junit.Suite{{Name: "foo"}},
+			},
+		},
+		{
+			name: "empty content",
+			opener: fakeOpener{
+				path: {data: ""},
+			},
+			parser: func(r io.Reader) (*junit.Suites, error) {
+				return &junit.Suites{}, nil
+			},
+			expected: &junit.Suites{},
+		},
+		{
+			name: "corrupt gzip returns error",
+			opener: fakeOpener{
+				path: {data: compress(Gzip, junitFoo)[:12]},
+			},
+		},
 		{
 			name:     "not found returns not found error",
 			checkErr: storage.ErrObjectNotExist,
@@ -1125,7 +1338,7 @@ func TestReadSuites(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual, err := readSuites(tc.ctx, tc.opener, path)
+			actual, err := readSuites(tc.ctx, tc.opener, path, tc.parser)
 			switch {
 			case err != nil:
 				if tc.expected != nil {
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -84,6 +85,39 @@ func (rgc realGCSClient) Upload(ctx context.Context, path Path, buf []byte, worl
 	return UploadHandle(ctx, rgc.handle(path, rgc.writeCond), buf, worldReadable, cacheControl)
 }
 
+func (rgc realGCSClient) UploadWithMetadata(ctx context.Context, path Path, buf []byte, worldReadable bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	return uploadHandle(ctx, rgc.handle(path, rgc.writeCond), buf, worldReadable, cacheControl, meta)
+}
+
+func (rgc realGCSClient) UploadFrom(ctx context.Context, path Path, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (*storage.ObjectAttrs, error) {
+	return UploadHandleFrom(ctx, rgc.handle(path, rgc.writeCond), r, worldReadable, cacheControl, meta)
+}
+
+// Stage uploads to the temporary object without conditions, which apply to the final object.
+func (rgc realGCSClient) Stage(ctx context.Context, path Path, r io.Reader, worldReadable bool, cacheControl string, meta map[string]string) (Path, error) {
+	staged := path.TempPath()
+	// An interrupted upload never creates the object.
+	_, err := UploadHandleFrom(ctx, rgc.handle(staged, nil), r, worldReadable, cacheControl, meta)
+	return staged, err
+}
+
+// Finalize copies the staged object into place, which atomically replaces the object at path.
+//
+// A copy receives the default ACL of the bucket, so this reapplies worldReadable.
+func (rgc realGCSClient) Finalize(ctx context.Context, staged, path Path, worldReadable bool) (*storage.ObjectAttrs, error) {
+	stagedH := rgc.handle(staged, nil)
+	copier := rgc.handle(path, rgc.writeCond).CopierFrom(stagedH)
+	if worldReadable {
+		copier.PredefinedACL = "publicRead"
+	}
+	attrs, err := copier.Run(ctx)
+	// A leftover staged object is harmless: the next Stage overwrites it.
+	if delErr := stagedH.Delete(ctx); delErr != nil {
+		logrus.WithError(delErr).WithField("staged", staged).Warning("Failed to delete staged object")
+	}
+	return attrs, err
+}
+
 func (rgc realGCSClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
 	return rgc.handle(path, rgc.readCond).Attrs(ctx)
 }
@@ -128,6 +128,7 @@ func Touch(ctx context.Context, client ConditionalClient, path Path, generation
 //   gs://b/1
 func Sort(builds []Build) {
 	sort.SliceStable(builds, func(i, j int) bool { // greater
-		return !sortorder.NaturalLess(builds[i].baseName, builds[j].baseName) && builds[i].baseName != builds[j].baseName
+		a, b := builds[i].sortName(), builds[j].sortName()
+		return !sortorder.NaturalLess(a, b) && a != b
 	})
 }
@@ -110,6 +110,19 @@ func TestSort(t *testing.T) {
 				{baseName: "a1b"},
 			},
 		},
+		{
+			name: "default to build id",
+			builds: []Build{
+				{Path: newPathOrDie("gs://b/job/1/")},
+				{Path: newPathOrDie("gs://a/job/5/")},
+				{Path: newPathOrDie("gs://c/job/10/")},
+			},
+			want: []Build{
+				{Path: newPathOrDie("gs://c/job/10/")},
+				{Path: newPathOrDie("gs://a/job/5/")},
+				{Path: newPathOrDie("gs://b/job/1/")},
+			},
+		},
 	}
 
 	for _, tc := range cases {
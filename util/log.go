@@ -64,3 +64,54 @@ func Progress(ctx context.Context, log logrus.FieldLogger, every time.Duration,
 		}
 	}
 }
+
+type contextKey int
+
+const (
+	runIDKey contextKey = iota
+	traceIDKey
+)
+
+// WithRunID returns a context holding the id of the current run, see Logger.
+func WithRunID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, runIDKey, id)
+}
+
+// RunID returns the run id of the context, if any.
+func RunID(ctx context.Context) string {
+	id, _ := ctx.Value(runIDKey).(string)
+	return id
+}
+
+// WithTraceID returns a context holding a trace id to correlate requests, see Logger.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey, id)
+}
+
+// TraceID returns the trace id of the context, if any.
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey).(string)
+	return id
+}
+
+// ContextFields returns the run and trace ids of the context as log fields.
+func ContextFields(ctx context.Context) logrus.Fields {
+	fields := logrus.Fields{}
+	if id := RunID(ctx); id != "" {
+		fields["run"] = id
+	}
+	if id := TraceID(ctx); id != "" {
+		fields["trace"] = id
+	}
+	return fields
+}
+
+// ContextLogger adds the run and trace ids of the context to log.
+func ContextLogger(ctx context.Context, log logrus.FieldLogger) logrus.FieldLogger {
+	return log.WithFields(ContextFields(ctx))
+}
+
+// Logger returns the standard logger with the run and trace ids of the context.
+func Logger(ctx context.Context) logrus.FieldLogger {
+	return ContextLogger(ctx, logrus.StandardLogger())
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestContextLogger(t *testing.T) {
+	cases := []struct {
+		name     string
+		ctx      func(context.Context) context.Context
+		expected logrus.Fields
+	}{
+		{
+			name:     "no ids",
+			ctx:      func(ctx context.Context) context.Context { return ctx },
+			expected: logrus.Fields{"hello": "world"},
+		},
+		{
+			name: "run id",
+			ctx: func(ctx context.Context) context.Context {
+				return WithRunID(ctx, "run-1")
+			},
+			expected: logrus.Fields{"hello": "world", "run": "run-1"},
+		},
+		{
+			name: "run and trace ids",
+			ctx: func(ctx context.Context) context.Context {
+				return WithTraceID(WithRunID(ctx, "run-1"), "trace-2")
+			},
+			expected: logrus.Fields{"hello": "world", "run": "run-1", "trace": "trace-2"},
+		},
+		{
+			name: "latest id wins",
+			ctx: func(ctx context.Context) context.Context {
+				return WithRunID(WithRunID(ctx, "old"), "new")
+			},
+			expected: logrus.Fields{"hello": "world", "run": "new"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, hook := test.NewNullLogger()
+			ctx := tc.ctx(context.Background())
+			log := ContextLogger(ctx, logger).WithField("hello", "world")
+			log.Info("first")
+			log.WithField("extra", true).Info("second")
+
+			entries := hook.AllEntries()
+			if len(entries) != 2 {
+				t.Fatalf("ContextLogger() logged %d lines, want 2", len(entries))
+			}
+			if diff := cmp.Diff(tc.expected, entries[0].Data); diff != "" {
+				t.Errorf("ContextLogger() got unexpected fields (-want +got):\n%s", diff)
+			}
+			if got, want := entries[1].Data["run"], tc.expected["run"]; got != want {
+				t.Errorf("ContextLogger() got run %v on a derived logger, want %v", got, want)
+			}
+		})
+	}
+}